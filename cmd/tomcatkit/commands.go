@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+)
+
+// runCommand dispatches CLI subcommands. It reports false when args do not
+// name a subcommand, in which case the interactive UI is started.
+func runCommand(args []string) (int, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+
+	switch args[0] {
+	case "validate":
+		return runValidate(args[1:]), true
+	case "instance":
+		return runInstance(args[1:]), true
+	case "systemd":
+		return runSystemd(args[1:]), true
+	case "compare":
+		return runCompare(args[1:]), true
+	case "plan":
+		return runPlan(args[1:]), true
+	case "apply":
+		return runApply(args[1:]), true
+	case "export":
+		return runExport(args[1:]), true
+	case "profile":
+		return runProfile(args[1:]), true
+	}
+	return 0, false
+}
+
+// resolveInstance returns CATALINA_HOME and CATALINA_BASE from flags,
+// falling back to the environment
+func resolveInstance(home, base string) (string, string) {
+	if home == "" {
+		home = os.Getenv("CATALINA_HOME")
+		if base == "" {
+			base = os.Getenv("CATALINA_BASE")
+		}
+	}
+	if base == "" {
+		base = home
+	}
+	return home, base
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/playok/tomcatkit/internal/compare"
+	"github.com/playok/tomcatkit/internal/config"
+)
+
+// runCompare implements "tomcatkit compare"
+func runCompare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	catalinaHome := fs.String("home", "", "Path to CATALINA_HOME of both instances (defaults to each CATALINA_BASE)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit compare [-home path] <baseA|name> <baseB|name>
+
+Compares conf/server.xml, context.xml, web.xml, tomcat-users.xml and
+logging.properties of two instances setting by setting. Elements are
+matched by what identifies them, not by their position: connectors by
+port, hosts and resources by name, contexts by path, users by username and
+valves and listeners by class. Instances may be given by CATALINA_BASE or
+by their name in the instance inventory.
+
+Exit status is 0 when the instances are configured the same, 1 when they
+differ and 2 when an instance cannot be read.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+
+	settings := config.NewSettingsManager()
+	settings.Load()
+
+	var instances [2]*config.TomcatInstance
+	for i, arg := range fs.Args() {
+		instance, err := compareInstance(arg, *catalinaHome, settings)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		instances[i] = instance
+	}
+
+	c := compare.New(instances[0], instances[1])
+	fmt.Printf("A: %s\nB: %s\n", instances[0].CatalinaBase, instances[1].CatalinaBase)
+	for _, file := range compare.Files {
+		for side, s := range []*compare.Snapshot{c.A, c.B} {
+			if err := s.Errors[file]; err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s not compared, side %c: %v\n", file, 'A'+side, err)
+			}
+		}
+	}
+
+	diffs := c.Differences()
+	file := ""
+	for _, d := range diffs {
+		if d.File != file {
+			file = d.File
+			fmt.Printf("\n%s\n", file)
+		}
+		switch d.Kind {
+		case compare.OnlyA:
+			fmt.Printf("  - %s  (only in A)\n      %s\n", d.Path, d.A)
+		case compare.OnlyB:
+			fmt.Printf("  + %s  (only in B)\n      %s\n", d.Path, d.B)
+		default:
+			fmt.Printf("  ~ %s\n      A: %s\n      B: %s\n", d.Setting(), valueText(d.A), valueText(d.B))
+		}
+	}
+
+	fmt.Println()
+	if len(diffs) == 0 {
+		fmt.Println("No differences found.")
+		return 0
+	}
+	fmt.Printf("%d difference(s).\n", len(diffs))
+	return 1
+}
+
+// compareInstance resolves an instance given on the command line, either a
+// CATALINA_BASE or the name of an inventory entry
+func compareInstance(arg, home string, settings *config.SettingsManager) (*config.TomcatInstance, error) {
+	if _, err := os.Stat(filepath.Join(arg, "conf", "server.xml")); err == nil {
+		base, _ := filepath.Abs(arg)
+		if home == "" {
+			home = base
+		}
+		return &config.TomcatInstance{CatalinaHome: home, CatalinaBase: base}, nil
+	}
+	for _, entry := range settings.GetInventory() {
+		if entry.Name == arg {
+			return entry.Instance(), nil
+		}
+	}
+	return nil, fmt.Errorf("%s is neither a CATALINA_BASE nor an inventory name", arg)
+}
+
+// valueText shows a value that is not set
+func valueText(value string) string {
+	if value == "" {
+		return "(not set)"
+	}
+	return value
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/compare"
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/desired"
+	"github.com/playok/tomcatkit/internal/docker"
+)
+
+// runExport implements "tomcatkit export"
+func runExport(args []string) int {
+	if len(args) > 0 && args[0] == "docker" {
+		return runExportDocker(args[1:])
+	}
+
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	catalinaHome := fs.String("home", "", "Path to CATALINA_HOME")
+	catalinaBase := fs.String("base", "", "Path to CATALINA_BASE (defaults to CATALINA_HOME)")
+	output := fs.String("o", "", "Write the document to this file instead of stdout")
+	format := fs.String("format", "", "yaml or json (defaults to json for -o files ending in .json, yaml otherwise)")
+	secrets := fs.String("secrets", "redact", "How to write passwords and secrets: redact, reference or keep")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit export [-home path] [-base path] [-o file] [-format yaml|json] [-secrets mode]
+  tomcatkit export docker [-home path] [-base path] -o dir [-image name]
+
+Writes the configuration of an instance as a desired-state document for
+"tomcatkit plan" and "tomcatkit apply", to bootstrap a configuration kept
+in version control. Values that are not set or at their Tomcat defaults
+are left out, so planning the document against the same instance shows no
+changes.
+
+Exported are the first service of server.xml (executors, connectors,
+engine, realm, valves and hosts), the resources of context.xml, the
+loggers of logging.properties, the roles and users of tomcat-users.xml and
+the session timeout, welcome files and servlets of web.xml where they
+differ from what Tomcat ships with.
+
+Secrets (passwords, keystore passes and AJP secrets) are:
+  redact     left out, so that apply keeps the instance's values (default)
+  reference  replaced by ${NAME} references that Tomcat resolves from
+             system properties, or from environment variables with
+             %s=
+             %s
+  keep       written as they are
+
+"tomcatkit export docker -h" describes the container build context.
+
+Options:
+`, placeholder.PropertySourceKey, placeholder.EnvironmentPropertySource)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	mode, err := desired.ParseSecrets(*secrets)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	isJSON := strings.EqualFold(filepath.Ext(*output), ".json")
+	switch strings.ToLower(*format) {
+	case "":
+	case "yaml":
+		isJSON = false
+	case "json":
+		isJSON = true
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use yaml or json)\n", *format)
+		return 2
+	}
+
+	home, base := resolveInstance(*catalinaHome, *catalinaBase)
+	if base == "" {
+		fmt.Fprintln(os.Stderr, "Error: no Tomcat instance given (use -home/-base or set CATALINA_HOME)")
+		return 2
+	}
+
+	snapshot := compare.Load(&config.TomcatInstance{CatalinaHome: home, CatalinaBase: base})
+	doc, references, err := desired.Export(snapshot, mode)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	for _, file := range compare.Files {
+		if err := snapshot.Errors[file]; err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s not exported: %v\n", file, err)
+		}
+	}
+	data, err := doc.Marshal(isJSON)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if *output == "" {
+		os.Stdout.Write(data)
+	} else {
+		if err := os.WriteFile(*output, data, 0640); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to write %s: %v\n", *output, err)
+			return 2
+		}
+		fmt.Printf("Wrote %s\n", *output)
+	}
+	if len(references) > 0 {
+		fmt.Fprintln(os.Stderr, "Secrets are referenced as properties; define these before applying:")
+		for _, name := range references {
+			fmt.Fprintf(os.Stderr, "  %s\n", name)
+		}
+	}
+	return 0
+}
+
+// runExportDocker implements "tomcatkit export docker"
+func runExportDocker(args []string) int {
+	fs := flag.NewFlagSet("export docker", flag.ExitOnError)
+	catalinaHome := fs.String("home", "", "Path to CATALINA_HOME")
+	catalinaBase := fs.String("base", "", "Path to CATALINA_BASE (defaults to CATALINA_HOME)")
+	output := fs.String("o", "", "Directory to write the build context to (must not exist or be empty)")
+	image := fs.String("image", "", "Base image (defaults to the official image of the detected version)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit export docker [-home path] [-base path] -o dir [-image name]
+
+Writes a container build context for an instance: a Dockerfile based on
+the official Tomcat image of the instance's version, a copy of its conf
+directory (and lib of a separate CATALINA_BASE) and %s.
+The instance itself is not changed.
+
+In the copied configuration:
+  - connector and shutdown ports become ${HTTP_PORT}, ${HTTPS_PORT},
+    ${AJP_PORT} and ${SHUTDOWN_PORT}, with the current ports as ENV defaults
+  - passwords and secrets become ${NAME} references that have to be given
+    to the container; the entrypoint refuses to start without them
+  - catalina.properties resolves references from environment variables
+    with %s
+  - paths into the instance point to %s
+
+The options of bin/setenv.sh are set as CATALINA_OPTS or JAVA_OPTS, and a
+HealthCheckValve in server.xml becomes the HEALTHCHECK. Applications are
+not copied; the Dockerfile shows where to add them.
+
+Options:
+`, docker.EntrypointFile, placeholder.EnvironmentPropertySource, docker.ImageHome)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *output == "" {
+		fmt.Fprintln(os.Stderr, "Error: -o is required")
+		return 2
+	}
+	home, base := resolveInstance(*catalinaHome, *catalinaBase)
+	if base == "" {
+		fmt.Fprintln(os.Stderr, "Error: no Tomcat instance given (use -home/-base or set CATALINA_HOME)")
+		return 2
+	}
+
+	result, err := docker.Generate(docker.Options{
+		Instance: &config.TomcatInstance{CatalinaHome: home, CatalinaBase: base},
+		Image:    *image,
+	}, *output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Printf("Wrote the build context for %s to %s\n", result.Image, result.Dir)
+	if len(result.Variables) > 0 {
+		fmt.Println("\nEnvironment variables:")
+		for _, v := range result.Variables {
+			value := v.Default
+			if v.Secret {
+				value = "(required)"
+			}
+			fmt.Printf("  %-28s %-12s %s\n", v.Name, value, v.Usage)
+		}
+	}
+	if len(result.Notes) > 0 {
+		fmt.Println("\nNotes:")
+		for _, note := range result.Notes {
+			fmt.Printf("  - %s\n", note)
+		}
+	}
+	fmt.Printf("\nBuild with: docker build -t app %s\n", result.Dir)
+	return 0
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/instance"
+)
+
+// runInstance implements "tomcatkit instance <subcommand>"
+func runInstance(args []string) int {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit instance create -base path [-home path] [-port-offset n]
+  tomcatkit instance clone -to path [-home path] [-base path] [-port-offset n] [-yes]
+
+Subcommands:
+  create          Lay out a new CATALINA_BASE from an existing CATALINA_HOME
+  clone           Copy an instance's configuration to another CATALINA_BASE
+`)
+	}
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+
+	switch args[0] {
+	case "create":
+		return runInstanceCreate(args[1:])
+	case "clone":
+		return runInstanceClone(args[1:])
+	case "-h", "-help", "--help", "help":
+		usage()
+		return 0
+	}
+	fmt.Fprintf(os.Stderr, "Error: unknown instance subcommand %q\n\n", args[0])
+	usage()
+	return 2
+}
+
+// runInstanceCreate implements "tomcatkit instance create"
+func runInstanceCreate(args []string) int {
+	fs := flag.NewFlagSet("instance create", flag.ExitOnError)
+	catalinaHome := fs.String("home", "", "Path to CATALINA_HOME to create the instance from")
+	catalinaBase := fs.String("base", "", "Path of the new CATALINA_BASE (must not exist or be empty)")
+	portOffset := fs.Int("port-offset", -1, "Offset added to the ports of CATALINA_HOME's server.xml (default: first free offset)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit instance create -base path [-home path] [-port-offset n]
+
+Creates a new CATALINA_BASE with conf, logs, temp, webapps, work, lib and
+bin/setenv.sh. The conf files are copied from CATALINA_HOME and the
+shutdown, HTTP, HTTPS and AJP ports are shifted so they do not conflict
+with ports bound on this machine or configured by other instances.
+
+The new instance is added to the recent instances list.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	home := *catalinaHome
+	if home == "" {
+		home = os.Getenv("CATALINA_HOME")
+	}
+	if home == "" || *catalinaBase == "" {
+		fmt.Fprintln(os.Stderr, "Error: -base is required, and -home unless CATALINA_HOME is set")
+		return 2
+	}
+
+	opts := instance.CreateOptions{CatalinaHome: home, CatalinaBase: *catalinaBase}
+	if *portOffset >= 0 {
+		opts.Ports = instance.WithOffset(*portOffset)
+	}
+
+	result, err := instance.Create(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Created %s from %s\n\n", result.Instance.CatalinaBase, result.Instance.CatalinaHome)
+	fmt.Println("Ports:")
+	for _, p := range []struct {
+		name string
+		port int
+	}{
+		{"shutdown", result.Ports.Shutdown},
+		{"http", result.Ports.HTTP},
+		{"https", result.Ports.HTTPS},
+		{"ajp", result.Ports.AJP},
+	} {
+		if p.port > 0 {
+			fmt.Printf("  %-9s %d\n", p.name, p.port)
+		}
+	}
+	fmt.Println("\nFiles:")
+	for _, f := range result.Files {
+		fmt.Printf("  %s\n", f)
+	}
+
+	if err := instance.Register(config.NewSettingsManager(), result.Instance); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	fmt.Printf("\nStart it with:\n  CATALINA_HOME=%s CATALINA_BASE=%s %s/bin/startup.sh\n",
+		result.Instance.CatalinaHome, result.Instance.CatalinaBase, result.Instance.CatalinaHome)
+	return 0
+}
+
+// runInstanceClone implements "tomcatkit instance clone"
+func runInstanceClone(args []string) int {
+	fs := flag.NewFlagSet("instance clone", flag.ExitOnError)
+	catalinaHome := fs.String("home", "", "Path to CATALINA_HOME of the source instance")
+	catalinaBase := fs.String("base", "", "Path to CATALINA_BASE of the source instance (defaults to CATALINA_HOME)")
+	target := fs.String("to", "", "Path of the target CATALINA_BASE")
+	portOffset := fs.Int("port-offset", 0, "Offset added to every port (default: first free multiple of 100)")
+	yes := fs.Bool("yes", false, "Write without asking for confirmation")
+	dryRun := fs.Bool("dry-run", false, "Only show the substitutions")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit instance clone -to path [-home path] [-base path] [-port-offset n] [-yes] [-dry-run]
+
+Copies the configuration files of an instance (server.xml, web.xml,
+context.xml, tomcat-users.xml, logging.properties, catalina.properties,
+setenv scripts and context descriptors) to another CATALINA_BASE.
+Every port is shifted by the offset and absolute paths into the source
+CATALINA_BASE are rewritten to the target. All substitutions are shown
+before anything is written.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	home, base := resolveInstance(*catalinaHome, *catalinaBase)
+	if base == "" || *target == "" {
+		fmt.Fprintln(os.Stderr, "Error: -to is required, and -home/-base unless CATALINA_HOME is set")
+		return 2
+	}
+
+	plan, err := instance.PlanClone(instance.CloneOptions{
+		Source:     &config.TomcatInstance{CatalinaHome: home, CatalinaBase: base},
+		TargetBase: *target,
+		PortOffset: *portOffset,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Clone %s -> %s (port offset %+d)\n\n", plan.Source.CatalinaBase, plan.Target.CatalinaBase, plan.PortOffset)
+	fmt.Println("Files:")
+	for _, f := range plan.Files {
+		note := ""
+		if f.Exists {
+			note = " (overwrite, backed up)"
+		}
+		fmt.Printf("  %s%s\n", f.Path, note)
+	}
+	fmt.Println("\nSubstitutions:")
+	if len(plan.Substitutions) == 0 {
+		fmt.Println("  none")
+	}
+	for _, s := range plan.Substitutions {
+		fmt.Printf("  %s:%d  %-4s %s -> %s\n", s.File, s.Line, s.Kind, s.Old, s.New)
+	}
+
+	if *dryRun {
+		return 0
+	}
+	if !*yes && !confirm("\nWrite these files?") {
+		fmt.Println("Aborted.")
+		return 1
+	}
+
+	if err := plan.Apply(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := instance.Register(config.NewSettingsManager(), plan.Target); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	fmt.Printf("Cloned %d file(s) to %s\n", len(plan.Files), plan.Target.CatalinaBase)
+	return 0
+}
+
+// confirm asks a yes/no question on stdin
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
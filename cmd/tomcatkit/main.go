@@ -17,6 +17,11 @@ var (
 )
 
 func main() {
+	// Run a subcommand if one was given
+	if code, ok := runCommand(os.Args[1:]); ok {
+		os.Exit(code)
+	}
+
 	// Define CLI flags
 	catalinaHome := flag.String("home", "", "Path to CATALINA_HOME (Tomcat installation directory)")
 	catalinaBase := flag.String("base", "", "Path to CATALINA_BASE (defaults to CATALINA_HOME if not specified)")
@@ -29,6 +34,19 @@ func main() {
 
 Usage:
   tomcatkit [options]
+  tomcatkit <command> [options]
+
+Commands:
+  validate        Check ports for conflicts with the system and other instances
+  instance create Create a new CATALINA_BASE from an existing CATALINA_HOME
+  instance clone  Copy an instance's configuration to another CATALINA_BASE
+  systemd generate  Generate a systemd unit file for an instance
+  compare         Show the configuration differences between two instances
+  plan            Show what a desired-state document would change
+  apply           Change an instance to match a desired-state document
+  export          Write an instance's configuration as a desired-state document
+  export docker   Write a Docker build context for an instance
+  profile         Base-plus-overlay documents for dev, staging and prod
 
 Options:
   -home string    Path to CATALINA_HOME (Tomcat installation directory)
@@ -40,6 +58,14 @@ Examples:
   tomcatkit                              # Auto-detect or select Tomcat instance
   tomcatkit -home /opt/tomcat            # Specify Tomcat home directory
   tomcatkit -home /opt/tomcat -base /var/tomcat  # Specify both home and base
+  tomcatkit validate -home /opt/tomcat   # Check the instance for port conflicts
+  tomcatkit instance create -home /opt/tomcat -base /srv/tomcat/app2  # New instance
+  tomcatkit systemd generate -home /opt/tomcat -o /etc/systemd/system  # Install a unit
+  tomcatkit compare /srv/tomcat/stage /srv/tomcat/prod  # Why does stage differ from prod?
+  tomcatkit apply -f desired.yaml -home /opt/tomcat      # Converge to a document
+  tomcatkit export -home /opt/tomcat -o desired.yaml     # Start a document from a server
+  tomcatkit export docker -base /srv/tomcat/app1 -o build  # Containerise an instance
+  tomcatkit profile render -d profiles -p prod -base /srv/tomcat/prod  # Render a profile
 
 Environment Variables:
   CATALINA_HOME   Tomcat installation directory
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/playok/tomcatkit/internal/compare"
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/desired"
+)
+
+// desiredUsage describes the desired-state document for plan and apply
+const desiredUsage = `The document is YAML, or JSON when the file ends in .json. Elements are
+matched by what identifies them and only the declared attributes are
+changed; everything not declared is left as it is. "absent: true" removes
+an element and "replaces" takes over another one, e.g. to move a connector
+to another port: {port: 80, replaces: 8080}.
+
+  server:     {port: 8005, shutdown: SHUTDOWN}
+  service:    Catalina                # first service by default
+  executors:  [{name: tomcatThreadPool, maxThreads: 300}]
+  connectors:                         # by port
+    - {port: 8080, protocol: HTTP/1.1, executor: tomcatThreadPool}
+    - {port: 8009, absent: true}
+  engine:     {defaultHost: localhost, jvmRoute: node1}
+  realm:                              # by className, with nested realms
+    className: org.apache.catalina.realm.LockOutRealm
+    realms: [{className: org.apache.catalina.realm.UserDatabaseRealm, resourceName: UserDatabase}]
+  valves:     [{className: org.apache.catalina.valves.StuckThreadDetectionValve, threshold: 600}]
+  hosts:                              # by name, with aliases and valves
+    - name: localhost
+      autoDeploy: false
+      aliases: [www.example.com]
+  resources:                          # conf/context.xml, by JNDI name
+    - {name: jdbc/app, type: javax.sql.DataSource, url: "jdbc:postgresql://db/app"}
+  loggers:                            # conf/logging.properties, by name
+    - {name: org.apache.catalina, level: FINE}
+  roles:      [{rolename: manager-gui}]         # conf/tomcat-users.xml
+  users:      [{username: admin, password: "${ADMIN_PASSWORD}", roles: manager-gui}]
+  web:                                # conf/web.xml
+    sessionTimeout: 60
+    welcomeFiles: [index.html, index.jsp]
+    servlets:                         # by name; init parameters one by one
+      - {name: default, initParams: {listings: false}}
+
+"tomcatkit export" writes the document of an existing instance.
+`
+
+// runPlan implements "tomcatkit plan"
+func runPlan(args []string) int {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	file := fs.String("f", "", "Desired-state document (YAML or JSON)")
+	catalinaHome := fs.String("home", "", "Path to CATALINA_HOME")
+	catalinaBase := fs.String("base", "", "Path to CATALINA_BASE (defaults to CATALINA_HOME)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit plan -f desired.yaml [-home path] [-base path]
+
+Shows what "tomcatkit apply" would change in the instance to make it look
+as the document describes. Nothing is written.
+
+Exit status is 0 when the instance already matches, 1 when there are
+changes and 2 when the document or the instance cannot be read.
+
+%s
+Options:
+`, desiredUsage)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	plan, code := loadPlan(*file, *catalinaHome, *catalinaBase)
+	if plan == nil {
+		return code
+	}
+	if len(plan.Changes) == 0 {
+		return 0
+	}
+	return 1
+}
+
+// runApply implements "tomcatkit apply"
+func runApply(args []string) int {
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+	file := fs.String("f", "", "Desired-state document (YAML or JSON)")
+	catalinaHome := fs.String("home", "", "Path to CATALINA_HOME")
+	catalinaBase := fs.String("base", "", "Path to CATALINA_BASE (defaults to CATALINA_HOME)")
+	yes := fs.Bool("yes", false, "Write without asking for confirmation")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit apply -f desired.yaml [-home path] [-base path] [-yes]
+
+Changes the instance to look as the document describes, through the same
+configuration services as the interactive UI. The plan is shown first and
+every changed file is backed up to conf/backup.
+
+%s
+Options:
+`, desiredUsage)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	plan, code := loadPlan(*file, *catalinaHome, *catalinaBase)
+	if plan == nil {
+		return code
+	}
+	return applyPlan(plan, *yes)
+}
+
+// applyPlan writes a plan after asking unless yes is set
+func applyPlan(plan *desired.Plan, yes bool) int {
+	if len(plan.Changes) == 0 {
+		return 0
+	}
+	if !yes && !confirm("\nApply these changes?") {
+		fmt.Println("Aborted.")
+		return 1
+	}
+
+	files := plan.Files()
+	if err := plan.Apply(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	for _, f := range files {
+		fmt.Printf("Wrote %s\n", f)
+	}
+	fmt.Println("Restart the instance to apply the changes.")
+	return 0
+}
+
+// loadPlan reads the document, plans it for the instance and prints the
+// plan. On errors it returns nil and the exit status.
+func loadPlan(file, home, base string) (*desired.Plan, int) {
+	if file == "" {
+		fmt.Fprintln(os.Stderr, "Error: -f is required")
+		return nil, 2
+	}
+	doc, err := desired.Load(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return nil, 2
+	}
+	return showPlan(doc, file, home, base)
+}
+
+// showPlan plans a document for an instance and prints the plan. On errors
+// it returns nil and the exit status.
+func showPlan(doc *desired.Document, source, home, base string) (*desired.Plan, int) {
+	home, base = resolveInstance(home, base)
+	if base == "" {
+		fmt.Fprintln(os.Stderr, "Error: no Tomcat instance given (use -home/-base or set CATALINA_HOME)")
+		return nil, 2
+	}
+
+	plan, err := desired.NewPlan(doc, &config.TomcatInstance{CatalinaHome: home, CatalinaBase: base})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return nil, 2
+	}
+
+	fmt.Printf("Plan for %s from %s\n", base, source)
+	current := ""
+	for _, c := range plan.Changes {
+		if c.File != current {
+			current = c.File
+			fmt.Printf("\n%s\n", current)
+		}
+		switch c.Kind {
+		case compare.OnlyB:
+			fmt.Printf("  + %s\n      %s\n", c.Path, c.B)
+		case compare.OnlyA:
+			fmt.Printf("  - %s\n", c.Path)
+		default:
+			fmt.Printf("  ~ %s: %s -> %s\n", c.Setting(), valueText(c.A), valueText(c.B))
+		}
+	}
+
+	fmt.Println()
+	if len(plan.Changes) == 0 {
+		fmt.Println("No changes. The instance matches the document.")
+	} else {
+		fmt.Printf("%d change(s) in %d file(s).\n", len(plan.Changes), len(plan.Files()))
+	}
+	return plan, 0
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/playok/tomcatkit/internal/desired"
+)
+
+// profileUsage describes the layout of a profiles directory
+const profileUsage = `A profiles directory holds base.yaml and an overlay per environment, such
+as dev.yaml, staging.yaml and prod.yaml. Overlays are desired-state
+documents that declare only what differs; elements are matched with the
+base by what identifies them and keep the attributes they do not change.
+"replaces" gives an element another identity, such as a new port:
+
+  # prod.yaml
+  connectors:
+    - {port: 80, replaces: 8080, maxThreads: 800}
+  hosts:
+    - {name: app.example.com, replaces: localhost}
+  engine: {defaultHost: app.example.com}
+  resources:
+    - {name: jdbc/app, url: "jdbc:postgresql://prod-db/app", maxTotal: 100}
+`
+
+// runProfile implements "tomcatkit profile"
+func runProfile(args []string) int {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit profile list -d dir
+  tomcatkit profile show -d dir -p profile [-format yaml|json]
+  tomcatkit profile diff -d dir <profileA> <profileB>
+  tomcatkit profile render -d dir -p profile [-home path] [-base path] [-yes]
+
+Subcommands:
+  list            List the profiles of a directory
+  show            Print the effective document of a profile
+  diff            Show the values two profiles declare differently
+  render          Change an instance to match the effective document of a profile
+
+%s`, profileUsage)
+	}
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+
+	switch args[0] {
+	case "list":
+		return runProfileList(args[1:])
+	case "show":
+		return runProfileShow(args[1:])
+	case "diff":
+		return runProfileDiff(args[1:])
+	case "render":
+		return runProfileRender(args[1:])
+	case "-h", "-help", "--help", "help":
+		usage()
+		return 0
+	}
+	fmt.Fprintf(os.Stderr, "Error: unknown profile subcommand %q\n\n", args[0])
+	usage()
+	return 2
+}
+
+// runProfileList implements "tomcatkit profile list"
+func runProfileList(args []string) int {
+	fs := flag.NewFlagSet("profile list", flag.ExitOnError)
+	dir := fs.String("d", "", "Profiles directory")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit profile list -d dir
+
+Lists the profiles of a directory, starting with the base.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	profiles, code := loadProfiles(*dir)
+	if profiles == nil {
+		return code
+	}
+	fmt.Println(desired.BaseProfile)
+	for _, name := range profiles.Names() {
+		fmt.Println(name)
+	}
+	return 0
+}
+
+// runProfileShow implements "tomcatkit profile show"
+func runProfileShow(args []string) int {
+	fs := flag.NewFlagSet("profile show", flag.ExitOnError)
+	dir := fs.String("d", "", "Profiles directory")
+	profile := fs.String("p", "", "Profile to show")
+	format := fs.String("format", "yaml", "yaml or json")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit profile show -d dir -p profile [-format yaml|json]
+
+Prints the effective document of a profile: the base with the overlay
+applied. It can be planned and applied as any other document.
+
+%s
+Options:
+`, profileUsage)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	var isJSON bool
+	switch *format {
+	case "yaml":
+	case "json":
+		isJSON = true
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use yaml or json)\n", *format)
+		return 2
+	}
+	doc, code := effectiveProfile(*dir, *profile)
+	if doc == nil {
+		return code
+	}
+	data, err := doc.Marshal(isJSON)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	os.Stdout.Write(data)
+	return 0
+}
+
+// runProfileDiff implements "tomcatkit profile diff"
+func runProfileDiff(args []string) int {
+	fs := flag.NewFlagSet("profile diff", flag.ExitOnError)
+	dir := fs.String("d", "", "Profiles directory")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit profile diff -d dir <profileA> <profileB>
+
+Shows the values that the effective documents of two profiles declare
+differently, e.g. "tomcatkit profile diff -d profiles staging prod".
+
+Exit status is 0 when the profiles declare the same values, 1 when they
+differ and 2 when a profile cannot be read.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+
+	profiles, code := loadProfiles(*dir)
+	if profiles == nil {
+		return code
+	}
+	var docs [2]*desired.Document
+	for i, name := range fs.Args() {
+		doc, err := profiles.Effective(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		docs[i] = doc
+	}
+	changes, err := desired.CompareDocuments(docs[0], docs[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Printf("A: %s\nB: %s\n\n", fs.Arg(0), fs.Arg(1))
+	for _, c := range changes {
+		fmt.Printf("  ~ %s\n      A: %s\n      B: %s\n", c.Path, valueText(c.A), valueText(c.B))
+	}
+	if len(changes) == 0 {
+		fmt.Println("The profiles declare the same values.")
+		return 0
+	}
+	fmt.Printf("\n%d difference(s).\n", len(changes))
+	return 1
+}
+
+// runProfileRender implements "tomcatkit profile render"
+func runProfileRender(args []string) int {
+	fs := flag.NewFlagSet("profile render", flag.ExitOnError)
+	dir := fs.String("d", "", "Profiles directory")
+	profile := fs.String("p", "", "Profile to render")
+	catalinaHome := fs.String("home", "", "Path to CATALINA_HOME")
+	catalinaBase := fs.String("base", "", "Path to CATALINA_BASE (defaults to CATALINA_HOME)")
+	yes := fs.Bool("yes", false, "Write without asking for confirmation")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit profile render -d dir -p profile [-home path] [-base path] [-yes]
+
+Changes an instance to match the effective document of a profile, as
+"tomcatkit apply" does: the plan is shown first and every changed file is
+backed up to conf/backup. A new CATALINA_BASE can be laid out with
+"tomcatkit instance create" first.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	doc, code := effectiveProfile(*dir, *profile)
+	if doc == nil {
+		return code
+	}
+	plan, code := showPlan(doc, "profile "+*profile, *catalinaHome, *catalinaBase)
+	if plan == nil {
+		return code
+	}
+	return applyPlan(plan, *yes)
+}
+
+// loadProfiles reads a profiles directory. On errors it returns nil and
+// the exit status.
+func loadProfiles(dir string) (*desired.Profiles, int) {
+	if dir == "" {
+		fmt.Fprintln(os.Stderr, "Error: -d is required")
+		return nil, 2
+	}
+	profiles, err := desired.LoadProfiles(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return nil, 2
+	}
+	return profiles, 0
+}
+
+// effectiveProfile returns the effective document of a profile. On errors
+// it returns nil and the exit status.
+func effectiveProfile(dir, profile string) (*desired.Document, int) {
+	if profile == "" {
+		fmt.Fprintln(os.Stderr, "Error: -p is required")
+		return nil, 2
+	}
+	profiles, code := loadProfiles(dir)
+	if profiles == nil {
+		return nil, code
+	}
+	doc, err := profiles.Effective(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return nil, 2
+	}
+	return doc, 0
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/systemd"
+)
+
+// runSystemd implements "tomcatkit systemd <subcommand>"
+func runSystemd(args []string) int {
+	usage := func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit systemd generate [-home path] [-base path] [options]
+
+Subcommands:
+  generate        Write a systemd unit file for an instance
+`)
+	}
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+
+	switch args[0] {
+	case "generate":
+		return runSystemdGenerate(args[1:])
+	case "-h", "-help", "--help", "help":
+		usage()
+		return 0
+	}
+	fmt.Fprintf(os.Stderr, "Error: unknown systemd subcommand %q\n\n", args[0])
+	usage()
+	return 2
+}
+
+// runSystemdGenerate implements "tomcatkit systemd generate"
+func runSystemdGenerate(args []string) int {
+	fs := flag.NewFlagSet("systemd generate", flag.ExitOnError)
+	catalinaHome := fs.String("home", "", "Path to CATALINA_HOME")
+	catalinaBase := fs.String("base", "", "Path to CATALINA_BASE (defaults to CATALINA_HOME)")
+	user := fs.String("user", systemd.DefaultUser, "User the service runs as")
+	group := fs.String("group", systemd.DefaultGroup, "Group the service runs as")
+	javaHome := fs.String("java-home", "", "JAVA_HOME (default: from setenv.sh or the environment)")
+	pidFile := fs.String("pid-file", "", "PID file (default: from setenv.sh or CATALINA_BASE/temp/tomcat.pid)")
+	limitNOFILE := fs.Int("limit-nofile", systemd.DefaultLimitNOFILE, "LimitNOFILE, 0 for the systemd default")
+	noHardening := fs.Bool("no-hardening", false, "Leave out ProtectSystem, NoNewPrivileges and the other hardening directives")
+	template := fs.Bool("template", false, "Generate tomcat@.service, where %i names a CATALINA_BASE below -bases-dir")
+	basesDir := fs.String("bases-dir", "", "Directory holding the instances of a template unit (default: parent of CATALINA_BASE)")
+	output := fs.String("o", "", "Write the unit to this file or directory instead of stdout (e.g. "+systemd.UnitDir+")")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit systemd generate [-home path] [-base path] [-template] [-o path] [options]
+
+Generates a systemd unit that starts the instance with bin/startup.sh as
+a forking service. User, Group, JAVA_HOME, CATALINA_HOME, CATALINA_BASE,
+the PID file and LimitNOFILE are set in the unit, and the service is
+sandboxed with ProtectSystem=strict, keeping logs, work, temp and webapps
+of CATALINA_BASE writable.
+
+With -template a tomcat@.service unit is generated instead, so each
+directory below -bases-dir can run as tomcat@<name>.service.
+
+The unit is printed unless -o is given. An existing file is kept as .bak.
+Run "systemctl daemon-reload" after installing it.
+
+Options:
+`)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	home, base := resolveInstance(*catalinaHome, *catalinaBase)
+	if home == "" {
+		fmt.Fprintln(os.Stderr, "Error: no Tomcat instance given (use -home/-base or set CATALINA_HOME)")
+		return 2
+	}
+
+	opts := systemd.DefaultOptions(&config.TomcatInstance{CatalinaHome: absPath(home), CatalinaBase: absPath(base)})
+	opts.User = *user
+	opts.Group = *group
+	if *javaHome != "" {
+		opts.JavaHome = *javaHome
+	}
+	if *pidFile != "" {
+		opts.PIDFile = *pidFile
+	}
+	opts.LimitNOFILE = *limitNOFILE
+	opts.Hardening = !*noHardening
+	opts.Template = *template
+	if *basesDir != "" {
+		opts.BasesDir = absPath(*basesDir)
+	}
+
+	unit, err := systemd.Generate(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if *output == "" {
+		fmt.Print(unit)
+		return 0
+	}
+
+	path := *output
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, opts.UnitName())
+	}
+	if err := systemd.Write(path, unit); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Wrote %s\n", path)
+	fmt.Println("Enable it with:")
+	fmt.Println("  systemctl daemon-reload")
+	if opts.Template {
+		fmt.Printf("  systemctl enable --now tomcat@%s\n", filepath.Base(base))
+	} else {
+		fmt.Printf("  systemctl enable --now %s\n", opts.UnitName())
+	}
+	return 0
+}
+
+// absPath makes a path absolute, as systemd requires absolute paths
+func absPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
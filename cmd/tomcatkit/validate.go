@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/ports"
+)
+
+// placeholderFiles are the configuration files Tomcat reads with ${...}
+// replacement enabled
+var placeholderFiles = []string{"server.xml", "context.xml"}
+
+// runValidate implements "tomcatkit validate"
+func runValidate(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	catalinaHome := fs.String("home", "", "Path to CATALINA_HOME")
+	catalinaBase := fs.String("base", "", "Path to CATALINA_BASE (defaults to CATALINA_HOME)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage:
+  tomcatkit validate [-home path] [-base path]
+
+Checks every port used by the instance (shutdown, connectors, cluster
+receiver and membership, JMX) against each other, against ports currently
+bound on this machine and against other detected Tomcat instances, and
+resolves the ${...} placeholders used in conf/server.xml and
+conf/context.xml.
+
+Exit status is 0 when no problems are found, 1 when conflicts or
+unresolved placeholders are found and 2 when the instance cannot be read.
+`)
+	}
+	fs.Parse(args)
+
+	home, base := resolveInstance(*catalinaHome, *catalinaBase)
+	if base == "" {
+		fmt.Fprintln(os.Stderr, "Error: no Tomcat instance given (use -home/-base or set CATALINA_HOME)")
+		return 2
+	}
+
+	usages, err := ports.CollectInstance(base)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	fmt.Printf("Validating %s\n\n", base)
+	fmt.Println("Ports:")
+	for _, u := range usages {
+		proto := "tcp"
+		if u.UDP {
+			proto = "udp"
+		}
+		port := fmt.Sprint(u.Port)
+		if u.Text != "" && u.Port == 0 {
+			port = "?"
+		}
+		line := fmt.Sprintf("  %-6s %-4s %-20s %s", port, proto, u.Kind, u.Where)
+		if u.Text != "" {
+			line += "  " + u.Text
+		}
+		fmt.Println(line)
+	}
+
+	unresolved := validatePlaceholders(home, base)
+
+	ownPID, others := ports.Discover(base)
+	checker := ports.NewChecker(ownPID, others)
+	conflicts := checker.Check(usages)
+
+	fmt.Println()
+	if len(conflicts) == 0 {
+		fmt.Println("No port conflicts found.")
+	} else {
+		fmt.Printf("%d port conflict(s):\n", len(conflicts))
+		for _, c := range conflicts {
+			fmt.Printf("  ✗ %s\n", c)
+		}
+	}
+	if unresolved > 0 {
+		fmt.Printf("%d unresolved placeholder(s).\n", unresolved)
+	}
+	if len(conflicts) > 0 || unresolved > 0 {
+		return 1
+	}
+	return 0
+}
+
+// validatePlaceholders prints the ${...} expressions used in the instance's
+// configuration with their resolved values and returns how many cannot be
+// resolved
+func validatePlaceholders(home, base string) int {
+	var refs []placeholder.Reference
+	for _, name := range placeholderFiles {
+		found, err := placeholder.ScanFile(base, filepath.Join(base, "conf", name))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			continue
+		}
+		refs = append(refs, found...)
+	}
+	if len(refs) == 0 {
+		return 0
+	}
+
+	r := placeholder.Load(home, base)
+	fmt.Println()
+	fmt.Println("Placeholders:")
+	if r.UsesEnvironment() {
+		fmt.Println("  (environment variables are resolved via EnvironmentPropertySource)")
+	}
+	unresolved := 0
+	for _, c := range r.CheckAll(refs) {
+		if !c.Resolved() {
+			unresolved++
+			fmt.Printf("  ✗ %s  %s  unresolved: %s\n", c.Where(), c.Text, strings.Join(c.Missing, ", "))
+			continue
+		}
+		line := fmt.Sprintf("  ✓ %s  %s = %s", c.Where(), c.Text, c.Value)
+		if len(c.Sources) > 0 {
+			line += "  (" + strings.Join(c.Sources, ", ") + ")"
+		}
+		fmt.Println(line)
+	}
+	return unresolved
+}
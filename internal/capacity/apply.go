@@ -0,0 +1,176 @@
+package capacity
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/playok/tomcatkit/internal/config/connector"
+	"github.com/playok/tomcatkit/internal/config/jndi"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/config/server"
+)
+
+// Change is a single attribute update proposed by a plan
+type Change struct {
+	Target    string // e.g. "Connector 8080", "Executor tomcatThreadPool"
+	Attribute string
+	Old       string // Value as written, "" when not set
+	New       int
+	apply     func()
+}
+
+// Apply writes the change into the configuration it was planned against
+func (c Change) Apply() {
+	c.apply()
+}
+
+// ApplyAll applies every change
+func ApplyAll(changes []Change) {
+	for _, c := range changes {
+		c.Apply()
+	}
+}
+
+// Save applies the server.xml and context.xml changes and saves each file
+// that has any. When context.xml cannot be saved, server.xml is restored
+// from its backup so the two are never left half applied.
+func Save(serverService *server.ConfigService, serverChanges []Change, contextService *jndi.ContextService, dsChanges []Change) error {
+	if len(serverChanges) > 0 {
+		ApplyAll(serverChanges)
+		if err := serverService.Save(); err != nil {
+			return err
+		}
+	}
+	if len(dsChanges) > 0 {
+		ApplyAll(dsChanges)
+		if err := contextService.Save(); err != nil {
+			contextService.Load()
+			if len(serverChanges) > 0 {
+				if rerr := serverService.RestoreBackup(); rerr != nil {
+					return fmt.Errorf("%w (server.xml left changed: %v)", err, rerr)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func intChange(changes []Change, target, attr string, field *int, value int) []Change {
+	if *field == value {
+		return changes
+	}
+	old := ""
+	if *field != 0 {
+		old = strconv.Itoa(*field)
+	}
+	return append(changes, Change{
+		Target:    target,
+		Attribute: attr,
+		Old:       old,
+		New:       value,
+		apply:     func() { *field = value },
+	})
+}
+
+// attrChange is intChange for server.xml attributes. A ${...} expression
+// is only replaced when it does not already resolve to the planned value.
+func attrChange(changes []Change, r *placeholder.Resolver, target, attr string, field *placeholder.Int, value int) []Change {
+	if current, err := field.Resolve(r); err == nil && current == value {
+		return changes
+	}
+	return append(changes, Change{
+		Target:    target,
+		Attribute: attr,
+		Old:       string(*field),
+		New:       value,
+		apply:     func() { *field = placeholder.IntOf(value) },
+	})
+}
+
+// ServerChanges returns the changes needed to bring HTTP connectors, the
+// executors they use and the DataSources among the GlobalNamingResources in
+// line with the plan. Connectors that use an executor get their thread
+// settings on the executor, as Tomcat ignores them on the connector in that
+// case.
+func (p *Plan) ServerChanges(srv *server.Server, r *placeholder.Resolver) []Change {
+	var changes []Change
+	if srv == nil {
+		return changes
+	}
+
+	for si := range srv.Services {
+		svc := &srv.Services[si]
+		planned := make(map[string]bool)
+		for ci := range svc.Connectors {
+			conn := &svc.Connectors[ci]
+			if connector.GetConnectorType(conn.Protocol) == connector.ConnectorTypeAJP {
+				continue
+			}
+
+			target := "Connector " + conn.Port.String()
+			if exec := connector.FindExecutor(svc, conn.Executor); exec != nil {
+				if !planned[exec.Name] {
+					planned[exec.Name] = true
+					execTarget := "Executor " + exec.Name
+					changes = attrChange(changes, r, execTarget, "maxThreads", &exec.MaxThreads, p.MaxThreads)
+					changes = attrChange(changes, r, execTarget, "minSpareThreads", &exec.MinSpareThreads, p.MinSpareThreads)
+				}
+			} else {
+				changes = attrChange(changes, r, target, "maxThreads", &conn.MaxThreads, p.MaxThreads)
+				changes = attrChange(changes, r, target, "minSpareThreads", &conn.MinSpareThreads, p.MinSpareThreads)
+			}
+			changes = attrChange(changes, r, target, "acceptCount", &conn.AcceptCount, p.AcceptCount)
+			changes = attrChange(changes, r, target, "maxConnections", &conn.MaxConnections, p.MaxConnections)
+		}
+	}
+
+	if srv.Resources != nil {
+		for i := range srv.Resources.Resources {
+			res := &srv.Resources.Resources[i]
+			if res.Type != string(jndi.ResourceTypeDataSource) {
+				continue
+			}
+			target := "DataSource " + res.Name
+			changes = resourceChange(changes, r, res, target, "maxTotal", p.DBMaxTotal)
+			changes = resourceChange(changes, r, res, target, "maxIdle", p.DBMaxIdle)
+			changes = resourceChange(changes, r, res, target, "minIdle", p.DBMinIdle)
+			changes = resourceChange(changes, r, res, target, "maxWaitMillis", p.DBMaxWaitMillis)
+		}
+	}
+	return changes
+}
+
+// resourceChange is attrChange for global resource attributes, which are
+// kept as written
+func resourceChange(changes []Change, r *placeholder.Resolver, res *server.Resource, target, attr string, value int) []Change {
+	old := res.GetAttribute(attr)
+	if current, err := placeholder.Int(old).Resolve(r); err == nil && current == value {
+		return changes
+	}
+	return append(changes, Change{
+		Target:    target,
+		Attribute: attr,
+		Old:       old,
+		New:       value,
+		apply:     func() { res.SetAttribute(attr, strconv.Itoa(value)) },
+	})
+}
+
+// DataSourceChanges returns the pool changes for the JDBC DataSources of
+// context.xml
+func (p *Plan) DataSourceChanges(resources []jndi.Resource) []Change {
+	var changes []Change
+	for i := range resources {
+		res := &resources[i]
+		if res.Type != string(jndi.ResourceTypeDataSource) {
+			continue
+		}
+		target := "DataSource " + res.Name
+		changes = intChange(changes, target, "maxTotal", &res.MaxTotal, p.DBMaxTotal)
+		changes = intChange(changes, target, "maxIdle", &res.MaxIdle, p.DBMaxIdle)
+		changes = intChange(changes, target, "minIdle", &res.MinIdle, p.DBMinIdle)
+		changes = intChange(changes, target, "maxWaitMillis", &res.MaxWaitMillis, p.DBMaxWaitMillis)
+	}
+	return changes
+}
@@ -0,0 +1,171 @@
+package capacity
+
+import (
+	"fmt"
+	"math"
+)
+
+// Input describes the expected load and the machine running Tomcat
+type Input struct {
+	RequestsPerSec float64 // Expected peak requests per second
+	AvgLatencyMs   float64 // Average request processing time
+	CPUs           int     // CPU cores available to Tomcat
+	MemoryMB       int     // Memory available to the Tomcat process
+	DBTimePercent  int     // Share of request time spent holding a DB connection
+}
+
+// Reason is one step of the planner's reasoning. Key is an i18n message
+// key and Args are its format arguments.
+type Reason struct {
+	Key  string
+	Args []interface{}
+}
+
+// Plan holds the recommended values
+type Plan struct {
+	Input Input
+
+	Concurrency     float64
+	MaxThreads      int
+	MinSpareThreads int
+	AcceptCount     int
+	MaxConnections  int
+
+	DBConcurrency   float64
+	DBMaxTotal      int
+	DBMaxIdle       int
+	DBMinIdle       int
+	DBMaxWaitMillis int
+
+	Reasons  []Reason
+	Warnings []Reason
+}
+
+// Sizing assumptions
+const (
+	threadHeadroom    = 1.5 // Spare capacity over the average concurrency
+	minThreads        = 25
+	memoryPerThreadMB = 2 // Thread stack plus request working set
+	threadMemoryShare = 2 // At most 1/2 of memory is spent on worker threads
+	connsPerThread    = 10
+	minConnections    = 1000
+	minAcceptCount    = 100
+	minPoolSize       = 5
+	minPoolIdle       = 2
+)
+
+// Calculate computes a coherent set of thread, connection and pool sizes
+func Calculate(in Input) (*Plan, error) {
+	if in.RequestsPerSec <= 0 {
+		return nil, fmt.Errorf("requests per second must be greater than 0")
+	}
+	if in.AvgLatencyMs <= 0 {
+		return nil, fmt.Errorf("average latency must be greater than 0")
+	}
+	if in.CPUs <= 0 {
+		return nil, fmt.Errorf("CPU count must be greater than 0")
+	}
+	if in.MemoryMB <= 0 {
+		return nil, fmt.Errorf("memory must be greater than 0")
+	}
+	if in.DBTimePercent < 0 || in.DBTimePercent > 100 {
+		return nil, fmt.Errorf("DB time must be between 0 and 100 percent")
+	}
+
+	p := &Plan{Input: in}
+
+	// Little's law: requests in flight = arrival rate x time in system
+	p.Concurrency = in.RequestsPerSec * in.AvgLatencyMs / 1000
+	p.reason("capacity.reason.concurrency", in.RequestsPerSec, in.AvgLatencyMs, p.Concurrency)
+
+	// Worker threads
+	wanted := int(math.Ceil(p.Concurrency * threadHeadroom))
+	memCap := in.MemoryMB / memoryPerThreadMB / threadMemoryShare
+	p.MaxThreads = wanted
+	p.reason("capacity.reason.maxthreads", p.Concurrency, threadHeadroom, wanted)
+	if p.MaxThreads < minThreads {
+		p.MaxThreads = minThreads
+		p.reason("capacity.reason.maxthreads.min", minThreads)
+	}
+	if memCap < minThreads {
+		memCap = minThreads
+	}
+	if p.MaxThreads > memCap {
+		p.MaxThreads = memCap
+		p.reason("capacity.reason.maxthreads.memory", in.MemoryMB, memCap)
+		p.warn("capacity.warn.memory", wanted, memCap)
+	}
+
+	p.MinSpareThreads = int(math.Ceil(p.Concurrency))
+	if p.MinSpareThreads < 10 {
+		p.MinSpareThreads = 10
+	}
+	if p.MinSpareThreads > p.MaxThreads {
+		p.MinSpareThreads = p.MaxThreads
+	}
+	p.reason("capacity.reason.minspare", p.MinSpareThreads)
+
+	// Connections and accept queue
+	p.MaxConnections = p.MaxThreads * connsPerThread
+	if p.MaxConnections < minConnections {
+		p.MaxConnections = minConnections
+	}
+	p.reason("capacity.reason.maxconnections", connsPerThread, p.MaxConnections)
+
+	p.AcceptCount = p.MaxThreads / 2
+	if p.AcceptCount < minAcceptCount {
+		p.AcceptCount = minAcceptCount
+	}
+	p.reason("capacity.reason.acceptcount", p.AcceptCount)
+
+	// CPU demand: time not spent waiting on the database is assumed to be CPU
+	cpuDemand := p.Concurrency * float64(100-in.DBTimePercent) / 100
+	p.reason("capacity.reason.cpu", cpuDemand, in.CPUs)
+	if cpuDemand > float64(in.CPUs) {
+		p.warn("capacity.warn.cpu", cpuDemand, in.CPUs)
+	}
+
+	// Database pool
+	p.DBConcurrency = p.Concurrency * float64(in.DBTimePercent) / 100
+	p.DBMaxTotal = int(math.Ceil(p.DBConcurrency * threadHeadroom))
+	if p.DBMaxTotal < minPoolSize {
+		p.DBMaxTotal = minPoolSize
+	}
+	p.reason("capacity.reason.pool", in.DBTimePercent, p.DBConcurrency, p.DBMaxTotal)
+	if p.DBMaxTotal > p.MaxThreads {
+		p.DBMaxTotal = p.MaxThreads
+		p.reason("capacity.reason.pool.cap", p.MaxThreads)
+	}
+
+	p.DBMinIdle = int(math.Ceil(p.DBConcurrency))
+	if p.DBMinIdle < minPoolIdle {
+		p.DBMinIdle = minPoolIdle
+	}
+	if p.DBMinIdle > p.DBMaxTotal {
+		p.DBMinIdle = p.DBMaxTotal
+	}
+	p.DBMaxIdle = p.DBMaxTotal / 2
+	if p.DBMaxIdle < p.DBMinIdle {
+		p.DBMaxIdle = p.DBMinIdle
+	}
+	p.reason("capacity.reason.poolidle", p.DBMinIdle, p.DBMaxIdle)
+
+	p.DBMaxWaitMillis = int(in.AvgLatencyMs * 4)
+	if p.DBMaxWaitMillis < 1000 {
+		p.DBMaxWaitMillis = 1000
+	}
+	if p.DBMaxWaitMillis > 30000 {
+		p.DBMaxWaitMillis = 30000
+	}
+	p.reason("capacity.reason.poolwait", p.DBMaxWaitMillis)
+
+	return p, nil
+}
+
+func (p *Plan) reason(key string, args ...interface{}) {
+	p.Reasons = append(p.Reasons, Reason{Key: key, Args: args})
+}
+
+func (p *Plan) warn(key string, args ...interface{}) {
+	p.Warnings = append(p.Warnings, Reason{Key: key, Args: args})
+}
@@ -0,0 +1,258 @@
+// Package compare finds the configuration differences between two Tomcat
+// instances. Elements are matched by what identifies them rather than by
+// position, so a connector is compared with the connector on the same port
+// and a resource with the resource of the same JNDI name.
+package compare
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/config/jndi"
+	"github.com/playok/tomcatkit/internal/config/logging"
+	"github.com/playok/tomcatkit/internal/config/realm"
+	"github.com/playok/tomcatkit/internal/config/server"
+	"github.com/playok/tomcatkit/internal/config/web"
+)
+
+// Compared files, relative to CATALINA_BASE
+const (
+	FileServer  = "conf/server.xml"
+	FileContext = "conf/context.xml"
+	FileWeb     = "conf/web.xml"
+	FileUsers   = "conf/tomcat-users.xml"
+	FileLogging = "conf/logging.properties"
+)
+
+// Files are the compared files in the order they are compared
+var Files = []string{FileServer, FileContext, FileWeb, FileUsers, FileLogging}
+
+// Kind tells how a difference came about
+type Kind int
+
+const (
+	Changed Kind = iota // A setting has different values
+	OnlyA               // An element exists on side A only
+	OnlyB               // An element exists on side B only
+)
+
+// Side is one of the two compared instances
+type Side int
+
+const (
+	SideA Side = iota
+	SideB
+)
+
+// Difference is one setting or element that differs
+type Difference struct {
+	File string // One of Files
+	Path string // Element path, e.g. Server/Service[Catalina]/Connector[8080]
+	Name string // Setting name; "" when the element exists on one side only
+	A, B string // Values as shown; "" when not set. Passwords and secrets are masked.
+	Kind Kind
+
+	copyTo func(to Side)
+}
+
+// Setting returns the path and the setting name of the difference
+func (d Difference) Setting() string {
+	if d.Name == "" {
+		return d.Path
+	}
+	return d.Path + "@" + d.Name
+}
+
+// Element returns the last element of the path, e.g. Connector[8080]. Keys
+// may contain slashes, as JNDI names do.
+func (d Difference) Element() string {
+	depth := 0
+	for i := len(d.Path) - 1; i >= 0; i-- {
+		switch d.Path[i] {
+		case ']':
+			depth++
+		case '[':
+			depth--
+		case '/':
+			if depth == 0 {
+				return d.Path[i+1:]
+			}
+		}
+	}
+	return d.Path
+}
+
+// Snapshot is the configuration of one instance as loaded by the
+// configuration services
+type Snapshot struct {
+	Instance *config.TomcatInstance
+	Errors   map[string]error // Files that could not be read
+
+	server   *server.ConfigService
+	context  *jndi.ContextService
+	web      *web.ConfigService
+	users    *realm.UsersService
+	logging  *logging.ConfigService
+	modified map[string]bool
+}
+
+// Load reads the compared files of an instance
+func Load(instance *config.TomcatInstance) *Snapshot {
+	base := instance.CatalinaBase
+	s := &Snapshot{
+		Instance: instance,
+		Errors:   make(map[string]error),
+		server:   server.NewConfigService(base),
+		context:  jndi.NewContextService(base),
+		web:      web.NewConfigService(base),
+		users:    realm.NewUsersService(base),
+		logging:  logging.NewConfigService(base),
+		modified: make(map[string]bool),
+	}
+	s.server.SetCatalinaHome(instance.CatalinaHome)
+
+	loaders := map[string]func() error{
+		FileServer:  s.server.Load,
+		FileContext: s.context.Load,
+		FileWeb:     s.web.Load,
+		FileUsers:   s.users.Load,
+		FileLogging: s.logging.Load,
+	}
+	for _, file := range Files {
+		if err := loaders[file](); err != nil {
+			s.Errors[file] = err
+		}
+	}
+	return s
+}
+
+// root returns the configuration read from a file, or nil
+func (s *Snapshot) root(file string) any {
+	if s.Errors[file] != nil {
+		return nil
+	}
+	switch file {
+	case FileServer:
+		return s.server.GetServer()
+	case FileContext:
+		return s.context.GetContext()
+	case FileWeb:
+		return s.web.GetWebApp()
+	case FileUsers:
+		return s.users.GetTomcatUsers()
+	case FileLogging:
+		return s.logging.GetConfig()
+	}
+	return nil
+}
+
+// Server returns the server.xml service of the snapshot
+func (s *Snapshot) Server() *server.ConfigService {
+	return s.server
+}
+
+// Context returns the context.xml service of the snapshot
+func (s *Snapshot) Context() *jndi.ContextService {
+	return s.context
+}
+
+// Web returns the web.xml service of the snapshot
+func (s *Snapshot) Web() *web.ConfigService {
+	return s.web
+}
+
+// Users returns the tomcat-users.xml service of the snapshot
+func (s *Snapshot) Users() *realm.UsersService {
+	return s.users
+}
+
+// Logging returns the logging.properties service of the snapshot
+func (s *Snapshot) Logging() *logging.ConfigService {
+	return s.logging
+}
+
+// SetModified marks a file changed through a service, so that Save
+// writes it
+func (s *Snapshot) SetModified(file string) {
+	s.modified[file] = true
+}
+
+// Modified returns the files changed by copying settings, in Files order
+func (s *Snapshot) Modified() []string {
+	var files []string
+	for _, file := range Files {
+		if s.modified[file] {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// Save writes the modified files. Each file is backed up by its service.
+func (s *Snapshot) Save() error {
+	savers := map[string]func() error{
+		FileServer:  s.server.Save,
+		FileContext: s.context.Save,
+		FileWeb:     s.web.Save,
+		FileUsers:   s.users.Save,
+		FileLogging: s.logging.Save,
+	}
+	for _, file := range s.Modified() {
+		if err := savers[file](); err != nil {
+			return fmt.Errorf("failed to save %s: %w", file, err)
+		}
+		delete(s.modified, file)
+	}
+	return nil
+}
+
+// Comparison compares two instances
+type Comparison struct {
+	A, B *Snapshot
+}
+
+// New loads both instances
+func New(a, b *config.TomcatInstance) *Comparison {
+	return &Comparison{A: Load(a), B: Load(b)}
+}
+
+// Snapshot returns the snapshot of a side
+func (c *Comparison) Snapshot(side Side) *Snapshot {
+	if side == SideA {
+		return c.A
+	}
+	return c.B
+}
+
+// Differences compares every file that could be read on both sides
+func (c *Comparison) Differences() []Difference {
+	var diffs []Difference
+	for _, file := range Files {
+		a, b := c.A.root(file), c.B.root(file)
+		if a == nil || b == nil {
+			continue
+		}
+		w := &walker{file: file}
+		w.compareStruct(rootNames[file], reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem())
+		diffs = append(diffs, w.diffs...)
+	}
+	return diffs
+}
+
+// rootNames start the paths of the differences in each file
+var rootNames = map[string]string{
+	FileServer:  "Server",
+	FileContext: "Context",
+	FileWeb:     "web-app",
+	FileUsers:   "tomcat-users",
+	FileLogging: "logging",
+}
+
+// Copy makes the setting or element of a difference on side to the same as
+// on the other side. Nothing is written until Save; the differences are
+// out of date afterwards and have to be compared again.
+func (c *Comparison) Copy(d Difference, to Side) {
+	d.copyTo(to)
+	c.Snapshot(to).modified[d.File] = true
+}
@@ -0,0 +1,449 @@
+package compare
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+)
+
+// keyNames are the settings that identify an element among its siblings,
+// in order of preference: connectors are matched by port, hosts and
+// resources by name, contexts by path, users by username and valves and
+// listeners by class
+var keyNames = []string{
+	"port", "name", "Name", "path", "username", "rolename", "Prefix",
+	"servlet-name", "filter-name", "param-name", "web-resource-name", "role-name",
+	"extension", "error-code", "exception-type", "listener-class", "className",
+	"type", "text",
+}
+
+// classKeys are keys holding Java class names, shown by their short name
+var classKeys = map[string]bool{"className": true, "listener-class": true}
+
+// masked is shown instead of passwords
+const masked = "******"
+
+var (
+	attrsType = reflect.TypeOf([]xml.Attr(nil))
+	nameType  = reflect.TypeOf(xml.Name{})
+)
+
+// walker compares two configurations of the same type field by field
+type walker struct {
+	file  string
+	diffs []Difference
+}
+
+// fieldName returns the name of a field as written in the file: the XML
+// name where the field has one and else the Go name. It reports false for
+// fields that are not settings.
+func fieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() || f.Type == nameType {
+		return "", false
+	}
+	tag := f.Tag.Get("xml")
+	if tag == "-" {
+		return "", false
+	}
+	name, flags, _ := strings.Cut(tag, ",")
+	switch {
+	case strings.Contains(flags, "comment"):
+		return "", false
+	case strings.HasPrefix(name, "xmlns"), strings.HasPrefix(name, "xsi:"):
+		// Namespace declarations are not settings, and some services add
+		// them when saving
+		return "", false
+	case strings.Contains(flags, "chardata"), strings.Contains(flags, "innerxml"):
+		return "text", true
+	case name == "":
+		return f.Name, true
+	}
+	return name, true
+}
+
+// isValue reports whether a field holds a single setting rather than
+// nested elements
+func isValue(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+// compareStruct compares two addressable structs of the same type
+func (w *walker) compareStruct(path string, a, b reflect.Value) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := fieldName(f)
+		if !ok {
+			continue
+		}
+		fa, fb := a.Field(i), b.Field(i)
+		switch {
+		case f.Type == attrsType:
+			w.compareAttrs(path, fa, fb)
+		case isValue(f.Type):
+			w.compareValue(path, name, fa, fb)
+		case f.Type.Kind() == reflect.Struct:
+			w.compareStruct(path+"/"+name, fa, fb)
+		case f.Type.Kind() == reflect.Pointer && f.Type.Elem().Kind() == reflect.Struct:
+			w.comparePointer(path+"/"+name, fa, fb)
+		case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.Struct:
+			if f.Tag.Get("xml") == "" {
+				// Untagged lists are named after their elements
+				name = f.Type.Elem().Name()
+			}
+			w.compareSlice(path, name, fa, fb)
+		}
+	}
+}
+
+// compareValue compares a single setting
+func (w *walker) compareValue(path, name string, a, b reflect.Value) {
+	if sameValue(a, b) {
+		return
+	}
+	w.diffs = append(w.diffs, Difference{
+		File: w.file,
+		Path: path,
+		Name: name,
+		A:    display(name, a),
+		B:    display(name, b),
+		Kind: Changed,
+		copyTo: func(to Side) {
+			dst, src := pick(to, a, b)
+			dst.Set(clone(src))
+		},
+	})
+}
+
+// compareAttrs compares attributes kept as they were read, such as the
+// tuning attributes of a connector
+func (w *walker) compareAttrs(path string, a, b reflect.Value) {
+	attrsA := a.Interface().([]xml.Attr)
+	attrsB := b.Interface().([]xml.Attr)
+
+	var names []string
+	values := make(map[string][2]string)
+	collect := func(attrs []xml.Attr, side int) {
+		for _, attr := range attrs {
+			v, seen := values[attr.Name.Local]
+			if !seen {
+				names = append(names, attr.Name.Local)
+			}
+			v[side] = attr.Value
+			values[attr.Name.Local] = v
+		}
+	}
+	collect(attrsA, 0)
+	collect(attrsB, 1)
+
+	for _, name := range names {
+		v := values[name]
+		if v[0] == v[1] {
+			continue
+		}
+		w.diffs = append(w.diffs, Difference{
+			File: w.file,
+			Path: path,
+			Name: name,
+			A:    maskValue(name, v[0]),
+			B:    maskValue(name, v[1]),
+			Kind: Changed,
+			copyTo: func(to Side) {
+				dst, _ := pick(to, a, b)
+				value := v[0]
+				if to == SideA {
+					value = v[1]
+				}
+				setAttr(dst, name, value)
+			},
+		})
+	}
+}
+
+// comparePointer compares optional elements
+func (w *walker) comparePointer(path string, a, b reflect.Value) {
+	switch {
+	case a.IsNil() && b.IsNil():
+		return
+	case !a.IsNil() && !b.IsNil():
+		w.compareStruct(path, a.Elem(), b.Elem())
+		return
+	}
+
+	d := Difference{
+		File: w.file,
+		Path: path,
+		Kind: OnlyA,
+		copyTo: func(to Side) {
+			dst, src := pick(to, a, b)
+			dst.Set(clone(src))
+		},
+	}
+	if a.IsNil() {
+		d.Kind = OnlyB
+		d.B = summary(b.Elem())
+	} else {
+		d.A = summary(a.Elem())
+	}
+	w.diffs = append(w.diffs, d)
+}
+
+// compareSlice compares repeated elements, matching them by key
+func (w *walker) compareSlice(path, name string, a, b reflect.Value) {
+	keysA := keys(a)
+	keysB := keys(b)
+	indexB := make(map[string]int, len(keysB))
+	for j, key := range keysB {
+		indexB[key] = j
+	}
+	indexA := make(map[string]int, len(keysA))
+	for i, key := range keysA {
+		indexA[key] = i
+	}
+
+	for i, key := range keysA {
+		elemPath := path + "/" + name + "[" + key + "]"
+		if j, ok := indexB[key]; ok {
+			w.compareStruct(elemPath, a.Index(i), b.Index(j))
+			continue
+		}
+		w.diffs = append(w.diffs, Difference{
+			File: w.file,
+			Path: elemPath,
+			A:    summary(a.Index(i)),
+			Kind: OnlyA,
+			copyTo: func(to Side) {
+				if to == SideB {
+					insertAt(b, insertIndex(keysA, i, indexB), clone(a.Index(i)))
+				} else {
+					removeAt(a, i)
+				}
+			},
+		})
+	}
+
+	for j, key := range keysB {
+		if _, ok := indexA[key]; ok {
+			continue
+		}
+		w.diffs = append(w.diffs, Difference{
+			File: w.file,
+			Path: path + "/" + name + "[" + key + "]",
+			B:    summary(b.Index(j)),
+			Kind: OnlyB,
+			copyTo: func(to Side) {
+				if to == SideA {
+					insertAt(a, insertIndex(keysB, j, indexA), clone(b.Index(j)))
+				} else {
+					removeAt(b, j)
+				}
+			},
+		})
+	}
+}
+
+// keys returns the key of every element of a slice. Elements without a key
+// are numbered and repeated keys get a counter, so that keys are unique.
+func keys(s reflect.Value) []string {
+	result := make([]string, s.Len())
+	seen := make(map[string]int)
+	for i := range result {
+		key := keyOf(s.Index(i))
+		if key == "" {
+			key = fmt.Sprintf("#%d", i+1)
+		}
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key = fmt.Sprintf("%s#%d", key, n)
+		}
+		result[i] = key
+	}
+	return result
+}
+
+// keyOf returns the value of the first key setting an element has
+func keyOf(v reflect.Value) string {
+	t := v.Type()
+	for _, key := range keyNames {
+		for i := 0; i < t.NumField(); i++ {
+			name, ok := fieldName(t.Field(i))
+			if !ok || name != key || !isValue(t.Field(i).Type) {
+				continue
+			}
+			f := v.Field(i)
+			if f.IsZero() {
+				continue
+			}
+			text := fmt.Sprint(f.Interface())
+			if classKeys[key] {
+				text = text[strings.LastIndex(text, ".")+1:]
+			}
+			return text
+		}
+	}
+	return ""
+}
+
+// insertIndex returns where to insert the i-th element of a slice with the
+// given keys into another slice: after the closest preceding element the
+// other slice has too
+func insertIndex(keys []string, i int, other map[string]int) int {
+	for k := i - 1; k >= 0; k-- {
+		if j, ok := other[keys[k]]; ok {
+			return j + 1
+		}
+	}
+	return 0
+}
+
+// insertAt inserts v into the slice s at index i
+func insertAt(s reflect.Value, i int, v reflect.Value) {
+	n := reflect.MakeSlice(s.Type(), 0, s.Len()+1)
+	n = reflect.AppendSlice(n, s.Slice(0, i))
+	n = reflect.Append(n, v)
+	n = reflect.AppendSlice(n, s.Slice(i, s.Len()))
+	s.Set(n)
+}
+
+// removeAt removes the element at index i from the slice s
+func removeAt(s reflect.Value, i int) {
+	n := reflect.MakeSlice(s.Type(), 0, s.Len()-1)
+	n = reflect.AppendSlice(n, s.Slice(0, i))
+	n = reflect.AppendSlice(n, s.Slice(i+1, s.Len()))
+	s.Set(n)
+}
+
+// setAttr sets an attribute of a []xml.Attr, removing it for ""
+func setAttr(s reflect.Value, name, value string) {
+	attrs := s.Interface().([]xml.Attr)
+	var result []xml.Attr
+	found := false
+	for _, attr := range attrs {
+		if attr.Name.Local == name {
+			found = true
+			if value == "" {
+				continue
+			}
+			attr.Value = value
+		}
+		result = append(result, attr)
+	}
+	if !found && value != "" {
+		result = append(result, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+	s.Set(reflect.ValueOf(result))
+}
+
+// pick returns the side written to and the side copied from
+func pick(to Side, a, b reflect.Value) (dst, src reflect.Value) {
+	if to == SideA {
+		return a, b
+	}
+	return b, a
+}
+
+// sameValue compares two settings; unset and empty are the same
+func sameValue(a, b reflect.Value) bool {
+	if a.IsZero() || (a.Kind() == reflect.Slice && a.Len() == 0) {
+		return b.IsZero() || (b.Kind() == reflect.Slice && b.Len() == 0)
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// display returns a setting as shown to the user, "" when it is not set
+func display(name string, v reflect.Value) string {
+	if v.IsZero() {
+		return ""
+	}
+	if v.Kind() == reflect.Slice {
+		return maskValue(name, strings.Join(v.Interface().([]string), ", "))
+	}
+	return maskValue(name, fmt.Sprint(v.Interface()))
+}
+
+// maskValue hides passwords and other secrets. ${...} references are
+// shown, as they name the secret rather than hold it.
+func maskValue(name, value string) string {
+	if value != "" && placeholder.IsSecret(name) && !placeholder.HasPlaceholder(value) {
+		return masked
+	}
+	return value
+}
+
+// summary lists the settings of an element that are set
+func summary(v reflect.Value) string {
+	var parts []string
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := fieldName(t.Field(i))
+		if !ok || !isValue(t.Field(i).Type) || v.Field(i).IsZero() {
+			continue
+		}
+		parts = append(parts, name+"="+display(name, v.Field(i)))
+	}
+	if attrs, ok := attrsField(v); ok {
+		for _, attr := range attrs {
+			parts = append(parts, attr.Name.Local+"="+maskValue(attr.Name.Local, attr.Value))
+		}
+	}
+	if len(parts) == 0 {
+		return "(" + t.Name() + ")"
+	}
+	return strings.Join(parts, " ")
+}
+
+// attrsField returns the preserved attributes of an element, if it has any
+func attrsField(v reflect.Value) ([]xml.Attr, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type == attrsType {
+			return v.Field(i).Interface().([]xml.Attr), true
+		}
+	}
+	return nil, false
+}
+
+// clone returns a deep copy of v, so that copied settings are not shared
+// between the two configurations
+func clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		p := reflect.New(v.Type().Elem())
+		p.Elem().Set(clone(v.Elem()))
+		return p
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		s := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s.Index(i).Set(clone(v.Index(i)))
+		}
+		return s
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(clone(v.Field(i)))
+			}
+		}
+		return c
+	}
+	return v
+}
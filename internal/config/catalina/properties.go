@@ -0,0 +1,274 @@
+package catalina
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// entry is a property or a run of non-property lines (comments, blanks)
+type entry struct {
+	key      string
+	value    string
+	raw      []string // Lines as read; rewritten only when the value changes
+	property bool
+	modified bool
+	list     bool // Write one comma-separated item per line
+}
+
+// Properties is a Java properties document that keeps comments, blank
+// lines and the layout of properties that are not changed
+type Properties struct {
+	entries []*entry
+}
+
+// NewProperties returns an empty properties document
+func NewProperties() *Properties {
+	return &Properties{}
+}
+
+// ParseProperties reads a properties document
+func ParseProperties(r io.Reader) (*Properties, error) {
+	p := NewProperties()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "!") {
+			p.entries = append(p.entries, &entry{raw: []string{line}})
+			continue
+		}
+
+		raw := []string{line}
+		logical := strings.TrimLeft(line, " \t\f")
+		for continues(logical) && scanner.Scan() {
+			next := scanner.Text()
+			raw = append(raw, next)
+			logical = logical[:len(logical)-1] + strings.TrimLeft(next, " \t\f")
+		}
+
+		key, value := splitProperty(logical)
+		p.entries = append(p.entries, &entry{key: key, value: value, raw: raw, property: true})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// continues reports whether a line ends with an odd number of backslashes
+func continues(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitProperty splits a logical line at the first unescaped '=', ':' or
+// whitespace and unescapes key and value
+func splitProperty(line string) (string, string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' {
+			i++
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			end = i
+			break
+		}
+	}
+	key := line[:end]
+	rest := strings.TrimLeft(line[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return unescape(key), unescape(rest)
+}
+
+// unescape decodes \\, \=, \:, \t, \n and \uXXXX escapes
+func unescape(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 >= len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			var r rune
+			if i+4 < len(s) {
+				if _, err := fmt.Sscanf(s[i+1:i+5], "%04x", &r); err == nil {
+					sb.WriteRune(r)
+					i += 4
+					continue
+				}
+			}
+			sb.WriteByte('u')
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+// escape encodes a key or value for writing. Properties files are read as
+// ISO-8859-1, so anything outside ASCII is written as \uXXXX.
+func escape(s string, isKey bool) string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			sb.WriteString("\\\\")
+		case r == '\t':
+			sb.WriteString("\\t")
+		case r == '\n':
+			sb.WriteString("\\n")
+		case r == '\r':
+			sb.WriteString("\\r")
+		case r == '\f':
+			sb.WriteString("\\f")
+		case (r == '=' || r == ':') && isKey:
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case r == ' ' && (isKey || i == 0):
+			sb.WriteString("\\ ")
+		case (r == '#' || r == '!') && i == 0 && isKey:
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case r > 0x7e:
+			if r > 0xffff {
+				// Encode as a UTF-16 surrogate pair
+				r -= 0x10000
+				sb.WriteString(fmt.Sprintf("\\u%04x\\u%04x", 0xd800+(r>>10), 0xdc00+(r&0x3ff)))
+			} else {
+				sb.WriteString(fmt.Sprintf("\\u%04x", r))
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func (p *Properties) find(key string) *entry {
+	for _, e := range p.entries {
+		if e.property && e.key == key {
+			return e
+		}
+	}
+	return nil
+}
+
+// Get returns the value of a property
+func (p *Properties) Get(key string) (string, bool) {
+	if e := p.find(key); e != nil {
+		return e.value, true
+	}
+	return "", false
+}
+
+// Set sets a property, appending it when it does not exist
+func (p *Properties) Set(key, value string) {
+	p.set(key, value, false)
+}
+
+// SetList sets a comma-separated list, written one item per line
+func (p *Properties) SetList(key string, items []string) {
+	p.set(key, strings.Join(items, ","), len(items) > 1)
+}
+
+func (p *Properties) set(key, value string, list bool) {
+	if e := p.find(key); e != nil {
+		if e.value != value {
+			e.value = value
+			e.modified = true
+			e.list = list
+		}
+		return
+	}
+	p.entries = append(p.entries, &entry{key: key, value: value, property: true, modified: true, list: list})
+}
+
+// Delete removes a property
+func (p *Properties) Delete(key string) {
+	for i, e := range p.entries {
+		if e.property && e.key == key {
+			p.entries = append(p.entries[:i], p.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// Keys returns the property keys in file order
+func (p *Properties) Keys() []string {
+	var keys []string
+	for _, e := range p.entries {
+		if e.property {
+			keys = append(keys, e.key)
+		}
+	}
+	return keys
+}
+
+// String renders the document
+func (p *Properties) String() string {
+	var sb strings.Builder
+	for _, e := range p.entries {
+		if !e.modified {
+			for _, line := range e.raw {
+				sb.WriteString(line)
+				sb.WriteByte('\n')
+			}
+			continue
+		}
+
+		sb.WriteString(escape(e.key, true))
+		sb.WriteByte('=')
+		if !e.list {
+			sb.WriteString(escape(e.value, false))
+			sb.WriteByte('\n')
+			continue
+		}
+		// Same layout as the lists in Tomcat's own catalina.properties
+		items := SplitList(e.value)
+		sb.WriteString("\\\n")
+		for i, item := range items {
+			sb.WriteString(escape(item, false))
+			if i < len(items)-1 {
+				sb.WriteString(",\\")
+			}
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// SplitList splits a comma-separated value into trimmed, non-empty items
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,206 @@
+package catalina
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Well-known catalina.properties keys
+const (
+	KeyCommonLoader      = "common.loader"
+	KeyServerLoader      = "server.loader"
+	KeySharedLoader      = "shared.loader"
+	KeyJarsToSkip        = "tomcat.util.scan.StandardJarScanFilter.jarsToSkip"
+	KeyJarsToScan        = "tomcat.util.scan.StandardJarScanFilter.jarsToScan"
+	KeyPackageAccess     = "package.access"
+	KeyPackageDefinition = "package.definition"
+)
+
+// LoaderKeys are the class loader properties, in loader hierarchy order
+var LoaderKeys = []string{KeyCommonLoader, KeyServerLoader, KeySharedLoader}
+
+// JarScanKeys are the StandardJarScanFilter properties
+var JarScanKeys = []string{KeyJarsToSkip, KeyJarsToScan}
+
+// PackageKeys are the SecurityManager package restriction properties
+var PackageKeys = []string{KeyPackageAccess, KeyPackageDefinition}
+
+// standardPrefixes are keys Tomcat defines itself; everything else is a
+// custom property (usually referenced as ${name} in server.xml)
+var standardPrefixes = []string{
+	"tomcat.util.buf.StringCache.",
+	"tomcat.util.scan.",
+}
+
+// Property is a key/value pair
+type Property struct {
+	Key   string
+	Value string
+}
+
+// ConfigService provides operations for conf/catalina.properties
+type ConfigService struct {
+	catalinaBase string
+	filePath     string
+	props        *Properties
+}
+
+// NewConfigService creates a new catalina.properties service
+func NewConfigService(catalinaBase string) *ConfigService {
+	return &ConfigService{
+		catalinaBase: catalinaBase,
+		filePath:     filepath.Join(catalinaBase, "conf", "catalina.properties"),
+	}
+}
+
+// GetFilePath returns the path to catalina.properties
+func (s *ConfigService) GetFilePath() string {
+	return s.filePath
+}
+
+// GetProperties returns the underlying properties document
+func (s *ConfigService) GetProperties() *Properties {
+	return s.props
+}
+
+// Load reads catalina.properties. A missing file loads as empty.
+func (s *ConfigService) Load() error {
+	file, err := os.Open(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			s.props = NewProperties()
+			return nil
+		}
+		return fmt.Errorf("failed to open catalina.properties: %w", err)
+	}
+	defer file.Close()
+
+	props, err := ParseProperties(file)
+	if err != nil {
+		return fmt.Errorf("failed to parse catalina.properties: %w", err)
+	}
+	s.props = props
+	return nil
+}
+
+// Save writes catalina.properties after backing up the current version
+func (s *ConfigService) Save() error {
+	if s.props == nil {
+		return fmt.Errorf("no catalina.properties loaded")
+	}
+
+	if err := s.createBackup(); err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, []byte(s.props.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write catalina.properties: %w", err)
+	}
+	return nil
+}
+
+func (s *ConfigService) createBackup() error {
+	backupDir := filepath.Join(s.catalinaBase, "conf", "backup")
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // No file to backup
+		}
+		return err
+	}
+
+	backupPath := filepath.Join(backupDir, "catalina.properties.bak")
+	return os.WriteFile(backupPath, data, 0644)
+}
+
+// GetList returns a comma-separated property as items. Class loader
+// entries are returned without their surrounding quotes.
+func (s *ConfigService) GetList(key string) []string {
+	value, _ := s.props.Get(key)
+	items := SplitList(value)
+	for i, item := range items {
+		if len(item) >= 2 && item[0] == '"' && item[len(item)-1] == '"' {
+			items[i] = item[1 : len(item)-1]
+		}
+	}
+	return items
+}
+
+// SetList sets a comma-separated property. Class loader entries are
+// quoted, as Tomcat requires for paths containing commas or spaces.
+func (s *ConfigService) SetList(key string, items []string) {
+	values := make([]string, len(items))
+	for i, item := range items {
+		if IsLoaderKey(key) {
+			item = `"` + strings.Trim(item, `"`) + `"`
+		}
+		values[i] = item
+	}
+	s.props.SetList(key, values)
+}
+
+// IsLoaderKey reports whether key is a class loader property
+func IsLoaderKey(key string) bool {
+	for _, k := range LoaderKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStandardKey reports whether key is defined by Tomcat itself
+func IsStandardKey(key string) bool {
+	for _, k := range LoaderKeys {
+		if k == key {
+			return true
+		}
+	}
+	for _, k := range PackageKeys {
+		if k == key {
+			return true
+		}
+	}
+	for _, prefix := range standardPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomProperties returns the properties not defined by Tomcat, sorted by key
+func (s *ConfigService) CustomProperties() []Property {
+	var custom []Property
+	for _, key := range s.props.Keys() {
+		if IsStandardKey(key) {
+			continue
+		}
+		value, _ := s.props.Get(key)
+		custom = append(custom, Property{Key: key, Value: value})
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i].Key < custom[j].Key })
+	return custom
+}
+
+// SetProperty sets a single-line property
+func (s *ConfigService) SetProperty(key, value string) {
+	s.props.Set(key, value)
+}
+
+// RemoveProperty removes a property
+func (s *ConfigService) RemoveProperty(key string) {
+	s.props.Delete(key)
+}
+
+// GetProperty returns a property value
+func (s *ConfigService) GetProperty(key string) (string, bool) {
+	return s.props.Get(key)
+}
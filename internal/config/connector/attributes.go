@@ -0,0 +1,270 @@
+package connector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+)
+
+// AttributeKind describes the value type of a connector attribute
+type AttributeKind string
+
+const (
+	AttrString AttributeKind = "string"
+	AttrInt    AttributeKind = "int"
+	AttrBool   AttributeKind = "bool"
+	AttrEnum   AttributeKind = "enum"
+)
+
+// AttributeScope describes which connector families an attribute applies to
+type AttributeScope int
+
+const (
+	ScopeHTTP AttributeScope = 1 << iota
+	ScopeAJP
+
+	ScopeAll = ScopeHTTP | ScopeAJP
+)
+
+// Attribute groups used to section generated forms
+const (
+	GroupGeneral     = "general"
+	GroupThreads     = "threads"
+	GroupTimeouts    = "timeouts"
+	GroupLimits      = "limits"
+	GroupRequest     = "request"
+	GroupProxy       = "proxy"
+	GroupCompression = "compression"
+	GroupAJP         = "ajp"
+	GroupSocket      = "socket"
+)
+
+// AttributeSpec is the metadata for a single Connector attribute.
+// Forms are generated from these specs, so supporting a new attribute
+// only requires adding an entry to the catalogue.
+type AttributeSpec struct {
+	Name     string
+	Kind     AttributeKind
+	Default  string
+	Min      int
+	Max      int // 0 means no upper bound
+	Ranged   bool
+	Options  []string
+	Scope    AttributeScope
+	Group    string
+	Advanced bool
+}
+
+// HelpKey returns the i18n key holding the attribute description
+func (a AttributeSpec) HelpKey() string {
+	return "help.connattr." + a.Name
+}
+
+// AppliesTo reports whether the attribute is valid for the connector type
+func (a AttributeSpec) AppliesTo(connType ConnectorType) bool {
+	if connType == ConnectorTypeAJP {
+		return a.Scope&ScopeAJP != 0
+	}
+	return a.Scope&ScopeHTTP != 0
+}
+
+// RangeString returns a human-readable range, or empty if unbounded
+func (a AttributeSpec) RangeString() string {
+	if !a.Ranged {
+		return ""
+	}
+	if a.Max == 0 {
+		return fmt.Sprintf(">= %d", a.Min)
+	}
+	return fmt.Sprintf("%d - %d", a.Min, a.Max)
+}
+
+// Validate checks a value against the attribute metadata. Empty values are
+// always valid and mean "use the Tomcat default"; ${...} expressions are
+// only known once Tomcat resolves them.
+func (a AttributeSpec) Validate(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" || placeholder.HasPlaceholder(value) {
+		return nil
+	}
+	switch a.Kind {
+	case AttrInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: %q is not a number", a.Name, value)
+		}
+		if a.Ranged && (n < a.Min || (a.Max != 0 && n > a.Max)) {
+			return fmt.Errorf("%s: %d is out of range (%s)", a.Name, n, a.RangeString())
+		}
+	case AttrBool:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%s: %q must be true or false", a.Name, value)
+		}
+	case AttrEnum:
+		for _, opt := range a.Options {
+			if opt == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: %q must be one of %s", a.Name, value, strings.Join(a.Options, ", "))
+	}
+	return nil
+}
+
+func intAttr(name, def string, min, max int, scope AttributeScope, group string, advanced bool) AttributeSpec {
+	return AttributeSpec{Name: name, Kind: AttrInt, Default: def, Min: min, Max: max, Ranged: true, Scope: scope, Group: group, Advanced: advanced}
+}
+
+func boolAttr(name, def string, scope AttributeScope, group string, advanced bool) AttributeSpec {
+	return AttributeSpec{Name: name, Kind: AttrBool, Default: def, Options: []string{"true", "false"}, Scope: scope, Group: group, Advanced: advanced}
+}
+
+func stringAttr(name, def string, scope AttributeScope, group string, advanced bool) AttributeSpec {
+	return AttributeSpec{Name: name, Kind: AttrString, Default: def, Scope: scope, Group: group, Advanced: advanced}
+}
+
+func enumAttr(name, def string, options []string, scope AttributeScope, group string, advanced bool) AttributeSpec {
+	return AttributeSpec{Name: name, Kind: AttrEnum, Default: def, Options: options, Scope: scope, Group: group, Advanced: advanced}
+}
+
+// connectorAttributes is the catalogue of Connector attributes (TLS
+// attributes are managed by the SSL/TLS forms and are not listed here)
+var connectorAttributes = []AttributeSpec{
+	// General
+	intAttr("port", "", 0, 65535, ScopeAll, GroupGeneral, false),
+	stringAttr("protocol", "HTTP/1.1", ScopeAll, GroupGeneral, false),
+	stringAttr("address", "", ScopeAll, GroupGeneral, false),
+	intAttr("redirectPort", "", 0, 65535, ScopeAll, GroupGeneral, false),
+	stringAttr("scheme", "http", ScopeAll, GroupGeneral, false),
+	boolAttr("secure", "false", ScopeAll, GroupGeneral, false),
+	stringAttr("executor", "", ScopeAll, GroupGeneral, false),
+	boolAttr("bindOnInit", "true", ScopeAll, GroupGeneral, true),
+	boolAttr("throwOnFailure", "false", ScopeAll, GroupGeneral, true),
+
+	// Threads
+	intAttr("maxThreads", "200", 1, 0, ScopeAll, GroupThreads, false),
+	intAttr("minSpareThreads", "10", 0, 0, ScopeAll, GroupThreads, false),
+	intAttr("acceptCount", "100", 0, 0, ScopeAll, GroupThreads, false),
+	intAttr("maxConnections", "8192", -1, 0, ScopeAll, GroupThreads, false),
+	boolAttr("useVirtualThreads", "false", ScopeAll, GroupThreads, false),
+	intAttr("threadPriority", "5", 1, 10, ScopeAll, GroupThreads, true),
+	intAttr("acceptorThreadPriority", "5", 1, 10, ScopeAll, GroupThreads, true),
+	intAttr("processorCache", "200", -1, 0, ScopeAll, GroupThreads, true),
+	intAttr("executorTerminationTimeoutMillis", "5000", 0, 0, ScopeAll, GroupThreads, true),
+
+	// Timeouts
+	intAttr("connectionTimeout", "60000", -1, 0, ScopeAll, GroupTimeouts, false),
+	intAttr("keepAliveTimeout", "", -1, 0, ScopeAll, GroupTimeouts, false),
+	intAttr("asyncTimeout", "30000", -1, 0, ScopeAll, GroupTimeouts, true),
+	boolAttr("disableUploadTimeout", "true", ScopeHTTP, GroupTimeouts, true),
+	intAttr("connectionUploadTimeout", "300000", 0, 0, ScopeHTTP, GroupTimeouts, true),
+	intAttr("selectorTimeout", "1000", 1, 0, ScopeAll, GroupTimeouts, true),
+
+	// Limits
+	intAttr("maxKeepAliveRequests", "100", -1, 0, ScopeHTTP, GroupLimits, false),
+	intAttr("maxHttpHeaderSize", "8192", 1, 0, ScopeHTTP, GroupLimits, false),
+	intAttr("maxPostSize", "2097152", -1, 0, ScopeAll, GroupLimits, false),
+	intAttr("maxSavePostSize", "4096", -1, 0, ScopeAll, GroupLimits, true),
+	intAttr("maxParameterCount", "10000", -1, 0, ScopeAll, GroupLimits, false),
+	intAttr("maxCookieCount", "200", -1, 0, ScopeAll, GroupLimits, true),
+	intAttr("maxSwallowSize", "2097152", -1, 0, ScopeHTTP, GroupLimits, true),
+	intAttr("maxTrailerSize", "8192", -1, 0, ScopeHTTP, GroupLimits, true),
+	intAttr("maxExtensionSize", "8192", -1, 0, ScopeHTTP, GroupLimits, true),
+
+	// Request handling
+	stringAttr("URIEncoding", "UTF-8", ScopeAll, GroupRequest, false),
+	boolAttr("useBodyEncodingForURI", "false", ScopeAll, GroupRequest, true),
+	boolAttr("enableLookups", "false", ScopeAll, GroupRequest, false),
+	boolAttr("allowTrace", "false", ScopeAll, GroupRequest, true),
+	stringAttr("parseBodyMethods", "POST", ScopeAll, GroupRequest, true),
+	stringAttr("relaxedPathChars", "", ScopeHTTP, GroupRequest, true),
+	stringAttr("relaxedQueryChars", "", ScopeHTTP, GroupRequest, true),
+	enumAttr("encodedSolidusHandling", "reject", []string{"reject", "decode", "passthrough"}, ScopeAll, GroupRequest, true),
+	boolAttr("rejectIllegalHeader", "true", ScopeHTTP, GroupRequest, true),
+	boolAttr("allowHostHeaderMismatch", "false", ScopeHTTP, GroupRequest, true),
+	stringAttr("restrictedUserAgents", "", ScopeHTTP, GroupRequest, true),
+	boolAttr("useIPVHosts", "false", ScopeAll, GroupRequest, true),
+	boolAttr("discardFacades", "true", ScopeAll, GroupRequest, true),
+
+	// Proxy and identity
+	stringAttr("proxyName", "", ScopeAll, GroupProxy, false),
+	intAttr("proxyPort", "", 0, 65535, ScopeAll, GroupProxy, false),
+	stringAttr("server", "", ScopeHTTP, GroupProxy, false),
+	boolAttr("serverRemoveAppProvidedValues", "false", ScopeHTTP, GroupProxy, true),
+	boolAttr("xpoweredBy", "false", ScopeAll, GroupProxy, false),
+
+	// Compression
+	stringAttr("compression", "off", ScopeHTTP, GroupCompression, false),
+	intAttr("compressionMinSize", "2048", 0, 0, ScopeHTTP, GroupCompression, false),
+	stringAttr("compressibleMimeType", "text/html,text/xml,text/plain,text/css,text/javascript,application/javascript,application/json,application/xml", ScopeHTTP, GroupCompression, true),
+	stringAttr("noCompressionUserAgents", "", ScopeHTTP, GroupCompression, true),
+	boolAttr("noCompressionStrongETag", "true", ScopeHTTP, GroupCompression, true),
+	boolAttr("useSendfile", "true", ScopeHTTP, GroupCompression, true),
+
+	// AJP
+	stringAttr("secret", "", ScopeAJP, GroupAJP, false),
+	boolAttr("secretRequired", "true", ScopeAJP, GroupAJP, false),
+	stringAttr("allowedRequestAttributesPattern", "", ScopeAJP, GroupAJP, true),
+	intAttr("packetSize", "8192", 8192, 65536, ScopeAJP, GroupAJP, true),
+	boolAttr("ajpFlush", "true", ScopeAJP, GroupAJP, true),
+	boolAttr("tomcatAuthentication", "true", ScopeAJP, GroupAJP, true),
+	boolAttr("tomcatAuthorization", "false", ScopeAJP, GroupAJP, true),
+
+	// Socket
+	intAttr("socket.rxBufSize", "", 1, 0, ScopeAll, GroupSocket, true),
+	intAttr("socket.txBufSize", "", 1, 0, ScopeAll, GroupSocket, true),
+	intAttr("socket.appReadBufSize", "8192", 1, 0, ScopeAll, GroupSocket, true),
+	intAttr("socket.appWriteBufSize", "8192", 1, 0, ScopeAll, GroupSocket, true),
+	boolAttr("socket.directBuffer", "false", ScopeAll, GroupSocket, true),
+	boolAttr("socket.tcpNoDelay", "true", ScopeAll, GroupSocket, true),
+	boolAttr("socket.soKeepAlive", "", ScopeAll, GroupSocket, true),
+	boolAttr("socket.soReuseAddress", "", ScopeAll, GroupSocket, true),
+	boolAttr("socket.soLingerOn", "", ScopeAll, GroupSocket, true),
+	intAttr("socket.soLingerTime", "", 0, 0, ScopeAll, GroupSocket, true),
+	intAttr("socket.soTimeout", "", 0, 0, ScopeAll, GroupSocket, true),
+	intAttr("socket.bufferPool", "500", -1, 0, ScopeAll, GroupSocket, true),
+	intAttr("socket.processorCache", "500", -1, 0, ScopeAll, GroupSocket, true),
+}
+
+// AttributeGroups returns the attribute groups in display order
+func AttributeGroups() []string {
+	return []string{
+		GroupGeneral, GroupThreads, GroupTimeouts, GroupLimits, GroupRequest,
+		GroupProxy, GroupCompression, GroupAJP, GroupSocket,
+	}
+}
+
+// Attributes returns the full connector attribute catalogue
+func Attributes() []AttributeSpec {
+	return connectorAttributes
+}
+
+// AttributesFor returns the attributes applicable to a connector type.
+// Advanced attributes are only included when advanced is true.
+func AttributesFor(connType ConnectorType, advanced bool) []AttributeSpec {
+	var specs []AttributeSpec
+	for _, group := range AttributeGroups() {
+		for _, spec := range connectorAttributes {
+			if spec.Group != group || !spec.AppliesTo(connType) {
+				continue
+			}
+			if spec.Advanced && !advanced {
+				continue
+			}
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+// LookupAttribute finds an attribute spec by XML name
+func LookupAttribute(name string) (AttributeSpec, bool) {
+	for _, spec := range connectorAttributes {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return AttributeSpec{}, false
+}
@@ -1,6 +1,7 @@
 package connector
 
 import (
+	"github.com/playok/tomcatkit/internal/config/placeholder"
 	"github.com/playok/tomcatkit/internal/config/server"
 )
 
@@ -59,26 +60,26 @@ func GetProtocolDescription(protocol string) string {
 // DefaultHTTPConnector creates a default HTTP connector
 func DefaultHTTPConnector() server.Connector {
 	return server.Connector{
-		Port:              8080,
+		Port:              placeholder.IntOf(8080),
 		Protocol:          ProtocolHTTP11Nio,
-		ConnectionTimeout: 20000,
-		RedirectPort:      8443,
-		MaxThreads:        200,
-		MinSpareThreads:   10,
+		ConnectionTimeout: placeholder.IntOf(20000),
+		RedirectPort:      placeholder.IntOf(8443),
+		MaxThreads:        placeholder.IntOf(200),
+		MinSpareThreads:   placeholder.IntOf(10),
 	}
 }
 
 // DefaultHTTPSConnector creates a default HTTPS connector
 func DefaultHTTPSConnector() server.Connector {
 	return server.Connector{
-		Port:              8443,
+		Port:              placeholder.IntOf(8443),
 		Protocol:          ProtocolHTTP11Nio,
-		SSLEnabled:        true,
+		SSLEnabled:        placeholder.BoolOf(true),
 		Scheme:            "https",
-		Secure:            true,
-		ConnectionTimeout: 20000,
-		MaxThreads:        200,
-		MinSpareThreads:   10,
+		Secure:            placeholder.BoolOf(true),
+		ConnectionTimeout: placeholder.IntOf(20000),
+		MaxThreads:        placeholder.IntOf(200),
+		MinSpareThreads:   placeholder.IntOf(10),
 		KeystoreFile:      "${user.home}/.keystore",
 		KeystorePass:      "changeit",
 		KeystoreType:      "JKS",
@@ -90,14 +91,68 @@ func DefaultHTTPSConnector() server.Connector {
 // DefaultAJPConnector creates a default AJP connector
 func DefaultAJPConnector() server.Connector {
 	return server.Connector{
-		Port:           8009,
+		Port:           placeholder.IntOf(8009),
 		Protocol:       ProtocolAJPNio,
-		RedirectPort:   8443,
-		SecretRequired: true,
+		RedirectPort:   placeholder.IntOf(8443),
+		SecretRequired: placeholder.BoolOf(true),
 		Secret:         "",
 	}
 }
 
+// DefaultHTTP2UpgradeProtocol creates an HTTP/2 upgrade protocol with Tomcat defaults
+func DefaultHTTP2UpgradeProtocol() server.UpgradeProtocol {
+	return server.UpgradeProtocol{
+		ClassName:            ProtocolHTTP2,
+		MaxConcurrentStreams: placeholder.IntOf(100),
+		InitialWindowSize:    placeholder.IntOf(65535),
+		ReadTimeout:          placeholder.IntOf(5000),
+		KeepAliveTimeout:     placeholder.IntOf(20000),
+		OverheadCountFactor:  placeholder.IntOf(10),
+	}
+}
+
+// FindHTTP2UpgradeProtocol returns the HTTP/2 upgrade protocol of a connector, if any
+func FindHTTP2UpgradeProtocol(conn *server.Connector) *server.UpgradeProtocol {
+	for i := range conn.UpgradeProtocols {
+		if conn.UpgradeProtocols[i].ClassName == ProtocolHTTP2 {
+			return &conn.UpgradeProtocols[i]
+		}
+	}
+	return nil
+}
+
+// HasHTTP2 returns true if the connector has HTTP/2 enabled via UpgradeProtocol
+func HasHTTP2(conn *server.Connector) bool {
+	return FindHTTP2UpgradeProtocol(conn) != nil
+}
+
+// EnableHTTP2 adds the HTTP/2 upgrade protocol to a connector. When it is
+// there already only its tuning settings are updated; other attributes are
+// kept.
+func EnableHTTP2(conn *server.Connector, upgrade server.UpgradeProtocol) {
+	upgrade.ClassName = ProtocolHTTP2
+	if existing := FindHTTP2UpgradeProtocol(conn); existing != nil {
+		existing.MaxConcurrentStreams = upgrade.MaxConcurrentStreams
+		existing.InitialWindowSize = upgrade.InitialWindowSize
+		existing.ReadTimeout = upgrade.ReadTimeout
+		existing.KeepAliveTimeout = upgrade.KeepAliveTimeout
+		existing.OverheadCountFactor = upgrade.OverheadCountFactor
+		return
+	}
+	conn.UpgradeProtocols = append(conn.UpgradeProtocols, upgrade)
+}
+
+// DisableHTTP2 removes the HTTP/2 upgrade protocol from a connector
+func DisableHTTP2(conn *server.Connector) {
+	var remaining []server.UpgradeProtocol
+	for _, up := range conn.UpgradeProtocols {
+		if up.ClassName != ProtocolHTTP2 {
+			remaining = append(remaining, up)
+		}
+	}
+	conn.UpgradeProtocols = remaining
+}
+
 // AvailableProtocols returns available HTTP protocols
 func AvailableHTTPProtocols() []string {
 	return []string{
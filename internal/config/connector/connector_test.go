@@ -0,0 +1,69 @@
+package connector
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/config/server"
+)
+
+// An overheadCountFactor of 0 disables the overhead protection, so it has
+// to be written out rather than left to the default of 10
+func TestHTTP2OverheadCountFactorZeroRoundTrips(t *testing.T) {
+	upgrade := DefaultHTTP2UpgradeProtocol()
+	factor, err := placeholder.ParseInt("0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upgrade.OverheadCountFactor = factor
+
+	conn := DefaultHTTPConnector()
+	EnableHTTP2(&conn, upgrade)
+	data, err := xml.Marshal(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var read server.Connector
+	if err := xml.Unmarshal(data, &read); err != nil {
+		t.Fatal(err)
+	}
+	got := FindHTTP2UpgradeProtocol(&read)
+	if got == nil {
+		t.Fatalf("UpgradeProtocol missing from %s", data)
+	}
+	if got.OverheadCountFactor.Text() != "0" {
+		t.Errorf("overheadCountFactor = %q, want \"0\" in %s", got.OverheadCountFactor.Text(), data)
+	}
+}
+
+// Http2Protocol attributes without a field of their own survive a load and
+// save, and enabling HTTP/2 again only updates the tuning settings
+func TestHTTP2KeepsOtherAttributes(t *testing.T) {
+	var conn server.Connector
+	err := xml.Unmarshal([]byte(`<Connector port="8443">
+  <UpgradeProtocol className="org.apache.coyote.http2.Http2Protocol" compression="on" maxHeaderSize="16384" readTimeout="3000"/>
+</Connector>`), &conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upgrade := DefaultHTTP2UpgradeProtocol()
+	EnableHTTP2(&conn, upgrade)
+	if len(conn.UpgradeProtocols) != 1 {
+		t.Fatalf("UpgradeProtocols = %d, want 1", len(conn.UpgradeProtocols))
+	}
+
+	data, err := xml.Marshal(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{`compression="on"`, `maxHeaderSize="16384"`, `readTimeout="5000"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%s missing from %s", want, out)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package connector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config/server"
+)
+
+// FindExecutor returns the executor with the given name in a service
+func FindExecutor(svc *server.Service, name string) *server.Executor {
+	if svc == nil || name == "" {
+		return nil
+	}
+	for i := range svc.Executors {
+		if svc.Executors[i].Name == name {
+			return &svc.Executors[i]
+		}
+	}
+	return nil
+}
+
+// ExecutorUsers returns the indexes of connectors that reference an executor
+func ExecutorUsers(svc *server.Service, name string) []int {
+	var users []int
+	if svc == nil || name == "" {
+		return users
+	}
+	for i, conn := range svc.Connectors {
+		if conn.Executor == name {
+			users = append(users, i)
+		}
+	}
+	return users
+}
+
+// AssignExecutor moves a connector onto a shared executor. The inline
+// thread settings are cleared because Tomcat ignores them while an
+// executor is in use.
+func AssignExecutor(svc *server.Service, connectorIndex int, name string) error {
+	if connectorIndex < 0 || connectorIndex >= len(svc.Connectors) {
+		return fmt.Errorf("connector %d not found", connectorIndex)
+	}
+	if FindExecutor(svc, name) == nil {
+		return fmt.Errorf("executor '%s' not found", name)
+	}
+
+	conn := &svc.Connectors[connectorIndex]
+	conn.Executor = name
+	conn.MaxThreads = ""
+	conn.MinSpareThreads = ""
+	return nil
+}
+
+// UseInternalPool moves a connector back to its own thread pool, seeding
+// the inline settings from the executor so capacity is preserved
+func UseInternalPool(svc *server.Service, connectorIndex int) error {
+	if connectorIndex < 0 || connectorIndex >= len(svc.Connectors) {
+		return fmt.Errorf("connector %d not found", connectorIndex)
+	}
+
+	conn := &svc.Connectors[connectorIndex]
+	if exec := FindExecutor(svc, conn.Executor); exec != nil && !exec.IsVirtualThread() {
+		if conn.MaxThreads == "" {
+			conn.MaxThreads = exec.MaxThreads
+		}
+		if conn.MinSpareThreads == "" {
+			conn.MinSpareThreads = exec.MinSpareThreads
+		}
+	}
+	conn.Executor = ""
+	return nil
+}
+
+// ConvertToExecutor creates a new shared executor from a connector's inline
+// maxThreads/minSpareThreads and moves the connector onto it
+func ConvertToExecutor(svc *server.Service, connectorIndex int, name string) (*server.Executor, error) {
+	if connectorIndex < 0 || connectorIndex >= len(svc.Connectors) {
+		return nil, fmt.Errorf("connector %d not found", connectorIndex)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("executor name is required")
+	}
+	if FindExecutor(svc, name) != nil {
+		return nil, fmt.Errorf("executor '%s' already exists", name)
+	}
+
+	conn := &svc.Connectors[connectorIndex]
+	exec := server.NewStandardExecutor(name)
+	if conn.MaxThreads != "" {
+		exec.MaxThreads = conn.MaxThreads
+	}
+	if conn.MinSpareThreads != "" {
+		exec.MinSpareThreads = conn.MinSpareThreads
+	}
+	svc.Executors = append(svc.Executors, *exec)
+
+	if err := AssignExecutor(svc, connectorIndex, name); err != nil {
+		return nil, err
+	}
+	return &svc.Executors[len(svc.Executors)-1], nil
+}
+
+// RenameExecutor renames an executor and the executor references of the
+// connectors using it
+func RenameExecutor(svc *server.Service, executorIndex int, name string) error {
+	if executorIndex < 0 || executorIndex >= len(svc.Executors) {
+		return fmt.Errorf("executor %d not found", executorIndex)
+	}
+	old := svc.Executors[executorIndex].Name
+	if name == old {
+		return nil
+	}
+	if FindExecutor(svc, name) != nil {
+		return fmt.Errorf("executor '%s' already exists", name)
+	}
+
+	for _, ci := range ExecutorUsers(svc, old) {
+		svc.Connectors[ci].Executor = name
+	}
+	svc.Executors[executorIndex].Name = name
+	return nil
+}
+
+// RemoveExecutor deletes an executor unless connectors still reference it
+func RemoveExecutor(svc *server.Service, executorIndex int) error {
+	if executorIndex < 0 || executorIndex >= len(svc.Executors) {
+		return fmt.Errorf("executor %d not found", executorIndex)
+	}
+
+	name := svc.Executors[executorIndex].Name
+	if users := ExecutorUsers(svc, name); len(users) > 0 {
+		portList := make([]string, len(users))
+		for i, idx := range users {
+			portList[i] = svc.Connectors[idx].Port.String()
+		}
+		return fmt.Errorf("executor '%s' is still used by connector(s) on port %s", name, strings.Join(portList, ", "))
+	}
+
+	svc.Executors = append(svc.Executors[:executorIndex], svc.Executors[executorIndex+1:]...)
+	return nil
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Environment is the stage an inventory entry belongs to
+type Environment string
+
+const (
+	EnvNone  Environment = ""
+	EnvDev   Environment = "dev"
+	EnvStage Environment = "stage"
+	EnvProd  Environment = "prod"
+)
+
+// Environments returns the selectable environments
+func Environments() []Environment {
+	return []Environment{EnvNone, EnvDev, EnvStage, EnvProd}
+}
+
+// InventoryColors are the tview colour names an entry can be shown in
+var InventoryColors = []string{"", "red", "orange", "yellow", "green", "aqua", "blue", "fuchsia", "white"}
+
+// InventoryEntry is an instance the user has named and tagged. Entries are
+// identified by CATALINA_HOME and CATALINA_BASE together, as several bases
+// can share a home.
+type InventoryEntry struct {
+	Name         string      `json:"name"`
+	CatalinaHome string      `json:"catalina_home"`
+	CatalinaBase string      `json:"catalina_base"`
+	Environment  Environment `json:"environment,omitempty"`
+	Notes        string      `json:"notes,omitempty"`
+	Color        string      `json:"color,omitempty"`
+	Added        time.Time   `json:"added"`
+}
+
+// InstanceKey identifies an instance by its home and base; an empty base
+// is the home
+func InstanceKey(catalinaHome, catalinaBase string) string {
+	if catalinaBase == "" {
+		catalinaBase = catalinaHome
+	}
+	return filepath.Clean(catalinaHome) + "\x00" + filepath.Clean(catalinaBase)
+}
+
+// Key returns the InstanceKey of the entry
+func (e *InventoryEntry) Key() string {
+	return InstanceKey(e.CatalinaHome, e.CatalinaBase)
+}
+
+// Instance returns the instance the entry names
+func (e *InventoryEntry) Instance() *TomcatInstance {
+	base := e.CatalinaBase
+	if base == "" {
+		base = e.CatalinaHome
+	}
+	return &TomcatInstance{CatalinaHome: e.CatalinaHome, CatalinaBase: base}
+}
+
+// IsProduction reports whether the entry is tagged prod
+func (e *InventoryEntry) IsProduction() bool {
+	return e.Environment == EnvProd
+}
+
+// Matches reports whether the name, paths, environment or notes contain
+// query, ignoring case
+func (e *InventoryEntry) Matches(query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+	for _, field := range []string{e.Name, e.CatalinaHome, e.CatalinaBase, string(e.Environment), e.Notes} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetInventory returns the inventory sorted by name
+func (m *SettingsManager) GetInventory() []InventoryEntry {
+	entries := append([]InventoryEntry{}, m.settings.Inventory...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
+	})
+	return entries
+}
+
+// FilterInventory returns the entries matching query and, unless env is
+// EnvNone, tagged env
+func (m *SettingsManager) FilterInventory(query string, env Environment) []InventoryEntry {
+	var entries []InventoryEntry
+	for _, entry := range m.GetInventory() {
+		if env != EnvNone && entry.Environment != env {
+			continue
+		}
+		if entry.Matches(query) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// FindInventoryEntry returns the entry of an instance, or nil
+func (m *SettingsManager) FindInventoryEntry(instance *TomcatInstance) *InventoryEntry {
+	if instance == nil {
+		return nil
+	}
+	key := InstanceKey(instance.CatalinaHome, instance.CatalinaBase)
+	for i := range m.settings.Inventory {
+		if m.settings.Inventory[i].Key() == key {
+			entry := m.settings.Inventory[i]
+			return &entry
+		}
+	}
+	return nil
+}
+
+// PutInventoryEntry adds an entry or replaces the entry of the same
+// instance, keeping the time it was first added
+func (m *SettingsManager) PutInventoryEntry(entry InventoryEntry) {
+	for i := range m.settings.Inventory {
+		if m.settings.Inventory[i].Key() == entry.Key() {
+			entry.Added = m.settings.Inventory[i].Added
+			m.settings.Inventory[i] = entry
+			return
+		}
+	}
+	if entry.Added.IsZero() {
+		entry.Added = time.Now()
+	}
+	m.settings.Inventory = append(m.settings.Inventory, entry)
+}
+
+// RemoveInventoryEntry removes the entry of an instance
+func (m *SettingsManager) RemoveInventoryEntry(instance *TomcatInstance) {
+	key := InstanceKey(instance.CatalinaHome, instance.CatalinaBase)
+	for i := range m.settings.Inventory {
+		if m.settings.Inventory[i].Key() == key {
+			m.settings.Inventory = append(m.settings.Inventory[:i], m.settings.Inventory[i+1:]...)
+			return
+		}
+	}
+}
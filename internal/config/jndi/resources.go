@@ -66,6 +66,9 @@ type Resource struct {
 	MailSmtpUser          string `xml:"mail.smtp.user,attr,omitempty"`
 	MailTransportProtocol string `xml:"mail.transport.protocol,attr,omitempty"`
 	MailDebug             string `xml:"mail.debug,attr,omitempty"`
+	// Any other attribute (maxActive, jdbcInterceptors, factory settings,
+	// ...) preserved as-is
+	ExtraAttrs []xml.Attr `xml:",any,attr"`
 }
 
 // Environment represents an environment entry
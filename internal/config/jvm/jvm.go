@@ -0,0 +1,225 @@
+package jvm
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Script identifies a setenv script flavour
+type Script string
+
+const (
+	ScriptSh  Script = "setenv.sh"
+	ScriptBat Script = "setenv.bat"
+)
+
+// GarbageCollector is a GC selected with -XX:+Use...GC
+type GarbageCollector string
+
+const (
+	GCDefault    GarbageCollector = ""
+	GCG1         GarbageCollector = "G1"
+	GCParallel   GarbageCollector = "Parallel"
+	GCSerial     GarbageCollector = "Serial"
+	GCZ          GarbageCollector = "Z"
+	GCShenandoah GarbageCollector = "Shenandoah"
+	GCCMS        GarbageCollector = "ConcMarkSweep"
+)
+
+// AvailableGCs returns the selectable garbage collectors. CMS only exists
+// up to Java 13.
+func AvailableGCs() []GarbageCollector {
+	return []GarbageCollector{GCDefault, GCG1, GCParallel, GCSerial, GCZ, GCShenandoah, GCCMS}
+}
+
+// Flag returns the -XX option that selects the collector
+func (gc GarbageCollector) Flag() string {
+	if gc == GCDefault {
+		return ""
+	}
+	return "-XX:+Use" + string(gc) + "GC"
+}
+
+// SystemProperty is a -Dname=value option
+type SystemProperty struct {
+	Name  string
+	Value string
+}
+
+// String returns the property as a JVM option
+func (p SystemProperty) String() string {
+	if p.Value == "" {
+		return "-D" + p.Name
+	}
+	return "-D" + p.Name + "=" + p.Value
+}
+
+// JMXOptions holds the com.sun.management.jmxremote settings. Settings
+// are written whenever they are set, also with JMX remote disabled.
+type JMXOptions struct {
+	Enabled      bool // -Dcom.sun.management.jmxremote or a port is set
+	Port         string
+	RMIPort      string
+	Authenticate string // "true", "false" or "" when not set
+	SSL          string // "true", "false" or "" when not set
+	Hostname     string // java.rmi.server.hostname
+}
+
+// Options are the JVM settings of a setenv script
+type Options struct {
+	JavaHome    string
+	CatalinaPID string
+	HeapMin     string // -Xms value, e.g. "512m"
+	HeapMax     string // -Xmx value
+	GC          GarbageCollector
+	XXFlags     []string // -XX options without the prefix, e.g. "MaxMetaspaceSize=256m"
+	Properties  []SystemProperty
+	JMX         JMXOptions
+	Other       []string // Options that are kept as written
+	OptsVar     string   // Variable holding the options (CATALINA_OPTS or JAVA_OPTS)
+}
+
+// JMX system property names
+const (
+	jmxPrefix       = "com.sun.management.jmxremote"
+	jmxPort         = jmxPrefix + ".port"
+	jmxRMIPort      = jmxPrefix + ".rmi.port"
+	jmxAuthenticate = jmxPrefix + ".authenticate"
+	jmxSSL          = jmxPrefix + ".ssl"
+	rmiHostname     = "java.rmi.server.hostname"
+)
+
+var memorySizeRe = regexp.MustCompile(`^\d+[kKmMgGtT]?$`)
+
+// ValidMemorySize reports whether size is a JVM memory size such as
+// "512m" or "2g"
+func ValidMemorySize(size string) bool {
+	return memorySizeRe.MatchString(size)
+}
+
+// NewOptions returns empty options using CATALINA_OPTS
+func NewOptions() *Options {
+	return &Options{OptsVar: "CATALINA_OPTS"}
+}
+
+// GetProperty returns the value of a system property
+func (o *Options) GetProperty(name string) (string, bool) {
+	for _, p := range o.Properties {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// SetProperty adds or updates a system property
+func (o *Options) SetProperty(name, value string) {
+	for i := range o.Properties {
+		if o.Properties[i].Name == name {
+			o.Properties[i].Value = value
+			return
+		}
+	}
+	o.Properties = append(o.Properties, SystemProperty{Name: name, Value: value})
+}
+
+// RemoveProperty removes a system property
+func (o *Options) RemoveProperty(name string) {
+	for i := range o.Properties {
+		if o.Properties[i].Name == name {
+			o.Properties = append(o.Properties[:i], o.Properties[i+1:]...)
+			return
+		}
+	}
+}
+
+// parseOption sorts a single JVM option into its structured field
+func (o *Options) parseOption(opt string) {
+	switch {
+	case strings.HasPrefix(opt, "-Xms"):
+		o.HeapMin = strings.TrimPrefix(opt, "-Xms")
+	case strings.HasPrefix(opt, "-Xmx"):
+		o.HeapMax = strings.TrimPrefix(opt, "-Xmx")
+	case strings.HasPrefix(opt, "-XX:"):
+		flag := strings.TrimPrefix(opt, "-XX:")
+		if strings.HasPrefix(flag, "+Use") && strings.HasSuffix(flag, "GC") {
+			name := GarbageCollector(strings.TrimSuffix(strings.TrimPrefix(flag, "+Use"), "GC"))
+			if isKnownGC(name) {
+				o.GC = name
+				return
+			}
+		}
+		o.XXFlags = append(o.XXFlags, flag)
+	case strings.HasPrefix(opt, "-D"):
+		name, value, _ := strings.Cut(strings.TrimPrefix(opt, "-D"), "=")
+		switch name {
+		case jmxPrefix:
+			o.JMX.Enabled = true
+		case jmxPort:
+			o.JMX.Enabled = true
+			o.JMX.Port = value
+		case jmxRMIPort:
+			o.JMX.RMIPort = value
+		case jmxAuthenticate:
+			o.JMX.Authenticate = value
+		case jmxSSL:
+			o.JMX.SSL = value
+		case rmiHostname:
+			o.JMX.Hostname = value
+		default:
+			o.Properties = append(o.Properties, SystemProperty{Name: name, Value: value})
+		}
+	default:
+		o.Other = append(o.Other, opt)
+	}
+}
+
+func isKnownGC(gc GarbageCollector) bool {
+	for _, known := range AvailableGCs() {
+		if known != GCDefault && known == gc {
+			return true
+		}
+	}
+	return false
+}
+
+// JVMArgs returns the options in a stable order: heap, GC, -XX flags,
+// JMX, system properties and finally the options kept as written. They are
+// not quoted; see QuoteArg.
+func (o *Options) JVMArgs() []string {
+	var args []string
+	if o.HeapMin != "" {
+		args = append(args, "-Xms"+o.HeapMin)
+	}
+	if o.HeapMax != "" {
+		args = append(args, "-Xmx"+o.HeapMax)
+	}
+	if flag := o.GC.Flag(); flag != "" {
+		args = append(args, flag)
+	}
+	for _, flag := range o.XXFlags {
+		args = append(args, "-XX:"+flag)
+	}
+	if o.JMX.Enabled {
+		args = append(args, "-D"+jmxPrefix)
+	}
+	if o.JMX.Port != "" {
+		args = append(args, "-D"+jmxPort+"="+o.JMX.Port)
+	}
+	if o.JMX.RMIPort != "" {
+		args = append(args, "-D"+jmxRMIPort+"="+o.JMX.RMIPort)
+	}
+	if o.JMX.Authenticate != "" {
+		args = append(args, "-D"+jmxAuthenticate+"="+o.JMX.Authenticate)
+	}
+	if o.JMX.SSL != "" {
+		args = append(args, "-D"+jmxSSL+"="+o.JMX.SSL)
+	}
+	if o.JMX.Hostname != "" {
+		args = append(args, "-D"+rmiHostname+"="+o.JMX.Hostname)
+	}
+	for _, p := range o.Properties {
+		args = append(args, p.String())
+	}
+	return append(args, o.Other...)
+}
@@ -0,0 +1,487 @@
+package jvm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// lineKind tells which setting a script line holds
+type lineKind int
+
+const (
+	lineRaw lineKind = iota // Kept as written
+	lineJavaHome
+	linePID
+	lineOpts
+)
+
+// scriptLine is one line of the script with what it was recognised as
+type scriptLine struct {
+	text string
+	kind lineKind
+}
+
+var (
+	shAssignRe  = regexp.MustCompile(`^\s*(export\s+)?(JAVA_HOME|CATALINA_PID|CATALINA_OPTS|JAVA_OPTS)=(.*)$`)
+	batAssignRe = regexp.MustCompile(`(?i)^\s*set\s+"?(JAVA_HOME|CATALINA_PID|CATALINA_OPTS|JAVA_OPTS)=(.*?)"?\s*$`)
+)
+
+// ConfigService provides operations for bin/setenv.sh and bin/setenv.bat
+type ConfigService struct {
+	catalinaBase string
+	script       Script
+	filePath     string
+	options      *Options
+	lines        []scriptLine
+	exported     map[lineKind]bool   // Assignments written as "export VAR=..."
+	loaded       map[lineKind]string // Settings as rendered right after Load
+	appendOpts   bool                // Options are appended to the inherited variable
+	crlf         bool
+	exists       bool
+}
+
+// NewConfigService creates a new setenv service for the given script
+func NewConfigService(catalinaBase string, script Script) *ConfigService {
+	return &ConfigService{
+		catalinaBase: catalinaBase,
+		script:       script,
+		filePath:     filepath.Join(catalinaBase, "bin", string(script)),
+	}
+}
+
+// GetFilePath returns the path to the script
+func (s *ConfigService) GetFilePath() string {
+	return s.filePath
+}
+
+// GetScript returns the script flavour
+func (s *ConfigService) GetScript() Script {
+	return s.script
+}
+
+// Exists reports whether the script existed when it was loaded
+func (s *ConfigService) Exists() bool {
+	return s.exists
+}
+
+// GetOptions returns the parsed options
+func (s *ConfigService) GetOptions() *Options {
+	return s.options
+}
+
+// Load reads and parses the script. A missing script loads as empty
+// options and is created on Save.
+func (s *ConfigService) Load() error {
+	s.options = NewOptions()
+	s.lines = nil
+	s.exported = make(map[lineKind]bool)
+	s.loaded = make(map[lineKind]string)
+	s.appendOpts = true
+	s.crlf = s.script == ScriptBat
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			s.exists = false
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", s.script, err)
+	}
+	s.exists = true
+
+	content := string(data)
+	s.crlf = strings.Contains(content, "\r\n")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.TrimSuffix(content, "\n")
+
+	rawLines := strings.Split(content, "\n")
+
+	// Prefer CATALINA_OPTS; only manage JAVA_OPTS when it is all there is
+	s.options.OptsVar = "JAVA_OPTS"
+	for _, line := range rawLines {
+		if name, _, _, ok := s.parseAssignment(line); ok && name == "CATALINA_OPTS" {
+			s.options.OptsVar = "CATALINA_OPTS"
+			break
+		}
+	}
+	hasOpts := false
+	sawSelfReference := false
+
+	for _, line := range rawLines {
+		name, value, exported, ok := s.parseAssignment(line)
+		if !ok {
+			s.lines = append(s.lines, scriptLine{text: line})
+			continue
+		}
+
+		switch name {
+		case "JAVA_HOME":
+			s.options.JavaHome = value
+			s.lines = append(s.lines, scriptLine{text: line, kind: lineJavaHome})
+		case "CATALINA_PID":
+			s.options.CatalinaPID = value
+			s.lines = append(s.lines, scriptLine{text: line, kind: linePID})
+		case s.options.OptsVar:
+			hasOpts = true
+			for _, opt := range s.splitOptions(value) {
+				if s.isSelfReference(opt) {
+					sawSelfReference = true
+					continue
+				}
+				s.options.parseOption(opt)
+			}
+			s.lines = append(s.lines, scriptLine{text: line, kind: lineOpts})
+		default:
+			s.lines = append(s.lines, scriptLine{text: line})
+			continue
+		}
+		if exported {
+			s.exported[s.lines[len(s.lines)-1].kind] = true
+		}
+	}
+
+	if hasOpts {
+		s.appendOpts = sawSelfReference
+	} else {
+		s.options.OptsVar = "CATALINA_OPTS"
+	}
+
+	for _, kind := range []lineKind{lineJavaHome, linePID, lineOpts} {
+		s.loaded[kind] = s.generateLine(kind)
+	}
+	return nil
+}
+
+// parseAssignment recognises a plain assignment of a managed variable.
+// Values using command substitution are left alone.
+func (s *ConfigService) parseAssignment(line string) (name, value string, exported, ok bool) {
+	if s.script == ScriptBat {
+		m := batAssignRe.FindStringSubmatch(line)
+		if m == nil {
+			return "", "", false, false
+		}
+		return strings.ToUpper(m[1]), m[2], false, true
+	}
+
+	m := shAssignRe.FindStringSubmatch(line)
+	if m == nil {
+		return "", "", false, false
+	}
+	value = strings.TrimSpace(m[3])
+	if strings.Contains(value, "`") || strings.Contains(value, "$(") {
+		return "", "", false, false
+	}
+	// The value is a single shell word, possibly followed by a comment
+	words, ok := shellWords(value)
+	if !ok || len(words) > 1 {
+		return "", "", false, false
+	}
+	value = ""
+	if len(words) == 1 {
+		value = words[0]
+	}
+	return m[2], value, m[1] != "", true
+}
+
+// isSelfReference reports whether an option is the inherited variable,
+// as in CATALINA_OPTS="$CATALINA_OPTS -Xmx1g"
+func (s *ConfigService) isSelfReference(opt string) bool {
+	v := s.options.OptsVar
+	return opt == "$"+v || opt == "${"+v+"}" || strings.EqualFold(opt, "%"+v+"%")
+}
+
+// splitOptions splits the options as they reach the JVM. catalina.sh
+// evaluates them with eval, so shell quoting applies and -Dfoo="a b" is a
+// single option; cmd leaves them to the Java launcher, which only knows
+// double quotes.
+func (s *ConfigService) splitOptions(value string) []string {
+	if s.script == ScriptBat {
+		return batWords(value)
+	}
+	words, _ := shellWords(value)
+	return words
+}
+
+// shellWords splits text into words as a POSIX shell does, without
+// expanding anything: quotes and backslashes are removed, except that \$
+// and \` stay escaped as the value is expanded later. An unquoted # starts
+// a comment. ok is false when a quote is not closed.
+func shellWords(text string) (words []string, ok bool) {
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	flush := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+			if quote == '"' && !strings.ContainsRune("$`\"\\\n", r) {
+				word.WriteRune('\\')
+			} else if r == '$' || r == '`' {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(r)
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			flush()
+		case r == '#' && !inWord:
+			flush()
+			return words, true
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return words, false
+	}
+	flush()
+	return words, true
+}
+
+// batWords splits on whitespace outside double quotes and removes the quotes
+func batWords(text string) []string {
+	var words []string
+	var word strings.Builder
+	inWord, quoted := false, false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inWord = true
+		case (r == ' ' || r == '\t') && !quoted:
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+// shellSpecial are the characters that make an option need quoting
+const shellSpecial = " \t'\"\\;&|<>()*?[]{}#~"
+
+// QuoteArg quotes an option for CATALINA_OPTS or JAVA_OPTS in setenv.sh,
+// which catalina.sh evaluates with eval. Options that need no quoting are
+// returned as they are; in -Dname=value options only the value is quoted.
+// $ is left for the shell to expand.
+func QuoteArg(arg string) string {
+	if !strings.ContainsAny(arg, shellSpecial) {
+		return arg
+	}
+	if name, value, ok := strings.Cut(arg, "="); ok && !strings.ContainsAny(name, shellSpecial) {
+		return name + "=" + doubleQuote(value)
+	}
+	return doubleQuote(arg)
+}
+
+// doubleQuote wraps text in double quotes, escaping what the shell would
+// otherwise take from it. \$ and \` are kept as they are.
+func doubleQuote(text string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '"':
+			b.WriteString(`\"`)
+		case c == '\\' && i+1 < len(text) && (text[i+1] == '$' || text[i+1] == '`'):
+			b.WriteString(text[i : i+2])
+			i++
+		case c == '\\':
+			b.WriteString(`\\`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// quoteBatArg quotes the value of an option with whitespace for setenv.bat
+func quoteBatArg(arg string) string {
+	if !strings.ContainsAny(arg, " \t") {
+		return arg
+	}
+	if name, value, ok := strings.Cut(arg, "="); ok && !strings.ContainsAny(name, " \t") {
+		return name + `="` + value + `"`
+	}
+	return `"` + arg + `"`
+}
+
+// UnmanagedLines returns the non-blank lines kept exactly as written
+func (s *ConfigService) UnmanagedLines() []string {
+	var lines []string
+	for _, l := range s.lines {
+		if l.kind == lineRaw && strings.TrimSpace(l.text) != "" {
+			lines = append(lines, l.text)
+		}
+	}
+	return lines
+}
+
+// Save writes the script after backing up the current version
+func (s *ConfigService) Save() error {
+	if s.options == nil {
+		return fmt.Errorf("no %s loaded", s.script)
+	}
+
+	if err := s.createBackup(); err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create bin directory: %w", err)
+	}
+
+	mode := os.FileMode(0644)
+	if s.script == ScriptSh {
+		mode = 0755
+	}
+	if err := os.WriteFile(s.filePath, []byte(s.GenerateContent()), mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.script, err)
+	}
+	s.exists = true
+	return nil
+}
+
+func (s *ConfigService) createBackup() error {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // No file to backup
+		}
+		return err
+	}
+
+	backupDir := filepath.Join(s.catalinaBase, "conf", "backup")
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return err
+	}
+
+	backupPath := filepath.Join(backupDir, string(s.script)+".bak")
+	return os.WriteFile(backupPath, data, 0644)
+}
+
+// GenerateContent renders the script. Settings that did not change keep
+// their original lines; changed settings replace the line they were read
+// from and settings that were not in the file are appended.
+func (s *ConfigService) GenerateContent() string {
+	var out []string
+	if len(s.lines) == 0 && s.script == ScriptSh {
+		out = append(out, "#!/bin/sh")
+	}
+
+	written := make(map[lineKind]bool)
+	emit := func(kind lineKind) {
+		if written[kind] {
+			return
+		}
+		written[kind] = true
+		if line := s.generateLine(kind); line != "" {
+			out = append(out, line)
+		}
+	}
+
+	for _, l := range s.lines {
+		if l.kind == lineRaw {
+			out = append(out, l.text)
+			continue
+		}
+		if s.generateLine(l.kind) == s.loaded[l.kind] {
+			written[l.kind] = true
+			out = append(out, l.text)
+			continue
+		}
+		emit(l.kind)
+	}
+	emit(lineJavaHome)
+	emit(linePID)
+	emit(lineOpts)
+
+	newline := "\n"
+	if s.crlf {
+		newline = "\r\n"
+	}
+	return strings.Join(out, newline) + newline
+}
+
+// generateLine renders one managed setting, or "" when it is unset
+func (s *ConfigService) generateLine(kind lineKind) string {
+	var name, value string
+	switch kind {
+	case lineJavaHome:
+		name, value = "JAVA_HOME", s.options.JavaHome
+	case linePID:
+		name, value = "CATALINA_PID", s.options.CatalinaPID
+	case lineOpts:
+		name = s.options.OptsVar
+		args := s.options.JVMArgs()
+		if len(args) == 0 {
+			return ""
+		}
+		quoted := make([]string, len(args))
+		for i, arg := range args {
+			if s.script == ScriptBat {
+				quoted[i] = quoteBatArg(arg)
+			} else {
+				quoted[i] = QuoteArg(arg)
+			}
+		}
+		value = strings.Join(quoted, " ")
+		if s.appendOpts {
+			if s.script == ScriptBat {
+				value = "%" + name + "% " + value
+			} else {
+				value = "$" + name + " " + value
+			}
+		}
+	}
+	if value == "" {
+		return ""
+	}
+
+	if s.script == ScriptBat {
+		return fmt.Sprintf(`set "%s=%s"`, name, value)
+	}
+	prefix := ""
+	if s.exported[kind] {
+		prefix = "export "
+	}
+	return prefix + name + "=" + doubleQuote(value)
+}
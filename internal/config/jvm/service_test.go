@@ -0,0 +1,118 @@
+package jvm
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// loadScript writes a setenv script into a new CATALINA_BASE and loads it
+func loadScript(t *testing.T, script Script, content string) *ConfigService {
+	t.Helper()
+	base := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(base, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(base, "bin", string(script)), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewConfigService(base, script)
+	if err := svc.Load(); err != nil {
+		t.Fatal(err)
+	}
+	return svc
+}
+
+func TestLoadKeepsUnchangedScript(t *testing.T) {
+	content := "#!/bin/sh\n" +
+		"# tuned by hand\n" +
+		`export CATALINA_OPTS="$CATALINA_OPTS -Xmx1g -Dfoo=\"a b\" -Dq='x y' -XX:+UseConcMarkSweepGC"` + "\n" +
+		"JAVA_HOME=/opt/java # comment\n"
+	svc := loadScript(t, ScriptSh, content)
+
+	if got := svc.GenerateContent(); got != content {
+		t.Errorf("unchanged script rendered as\n%s\nwant\n%s", got, content)
+	}
+	opts := svc.GetOptions()
+	if opts.JavaHome != "/opt/java" || opts.HeapMax != "1g" || opts.GC != GCCMS {
+		t.Errorf("JavaHome=%q HeapMax=%q GC=%q", opts.JavaHome, opts.HeapMax, opts.GC)
+	}
+	want := []SystemProperty{{Name: "foo", Value: "a b"}, {Name: "q", Value: "x y"}}
+	if !reflect.DeepEqual(opts.Properties, want) {
+		t.Errorf("Properties = %q, want %q", opts.Properties, want)
+	}
+}
+
+func TestQuotedOptionsSurviveAChange(t *testing.T) {
+	svc := loadScript(t, ScriptSh, `CATALINA_OPTS="$CATALINA_OPTS -Xmx1g -Dfoo=\"a b\""`+"\n")
+	svc.GetOptions().HeapMax = "2g"
+	svc.GetOptions().SetProperty("quote", `c "d"`)
+
+	content := svc.GenerateContent()
+	want := `CATALINA_OPTS="$CATALINA_OPTS -Xmx2g -Dfoo=\"a b\" -Dquote=\"c \\\"d\\\"\""`
+	if !strings.Contains(content, want) {
+		t.Fatalf("rendered\n%s\nwant a line\n%s", content, want)
+	}
+
+	again := loadScript(t, ScriptSh, content)
+	want2 := []SystemProperty{{Name: "foo", Value: "a b"}, {Name: "quote", Value: `c "d"`}}
+	if got := again.GetOptions().Properties; !reflect.DeepEqual(got, want2) {
+		t.Errorf("Properties = %q, want %q", got, want2)
+	}
+}
+
+// JMX settings without JMX remote being enabled are still written back
+func TestJMXSettingsKeptWhenDisabled(t *testing.T) {
+	svc := loadScript(t, ScriptSh, `CATALINA_OPTS="$CATALINA_OPTS -Xmx1g -Djava.rmi.server.hostname=10.0.0.5 -Dcom.sun.management.jmxremote.ssl=false"`+"\n")
+	opts := svc.GetOptions()
+	if opts.JMX.Enabled {
+		t.Error("JMX enabled without jmxremote or a port")
+	}
+	opts.HeapMax = "2g"
+
+	content := svc.GenerateContent()
+	for _, want := range []string{"-Xmx2g", "-Djava.rmi.server.hostname=10.0.0.5", "-Dcom.sun.management.jmxremote.ssl=false"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("%s missing from %s", want, content)
+		}
+	}
+	if strings.Contains(content, "-Dcom.sun.management.jmxremote ") {
+		t.Errorf("JMX remote enabled by the rewrite: %s", content)
+	}
+}
+
+func TestSplitOptions(t *testing.T) {
+	for _, tc := range []struct {
+		script Script
+		value  string
+		want   []string
+	}{
+		{ScriptSh, `-Xmx1g  -Dfoo="a b" -Dbar='c d'`, []string{"-Xmx1g", "-Dfoo=a b", "-Dbar=c d"}},
+		{ScriptSh, `-Dpath=$CATALINA_BASE/x -Dhome=\$HOME`, []string{"-Dpath=$CATALINA_BASE/x", `-Dhome=\$HOME`}},
+		{ScriptSh, `-Da=1 # rest is a comment`, []string{"-Da=1"}},
+		{ScriptBat, `%CATALINA_OPTS% -Dfoo="a b" -Dq='x'`, []string{"%CATALINA_OPTS%", "-Dfoo=a b", "-Dq='x'"}},
+	} {
+		svc := NewConfigService("", tc.script)
+		if got := svc.splitOptions(tc.value); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: splitOptions(%q) = %q, want %q", tc.script, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestQuoteArg(t *testing.T) {
+	for arg, want := range map[string]string{
+		"-Xmx1g":                 "-Xmx1g",
+		"-Dfoo=a b":              `-Dfoo="a b"`,
+		`-Dq=it's`:               `-Dq="it's"`,
+		`-Dhome=\$HOME`:          `-Dhome="\$HOME"`,
+		"-Dpath=$CATALINA_BASE":  "-Dpath=$CATALINA_BASE",
+		"-javaagent:/a b/x.jar":  `"-javaagent:/a b/x.jar"`,
+		`-Dx="quoted" and\slash`: `-Dx="\"quoted\" and\\slash"`,
+	} {
+		if got := QuoteArg(arg); got != want {
+			t.Errorf("QuoteArg(%q) = %s, want %s", arg, got, want)
+		}
+	}
+}
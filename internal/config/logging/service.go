@@ -283,7 +283,7 @@ func (s *ConfigService) Save() error {
 	}
 
 	// Generate content
-	content := s.generateContent()
+	content := s.GenerateContent()
 
 	// Write file
 	if err := os.WriteFile(s.configPath, []byte(content), 0644); err != nil {
@@ -315,8 +315,8 @@ func (s *ConfigService) createBackup() error {
 	return os.WriteFile(backupPath, data, 0644)
 }
 
-// generateContent creates the logging.properties content
-func (s *ConfigService) generateContent() string {
+// GenerateContent creates the logging.properties content
+func (s *ConfigService) GenerateContent() string {
 	var sb strings.Builder
 
 	// Header
@@ -0,0 +1,184 @@
+package placeholder
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config/catalina"
+	"github.com/playok/tomcatkit/internal/config/jvm"
+)
+
+// Sources a resolved value can come from
+const (
+	SourceBuiltin     = "built-in"
+	SourceSetenv      = "setenv"
+	SourceCatalina    = "catalina.properties"
+	SourceEnvironment = "environment"
+)
+
+// PropertySourceKey selects an additional property source for the digester
+const PropertySourceKey = "org.apache.tomcat.util.digester.PROPERTY_SOURCE"
+
+// EnvironmentPropertySource is the property source that reads environment variables
+const EnvironmentPropertySource = "org.apache.tomcat.util.digester.EnvironmentPropertySource"
+
+var placeholderRe = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// HasPlaceholder reports whether text contains a ${...} expression
+func HasPlaceholder(text string) bool {
+	return placeholderRe.MatchString(text)
+}
+
+// property is a resolvable value and where it was defined
+type property struct {
+	value  string
+	source string
+}
+
+// Resolver replaces ${...} expressions the way Tomcat does when it reads
+// its configuration files: from system properties (catalina.home,
+// catalina.base, -D options in setenv and everything in
+// catalina.properties) and, when EnvironmentPropertySource is configured,
+// from environment variables
+type Resolver struct {
+	props       map[string]property
+	environment bool
+	lookupEnv   func(string) (string, bool)
+}
+
+// NewResolver returns a resolver without any properties
+func NewResolver() *Resolver {
+	return &Resolver{
+		props:     make(map[string]property),
+		lookupEnv: os.LookupEnv,
+	}
+}
+
+// Load returns a resolver for an instance. Sources that are missing or
+// cannot be read are skipped, so a resolver is always returned.
+func Load(catalinaHome, catalinaBase string) *Resolver {
+	r := NewResolver()
+	if catalinaHome == "" {
+		catalinaHome = catalinaBase
+	}
+	r.Set("catalina.home", catalinaHome, SourceBuiltin)
+	r.Set("catalina.base", catalinaBase, SourceBuiltin)
+
+	// -D options are set when the JVM starts; catalina.properties is
+	// applied afterwards and overrides them
+	for _, script := range []jvm.Script{jvm.ScriptSh, jvm.ScriptBat} {
+		svc := jvm.NewConfigService(catalinaBase, script)
+		if err := svc.Load(); err != nil || !svc.Exists() {
+			continue
+		}
+		for _, p := range svc.GetOptions().Properties {
+			r.Set(p.Name, p.Value, SourceSetenv+" ("+string(script)+")")
+		}
+		break
+	}
+
+	props := catalina.NewConfigService(catalinaBase)
+	if _, err := os.Stat(props.GetFilePath()); os.IsNotExist(err) {
+		props = catalina.NewConfigService(catalinaHome)
+	}
+	if err := props.Load(); err == nil {
+		p := props.GetProperties()
+		for _, key := range p.Keys() {
+			value, _ := p.Get(key)
+			r.Set(key, value, SourceCatalina)
+		}
+	}
+
+	if source, ok := r.props[PropertySourceKey]; ok && strings.TrimSpace(source.value) == EnvironmentPropertySource {
+		r.environment = true
+	}
+	return r
+}
+
+// Set defines a property, replacing an earlier definition
+func (r *Resolver) Set(name, value, source string) {
+	r.props[name] = property{value: value, source: source}
+}
+
+// EnableEnvironment makes environment variables resolvable
+func (r *Resolver) EnableEnvironment() {
+	r.environment = true
+}
+
+// UsesEnvironment reports whether environment variables are resolvable
+func (r *Resolver) UsesEnvironment() bool {
+	return r != nil && r.environment
+}
+
+// Lookup returns the value of a property and the source that defined it
+func (r *Resolver) Lookup(name string) (string, string, bool) {
+	if r == nil {
+		return "", "", false
+	}
+	if p, ok := r.props[name]; ok {
+		return p.value, p.source, true
+	}
+	if r.environment {
+		if value, ok := r.lookupEnv(name); ok {
+			return value, SourceEnvironment, true
+		}
+	}
+	return "", "", false
+}
+
+// Expand replaces every ${name} and ${name:-default} in text. Expressions
+// that cannot be resolved are left as written and their names returned.
+func (r *Resolver) Expand(text string) (string, []string) {
+	var missing []string
+	expanded := placeholderRe.ReplaceAllStringFunc(text, func(expr string) string {
+		name := expr[2 : len(expr)-1]
+		fallback, hasDefault := "", false
+		if i := strings.Index(name, ":-"); i >= 0 {
+			name, fallback, hasDefault = name[:i], name[i+2:], true
+		}
+		if value, _, ok := r.Lookup(name); ok {
+			return value
+		}
+		if hasDefault {
+			return fallback
+		}
+		missing = append(missing, name)
+		return expr
+	})
+	return expanded, missing
+}
+
+// Names returns the property names referenced by text
+func Names(text string) []string {
+	var names []string
+	for _, m := range placeholderRe.FindAllStringSubmatch(text, -1) {
+		name := m[1]
+		if i := strings.Index(name, ":-"); i >= 0 {
+			name = name[:i]
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// Sources describes where the properties used by text come from, e.g.
+// "http.port: catalina.properties"
+func (r *Resolver) Sources(text string) []string {
+	var sources []string
+	for _, name := range Names(text) {
+		if _, source, ok := r.Lookup(name); ok {
+			sources = append(sources, name+": "+source)
+		}
+	}
+	return sources
+}
+
+// relPath shortens a path below dir for messages
+func relPath(dir, path string) string {
+	if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
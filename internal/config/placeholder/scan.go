@@ -0,0 +1,96 @@
+package placeholder
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Reference is a ${...} expression found in an XML attribute
+type Reference struct {
+	File      string
+	Line      int
+	Element   string
+	Attribute string
+	Text      string // Attribute value as written
+}
+
+// Where returns a short location, e.g. "conf/server.xml:69 Connector@port"
+func (ref Reference) Where() string {
+	return fmt.Sprintf("%s:%d %s@%s", ref.File, ref.Line, ref.Element, ref.Attribute)
+}
+
+// Check is a reference together with its resolved value
+type Check struct {
+	Reference
+	Value   string   // Expanded value
+	Missing []string // Names no source defines
+	Sources []string // Where the resolved names come from
+}
+
+// Resolved reports whether every name in the reference was found
+func (c Check) Resolved() bool {
+	return len(c.Missing) == 0
+}
+
+// ScanFile returns the placeholders used in the attributes of an XML
+// file. File names in the result are relative to base when possible.
+func ScanFile(base, path string) ([]Reference, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := ScanXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", relPath(base, path), err)
+	}
+	for i := range refs {
+		refs[i].File = relPath(base, path)
+	}
+	return refs, nil
+}
+
+// ScanXML returns the placeholders used in the attributes of an XML document
+func ScanXML(data []byte) ([]Reference, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	var refs []Reference
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		line, _ := decoder.InputPos()
+		for _, attr := range start.Attr {
+			if HasPlaceholder(attr.Value) {
+				refs = append(refs, Reference{
+					Line:      line,
+					Element:   start.Name.Local,
+					Attribute: attr.Name.Local,
+					Text:      attr.Value,
+				})
+			}
+		}
+	}
+	return refs, nil
+}
+
+// CheckAll resolves each reference
+func (r *Resolver) CheckAll(refs []Reference) []Check {
+	checks := make([]Check, len(refs))
+	for i, ref := range refs {
+		value, missing := r.Expand(ref.Text)
+		checks[i] = Check{Reference: ref, Value: value, Missing: missing, Sources: r.Sources(ref.Text)}
+	}
+	return checks
+}
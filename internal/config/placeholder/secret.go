@@ -0,0 +1,41 @@
+package placeholder
+
+import (
+	"strings"
+	"unicode"
+)
+
+// IsSecret reports whether an attribute holds a password or a shared
+// secret, such as password, keystorePass or the secret of an AJP connector
+func IsSecret(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "password") || strings.HasSuffix(name, "pass") || name == "secret"
+}
+
+// ReferenceName returns the property a value is referenced by when it is
+// moved out of a configuration file, e.g. JDBC_APP_PASSWORD for the
+// password of resource jdbc/app. It is also valid as an environment
+// variable name.
+func ReferenceName(scope, name string) string {
+	var b strings.Builder
+	previous := '_'
+	for _, r := range scope + "_" + name {
+		switch {
+		case unicode.IsUpper(r) && unicode.IsLower(previous):
+			b.WriteRune('_')
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			r = '_'
+			if previous == '_' {
+				continue
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+		previous = r
+	}
+	return strings.Trim(b.String(), "_")
+}
+
+// Expression returns the ${...} expression of a property
+func Expression(name string) string {
+	return "${" + name + "}"
+}
@@ -0,0 +1,196 @@
+package placeholder
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Int is a numeric attribute as written in a configuration file. Besides
+// plain numbers it may hold a ${...} expression that Tomcat replaces when
+// it reads the file, so the text is kept and written back unchanged.
+type Int string
+
+// IntOf returns an Int holding a plain number, for defaults built in code.
+// 0 is the zero value, so optional attributes are left out as they were
+// with a plain int; values given by the user go through ParseInt instead.
+func IntOf(n int) Int {
+	if n == 0 {
+		return ""
+	}
+	return Int(strconv.Itoa(n))
+}
+
+// ParseInt accepts a number, a ${...} expression or "" (not set). The text
+// is kept as written, so an explicit 0 is saved rather than left out.
+func ParseInt(text string) (Int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || HasPlaceholder(text) {
+		return Int(text), nil
+	}
+	if _, err := strconv.Atoi(text); err != nil {
+		return "", fmt.Errorf("%q is not a number", text)
+	}
+	return Int(text), nil
+}
+
+// Int returns the literal value; expressions and invalid text give 0
+func (i Int) Int() int {
+	n, _ := strconv.Atoi(strings.TrimSpace(string(i)))
+	return n
+}
+
+// IsPlaceholder reports whether the value contains a ${...} expression
+func (i Int) IsPlaceholder() bool {
+	return HasPlaceholder(string(i))
+}
+
+// Text returns the value as written, or "" when it is not set
+func (i Int) Text() string {
+	return string(i)
+}
+
+// String returns the value as written, or "0" when it is not set
+func (i Int) String() string {
+	if i == "" {
+		return "0"
+	}
+	return string(i)
+}
+
+// Value returns the resolved value, or 0 when it cannot be resolved
+func (i Int) Value(r *Resolver) int {
+	n, _ := i.Resolve(r)
+	return n
+}
+
+// Resolve returns the value Tomcat will use. A nil resolver only accepts
+// literal numbers.
+func (i Int) Resolve(r *Resolver) (int, error) {
+	text := strings.TrimSpace(string(i))
+	if text == "" {
+		return 0, nil
+	}
+	if HasPlaceholder(text) {
+		var missing []string
+		text, missing = r.Expand(text)
+		if len(missing) > 0 {
+			return 0, &UnresolvedError{Names: missing}
+		}
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", text)
+	}
+	return n, nil
+}
+
+// MarshalXMLAttr writes the text as read. Attributes without omitempty
+// are written as 0 when not set, as the plain int did.
+func (i Int) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: i.String()}, nil
+}
+
+// Bool is a boolean attribute that may hold a ${...} expression
+type Bool string
+
+// BoolOf returns a Bool holding a plain value, for defaults built in code.
+// false is the zero value, so optional attributes are left out as they
+// were with a plain bool; values given by the user go through ParseBool.
+func BoolOf(b bool) Bool {
+	if !b {
+		return ""
+	}
+	return "true"
+}
+
+// ParseBool accepts true, false, a ${...} expression or "" (not set). The
+// text is kept as written, so an explicit false is saved rather than left
+// out.
+func ParseBool(text string) (Bool, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || HasPlaceholder(text) {
+		return Bool(text), nil
+	}
+	if _, err := strconv.ParseBool(text); err != nil {
+		return "", fmt.Errorf("%q is not a boolean", text)
+	}
+	return Bool(text), nil
+}
+
+// Bool returns the literal value; expressions and invalid text give false
+func (b Bool) Bool() bool {
+	v, _ := strconv.ParseBool(strings.TrimSpace(string(b)))
+	return v
+}
+
+// IsPlaceholder reports whether the value contains a ${...} expression
+func (b Bool) IsPlaceholder() bool {
+	return HasPlaceholder(string(b))
+}
+
+// Text returns the value as written, or "" when it is not set
+func (b Bool) Text() string {
+	return string(b)
+}
+
+// String returns the value as written, or "false" when it is not set
+func (b Bool) String() string {
+	if b == "" {
+		return "false"
+	}
+	return string(b)
+}
+
+// Value returns the resolved value, or false when it cannot be resolved
+func (b Bool) Value(r *Resolver) bool {
+	v, _ := b.Resolve(r)
+	return v
+}
+
+// With returns b unchanged when Value already gives v, so a checkbox that
+// was not toggled keeps the expression; otherwise it returns the plain
+// value, written out even when it is false
+func (b Bool) With(v bool, r *Resolver) Bool {
+	if b.Value(r) == v {
+		return b
+	}
+	return Bool(strconv.FormatBool(v))
+}
+
+// Resolve returns the value Tomcat will use. A nil resolver only accepts
+// literal values.
+func (b Bool) Resolve(r *Resolver) (bool, error) {
+	text := strings.TrimSpace(string(b))
+	if text == "" {
+		return false, nil
+	}
+	if HasPlaceholder(text) {
+		var missing []string
+		text, missing = r.Expand(text)
+		if len(missing) > 0 {
+			return false, &UnresolvedError{Names: missing}
+		}
+	}
+	v, err := strconv.ParseBool(strings.TrimSpace(text))
+	if err != nil {
+		return false, fmt.Errorf("%q is not a boolean", text)
+	}
+	return v, nil
+}
+
+// MarshalXMLAttr writes the text as read. Attributes without omitempty
+// are written as false when not set, as the plain bool did.
+func (b Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: b.String()}, nil
+}
+
+// UnresolvedError reports placeholders that no property source defines
+type UnresolvedError struct {
+	Names []string
+}
+
+func (e *UnresolvedError) Error() string {
+	return "unresolved placeholder ${" + strings.Join(e.Names, "}, ${") + "}"
+}
@@ -140,6 +140,11 @@ func (s *UsersService) createBackup() error {
 	return os.WriteFile(backupPath, data, 0640)
 }
 
+// GetTomcatUsers returns the whole tomcat-users.xml configuration
+func (s *UsersService) GetTomcatUsers() *TomcatUsers {
+	return s.users
+}
+
 // GetUsers returns all users
 func (s *UsersService) GetUsers() []User {
 	if s.users != nil {
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+)
+
+// attributeField finds the struct field mapped to an XML attribute name
+func attributeField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("xml")
+		parts := strings.Split(tag, ",")
+		if len(parts) < 2 || parts[0] != name {
+			continue
+		}
+		for _, opt := range parts[1:] {
+			if opt == "attr" {
+				return v.Field(i), true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// GetAttribute returns the value of an XML attribute on an element struct.
+// Attributes without a typed field are looked up in the extra attribute list.
+func GetAttribute(element interface{}, extra []xml.Attr, name string) string {
+	v := reflect.Indirect(reflect.ValueOf(element))
+	if field, ok := attributeField(v, name); ok {
+		switch field.Kind() {
+		case reflect.String:
+			return field.String()
+		case reflect.Int:
+			if field.Int() == 0 {
+				return ""
+			}
+			return strconv.FormatInt(field.Int(), 10)
+		case reflect.Bool:
+			if !field.Bool() {
+				return ""
+			}
+			return "true"
+		}
+		return ""
+	}
+	for _, attr := range extra {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// SetAttribute sets an XML attribute on an element struct. An empty value
+// removes the attribute. Attributes without a typed field are stored in extra.
+func SetAttribute(element interface{}, extra *[]xml.Attr, name, value string) error {
+	v := reflect.Indirect(reflect.ValueOf(element))
+	if field, ok := attributeField(v, name); ok {
+		switch field.Interface().(type) {
+		case placeholder.Int:
+			n, err := placeholder.ParseInt(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			field.Set(reflect.ValueOf(n))
+			return nil
+		case placeholder.Bool:
+			b, err := placeholder.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			field.Set(reflect.ValueOf(b))
+			return nil
+		}
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Int:
+			if value == "" {
+				field.SetInt(0)
+				return nil
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%s: %q is not a number", name, value)
+			}
+			field.SetInt(int64(n))
+		case reflect.Bool:
+			if value == "" {
+				field.SetBool(false)
+				return nil
+			}
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%s: %q is not a boolean", name, value)
+			}
+			field.SetBool(b)
+		default:
+			return fmt.Errorf("%s: unsupported attribute type", name)
+		}
+		return nil
+	}
+
+	for i, attr := range *extra {
+		if attr.Name.Local == name {
+			if value == "" {
+				*extra = append((*extra)[:i], (*extra)[i+1:]...)
+			} else {
+				(*extra)[i].Value = value
+			}
+			return nil
+		}
+	}
+	if value != "" {
+		*extra = append(*extra, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+	return nil
+}
+
+// GetAttribute returns a connector attribute by its XML name
+func (c *Connector) GetAttribute(name string) string {
+	return GetAttribute(c, c.ExtraAttrs, name)
+}
+
+// SetAttribute sets a connector attribute by its XML name (empty removes it)
+func (c *Connector) SetAttribute(name, value string) error {
+	return SetAttribute(c, &c.ExtraAttrs, name, value)
+}
+
+// GetAttribute returns a resource attribute by its XML name
+func (r *Resource) GetAttribute(name string) string {
+	return GetAttribute(r, r.ExtraAttrs, name)
+}
+
+// SetAttribute sets a resource attribute by its XML name
+func (r *Resource) SetAttribute(name, value string) error {
+	return SetAttribute(r, &r.ExtraAttrs, name, value)
+}
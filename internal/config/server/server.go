@@ -1,8 +1,14 @@
 package server
 
+import (
+	"encoding/xml"
+
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+)
+
 // Server represents the root server.xml element
 type Server struct {
-	Port      int                    `xml:"port,attr"`
+	Port      placeholder.Int        `xml:"port,attr"`
 	Shutdown  string                 `xml:"shutdown,attr"`
 	Listeners []Listener             `xml:"Listener"`
 	Resources *GlobalNamingResources `xml:"GlobalNamingResources"`
@@ -30,6 +36,8 @@ type Resource struct {
 	Description string `xml:"description,attr,omitempty"`
 	Factory     string `xml:"factory,attr,omitempty"`
 	Pathname    string `xml:"pathname,attr,omitempty"`
+	// Any other attribute (DataSource pool settings, ...) preserved as-is
+	ExtraAttrs []xml.Attr `xml:",any,attr"`
 }
 
 // Service represents a Tomcat service
@@ -42,14 +50,14 @@ type Service struct {
 
 // Executor represents a thread pool executor
 type Executor struct {
-	ClassName       string `xml:"className,attr,omitempty"`
-	Name            string `xml:"name,attr"`
-	NamePrefix      string `xml:"namePrefix,attr,omitempty"`
-	MaxThreads      int    `xml:"maxThreads,attr,omitempty"`
-	MinSpareThreads int    `xml:"minSpareThreads,attr,omitempty"`
-	MaxIdleTime     int    `xml:"maxIdleTime,attr,omitempty"`
+	ClassName       string          `xml:"className,attr,omitempty"`
+	Name            string          `xml:"name,attr"`
+	NamePrefix      string          `xml:"namePrefix,attr,omitempty"`
+	MaxThreads      placeholder.Int `xml:"maxThreads,attr,omitempty"`
+	MinSpareThreads placeholder.Int `xml:"minSpareThreads,attr,omitempty"`
+	MaxIdleTime     placeholder.Int `xml:"maxIdleTime,attr,omitempty"`
 	// Virtual Thread specific (Tomcat 11+)
-	MaxQueueSize int `xml:"maxQueueSize,attr,omitempty"`
+	MaxQueueSize placeholder.Int `xml:"maxQueueSize,attr,omitempty"`
 }
 
 // Executor class names
@@ -64,9 +72,9 @@ func NewStandardExecutor(name string) *Executor {
 		ClassName:       ExecutorStandardThreadPool,
 		Name:            name,
 		NamePrefix:      "catalina-exec-",
-		MaxThreads:      200,
-		MinSpareThreads: 25,
-		MaxIdleTime:     60000,
+		MaxThreads:      placeholder.IntOf(200),
+		MinSpareThreads: placeholder.IntOf(25),
+		MaxIdleTime:     placeholder.IntOf(60000),
 	}
 }
 
@@ -76,7 +84,7 @@ func NewVirtualThreadExecutor(name string) *Executor {
 		ClassName:    ExecutorVirtualThread,
 		Name:         name,
 		NamePrefix:   "catalina-virt-",
-		MaxQueueSize: 100,
+		MaxQueueSize: placeholder.IntOf(100),
 	}
 }
 
@@ -87,42 +95,76 @@ func (e *Executor) IsVirtualThread() bool {
 
 // Connector represents HTTP/AJP connector
 type Connector struct {
-	Port              int    `xml:"port,attr"`
-	Protocol          string `xml:"protocol,attr,omitempty"`
-	ConnectionTimeout int    `xml:"connectionTimeout,attr,omitempty"`
-	RedirectPort      int    `xml:"redirectPort,attr,omitempty"`
+	Port              placeholder.Int `xml:"port,attr"`
+	Protocol          string          `xml:"protocol,attr,omitempty"`
+	ConnectionTimeout placeholder.Int `xml:"connectionTimeout,attr,omitempty"`
+	RedirectPort      placeholder.Int `xml:"redirectPort,attr,omitempty"`
 	// Common attributes
 	Address string `xml:"address,attr,omitempty"`
 	// HTTP specific
-	MaxThreads      int `xml:"maxThreads,attr,omitempty"`
-	MinSpareThreads int `xml:"minSpareThreads,attr,omitempty"`
-	AcceptCount     int `xml:"acceptCount,attr,omitempty"`
+	MaxThreads      placeholder.Int `xml:"maxThreads,attr,omitempty"`
+	MinSpareThreads placeholder.Int `xml:"minSpareThreads,attr,omitempty"`
+	AcceptCount     placeholder.Int `xml:"acceptCount,attr,omitempty"`
 	// SSL specific
-	SSLEnabled   bool   `xml:"SSLEnabled,attr,omitempty"`
-	Scheme       string `xml:"scheme,attr,omitempty"`
-	Secure       bool   `xml:"secure,attr,omitempty"`
-	KeystoreFile string `xml:"keystoreFile,attr,omitempty"`
-	KeystorePass string `xml:"keystorePass,attr,omitempty"`
-	KeystoreType string `xml:"keystoreType,attr,omitempty"`
-	ClientAuth   string `xml:"clientAuth,attr,omitempty"`
-	SSLProtocol  string `xml:"sslProtocol,attr,omitempty"`
+	SSLEnabled   placeholder.Bool `xml:"SSLEnabled,attr,omitempty"`
+	Scheme       string           `xml:"scheme,attr,omitempty"`
+	Secure       placeholder.Bool `xml:"secure,attr,omitempty"`
+	KeystoreFile string           `xml:"keystoreFile,attr,omitempty"`
+	KeystorePass string           `xml:"keystorePass,attr,omitempty"`
+	KeystoreType string           `xml:"keystoreType,attr,omitempty"`
+	ClientAuth   string           `xml:"clientAuth,attr,omitempty"`
+	SSLProtocol  string           `xml:"sslProtocol,attr,omitempty"`
 	// AJP specific
-	SecretRequired                  bool   `xml:"secretRequired,attr,omitempty"`
-	Secret                          string `xml:"secret,attr,omitempty"`
-	AllowedRequestAttributesPattern string `xml:"allowedRequestAttributesPattern,attr,omitempty"`
+	SecretRequired                  placeholder.Bool `xml:"secretRequired,attr,omitempty"`
+	Secret                          string           `xml:"secret,attr,omitempty"`
+	AllowedRequestAttributesPattern string           `xml:"allowedRequestAttributesPattern,attr,omitempty"`
 	// Executor reference
 	Executor string `xml:"executor,attr,omitempty"`
 	// Virtual Thread (Tomcat 11+ direct support)
 	UseVirtualThreads string `xml:"useVirtualThreads,attr,omitempty"`
 	// Compression settings
-	Compression             string `xml:"compression,attr,omitempty"`
-	CompressionMinSize      int    `xml:"compressionMinSize,attr,omitempty"`
-	CompressibleMimeType    string `xml:"compressibleMimeType,attr,omitempty"`
-	NoCompressionUserAgents string `xml:"noCompressionUserAgents,attr,omitempty"`
+	Compression             string          `xml:"compression,attr,omitempty"`
+	CompressionMinSize      placeholder.Int `xml:"compressionMinSize,attr,omitempty"`
+	CompressibleMimeType    string          `xml:"compressibleMimeType,attr,omitempty"`
+	NoCompressionUserAgents string          `xml:"noCompressionUserAgents,attr,omitempty"`
+	// Connection limits
+	MaxConnections       placeholder.Int `xml:"maxConnections,attr,omitempty"`
+	KeepAliveTimeout     placeholder.Int `xml:"keepAliveTimeout,attr,omitempty"`
+	MaxKeepAliveRequests placeholder.Int `xml:"maxKeepAliveRequests,attr,omitempty"`
+	MaxHttpHeaderSize    placeholder.Int `xml:"maxHttpHeaderSize,attr,omitempty"`
+	MaxPostSize          placeholder.Int `xml:"maxPostSize,attr,omitempty"`
+	// Request handling
+	URIEncoding       string `xml:"URIEncoding,attr,omitempty"`
+	RelaxedPathChars  string `xml:"relaxedPathChars,attr,omitempty"`
+	RelaxedQueryChars string `xml:"relaxedQueryChars,attr,omitempty"`
+	EnableLookups     string `xml:"enableLookups,attr,omitempty"`
+	// Proxy and identity
+	ProxyName  string          `xml:"proxyName,attr,omitempty"`
+	ProxyPort  placeholder.Int `xml:"proxyPort,attr,omitempty"`
+	Server     string          `xml:"server,attr,omitempty"`
+	XpoweredBy string          `xml:"xpoweredBy,attr,omitempty"`
+	// Any other attribute (socket.*, tuning flags, ...) preserved as-is
+	ExtraAttrs []xml.Attr `xml:",any,attr"`
+	// Nested upgrade protocols (HTTP/2)
+	UpgradeProtocols []UpgradeProtocol `xml:"UpgradeProtocol"`
 	// Nested SSL configuration
 	SSLHostConfig *SSLHostConfig `xml:"SSLHostConfig,omitempty"`
 }
 
+// UpgradeProtocol represents a nested protocol upgrade handler (e.g. HTTP/2)
+type UpgradeProtocol struct {
+	ClassName string `xml:"className,attr"`
+	// HTTP/2 tuning
+	MaxConcurrentStreams placeholder.Int `xml:"maxConcurrentStreams,attr,omitempty"`
+	InitialWindowSize    placeholder.Int `xml:"initialWindowSize,attr,omitempty"`
+	ReadTimeout          placeholder.Int `xml:"readTimeout,attr,omitempty"`
+	KeepAliveTimeout     placeholder.Int `xml:"keepAliveTimeout,attr,omitempty"`
+	OverheadCountFactor  placeholder.Int `xml:"overheadCountFactor,attr,omitempty"`
+	// Any other attribute (compression, maxHeaderSize, writeTimeout, ...)
+	// preserved as-is
+	ExtraAttrs []xml.Attr `xml:",any,attr"`
+}
+
 // SSLHostConfig represents SSL host configuration
 type SSLHostConfig struct {
 	Protocols               string        `xml:"protocols,attr,omitempty"`
@@ -152,17 +194,17 @@ type Engine struct {
 
 // Host represents a virtual host
 type Host struct {
-	Name             string `xml:"name,attr"`
-	AppBase          string `xml:"appBase,attr"`
-	UnpackWARs       bool   `xml:"unpackWARs,attr,omitempty"`
-	AutoDeploy       bool   `xml:"autoDeploy,attr,omitempty"`
-	DeployOnStartup  bool   `xml:"deployOnStartup,attr,omitempty"`
-	CreateDirs       bool   `xml:"createDirs,attr,omitempty"`
-	DeployXML        bool   `xml:"deployXML,attr,omitempty"`
-	CopyXML          bool   `xml:"copyXML,attr,omitempty"`
-	WorkDir          string `xml:"workDir,attr,omitempty"`
-	DeployIgnore     string `xml:"deployIgnore,attr,omitempty"`
-	StartStopThreads int    `xml:"startStopThreads,attr,omitempty"`
+	Name             string           `xml:"name,attr"`
+	AppBase          string           `xml:"appBase,attr"`
+	UnpackWARs       placeholder.Bool `xml:"unpackWARs,attr,omitempty"`
+	AutoDeploy       placeholder.Bool `xml:"autoDeploy,attr,omitempty"`
+	DeployOnStartup  placeholder.Bool `xml:"deployOnStartup,attr,omitempty"`
+	CreateDirs       placeholder.Bool `xml:"createDirs,attr,omitempty"`
+	DeployXML        placeholder.Bool `xml:"deployXML,attr,omitempty"`
+	CopyXML          placeholder.Bool `xml:"copyXML,attr,omitempty"`
+	WorkDir          string           `xml:"workDir,attr,omitempty"`
+	DeployIgnore     string           `xml:"deployIgnore,attr,omitempty"`
+	StartStopThreads placeholder.Int  `xml:"startStopThreads,attr,omitempty"`
 	// Error handling
 	ErrorReportValveClass string `xml:"errorReportValveClass,attr,omitempty"`
 	// Nested elements
@@ -179,28 +221,28 @@ type Alias struct {
 
 // Context represents a web application context
 type Context struct {
-	Path         string `xml:"path,attr"`
-	DocBase      string `xml:"docBase,attr"`
-	Reloadable   bool   `xml:"reloadable,attr,omitempty"`
-	CrossContext bool   `xml:"crossContext,attr,omitempty"`
-	Privileged   bool   `xml:"privileged,attr,omitempty"`
+	Path         string           `xml:"path,attr"`
+	DocBase      string           `xml:"docBase,attr"`
+	Reloadable   placeholder.Bool `xml:"reloadable,attr,omitempty"`
+	CrossContext placeholder.Bool `xml:"crossContext,attr,omitempty"`
+	Privileged   placeholder.Bool `xml:"privileged,attr,omitempty"`
 	// Session configuration
-	Cookies                            bool   `xml:"cookies,attr,omitempty"`
-	SessionCookieName                  string `xml:"sessionCookieName,attr,omitempty"`
-	SessionCookiePath                  string `xml:"sessionCookiePath,attr,omitempty"`
-	SessionCookieDomain                string `xml:"sessionCookieDomain,attr,omitempty"`
-	SessionCookiePathUsesTrailingSlash bool   `xml:"sessionCookiePathUsesTrailingSlash,attr,omitempty"`
-	UseHttpOnly                        bool   `xml:"useHttpOnly,attr,omitempty"`
+	Cookies                            placeholder.Bool `xml:"cookies,attr,omitempty"`
+	SessionCookieName                  string           `xml:"sessionCookieName,attr,omitempty"`
+	SessionCookiePath                  string           `xml:"sessionCookiePath,attr,omitempty"`
+	SessionCookieDomain                string           `xml:"sessionCookieDomain,attr,omitempty"`
+	SessionCookiePathUsesTrailingSlash placeholder.Bool `xml:"sessionCookiePathUsesTrailingSlash,attr,omitempty"`
+	UseHttpOnly                        placeholder.Bool `xml:"useHttpOnly,attr,omitempty"`
 	// Resource handling
-	AntiResourceLocking bool `xml:"antiResourceLocking,attr,omitempty"`
-	SwallowOutput       bool `xml:"swallowOutput,attr,omitempty"`
+	AntiResourceLocking placeholder.Bool `xml:"antiResourceLocking,attr,omitempty"`
+	SwallowOutput       placeholder.Bool `xml:"swallowOutput,attr,omitempty"`
 	// Override settings
-	Override                    bool `xml:"override,attr,omitempty"`
-	AllowCasualMultipartParsing bool `xml:"allowCasualMultipartParsing,attr,omitempty"`
+	Override                    placeholder.Bool `xml:"override,attr,omitempty"`
+	AllowCasualMultipartParsing placeholder.Bool `xml:"allowCasualMultipartParsing,attr,omitempty"`
 	// Cache settings
-	CachingAllowed bool `xml:"cachingAllowed,attr,omitempty"`
-	CacheMaxSize   int  `xml:"cacheMaxSize,attr,omitempty"`
-	CacheTTL       int  `xml:"cacheTTL,attr,omitempty"`
+	CachingAllowed placeholder.Bool `xml:"cachingAllowed,attr,omitempty"`
+	CacheMaxSize   placeholder.Int  `xml:"cacheMaxSize,attr,omitempty"`
+	CacheTTL       placeholder.Int  `xml:"cacheTTL,attr,omitempty"`
 	// Nested elements
 	Resources        []Resource    `xml:"Resource"`
 	Environments     []Environment `xml:"Environment"`
@@ -214,38 +256,38 @@ type Context struct {
 
 // Parameter represents a context init parameter
 type Parameter struct {
-	Name        string `xml:"name,attr"`
-	Value       string `xml:"value,attr"`
-	Override    bool   `xml:"override,attr,omitempty"`
-	Description string `xml:"description,attr,omitempty"`
+	Name        string           `xml:"name,attr"`
+	Value       string           `xml:"value,attr"`
+	Override    placeholder.Bool `xml:"override,attr,omitempty"`
+	Description string           `xml:"description,attr,omitempty"`
 }
 
 // Manager represents a session manager
 type Manager struct {
-	ClassName               string `xml:"className,attr,omitempty"`
-	MaxActiveSessions       int    `xml:"maxActiveSessions,attr,omitempty"`
-	SessionIdLength         int    `xml:"sessionIdLength,attr,omitempty"`
-	MaxInactiveInterval     int    `xml:"maxInactiveInterval,attr,omitempty"`
-	Pathname                string `xml:"pathname,attr,omitempty"`
-	ProcessExpiresFrequency int    `xml:"processExpiresFrequency,attr,omitempty"`
-	SecureRandomClass       string `xml:"secureRandomClass,attr,omitempty"`
-	SecureRandomAlgorithm   string `xml:"secureRandomAlgorithm,attr,omitempty"`
+	ClassName               string          `xml:"className,attr,omitempty"`
+	MaxActiveSessions       placeholder.Int `xml:"maxActiveSessions,attr,omitempty"`
+	SessionIdLength         placeholder.Int `xml:"sessionIdLength,attr,omitempty"`
+	MaxInactiveInterval     placeholder.Int `xml:"maxInactiveInterval,attr,omitempty"`
+	Pathname                string          `xml:"pathname,attr,omitempty"`
+	ProcessExpiresFrequency placeholder.Int `xml:"processExpiresFrequency,attr,omitempty"`
+	SecureRandomClass       string          `xml:"secureRandomClass,attr,omitempty"`
+	SecureRandomAlgorithm   string          `xml:"secureRandomAlgorithm,attr,omitempty"`
 }
 
 // Loader represents a web application class loader
 type Loader struct {
-	ClassName          string `xml:"className,attr,omitempty"`
-	Delegate           bool   `xml:"delegate,attr,omitempty"`
-	Reloadable         bool   `xml:"reloadable,attr,omitempty"`
-	SearchVirtualFirst bool   `xml:"searchVirtualFirst,attr,omitempty"`
+	ClassName          string           `xml:"className,attr,omitempty"`
+	Delegate           placeholder.Bool `xml:"delegate,attr,omitempty"`
+	Reloadable         placeholder.Bool `xml:"reloadable,attr,omitempty"`
+	SearchVirtualFirst placeholder.Bool `xml:"searchVirtualFirst,attr,omitempty"`
 }
 
 // Environment represents an environment entry
 type Environment struct {
-	Name     string `xml:"name,attr"`
-	Value    string `xml:"value,attr"`
-	Type     string `xml:"type,attr"`
-	Override bool   `xml:"override,attr,omitempty"`
+	Name     string           `xml:"name,attr"`
+	Value    string           `xml:"value,attr"`
+	Type     string           `xml:"type,attr"`
+	Override placeholder.Bool `xml:"override,attr,omitempty"`
 }
 
 // Realm represents authentication realm
@@ -278,10 +320,10 @@ type Realm struct {
 
 // CredentialHandler represents password hashing configuration
 type CredentialHandler struct {
-	ClassName  string `xml:"className,attr"`
-	Algorithm  string `xml:"algorithm,attr,omitempty"`
-	Iterations int    `xml:"iterations,attr,omitempty"`
-	SaltLength int    `xml:"saltLength,attr,omitempty"`
+	ClassName  string          `xml:"className,attr"`
+	Algorithm  string          `xml:"algorithm,attr,omitempty"`
+	Iterations placeholder.Int `xml:"iterations,attr,omitempty"`
+	SaltLength placeholder.Int `xml:"saltLength,attr,omitempty"`
 }
 
 // Valve represents a request processing valve
@@ -289,76 +331,79 @@ type Valve struct {
 	ClassName string `xml:"className,attr"`
 
 	// AccessLogValve attributes
-	Directory                string `xml:"directory,attr,omitempty"`
-	Prefix                   string `xml:"prefix,attr,omitempty"`
-	Suffix                   string `xml:"suffix,attr,omitempty"`
-	Pattern                  string `xml:"pattern,attr,omitempty"`
-	Rotatable                bool   `xml:"rotatable,attr,omitempty"`
-	RenameOnRotate           bool   `xml:"renameOnRotate,attr,omitempty"`
-	FileDateFormat           string `xml:"fileDateFormat,attr,omitempty"`
-	Encoding                 string `xml:"encoding,attr,omitempty"`
-	Locale                   string `xml:"locale,attr,omitempty"`
-	RequestAttributesEnabled bool   `xml:"requestAttributesEnabled,attr,omitempty"`
-	Buffered                 bool   `xml:"buffered,attr,omitempty"`
-	MaxLogMessageBufferSize  int    `xml:"maxLogMessageBufferSize,attr,omitempty"`
-	ConditionIf              string `xml:"conditionIf,attr,omitempty"`
-	ConditionUnless          string `xml:"conditionUnless,attr,omitempty"`
+	Directory                string           `xml:"directory,attr,omitempty"`
+	Prefix                   string           `xml:"prefix,attr,omitempty"`
+	Suffix                   string           `xml:"suffix,attr,omitempty"`
+	Pattern                  string           `xml:"pattern,attr,omitempty"`
+	Rotatable                placeholder.Bool `xml:"rotatable,attr,omitempty"`
+	RenameOnRotate           placeholder.Bool `xml:"renameOnRotate,attr,omitempty"`
+	FileDateFormat           string           `xml:"fileDateFormat,attr,omitempty"`
+	Encoding                 string           `xml:"encoding,attr,omitempty"`
+	Locale                   string           `xml:"locale,attr,omitempty"`
+	RequestAttributesEnabled placeholder.Bool `xml:"requestAttributesEnabled,attr,omitempty"`
+	Buffered                 placeholder.Bool `xml:"buffered,attr,omitempty"`
+	MaxLogMessageBufferSize  placeholder.Int  `xml:"maxLogMessageBufferSize,attr,omitempty"`
+	ConditionIf              string           `xml:"conditionIf,attr,omitempty"`
+	ConditionUnless          string           `xml:"conditionUnless,attr,omitempty"`
 
 	// RemoteAddrValve / RemoteCIDRValve / RemoteHostValve
-	Allow                         string `xml:"allow,attr,omitempty"`
-	Deny                          string `xml:"deny,attr,omitempty"`
-	DenyStatus                    int    `xml:"denyStatus,attr,omitempty"`
-	AddConnectorPort              bool   `xml:"addConnectorPort,attr,omitempty"`
-	InvalidAuthenticationWhenDeny bool   `xml:"invalidAuthenticationWhenDeny,attr,omitempty"`
+	Allow                         string           `xml:"allow,attr,omitempty"`
+	Deny                          string           `xml:"deny,attr,omitempty"`
+	DenyStatus                    placeholder.Int  `xml:"denyStatus,attr,omitempty"`
+	AddConnectorPort              placeholder.Bool `xml:"addConnectorPort,attr,omitempty"`
+	InvalidAuthenticationWhenDeny placeholder.Bool `xml:"invalidAuthenticationWhenDeny,attr,omitempty"`
 
 	// RemoteIpValve
-	RemoteIpHeader           string `xml:"remoteIpHeader,attr,omitempty"`
-	ProtocolHeader           string `xml:"protocolHeader,attr,omitempty"`
-	ProtocolHeaderHttpsValue string `xml:"protocolHeaderHttpsValue,attr,omitempty"`
-	PortHeader               string `xml:"portHeader,attr,omitempty"`
-	ProxiesHeader            string `xml:"proxiesHeader,attr,omitempty"`
-	RemoteIpProxiesHeader    string `xml:"remoteIpProxiesHeader,attr,omitempty"`
-	InternalProxies          string `xml:"internalProxies,attr,omitempty"`
-	TrustedProxies           string `xml:"trustedProxies,attr,omitempty"`
-	ChangeLocalPort          bool   `xml:"changeLocalPort,attr,omitempty"`
-	ChangeLocalName          bool   `xml:"changeLocalName,attr,omitempty"`
+	RemoteIpHeader           string           `xml:"remoteIpHeader,attr,omitempty"`
+	ProtocolHeader           string           `xml:"protocolHeader,attr,omitempty"`
+	ProtocolHeaderHttpsValue string           `xml:"protocolHeaderHttpsValue,attr,omitempty"`
+	PortHeader               string           `xml:"portHeader,attr,omitempty"`
+	ProxiesHeader            string           `xml:"proxiesHeader,attr,omitempty"`
+	RemoteIpProxiesHeader    string           `xml:"remoteIpProxiesHeader,attr,omitempty"`
+	InternalProxies          string           `xml:"internalProxies,attr,omitempty"`
+	TrustedProxies           string           `xml:"trustedProxies,attr,omitempty"`
+	ChangeLocalPort          placeholder.Bool `xml:"changeLocalPort,attr,omitempty"`
+	ChangeLocalName          placeholder.Bool `xml:"changeLocalName,attr,omitempty"`
 
 	// ErrorReportValve
-	ShowServerInfo bool `xml:"showServerInfo,attr,omitempty"`
-	ShowReport     bool `xml:"showReport,attr,omitempty"`
+	ShowServerInfo placeholder.Bool `xml:"showServerInfo,attr,omitempty"`
+	ShowReport     placeholder.Bool `xml:"showReport,attr,omitempty"`
 
 	// SingleSignOn
-	CookieDomain            string `xml:"cookieDomain,attr,omitempty"`
-	CookieName              string `xml:"cookieName,attr,omitempty"`
-	RequireReauthentication bool   `xml:"requireReauthentication,attr,omitempty"`
+	CookieDomain            string           `xml:"cookieDomain,attr,omitempty"`
+	CookieName              string           `xml:"cookieName,attr,omitempty"`
+	RequireReauthentication placeholder.Bool `xml:"requireReauthentication,attr,omitempty"`
 
 	// ReplicationValve
-	Filter               string `xml:"filter,attr,omitempty"`
-	PrimaryIndicator     bool   `xml:"primaryIndicator,attr,omitempty"`
-	PrimaryIndicatorName string `xml:"primaryIndicatorName,attr,omitempty"`
+	Filter               string           `xml:"filter,attr,omitempty"`
+	PrimaryIndicator     placeholder.Bool `xml:"primaryIndicator,attr,omitempty"`
+	PrimaryIndicatorName string           `xml:"primaryIndicatorName,attr,omitempty"`
 
 	// StuckThreadDetectionValve
-	Threshold                int `xml:"threshold,attr,omitempty"`
-	InterruptThreadThreshold int `xml:"interruptThreadThreshold,attr,omitempty"`
+	Threshold                placeholder.Int `xml:"threshold,attr,omitempty"`
+	InterruptThreadThreshold placeholder.Int `xml:"interruptThreadThreshold,attr,omitempty"`
 
 	// CrawlerSessionManagerValve
-	CrawlerUserAgents       string `xml:"crawlerUserAgents,attr,omitempty"`
-	SessionInactiveInterval int    `xml:"sessionInactiveInterval,attr,omitempty"`
+	CrawlerUserAgents       string          `xml:"crawlerUserAgents,attr,omitempty"`
+	SessionInactiveInterval placeholder.Int `xml:"sessionInactiveInterval,attr,omitempty"`
 
 	// SemaphoreValve
-	Concurrency int  `xml:"concurrency,attr,omitempty"`
-	Fairness    bool `xml:"fairness,attr,omitempty"`
-	Block       bool `xml:"block,attr,omitempty"`
+	Concurrency placeholder.Int  `xml:"concurrency,attr,omitempty"`
+	Fairness    placeholder.Bool `xml:"fairness,attr,omitempty"`
+	Block       placeholder.Bool `xml:"block,attr,omitempty"`
+
+	// HealthCheckValve
+	Path string `xml:"path,attr,omitempty"`
 
 	// AuthenticatorValve common
-	AlwaysUseSession                bool   `xml:"alwaysUseSession,attr,omitempty"`
-	Cache                           bool   `xml:"cache,attr,omitempty"`
-	CacheSize                       int    `xml:"cacheSize,attr,omitempty"`
-	ChangeSessionIdOnAuthentication bool   `xml:"changeSessionIdOnAuthentication,attr,omitempty"`
-	DisableProxyCaching             bool   `xml:"disableProxyCaching,attr,omitempty"`
-	SecurePagesWithPragma           bool   `xml:"securePagesWithPragma,attr,omitempty"`
-	SecureRandomClass               string `xml:"secureRandomClass,attr,omitempty"`
-	SecureRandomAlgorithm           string `xml:"secureRandomAlgorithm,attr,omitempty"`
+	AlwaysUseSession                placeholder.Bool `xml:"alwaysUseSession,attr,omitempty"`
+	Cache                           placeholder.Bool `xml:"cache,attr,omitempty"`
+	CacheSize                       placeholder.Int  `xml:"cacheSize,attr,omitempty"`
+	ChangeSessionIdOnAuthentication placeholder.Bool `xml:"changeSessionIdOnAuthentication,attr,omitempty"`
+	DisableProxyCaching             placeholder.Bool `xml:"disableProxyCaching,attr,omitempty"`
+	SecurePagesWithPragma           placeholder.Bool `xml:"securePagesWithPragma,attr,omitempty"`
+	SecureRandomClass               string           `xml:"secureRandomClass,attr,omitempty"`
+	SecureRandomAlgorithm           string           `xml:"secureRandomAlgorithm,attr,omitempty"`
 }
 
 // Valve class names
@@ -467,7 +512,7 @@ func DefaultAccessLogValve() Valve {
 		Prefix:         "localhost_access_log",
 		Suffix:         ".txt",
 		Pattern:        "%h %l %u %t \"%r\" %s %b",
-		Rotatable:      true,
+		Rotatable:      placeholder.BoolOf(true),
 		FileDateFormat: ".yyyy-MM-dd",
 	}
 }
@@ -477,7 +522,7 @@ func DefaultRemoteAddrValve() Valve {
 	return Valve{
 		ClassName:  ValveRemoteAddr,
 		Allow:      "127\\.\\d+\\.\\d+\\.\\d+|::1|0:0:0:0:0:0:0:1",
-		DenyStatus: 403,
+		DenyStatus: placeholder.IntOf(403),
 	}
 }
 
@@ -495,8 +540,8 @@ func DefaultRemoteIpValve() Valve {
 func DefaultErrorReportValve() Valve {
 	return Valve{
 		ClassName:      ValveErrorReport,
-		ShowServerInfo: false,
-		ShowReport:     true,
+		ShowServerInfo: placeholder.Bool("false"), // Tomcat shows it by default
+		ShowReport:     placeholder.BoolOf(true),
 	}
 }
 
@@ -511,7 +556,7 @@ func DefaultSingleSignOnValve() Valve {
 func DefaultStuckThreadDetectionValve() Valve {
 	return Valve{
 		ClassName: ValveStuckThreadDetection,
-		Threshold: 600,
+		Threshold: placeholder.IntOf(600),
 	}
 }
 
@@ -520,7 +565,7 @@ type Cluster struct {
 	ClassName                        string            `xml:"className,attr"`
 	ChannelSendOptions               string            `xml:"channelSendOptions,attr,omitempty"`
 	ChannelStartOptions              string            `xml:"channelStartOptions,attr,omitempty"`
-	NotifyLifecycleListenerOnFailure bool              `xml:"notifyLifecycleListenerOnFailure,attr,omitempty"`
+	NotifyLifecycleListenerOnFailure placeholder.Bool  `xml:"notifyLifecycleListenerOnFailure,attr,omitempty"`
 	Manager                          *ClusterManager   `xml:"Manager,omitempty"`
 	Channel                          *Channel          `xml:"Channel,omitempty"`
 	Valves                           []Valve           `xml:"Valve"`
@@ -533,20 +578,20 @@ type ClusterManager struct {
 	ClassName string `xml:"className,attr"`
 	Name      string `xml:"name,attr,omitempty"`
 	// Common attributes
-	ExpireSessionsOnShutdown              bool `xml:"expireSessionsOnShutdown,attr,omitempty"`
-	NotifyListenersOnReplication          bool `xml:"notifyListenersOnReplication,attr,omitempty"`
-	NotifyContainerListenersOnReplication bool `xml:"notifyContainerListenersOnReplication,attr,omitempty"`
-	NotifySessionListenersOnReplication   bool `xml:"notifySessionListenersOnReplication,attr,omitempty"`
+	ExpireSessionsOnShutdown              placeholder.Bool `xml:"expireSessionsOnShutdown,attr,omitempty"`
+	NotifyListenersOnReplication          placeholder.Bool `xml:"notifyListenersOnReplication,attr,omitempty"`
+	NotifyContainerListenersOnReplication placeholder.Bool `xml:"notifyContainerListenersOnReplication,attr,omitempty"`
+	NotifySessionListenersOnReplication   placeholder.Bool `xml:"notifySessionListenersOnReplication,attr,omitempty"`
 	// DeltaManager specific
-	StateTransferTimeout    int  `xml:"stateTransferTimeout,attr,omitempty"`
-	SendAllSessions         bool `xml:"sendAllSessions,attr,omitempty"`
-	SendAllSessionsSize     int  `xml:"sendAllSessionsSize,attr,omitempty"`
-	SendAllSessionsWaitTime int  `xml:"sendAllSessionsWaitTime,attr,omitempty"`
+	StateTransferTimeout    placeholder.Int  `xml:"stateTransferTimeout,attr,omitempty"`
+	SendAllSessions         placeholder.Bool `xml:"sendAllSessions,attr,omitempty"`
+	SendAllSessionsSize     placeholder.Int  `xml:"sendAllSessionsSize,attr,omitempty"`
+	SendAllSessionsWaitTime placeholder.Int  `xml:"sendAllSessionsWaitTime,attr,omitempty"`
 	// BackupManager specific
-	MapSendOptions          string `xml:"mapSendOptions,attr,omitempty"`
-	RpcTimeout              int    `xml:"rpcTimeout,attr,omitempty"`
-	TerminateOnStartFailure bool   `xml:"terminateOnStartFailure,attr,omitempty"`
-	AccessTimeout           int    `xml:"accessTimeout,attr,omitempty"`
+	MapSendOptions          string           `xml:"mapSendOptions,attr,omitempty"`
+	RpcTimeout              placeholder.Int  `xml:"rpcTimeout,attr,omitempty"`
+	TerminateOnStartFailure placeholder.Bool `xml:"terminateOnStartFailure,attr,omitempty"`
+	AccessTimeout           placeholder.Int  `xml:"accessTimeout,attr,omitempty"`
 }
 
 // Channel represents cluster communication channel
@@ -560,35 +605,35 @@ type Channel struct {
 
 // Membership represents cluster membership service
 type Membership struct {
-	ClassName             string `xml:"className,attr"`
-	Address               string `xml:"address,attr,omitempty"`
-	Port                  int    `xml:"port,attr,omitempty"`
-	Frequency             int    `xml:"frequency,attr,omitempty"`
-	DropTime              int    `xml:"dropTime,attr,omitempty"`
-	Bind                  string `xml:"bind,attr,omitempty"`
-	RecoveryEnabled       bool   `xml:"recoveryEnabled,attr,omitempty"`
-	RecoveryCounter       int    `xml:"recoveryCounter,attr,omitempty"`
-	RecoverySleepTime     int    `xml:"recoverySleepTime,attr,omitempty"`
-	LocalLoopbackDisabled bool   `xml:"localLoopbackDisabled,attr,omitempty"`
+	ClassName             string           `xml:"className,attr"`
+	Address               string           `xml:"address,attr,omitempty"`
+	Port                  placeholder.Int  `xml:"port,attr,omitempty"`
+	Frequency             placeholder.Int  `xml:"frequency,attr,omitempty"`
+	DropTime              placeholder.Int  `xml:"dropTime,attr,omitempty"`
+	Bind                  string           `xml:"bind,attr,omitempty"`
+	RecoveryEnabled       placeholder.Bool `xml:"recoveryEnabled,attr,omitempty"`
+	RecoveryCounter       placeholder.Int  `xml:"recoveryCounter,attr,omitempty"`
+	RecoverySleepTime     placeholder.Int  `xml:"recoverySleepTime,attr,omitempty"`
+	LocalLoopbackDisabled placeholder.Bool `xml:"localLoopbackDisabled,attr,omitempty"`
 }
 
 // Receiver represents cluster message receiver
 type Receiver struct {
-	ClassName       string `xml:"className,attr"`
-	Address         string `xml:"address,attr,omitempty"`
-	Port            int    `xml:"port,attr,omitempty"`
-	AutoBind        int    `xml:"autoBind,attr,omitempty"`
-	SelectorTimeout int    `xml:"selectorTimeout,attr,omitempty"`
-	MaxThreads      int    `xml:"maxThreads,attr,omitempty"`
-	MinThreads      int    `xml:"minThreads,attr,omitempty"`
-	MaxIdleTime     int    `xml:"maxIdleTime,attr,omitempty"`
-	OoBInline       bool   `xml:"ooBInline,attr,omitempty"`
-	RxBufSize       int    `xml:"rxBufSize,attr,omitempty"`
-	TxBufSize       int    `xml:"txBufSize,attr,omitempty"`
-	UdpRxBufSize    int    `xml:"udpRxBufSize,attr,omitempty"`
-	UdpTxBufSize    int    `xml:"udpTxBufSize,attr,omitempty"`
-	SoTimeout       int    `xml:"soTimeout,attr,omitempty"`
-	Timeout         int    `xml:"timeout,attr,omitempty"`
+	ClassName       string           `xml:"className,attr"`
+	Address         string           `xml:"address,attr,omitempty"`
+	Port            placeholder.Int  `xml:"port,attr,omitempty"`
+	AutoBind        placeholder.Int  `xml:"autoBind,attr,omitempty"`
+	SelectorTimeout placeholder.Int  `xml:"selectorTimeout,attr,omitempty"`
+	MaxThreads      placeholder.Int  `xml:"maxThreads,attr,omitempty"`
+	MinThreads      placeholder.Int  `xml:"minThreads,attr,omitempty"`
+	MaxIdleTime     placeholder.Int  `xml:"maxIdleTime,attr,omitempty"`
+	OoBInline       placeholder.Bool `xml:"ooBInline,attr,omitempty"`
+	RxBufSize       placeholder.Int  `xml:"rxBufSize,attr,omitempty"`
+	TxBufSize       placeholder.Int  `xml:"txBufSize,attr,omitempty"`
+	UdpRxBufSize    placeholder.Int  `xml:"udpRxBufSize,attr,omitempty"`
+	UdpTxBufSize    placeholder.Int  `xml:"udpTxBufSize,attr,omitempty"`
+	SoTimeout       placeholder.Int  `xml:"soTimeout,attr,omitempty"`
+	Timeout         placeholder.Int  `xml:"timeout,attr,omitempty"`
 }
 
 // Sender represents cluster message sender
@@ -599,41 +644,41 @@ type Sender struct {
 
 // Transport represents sender transport
 type Transport struct {
-	ClassName        string `xml:"className,attr"`
-	RxBufSize        int    `xml:"rxBufSize,attr,omitempty"`
-	TxBufSize        int    `xml:"txBufSize,attr,omitempty"`
-	UdpRxBufSize     int    `xml:"udpRxBufSize,attr,omitempty"`
-	UdpTxBufSize     int    `xml:"udpTxBufSize,attr,omitempty"`
-	DirectBuffer     bool   `xml:"directBuffer,attr,omitempty"`
-	KeepAliveCount   int    `xml:"keepAliveCount,attr,omitempty"`
-	KeepAliveTime    int    `xml:"keepAliveTime,attr,omitempty"`
-	Timeout          int    `xml:"timeout,attr,omitempty"`
-	MaxRetryAttempts int    `xml:"maxRetryAttempts,attr,omitempty"`
-	OoBInline        bool   `xml:"ooBInline,attr,omitempty"`
-	SoKeepAlive      bool   `xml:"soKeepAlive,attr,omitempty"`
-	SoLingerOn       bool   `xml:"soLingerOn,attr,omitempty"`
-	SoLingerTime     int    `xml:"soLingerTime,attr,omitempty"`
-	SoReuseAddress   bool   `xml:"soReuseAddress,attr,omitempty"`
-	SoTrafficClass   int    `xml:"soTrafficClass,attr,omitempty"`
-	TcpNoDelay       bool   `xml:"tcpNoDelay,attr,omitempty"`
-	ThrowOnFailedAck bool   `xml:"throwOnFailedAck,attr,omitempty"`
+	ClassName        string           `xml:"className,attr"`
+	RxBufSize        placeholder.Int  `xml:"rxBufSize,attr,omitempty"`
+	TxBufSize        placeholder.Int  `xml:"txBufSize,attr,omitempty"`
+	UdpRxBufSize     placeholder.Int  `xml:"udpRxBufSize,attr,omitempty"`
+	UdpTxBufSize     placeholder.Int  `xml:"udpTxBufSize,attr,omitempty"`
+	DirectBuffer     placeholder.Bool `xml:"directBuffer,attr,omitempty"`
+	KeepAliveCount   placeholder.Int  `xml:"keepAliveCount,attr,omitempty"`
+	KeepAliveTime    placeholder.Int  `xml:"keepAliveTime,attr,omitempty"`
+	Timeout          placeholder.Int  `xml:"timeout,attr,omitempty"`
+	MaxRetryAttempts placeholder.Int  `xml:"maxRetryAttempts,attr,omitempty"`
+	OoBInline        placeholder.Bool `xml:"ooBInline,attr,omitempty"`
+	SoKeepAlive      placeholder.Bool `xml:"soKeepAlive,attr,omitempty"`
+	SoLingerOn       placeholder.Bool `xml:"soLingerOn,attr,omitempty"`
+	SoLingerTime     placeholder.Int  `xml:"soLingerTime,attr,omitempty"`
+	SoReuseAddress   placeholder.Bool `xml:"soReuseAddress,attr,omitempty"`
+	SoTrafficClass   placeholder.Int  `xml:"soTrafficClass,attr,omitempty"`
+	TcpNoDelay       placeholder.Bool `xml:"tcpNoDelay,attr,omitempty"`
+	ThrowOnFailedAck placeholder.Bool `xml:"throwOnFailedAck,attr,omitempty"`
 }
 
 // Interceptor represents channel interceptor
 type Interceptor struct {
 	ClassName string `xml:"className,attr"`
 	// TcpFailureDetector
-	ConnectTimeout        int  `xml:"connectTimeout,attr,omitempty"`
-	PerformSendTest       bool `xml:"performSendTest,attr,omitempty"`
-	PerformReadTest       bool `xml:"performReadTest,attr,omitempty"`
-	ReadTestTimeout       int  `xml:"readTestTimeout,attr,omitempty"`
-	RemoveSuspectsTimeout int  `xml:"removeSuspectsTimeout,attr,omitempty"`
+	ConnectTimeout        placeholder.Int  `xml:"connectTimeout,attr,omitempty"`
+	PerformSendTest       placeholder.Bool `xml:"performSendTest,attr,omitempty"`
+	PerformReadTest       placeholder.Bool `xml:"performReadTest,attr,omitempty"`
+	ReadTestTimeout       placeholder.Int  `xml:"readTestTimeout,attr,omitempty"`
+	RemoveSuspectsTimeout placeholder.Int  `xml:"removeSuspectsTimeout,attr,omitempty"`
 	// MessageDispatchInterceptor
-	MaxQueueSize  int  `xml:"maxQueueSize,attr,omitempty"`
-	OptionalQueue bool `xml:"optionalQueue,attr,omitempty"`
-	AlwaysSend    bool `xml:"alwaysSend,attr,omitempty"`
+	MaxQueueSize  placeholder.Int  `xml:"maxQueueSize,attr,omitempty"`
+	OptionalQueue placeholder.Bool `xml:"optionalQueue,attr,omitempty"`
+	AlwaysSend    placeholder.Bool `xml:"alwaysSend,attr,omitempty"`
 	// ThroughputInterceptor
-	Interval int `xml:"interval,attr,omitempty"`
+	Interval placeholder.Int `xml:"interval,attr,omitempty"`
 	// StaticMembershipInterceptor
 	// (Members are defined as nested Member elements)
 	// EncryptInterceptor
@@ -650,12 +695,12 @@ type ClusterListener struct {
 
 // FarmWarDeployer represents cluster deployment
 type FarmWarDeployer struct {
-	ClassName              string `xml:"className,attr"`
-	TempDir                string `xml:"tempDir,attr,omitempty"`
-	DeployDir              string `xml:"deployDir,attr,omitempty"`
-	WatchDir               string `xml:"watchDir,attr,omitempty"`
-	WatchEnabled           bool   `xml:"watchEnabled,attr,omitempty"`
-	ProcessDeployFrequency int    `xml:"processDeployFrequency,attr,omitempty"`
+	ClassName              string           `xml:"className,attr"`
+	TempDir                string           `xml:"tempDir,attr,omitempty"`
+	DeployDir              string           `xml:"deployDir,attr,omitempty"`
+	WatchDir               string           `xml:"watchDir,attr,omitempty"`
+	WatchEnabled           placeholder.Bool `xml:"watchEnabled,attr,omitempty"`
+	ProcessDeployFrequency placeholder.Int  `xml:"processDeployFrequency,attr,omitempty"`
 }
 
 // Cluster class names
@@ -796,25 +841,25 @@ func DefaultCluster() *Cluster {
 		ChannelSendOptions: "8",
 		Manager: &ClusterManager{
 			ClassName:                    ClusterManagerDelta,
-			ExpireSessionsOnShutdown:     false,
-			NotifyListenersOnReplication: true,
+			ExpireSessionsOnShutdown:     placeholder.BoolOf(false),
+			NotifyListenersOnReplication: placeholder.BoolOf(true),
 		},
 		Channel: &Channel{
 			ClassName: ChannelGroupChannel,
 			Membership: &Membership{
 				ClassName: MembershipMcastService,
 				Address:   "228.0.0.4",
-				Port:      45564,
-				Frequency: 500,
-				DropTime:  3000,
+				Port:      placeholder.IntOf(45564),
+				Frequency: placeholder.IntOf(500),
+				DropTime:  placeholder.IntOf(3000),
 			},
 			Receiver: &Receiver{
 				ClassName:       ReceiverNioReceiver,
 				Address:         "auto",
-				Port:            4000,
-				AutoBind:        100,
-				SelectorTimeout: 5000,
-				MaxThreads:      6,
+				Port:            placeholder.IntOf(4000),
+				AutoBind:        placeholder.IntOf(100),
+				SelectorTimeout: placeholder.IntOf(5000),
+				MaxThreads:      placeholder.IntOf(6),
 			},
 			Sender: &Sender{
 				ClassName: SenderReplicationTransmitter,
@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/playok/tomcatkit/internal/config/placeholder"
 )
 
 // ConfigService handles server.xml configuration operations
 type ConfigService struct {
 	catalinaBase string
+	catalinaHome string
 	server       *Server
 	filePath     string
+	resolver     *placeholder.Resolver
 }
 
 // NewConfigService creates a new server configuration service
@@ -35,6 +39,7 @@ func (s *ConfigService) Load() error {
 	}
 
 	s.server = &server
+	s.resolver = nil
 	return nil
 }
 
@@ -49,15 +54,11 @@ func (s *ConfigService) Save() error {
 		return fmt.Errorf("failed to create backup: %w", err)
 	}
 
-	data, err := xml.MarshalIndent(s.server, "", "  ")
+	output, err := s.Render()
 	if err != nil {
-		return fmt.Errorf("failed to marshal server.xml: %w", err)
+		return err
 	}
 
-	// Add XML declaration
-	output := []byte(xml.Header)
-	output = append(output, data...)
-
 	if err := os.WriteFile(s.filePath, output, 0644); err != nil {
 		return fmt.Errorf("failed to write server.xml: %w", err)
 	}
@@ -65,6 +66,35 @@ func (s *ConfigService) Save() error {
 	return nil
 }
 
+// RestoreBackup puts back server.xml as it was before the last Save and
+// reloads it
+func (s *ConfigService) RestoreBackup() error {
+	data, err := os.ReadFile(filepath.Join(s.catalinaBase, "conf", "backup", "server.xml.bak"))
+	if err != nil {
+		return fmt.Errorf("failed to read server.xml backup: %w", err)
+	}
+	if err := os.WriteFile(s.filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to restore server.xml: %w", err)
+	}
+	return s.Load()
+}
+
+// Render returns server.xml as Save writes it
+func (s *ConfigService) Render() ([]byte, error) {
+	if s.server == nil {
+		return nil, fmt.Errorf("no server configuration loaded")
+	}
+
+	data, err := xml.MarshalIndent(s.server, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server.xml: %w", err)
+	}
+
+	// Add XML declaration
+	output := []byte(xml.Header)
+	return append(output, data...), nil
+}
+
 // createBackup creates a backup of the current server.xml
 func (s *ConfigService) createBackup() error {
 	backupDir := filepath.Join(s.catalinaBase, "conf", "backup")
@@ -94,6 +124,26 @@ func (s *ConfigService) GetConfig() *Server {
 	return s.server
 }
 
+// GetCatalinaBase returns the CATALINA_BASE this service was created for
+func (s *ConfigService) GetCatalinaBase() string {
+	return s.catalinaBase
+}
+
+// SetCatalinaHome sets the CATALINA_HOME used for ${catalina.home}
+func (s *ConfigService) SetCatalinaHome(catalinaHome string) {
+	s.catalinaHome = catalinaHome
+	s.resolver = nil
+}
+
+// Resolver returns the resolver for ${...} expressions in server.xml. It
+// reads catalina.properties and setenv on first use after each Load.
+func (s *ConfigService) Resolver() *placeholder.Resolver {
+	if s.resolver == nil {
+		s.resolver = placeholder.Load(s.catalinaHome, s.catalinaBase)
+	}
+	return s.resolver
+}
+
 // GetFilePath returns the server.xml file path
 func (s *ConfigService) GetFilePath() string {
 	return s.filePath
@@ -102,7 +152,7 @@ func (s *ConfigService) GetFilePath() string {
 // UpdateServerPort updates the shutdown port
 func (s *ConfigService) UpdateServerPort(port int) {
 	if s.server != nil {
-		s.server.Port = port
+		s.server.Port = placeholder.IntOf(port)
 	}
 }
 
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+)
+
+// CompressibleMimeTypes are the types EnableCompression compresses
+const CompressibleMimeTypes = "text/html,text/xml,text/plain,text/css,text/javascript,application/javascript,application/json,application/xml"
+
+// ListenerSecurity is the listener that refuses to start Tomcat as root
+const ListenerSecurity = "org.apache.catalina.security.SecurityListener"
+
+// EnableCompression turns on gzip compression for the HTTP connectors of
+// the first service and returns how many were changed
+func EnableCompression(srv *Server, minSize int) int {
+	if len(srv.Services) == 0 {
+		return 0
+	}
+	changed := 0
+	for i := range srv.Services[0].Connectors {
+		conn := &srv.Services[0].Connectors[i]
+		if conn.Protocol == "" || strings.Contains(conn.Protocol, "HTTP") {
+			conn.Compression = "on"
+			conn.CompressionMinSize = placeholder.IntOf(minSize)
+			conn.CompressibleMimeType = CompressibleMimeTypes
+			changed++
+		}
+	}
+	return changed
+}
+
+// Hardening selects the parts of HardenSecurity to apply
+type Hardening struct {
+	// DisableShutdown sets the shutdown port to -1 and replaces the
+	// shutdown command with ShutdownCommand
+	DisableShutdown bool
+	ShutdownCommand string
+	// HideServerInfo keeps the Tomcat version off error pages
+	HideServerInfo bool
+	// SecurityListener adds the listener that refuses to run as root
+	SecurityListener bool
+}
+
+// HardenSecurity applies the security hardening template
+func HardenSecurity(srv *Server, h Hardening) {
+	if h.DisableShutdown {
+		srv.Port = placeholder.IntOf(-1)
+		srv.Shutdown = h.ShutdownCommand
+	}
+
+	if h.SecurityListener {
+		hasSecurityListener := false
+		for _, l := range srv.Listeners {
+			if l.ClassName == ListenerSecurity {
+				hasSecurityListener = true
+				break
+			}
+		}
+		if !hasSecurityListener {
+			srv.Listeners = append(srv.Listeners, Listener{ClassName: ListenerSecurity})
+		}
+	}
+
+	// Every host gets an ErrorReportValve with showServerInfo=false,
+	// written out as the default is true
+	hide := placeholder.Bool("false")
+	if h.HideServerInfo && len(srv.Services) > 0 {
+		hosts := srv.Services[0].Engine.Hosts
+		for i := range hosts {
+			hasErrorValve := false
+			for j := range hosts[i].Valves {
+				if hosts[i].Valves[j].ClassName == ValveErrorReport {
+					hosts[i].Valves[j].ShowServerInfo = hide
+					hasErrorValve = true
+				}
+			}
+			if !hasErrorValve {
+				hosts[i].Valves = append(hosts[i].Valves, Valve{
+					ClassName:      ValveErrorReport,
+					ShowServerInfo: hide,
+				})
+			}
+		}
+	}
+}
+
+// PutEngineValve adds a valve to the engine of the first service, replacing
+// the engine's valve of the same class. It reports whether one was
+// replaced.
+func PutEngineValve(srv *Server, valve Valve) bool {
+	if len(srv.Services) == 0 {
+		return false
+	}
+	engine := &srv.Services[0].Engine
+	for i := range engine.Valves {
+		if engine.Valves[i].ClassName == valve.ClassName {
+			engine.Valves[i] = valve
+			return true
+		}
+	}
+	engine.Valves = append(engine.Valves, valve)
+	return false
+}
@@ -12,6 +12,13 @@ type Settings struct {
 	LastCatalinaBase string           `json:"last_catalina_base"`
 	RecentPaths      []TomcatInstance `json:"recent_paths"`
 	Language         string           `json:"language,omitempty"`
+	// HealthURLs are checked after changes are applied, by CATALINA_BASE
+	HealthURLs map[string][]string `json:"health_urls,omitempty"`
+	// SearchRoots and SearchDepth configure the instance scan
+	SearchRoots []string `json:"search_roots,omitempty"`
+	SearchDepth int      `json:"search_depth,omitempty"`
+	// Inventory holds the named instances, without a limit
+	Inventory []InventoryEntry `json:"inventory,omitempty"`
 }
 
 // SettingsManager handles loading and saving settings
@@ -71,24 +78,19 @@ func (m *SettingsManager) GetSettings() *Settings {
 func (m *SettingsManager) SetLastInstance(instance *TomcatInstance) {
 	m.settings.LastCatalinaHome = instance.CatalinaHome
 	m.settings.LastCatalinaBase = instance.CatalinaBase
+	m.AddRecentInstance(instance)
+}
 
-	// Add to recent paths if not already there
-	found := false
+// AddRecentInstance puts an instance at the front of the recent list.
+// Instances sharing a CATALINA_HOME are told apart by CATALINA_BASE.
+func (m *SettingsManager) AddRecentInstance(instance *TomcatInstance) {
 	for i, recent := range m.settings.RecentPaths {
-		if recent.CatalinaHome == instance.CatalinaHome {
-			// Move to front
-			m.settings.RecentPaths = append(
-				[]TomcatInstance{*instance},
-				append(m.settings.RecentPaths[:i], m.settings.RecentPaths[i+1:]...)...,
-			)
-			found = true
+		if recent.CatalinaHome == instance.CatalinaHome && recent.CatalinaBase == instance.CatalinaBase {
+			m.settings.RecentPaths = append(m.settings.RecentPaths[:i], m.settings.RecentPaths[i+1:]...)
 			break
 		}
 	}
-
-	if !found {
-		m.settings.RecentPaths = append([]TomcatInstance{*instance}, m.settings.RecentPaths...)
-	}
+	m.settings.RecentPaths = append([]TomcatInstance{*instance}, m.settings.RecentPaths...)
 
 	// Keep only last 5 recent paths
 	if len(m.settings.RecentPaths) > 5 {
@@ -128,3 +130,41 @@ func (m *SettingsManager) GetLanguage() string {
 func (m *SettingsManager) SetLanguage(lang string) {
 	m.settings.Language = lang
 }
+
+// GetHealthURLs returns the health URLs of an instance
+func (m *SettingsManager) GetHealthURLs(catalinaBase string) []string {
+	return m.settings.HealthURLs[catalinaBase]
+}
+
+// SetHealthURLs sets the health URLs of an instance
+func (m *SettingsManager) SetHealthURLs(catalinaBase string, urls []string) {
+	if len(urls) == 0 {
+		delete(m.settings.HealthURLs, catalinaBase)
+		return
+	}
+	if m.settings.HealthURLs == nil {
+		m.settings.HealthURLs = make(map[string][]string)
+	}
+	m.settings.HealthURLs[catalinaBase] = urls
+}
+
+// GetSearchRoots returns the directories the instance scan searches
+func (m *SettingsManager) GetSearchRoots() []string {
+	return m.settings.SearchRoots
+}
+
+// SetSearchRoots sets the directories the instance scan searches
+func (m *SettingsManager) SetSearchRoots(roots []string) {
+	m.settings.SearchRoots = roots
+}
+
+// GetSearchDepth returns how deep the instance scan searches, 0 for the
+// default
+func (m *SettingsManager) GetSearchDepth() int {
+	return m.settings.SearchDepth
+}
+
+// SetSearchDepth sets how deep the instance scan searches
+func (m *SettingsManager) SetSearchDepth(depth int) {
+	m.settings.SearchDepth = depth
+}
@@ -1,10 +1,106 @@
 package config
 
+import (
+	"strings"
+	"time"
+)
+
 // TomcatInstance represents a detected Tomcat installation
 type TomcatInstance struct {
 	CatalinaHome string
 	CatalinaBase string
 	Version      string
+	VersionInfo  TomcatVersion `json:"-"`
 	IsRunning    bool
 	PID          int
+
+	// Details of the running process, as found by the detector. They are
+	// not kept with the recent instances in the settings.
+	User        string    `json:"-"`
+	WorkingDir  string    `json:"-"`
+	JavaHome    string    `json:"-"`
+	JavaVersion string    `json:"-"`
+	StartTime   time.Time `json:"-"`
+
+	// Container is set when the process runs in a local container
+	Container *ContainerInfo `json:"-"`
+
+	// Layout is set for instances found through systemd units or the
+	// environment files of distribution packages
+	Layout *DistroLayout `json:"-"`
+}
+
+// DistroLayout describes how an instance was set up outside its own
+// directory, e.g. by the Debian tomcat9 package, which keeps CATALINA_HOME
+// in /usr/share/tomcat9, CATALINA_BASE in /var/lib/tomcat9 and links conf
+// to /etc/tomcat9
+type DistroLayout struct {
+	Distro   string // debian, rhel, suse or the os-release ID
+	Packaged bool   // CATALINA_HOME is installed below /usr/share
+	Service  string // systemd unit, e.g. tomcat9.service
+	Source   string // File CATALINA_HOME and CATALINA_BASE were read from
+	ConfDir  string // conf with symlinks resolved, e.g. /etc/tomcat9
+	LogsDir  string // logs with symlinks resolved, e.g. /var/log/tomcat9
+}
+
+// Description returns a short description, e.g. "debian package, tomcat9.service"
+func (l *DistroLayout) Description() string {
+	var parts []string
+	if l.Packaged {
+		kind := "package"
+		if l.Distro != "" {
+			kind = l.Distro + " package"
+		}
+		parts = append(parts, kind)
+	}
+	if l.Service != "" {
+		parts = append(parts, l.Service)
+	} else {
+		parts = append(parts, l.Source)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ContainerInfo describes the container a Tomcat process runs in
+type ContainerInfo struct {
+	Runtime string // docker, podman, containerd or cri-o
+	ID      string
+	Name    string // From the runtime's API, when its socket is reachable
+	Image   string
+
+	// CatalinaHome and CatalinaBase as seen inside the container
+	CatalinaHome string
+	CatalinaBase string
+
+	// Mounted is true when CATALINA_BASE is a bind-mounted host directory.
+	// Otherwise it is reached through /proc/<pid>/root, and changes are
+	// lost when the container is recreated.
+	Mounted bool
+}
+
+// ShortID returns the first 12 characters of the container ID
+func (c *ContainerInfo) ShortID() string {
+	if len(c.ID) > 12 {
+		return c.ID[:12]
+	}
+	return c.ID
+}
+
+// Label returns the runtime and the name or short ID, e.g. "docker: web"
+func (c *ContainerInfo) Label() string {
+	name := c.Name
+	if name == "" {
+		name = c.ShortID()
+	}
+	return c.Runtime + ": " + name
+}
+
+// ParsedVersion returns the parsed version of the instance. Instances loaded
+// from the settings only have the version text, which is parsed then.
+func (t *TomcatInstance) ParsedVersion() TomcatVersion {
+	if !t.VersionInfo.IsZero() {
+		return t.VersionInfo
+	}
+	v, _ := ParseTomcatVersion(t.Version)
+	return v
 }
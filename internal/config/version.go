@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// TomcatVersion is a parsed Tomcat version such as 10.1.28
+type TomcatVersion struct {
+	Major  int
+	Minor  int
+	Patch  int
+	Suffix string // Pre-release marker, e.g. "-M1"
+	Built  string // Build date as reported by catalina.jar, if known
+}
+
+var tomcatVersionRe = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?((?:-[A-Za-z]+\d*)?)`)
+
+// ParseTomcatVersion reads the first version number in text, e.g. from
+// "Apache Tomcat/10.1.28" or "9.0.93.0"
+func ParseTomcatVersion(text string) (TomcatVersion, bool) {
+	m := tomcatVersionRe.FindStringSubmatch(text)
+	if m == nil {
+		return TomcatVersion{}, false
+	}
+	v := TomcatVersion{Suffix: m[4]}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	v.Patch, _ = strconv.Atoi(m[3])
+	return v, true
+}
+
+// IsZero reports whether the version is unknown
+func (v TomcatVersion) IsZero() bool {
+	return v.Major == 0 && v.Minor == 0 && v.Patch == 0
+}
+
+// String returns the full version, e.g. "10.1.28"
+func (v TomcatVersion) String() string {
+	if v.IsZero() {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d.%d.%d%s", v.Major, v.Minor, v.Patch, v.Suffix)
+}
+
+// Line returns the release line: "8.5" and "10.1" keep the minor version,
+// while lines from 9 on that start at .0 are named by the major version
+// alone ("9", "10", "11")
+func (v TomcatVersion) Line() string {
+	if v.IsZero() {
+		return ""
+	}
+	if v.Major >= 9 && v.Minor == 0 {
+		return strconv.Itoa(v.Major)
+	}
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// Compare returns -1, 0 or 1 when v is older than, equal to or newer than
+// major.minor.patch
+func (v TomcatVersion) Compare(major, minor, patch int) int {
+	for _, d := range [][2]int{{v.Major, major}, {v.Minor, minor}, {v.Patch, patch}} {
+		switch {
+		case d[0] < d[1]:
+			return -1
+		case d[0] > d[1]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast reports whether v is major.minor.patch or newer
+func (v TomcatVersion) AtLeast(major, minor, patch int) bool {
+	return v.Compare(major, minor, patch) >= 0
+}
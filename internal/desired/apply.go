@@ -0,0 +1,461 @@
+package desired
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config/jndi"
+	"github.com/playok/tomcatkit/internal/config/logging"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/config/realm"
+	"github.com/playok/tomcatkit/internal/config/server"
+	"github.com/playok/tomcatkit/internal/config/web"
+)
+
+var (
+	attrsType = reflect.TypeOf([]xml.Attr(nil))
+	intType   = reflect.TypeOf(placeholder.Int(""))
+	boolType  = reflect.TypeOf(placeholder.Bool(""))
+)
+
+// HasServer reports whether the document declares anything in server.xml
+func (d *Document) HasServer() bool {
+	return len(d.Server) > 0 || d.hasService()
+}
+
+// hasService reports whether the document declares anything in the service
+func (d *Document) hasService() bool {
+	return d.Service != "" || len(d.Executors) > 0 || len(d.Connectors) > 0 ||
+		len(d.Engine) > 0 || d.Realm != nil || len(d.Valves) > 0 || len(d.Hosts) > 0
+}
+
+// ApplyServer makes server.xml look as declared
+func (d *Document) ApplyServer(srv *server.Server) error {
+	if err := setAttrs(reflect.ValueOf(srv).Elem(), d.Server); err != nil {
+		return fmt.Errorf("server: %w", err)
+	}
+	if !d.hasService() {
+		return nil
+	}
+
+	var service *server.Service
+	for i := range srv.Services {
+		if d.Service == "" || srv.Services[i].Name == d.Service {
+			service = &srv.Services[i]
+			break
+		}
+	}
+	if service == nil {
+		if d.Service == "" {
+			return fmt.Errorf("server.xml has no service")
+		}
+		return fmt.Errorf("server.xml has no service %q", d.Service)
+	}
+
+	for _, e := range d.Executors {
+		if err := converge(reflect.ValueOf(&service.Executors).Elem(), "name", e); err != nil {
+			return fmt.Errorf("executor: %w", err)
+		}
+	}
+	for _, e := range d.Connectors {
+		if err := converge(reflect.ValueOf(&service.Connectors).Elem(), "port", e); err != nil {
+			return fmt.Errorf("connector: %w", err)
+		}
+	}
+
+	engine := &service.Engine
+	if err := setAttrs(reflect.ValueOf(engine).Elem(), d.Engine); err != nil {
+		return fmt.Errorf("engine: %w", err)
+	}
+	if d.Realm != nil {
+		realm, err := applyRealm(engine.Realm, *d.Realm)
+		if err != nil {
+			return fmt.Errorf("realm: %w", err)
+		}
+		engine.Realm = realm
+	}
+	for _, e := range d.Valves {
+		if err := converge(reflect.ValueOf(&engine.Valves).Elem(), "className", e); err != nil {
+			return fmt.Errorf("valve: %w", err)
+		}
+	}
+
+	for _, h := range d.Hosts {
+		if err := converge(reflect.ValueOf(&engine.Hosts).Elem(), "name", h.Element); err != nil {
+			return fmt.Errorf("host: %w", err)
+		}
+		if h.Absent() {
+			continue
+		}
+		host := findHost(engine, h.Element["name"])
+		for _, alias := range h.Aliases {
+			if !hasAlias(host, alias) {
+				host.Aliases = append(host.Aliases, server.Alias{Name: alias})
+			}
+		}
+		for _, e := range h.Valves {
+			if err := converge(reflect.ValueOf(&host.Valves).Elem(), "className", e); err != nil {
+				return fmt.Errorf("host %s: valve: %w", host.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// findHost returns the host with the given name
+func findHost(engine *server.Engine, name string) *server.Host {
+	for i := range engine.Hosts {
+		if engine.Hosts[i].Name == name {
+			return &engine.Hosts[i]
+		}
+	}
+	return nil
+}
+
+// hasAlias reports whether a host has an alias
+func hasAlias(host *server.Host, alias string) bool {
+	for _, a := range host.Aliases {
+		if a.Name == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRealm returns the realm as declared. The current realm is changed
+// when it has the declared class and replaced otherwise.
+func applyRealm(current *server.Realm, want Realm) (*server.Realm, error) {
+	if want.Absent() {
+		return nil, nil
+	}
+	className := want.Element["className"]
+	if className == "" {
+		return nil, fmt.Errorf("className is required")
+	}
+	if _, ok := want.Element[replacesKey]; ok {
+		return nil, fmt.Errorf("%s is not supported for realms", replacesKey)
+	}
+	realm := current
+	if realm == nil || realm.ClassName != className {
+		realm = &server.Realm{}
+	}
+	if err := setAttrs(reflect.ValueOf(realm).Elem(), want.Element); err != nil {
+		return nil, err
+	}
+
+	for _, nested := range want.Realms {
+		index := -1
+		for i := range realm.NestedRealms {
+			if realm.NestedRealms[i].ClassName == nested.Element["className"] {
+				index = i
+				break
+			}
+		}
+		var existing *server.Realm
+		if index >= 0 {
+			existing = &realm.NestedRealms[index]
+		}
+		updated, err := applyRealm(existing, nested)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case updated == nil && index >= 0:
+			realm.NestedRealms = append(realm.NestedRealms[:index], realm.NestedRealms[index+1:]...)
+		case updated != nil && index >= 0:
+			realm.NestedRealms[index] = *updated
+		case updated != nil:
+			realm.NestedRealms = append(realm.NestedRealms, *updated)
+		}
+	}
+	return realm, nil
+}
+
+// ApplyContext makes the resources of context.xml look as declared
+func (d *Document) ApplyContext(ctx *jndi.Context) error {
+	for _, e := range d.Resources {
+		if err := converge(reflect.ValueOf(&ctx.Resources).Elem(), "name", e); err != nil {
+			return fmt.Errorf("resource: %w", err)
+		}
+	}
+	return nil
+}
+
+// ApplyLogging makes the loggers of logging.properties look as declared
+func (d *Document) ApplyLogging(svc *logging.ConfigService) error {
+	for _, l := range d.Loggers {
+		if l.Name == "" {
+			return fmt.Errorf("logger: name is required")
+		}
+		if l.Absent {
+			svc.RemoveLogger(l.Name)
+			continue
+		}
+		logger := svc.GetLogger(l.Name)
+		if logger == nil {
+			logger = &logging.Logger{Name: l.Name, UseParentHandlers: true}
+			svc.AddLogger(logger)
+			logger = svc.GetLogger(l.Name)
+		}
+		if l.Level != "" {
+			level, ok := parseLevel(l.Level)
+			if !ok {
+				return fmt.Errorf("logger %s: unknown level %q", l.Name, l.Level)
+			}
+			logger.Level = level
+		}
+		if l.Handlers != nil {
+			logger.Handlers = l.Handlers
+		}
+		if l.UseParentHandlers != nil {
+			logger.UseParentHandlers = *l.UseParentHandlers
+		}
+	}
+	return nil
+}
+
+// ApplyUsers makes the roles and users of tomcat-users.xml look as declared
+func (d *Document) ApplyUsers(users *realm.TomcatUsers) error {
+	for _, e := range d.Roles {
+		if err := converge(reflect.ValueOf(&users.Roles).Elem(), "rolename", e); err != nil {
+			return fmt.Errorf("role: %w", err)
+		}
+	}
+	for _, e := range d.Users {
+		if err := converge(reflect.ValueOf(&users.Users).Elem(), "username", e); err != nil {
+			return fmt.Errorf("user: %w", err)
+		}
+	}
+	return nil
+}
+
+// ApplyWeb makes the application defaults of web.xml look as declared
+func (d *Document) ApplyWeb(svc *web.ConfigService) error {
+	if d.Web.SessionTimeout != nil {
+		session := svc.GetSessionConfig()
+		if session == nil {
+			session = &web.SessionConfig{}
+		}
+		session.SessionTimeout = *d.Web.SessionTimeout
+		svc.SetSessionConfig(session)
+	}
+	if d.Web.WelcomeFiles != nil {
+		svc.SetWelcomeFiles(append([]string(nil), d.Web.WelcomeFiles...))
+	}
+
+	for _, s := range d.Web.Servlets {
+		if s.Name == "" {
+			return fmt.Errorf("servlet: name is required")
+		}
+		if s.Absent {
+			if svc.GetServlet(s.Name) != nil {
+				svc.DeleteServlet(s.Name)
+			}
+			continue
+		}
+		if svc.GetServlet(s.Name) == nil {
+			if s.Class == "" {
+				// The servlets Tomcat ships with are known by name
+				s.Class = shippedServlets[s.Name].class
+			}
+			if s.Class == "" {
+				return fmt.Errorf("servlet %s: class is required", s.Name)
+			}
+			if err := svc.AddServlet(web.Servlet{ServletName: s.Name}); err != nil {
+				return err
+			}
+		}
+		servlet := svc.GetServlet(s.Name)
+		if s.Class != "" {
+			servlet.ServletClass = s.Class
+		}
+		if s.LoadOnStartup != nil {
+			servlet.LoadOnStartup = strconv.Itoa(*s.LoadOnStartup)
+		}
+		for _, name := range s.InitParams.Names() {
+			servlet.InitParams = setInitParam(servlet.InitParams, name, s.InitParams[name])
+		}
+	}
+	return nil
+}
+
+// setInitParam sets an init parameter, removing it for ""
+func setInitParam(params []web.InitParam, name, value string) []web.InitParam {
+	for i := range params {
+		if params[i].ParamName == name {
+			if value == "" {
+				return append(params[:i], params[i+1:]...)
+			}
+			params[i].ParamValue = value
+			return params
+		}
+	}
+	if value == "" {
+		return params
+	}
+	return append(params, web.InitParam{ParamName: name, ParamValue: value})
+}
+
+// parseLevel returns the java.util.logging level of a name
+func parseLevel(name string) (logging.LogLevel, bool) {
+	for _, level := range logging.AvailableLogLevels() {
+		if strings.EqualFold(string(level), name) {
+			return level, true
+		}
+	}
+	return "", false
+}
+
+// converge makes the element of a slice with the key of e look as
+// declared, adding it when missing and removing it when it is absent. An
+// element that replaces another takes over the other one.
+func converge(list reflect.Value, key string, e Element) error {
+	id := e[key]
+	if id == "" {
+		return fmt.Errorf("%s is required", key)
+	}
+	index := findElement(list, key, id)
+	if replaced := e[replacesKey]; replaced != "" && replaced != id {
+		if old := findElement(list, key, replaced); old >= 0 {
+			if index < 0 {
+				index = old
+			} else {
+				removeElement(list, old)
+				index = findElement(list, key, id)
+			}
+		}
+	}
+
+	if e.Absent() {
+		if index >= 0 {
+			removeElement(list, index)
+		}
+		return nil
+	}
+	if index < 0 {
+		list.Set(reflect.Append(list, reflect.New(list.Type().Elem()).Elem()))
+		index = list.Len() - 1
+	}
+	if err := setAttrs(list.Index(index), e); err != nil {
+		return fmt.Errorf("%s %s: %w", key, id, err)
+	}
+	return nil
+}
+
+// findElement returns the index of the element of a slice with a key, or -1
+func findElement(list reflect.Value, key, id string) int {
+	for i := 0; i < list.Len(); i++ {
+		if value, ok := getAttr(list.Index(i), key); ok && value == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeElement removes an element from a slice
+func removeElement(list reflect.Value, index int) {
+	n := reflect.MakeSlice(list.Type(), 0, list.Len()-1)
+	n = reflect.AppendSlice(n, list.Slice(0, index))
+	n = reflect.AppendSlice(n, list.Slice(index+1, list.Len()))
+	list.Set(n)
+}
+
+// attrField returns the field of a struct holding an XML attribute
+func attrField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("xml")
+		tagName, flags, _ := strings.Cut(tag, ",")
+		if tagName == name && strings.Contains(flags, "attr") {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// getAttr returns an attribute of an element as written in the file
+func getAttr(v reflect.Value, name string) (string, bool) {
+	if f, ok := attrField(v, name); ok {
+		return fmt.Sprint(f.Interface()), true
+	}
+	return "", false
+}
+
+// setAttrs sets the declared attributes of an element
+func setAttrs(v reflect.Value, e Element) error {
+	for _, name := range e.Names() {
+		if err := setAttr(v, name, e[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setAttr sets an attribute of an element. Attributes without a field are
+// kept with the other preserved attributes where the element has them.
+func setAttr(v reflect.Value, name, value string) error {
+	f, ok := attrField(v, name)
+	if !ok {
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).Type() == attrsType {
+				setExtraAttr(v.Field(i), name, value)
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown attribute %q", name)
+	}
+
+	switch {
+	case f.Type() == intType:
+		n, err := placeholder.ParseInt(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		f.Set(reflect.ValueOf(n))
+	case f.Type() == boolType:
+		b, err := placeholder.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		f.Set(reflect.ValueOf(b))
+	case f.Kind() == reflect.String:
+		f.SetString(value)
+	case f.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil && value != "" {
+			return fmt.Errorf("%s: %q is not true or false", name, value)
+		}
+		f.SetBool(b)
+	case f.CanInt():
+		n, err := strconv.Atoi(value)
+		if err != nil && value != "" {
+			return fmt.Errorf("%s: %q is not a number", name, value)
+		}
+		f.SetInt(int64(n))
+	default:
+		return fmt.Errorf("attribute %q cannot be set", name)
+	}
+	return nil
+}
+
+// setExtraAttr sets a preserved attribute, removing it for ""
+func setExtraAttr(f reflect.Value, name, value string) {
+	attrs := f.Interface().([]xml.Attr)
+	for i := range attrs {
+		if attrs[i].Name.Local == name {
+			if value == "" {
+				attrs = append(attrs[:i], attrs[i+1:]...)
+			} else {
+				attrs[i].Value = value
+			}
+			f.Set(reflect.ValueOf(attrs))
+			return
+		}
+	}
+	if value != "" {
+		f.Set(reflect.ValueOf(append(attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})))
+	}
+}
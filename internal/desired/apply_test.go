@@ -0,0 +1,148 @@
+package desired
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/config/jndi"
+	"github.com/playok/tomcatkit/internal/config/server"
+)
+
+const contextXML = `<Context>
+  <Resource name="jdbc/app" auth="Container" type="javax.sql.DataSource"
+            url="jdbc:x" maxActive="20" maxWait="10000"
+            jdbcInterceptors="ConnectionState;StatementFinalizer"/>
+</Context>
+`
+
+// undeclaredAttrs are attributes of contextXML without a typed field
+var undeclaredAttrs = []string{`maxActive="20"`, `maxWait="10000"`, `jdbcInterceptors="ConnectionState;StatementFinalizer"`}
+
+func TestApplyContextKeepsUndeclaredAttributes(t *testing.T) {
+	var ctx jndi.Context
+	if err := xml.Unmarshal([]byte(contextXML), &ctx); err != nil {
+		t.Fatal(err)
+	}
+	doc := &Document{Resources: []Element{{"name": "jdbc/app", "url": "jdbc:y", "maxActive": "50"}}}
+	if err := doc.ApplyContext(&ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := xml.Marshal(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{`url="jdbc:y"`, `maxActive="50"`, `maxWait="10000"`, `jdbcInterceptors="ConnectionState;StatementFinalizer"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%s missing from %s", want, out)
+		}
+	}
+}
+
+func TestApplyContextAddsAndRemovesResources(t *testing.T) {
+	var ctx jndi.Context
+	if err := xml.Unmarshal([]byte(contextXML), &ctx); err != nil {
+		t.Fatal(err)
+	}
+	doc := &Document{Resources: []Element{
+		{"name": "jdbc/app", "absent": "true"},
+		{"name": "jdbc/new", "type": "javax.sql.DataSource", "url": "jdbc:z"},
+	}}
+	if err := doc.ApplyContext(&ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(ctx.Resources) != 1 || ctx.Resources[0].Name != "jdbc/new" || ctx.Resources[0].URL != "jdbc:z" {
+		t.Errorf("resources = %+v, want only jdbc/new", ctx.Resources)
+	}
+}
+
+func TestApplyServerConvergesConnectors(t *testing.T) {
+	var srv server.Server
+	err := xml.Unmarshal([]byte(`<Server port="8005" shutdown="SHUTDOWN">
+  <Service name="Catalina">
+    <Connector port="8080" protocol="HTTP/1.1" socket.soKeepAlive="true"/>
+    <Connector port="8009" protocol="AJP/1.3"/>
+    <Engine name="Catalina" defaultHost="localhost"/>
+  </Service>
+</Server>`), &srv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := &Document{Connectors: []Element{
+		{"port": "8080", "maxThreads": "400", "compression": "on"},
+		{"port": "8009", "absent": "true"},
+	}}
+	if err := doc.ApplyServer(&srv); err != nil {
+		t.Fatal(err)
+	}
+
+	conns := srv.Services[0].Connectors
+	if len(conns) != 1 {
+		t.Fatalf("connectors = %d, want 1", len(conns))
+	}
+	conn := &conns[0]
+	if conn.MaxThreads.Text() != "400" {
+		t.Errorf("maxThreads = %q, want 400", conn.MaxThreads.Text())
+	}
+	if got := conn.GetAttribute("compression"); got != "on" {
+		t.Errorf("compression = %q, want on", got)
+	}
+	if got := conn.GetAttribute("socket.soKeepAlive"); got != "true" {
+		t.Errorf("socket.soKeepAlive = %q, want it kept", got)
+	}
+}
+
+func TestApplyServerRejectsInvalidValues(t *testing.T) {
+	srv := server.Server{Services: []server.Service{{Name: "Catalina", Connectors: []server.Connector{{Port: "8080"}}}}}
+	doc := &Document{Connectors: []Element{{"port": "8080", "maxThreads": "many"}}}
+	if err := doc.ApplyServer(&srv); err == nil {
+		t.Error("ApplyServer succeeded, want an error for maxThreads=many")
+	}
+}
+
+// A plan lists only the declared change and writing it keeps the rest of
+// the element
+func TestPlanApplyKeepsUndeclaredAttributes(t *testing.T) {
+	base := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(base, "conf"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	contextPath := filepath.Join(base, "conf", "context.xml")
+	if err := os.WriteFile(contextPath, []byte(contextXML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := Parse([]byte("resources:\n  - name: jdbc/app\n    url: \"jdbc:y\"\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := NewPlan(doc, &config.TomcatInstance{CatalinaHome: base, CatalinaBase: base})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Name != "url" || plan.Changes[0].B != "jdbc:y" {
+		t.Fatalf("changes = %+v, want only url -> jdbc:y", plan.Changes)
+	}
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(contextPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	if !strings.Contains(out, `url="jdbc:y"`) {
+		t.Errorf("url not changed in %s", out)
+	}
+	for _, want := range undeclaredAttrs {
+		if !strings.Contains(out, want) {
+			t.Errorf("%s missing from %s", want, out)
+		}
+	}
+}
@@ -0,0 +1,422 @@
+// Package desired reads and writes desired-state documents: compact YAML
+// or JSON descriptions of the configuration an instance should have.
+// Elements are identified the way Tomcat identifies them (connectors by
+// port, hosts and resources by name, valves and realms by class), and only
+// the declared attributes are changed.
+package desired
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document describes the desired configuration of an instance. Elements
+// that are not declared are left as they are.
+type Document struct {
+	// Server holds attributes of <Server>, such as port and shutdown
+	Server Element `json:"server,omitempty"`
+	// Service names the service to change; the first one by default
+	Service    string    `json:"service,omitempty"`
+	Executors  []Element `json:"executors,omitempty"`  // By name
+	Connectors []Element `json:"connectors,omitempty"` // By port
+	// Engine holds attributes of <Engine>, such as defaultHost and jvmRoute
+	Engine Element   `json:"engine,omitempty"`
+	Realm  *Realm    `json:"realm,omitempty"`  // The realm of the engine
+	Valves []Element `json:"valves,omitempty"` // Engine valves, by className
+	Hosts  []Host    `json:"hosts,omitempty"`  // By name
+	// Resources are the JNDI resources of conf/context.xml, by name
+	Resources []Element `json:"resources,omitempty"`
+	Loggers   []Logger  `json:"loggers,omitempty"` // By name
+	// Roles and Users are the entries of conf/tomcat-users.xml, by rolename
+	// and username
+	Roles []Element `json:"roles,omitempty"`
+	Users []Element `json:"users,omitempty"`
+	// Web holds the application defaults of conf/web.xml
+	Web *Web `json:"web,omitempty"`
+}
+
+// Element is the attributes of an element as written in the configuration
+// file. "absent: true" removes the element.
+type Element map[string]string
+
+// Reserved keys: absentKey marks elements to remove and replacesKey names
+// the element that an element takes the place of, e.g. the connector on
+// another port, so that identities can change
+const (
+	absentKey   = "absent"
+	replacesKey = "replaces"
+)
+
+// Absent reports whether the element is to be removed
+func (e Element) Absent() bool {
+	return e[absentKey] == "true"
+}
+
+// Names returns the attribute names in sorted order, without the reserved
+// keys
+func (e Element) Names() []string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		if name != absentKey && name != replacesKey {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UnmarshalJSON accepts strings, numbers and booleans as attribute values
+func (e *Element) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw map[string]any
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	*e = make(Element, len(raw))
+	for name, value := range raw {
+		switch v := value.(type) {
+		case string:
+			(*e)[name] = v
+		case json.Number, bool:
+			(*e)[name] = fmt.Sprint(v)
+		case nil:
+			(*e)[name] = ""
+		default:
+			return fmt.Errorf("attribute %s must be a single value", name)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON writes numbers and booleans as such, so that exported
+// documents read naturally
+func (e Element) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range e.sortedNames() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		value := e[name]
+		if isScalar(value) {
+			buf.WriteString(value)
+			continue
+		}
+		text, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(text)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// identityKeys identify elements and are written first
+var identityKeys = []string{"name", "port", "className", "rolename", "username"}
+
+// sortedNames returns all names with the identifying one first, the others
+// in sorted order and the reserved keys last
+func (e Element) sortedNames() []string {
+	names := make([]string, 0, len(e))
+	for _, key := range identityKeys {
+		if _, ok := e[key]; ok {
+			names = append(names, key)
+			break
+		}
+	}
+	for _, name := range e.Names() {
+		if len(names) == 0 || name != names[0] {
+			names = append(names, name)
+		}
+	}
+	for _, key := range []string{replacesKey, absentKey} {
+		if _, ok := e[key]; ok {
+			names = append(names, key)
+		}
+	}
+	return names
+}
+
+// isScalar reports whether a value reads the same as a JSON number or
+// boolean
+func isScalar(value string) bool {
+	if value == "true" || value == "false" {
+		return true
+	}
+	n, err := strconv.Atoi(value)
+	return err == nil && strconv.Itoa(n) == value
+}
+
+// Host is a virtual host with its aliases and valves
+type Host struct {
+	Element
+	Aliases []string  // Added when missing; other aliases are kept
+	Valves  []Element // By className
+}
+
+// MarshalJSON writes the attributes of a host followed by its nested lists
+func (h Host) MarshalJSON() ([]byte, error) {
+	return joinNested(h.Element, struct {
+		Aliases []string  `json:"aliases,omitempty"`
+		Valves  []Element `json:"valves,omitempty"`
+	}{h.Aliases, h.Valves})
+}
+
+// UnmarshalJSON reads the nested lists and the attributes of a host
+func (h *Host) UnmarshalJSON(data []byte) error {
+	var nested struct {
+		Aliases []string  `json:"aliases"`
+		Valves  []Element `json:"valves"`
+	}
+	rest, err := splitNested(data, &nested, "aliases", "valves")
+	if err != nil {
+		return err
+	}
+	h.Aliases, h.Valves = nested.Aliases, nested.Valves
+	return json.Unmarshal(rest, &h.Element)
+}
+
+// Realm is a realm with the realms nested in it, as used by LockOutRealm
+// and CombinedRealm
+type Realm struct {
+	Element
+	Realms []Realm // By className
+}
+
+// MarshalJSON writes the attributes of a realm followed by its nested realms
+func (r Realm) MarshalJSON() ([]byte, error) {
+	return joinNested(r.Element, struct {
+		Realms []Realm `json:"realms,omitempty"`
+	}{r.Realms})
+}
+
+// UnmarshalJSON reads the nested realms and the attributes of a realm
+func (r *Realm) UnmarshalJSON(data []byte) error {
+	var nested struct {
+		Realms []Realm `json:"realms"`
+	}
+	rest, err := splitNested(data, &nested, "realms")
+	if err != nil {
+		return err
+	}
+	r.Realms = nested.Realms
+	return json.Unmarshal(rest, &r.Element)
+}
+
+// splitNested decodes the nested lists of an element into nested and
+// returns the remaining attributes
+func splitNested(data []byte, nested any, names ...string) ([]byte, error) {
+	if err := json.Unmarshal(data, nested); err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		delete(raw, name)
+	}
+	return json.Marshal(raw)
+}
+
+// joinNested writes the attributes of an element and then the nested
+// lists, the reverse of splitNested
+func joinNested(e Element, nested any) ([]byte, error) {
+	attrs, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := json.Marshal(nested)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(lists) <= 2:
+		return attrs, nil
+	case len(attrs) <= 2:
+		return lists, nil
+	}
+	joined := append(attrs[:len(attrs)-1:len(attrs)-1], ',')
+	return append(joined, lists[1:]...), nil
+}
+
+// Logger is a logger of logging.properties
+type Logger struct {
+	Name              string   `json:"name"`
+	Level             string   `json:"level,omitempty"`
+	Handlers          []string `json:"handlers,omitempty"`
+	UseParentHandlers *bool    `json:"useParentHandlers,omitempty"`
+	Absent            bool     `json:"absent,omitempty"`
+}
+
+// Web is the part of conf/web.xml that the applications of an instance
+// inherit. Servlet mappings and MIME types are not covered.
+type Web struct {
+	SessionTimeout *int      `json:"sessionTimeout,omitempty"` // In minutes
+	WelcomeFiles   []string  `json:"welcomeFiles,omitempty"`   // Replaces the list
+	Servlets       []Servlet `json:"servlets,omitempty"`       // By name
+}
+
+// Servlet is a servlet of conf/web.xml, such as the default or the JSP
+// servlet. Only the declared init parameters are changed and "" removes one.
+type Servlet struct {
+	Name          string  `json:"name"`
+	Class         string  `json:"class,omitempty"`
+	LoadOnStartup *int    `json:"loadOnStartup,omitempty"`
+	InitParams    Element `json:"initParams,omitempty"`
+	Absent        bool    `json:"absent,omitempty"`
+}
+
+// Load reads a desired-state document. Files ending in .json are read as
+// JSON and all others as YAML.
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	doc, err := Parse(data, strings.EqualFold(filepath.Ext(path), ".json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// Parse reads a document from YAML, or from JSON when isJSON is set.
+// Unknown sections are rejected so that typos do not go unnoticed.
+func Parse(data []byte, isJSON bool) (*Document, error) {
+	if !isJSON {
+		// YAML is read through JSON, so that both share the json tags and
+		// the element decoding above
+		var tree any
+		if err := yaml.Unmarshal(data, &tree); err != nil {
+			return nil, err
+		}
+		if tree == nil {
+			return &Document{}, nil
+		}
+		converted, err := json.Marshal(tree)
+		if err != nil {
+			return nil, err
+		}
+		data = converted
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var doc Document
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Marshal writes a document as YAML, or as JSON when isJSON is set
+func (d *Document) Marshal(isJSON bool) ([]byte, error) {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if isJSON {
+		return append(data, '\n'), nil
+	}
+
+	// YAML is written through JSON as well, keeping the order of the
+	// sections and the types of the values
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	node, err := yamlNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlNode reads the next JSON value as a YAML node
+func yamlNode(dec *json.Decoder) (*yaml.Node, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := token.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		if t == '{' {
+			node.Kind = yaml.MappingNode
+		}
+		for dec.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, scalarNode("!!str", fmt.Sprint(key)))
+			}
+			value, err := yamlNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, value)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		if isFlat(node) {
+			node.Style = yaml.FlowStyle
+		}
+		return node, nil
+	case json.Number:
+		if strings.ContainsAny(t.String(), ".eE") {
+			return scalarNode("!!float", t.String()), nil
+		}
+		return scalarNode("!!int", t.String()), nil
+	case bool:
+		return scalarNode("!!bool", strconv.FormatBool(t)), nil
+	case nil:
+		return scalarNode("!!null", "null"), nil
+	}
+	return scalarNode("!!str", fmt.Sprint(token)), nil
+}
+
+// scalarNode returns a YAML scalar with a type tag, so that strings that
+// look like numbers are quoted
+func scalarNode(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+// isFlat reports whether a node is short and holds scalars only, so that
+// it reads best on one line as in the examples
+func isFlat(node *yaml.Node) bool {
+	width := 0
+	for _, n := range node.Content {
+		if n.Kind != yaml.ScalarNode {
+			return false
+		}
+		width += len(n.Value) + 2
+	}
+	return len(node.Content) > 0 && width <= 72
+}
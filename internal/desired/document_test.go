@@ -0,0 +1,88 @@
+package desired
+
+import "testing"
+
+func TestParseYAMLReadsScalarsAsText(t *testing.T) {
+	doc, err := Parse([]byte(`
+connectors:
+  - port: 8080
+    compression: on
+    enableLookups: false
+  - port: 8009
+    absent: true
+resources:
+  - name: jdbc/app
+    url: "jdbc:y"
+`), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Connectors) != 2 {
+		t.Fatalf("connectors = %v, want 2", doc.Connectors)
+	}
+	http := doc.Connectors[0]
+	for name, want := range map[string]string{"port": "8080", "compression": "on", "enableLookups": "false"} {
+		if http[name] != want {
+			t.Errorf("%s = %q, want %q", name, http[name], want)
+		}
+	}
+	if !doc.Connectors[1].Absent() {
+		t.Errorf("connector 8009 is not absent")
+	}
+	if got := doc.Connectors[1].Names(); len(got) != 1 || got[0] != "port" {
+		t.Errorf("Names() = %v, want [port]", got)
+	}
+	if doc.Resources[0]["url"] != "jdbc:y" {
+		t.Errorf("url = %q, want jdbc:y", doc.Resources[0]["url"])
+	}
+}
+
+func TestParseRejectsUnknownSections(t *testing.T) {
+	for _, tc := range []struct {
+		data   string
+		isJSON bool
+	}{
+		{"conectors: []\n", false},
+		{`{"conectors": []}`, true},
+	} {
+		if _, err := Parse([]byte(tc.data), tc.isJSON); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", tc.data)
+		}
+	}
+}
+
+func TestParseRejectsNestedAttributeValues(t *testing.T) {
+	if _, err := Parse([]byte("resources:\n  - name: jdbc/app\n    url: [a, b]\n"), false); err == nil {
+		t.Error("Parse succeeded, want an error for a list value")
+	}
+}
+
+func TestMarshalRoundTrips(t *testing.T) {
+	doc, err := Parse([]byte(`
+hosts:
+  - name: localhost
+    appBase: webapps
+    aliases: [www.example.com]
+    valves:
+      - className: org.apache.catalina.valves.AccessLogValve
+        pattern: common
+`), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, isJSON := range []bool{false, true} {
+		data, err := doc.Marshal(isJSON)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := Parse(data, isJSON)
+		if err != nil {
+			t.Fatalf("%v: %s", err, data)
+		}
+		host := again.Hosts[0]
+		if host.Element["appBase"] != "webapps" || len(host.Aliases) != 1 || host.Valves[0]["pattern"] != "common" {
+			t.Errorf("round trip lost settings: %s", data)
+		}
+	}
+}
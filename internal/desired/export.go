@@ -0,0 +1,297 @@
+package desired
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/compare"
+	"github.com/playok/tomcatkit/internal/config/connector"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/config/server"
+	"github.com/playok/tomcatkit/internal/config/web"
+)
+
+// Secrets tells how Export writes passwords and other secrets
+type Secrets int
+
+const (
+	SecretsRedact    Secrets = iota // Left out, so that apply keeps them as they are
+	SecretsReference                // Replaced by ${...} references
+	SecretsKeep                     // Written as they are
+)
+
+// ParseSecrets returns the secrets mode of a name: redact, reference or keep
+func ParseSecrets(name string) (Secrets, error) {
+	switch strings.ToLower(name) {
+	case "redact":
+		return SecretsRedact, nil
+	case "reference":
+		return SecretsReference, nil
+	case "keep":
+		return SecretsKeep, nil
+	}
+	return 0, fmt.Errorf("unknown secrets mode %q (use redact, reference or keep)", name)
+}
+
+// Defaults that exported documents leave out. Connector defaults come from
+// the attribute catalogue.
+var (
+	executorDefaults = map[string]string{
+		"className":       "org.apache.catalina.core.StandardThreadExecutor",
+		"namePrefix":      "tomcat-exec-",
+		"maxThreads":      "200",
+		"minSpareThreads": "25",
+		"maxIdleTime":     "60000",
+	}
+	hostDefaults = map[string]string{
+		"appBase":         "webapps",
+		"unpackWARs":      "true",
+		"autoDeploy":      "true",
+		"deployOnStartup": "true",
+		"createDirs":      "true",
+		"copyXML":         "false",
+	}
+)
+
+// shippedServlet is a servlet as conf/web.xml ships with Tomcat
+type shippedServlet struct {
+	class  string
+	load   string
+	params map[string]string
+}
+
+// The application defaults conf/web.xml ships with
+var (
+	shippedServlets = map[string]shippedServlet{
+		"default": {
+			class:  "org.apache.catalina.servlets.DefaultServlet",
+			load:   "1",
+			params: map[string]string{"debug": "0", "listings": "false"},
+		},
+		"jsp": {
+			class:  "org.apache.jasper.servlet.JspServlet",
+			load:   "3",
+			params: map[string]string{"fork": "false", "xpoweredBy": "false"},
+		},
+	}
+	shippedWelcomeFiles   = []string{"index.html", "index.htm", "index.jsp"}
+	shippedSessionTimeout = 30
+)
+
+// exporter collects the settings of an instance into a document
+type exporter struct {
+	secrets    Secrets
+	references map[string]bool
+}
+
+// Export describes the configuration of an instance as a document. Values
+// that are not set or at their Tomcat defaults are left out, so applying
+// the document to the same instance changes nothing. Only the first service
+// of server.xml is exported, and files that could not be read are skipped.
+// It also returns the names of the ${...} references written for secrets.
+func Export(s *compare.Snapshot, secrets Secrets) (*Document, []string, error) {
+	if err := s.Errors[compare.FileServer]; err != nil {
+		return nil, nil, err
+	}
+	x := &exporter{secrets: secrets, references: make(map[string]bool)}
+	doc := &Document{}
+	x.exportServer(doc, s.Server().GetServer())
+
+	if s.Errors[compare.FileContext] == nil {
+		for i := range s.Context().GetContext().Resources {
+			r := &s.Context().GetContext().Resources[i]
+			doc.Resources = append(doc.Resources, x.element(reflect.ValueOf(r).Elem(), r.Name, nil))
+		}
+	}
+	if s.Errors[compare.FileLogging] == nil {
+		for _, l := range s.Logging().GetConfig().Loggers {
+			logger := Logger{Name: l.Name, Level: string(l.Level), Handlers: l.Handlers}
+			if !l.UseParentHandlers {
+				logger.UseParentHandlers = &l.UseParentHandlers
+			}
+			doc.Loggers = append(doc.Loggers, logger)
+		}
+	}
+	if s.Errors[compare.FileUsers] == nil {
+		users := s.Users().GetTomcatUsers()
+		for i := range users.Roles {
+			doc.Roles = append(doc.Roles, x.element(reflect.ValueOf(&users.Roles[i]).Elem(), "", nil))
+		}
+		for i := range users.Users {
+			u := &users.Users[i]
+			doc.Users = append(doc.Users, x.element(reflect.ValueOf(u).Elem(), "user "+u.Username, nil))
+		}
+	}
+	if s.Errors[compare.FileWeb] == nil {
+		doc.Web = x.exportWeb(s.Web().GetWebApp())
+	}
+
+	references := make([]string, 0, len(x.references))
+	for name := range x.references {
+		references = append(references, name)
+	}
+	sort.Strings(references)
+	return doc, references, nil
+}
+
+// exportServer adds the sections of server.xml
+func (x *exporter) exportServer(doc *Document, srv *server.Server) {
+	doc.Server = x.element(reflect.ValueOf(srv).Elem(), "server", nil)
+	if len(srv.Services) == 0 {
+		return
+	}
+	service := &srv.Services[0]
+	if len(srv.Services) > 1 {
+		doc.Service = service.Name
+	}
+
+	for i := range service.Executors {
+		doc.Executors = append(doc.Executors, x.element(reflect.ValueOf(&service.Executors[i]).Elem(), "", executorDefaults))
+	}
+	connectorDefaults := make(map[string]string)
+	for _, spec := range connector.Attributes() {
+		connectorDefaults[spec.Name] = spec.Default
+	}
+	for i := range service.Connectors {
+		c := &service.Connectors[i]
+		doc.Connectors = append(doc.Connectors, x.element(reflect.ValueOf(c).Elem(), "connector "+string(c.Port), connectorDefaults))
+	}
+
+	engine := &service.Engine
+	doc.Engine = x.element(reflect.ValueOf(engine).Elem(), "engine", nil)
+	if engine.Realm != nil {
+		doc.Realm = x.exportRealm(engine.Realm)
+	}
+	doc.Valves = x.elements(reflect.ValueOf(engine.Valves))
+	for i := range engine.Hosts {
+		h := &engine.Hosts[i]
+		host := Host{Element: x.element(reflect.ValueOf(h).Elem(), "host "+h.Name, hostDefaults)}
+		for _, alias := range h.Aliases {
+			host.Aliases = append(host.Aliases, alias.Name)
+		}
+		host.Valves = x.elements(reflect.ValueOf(h.Valves))
+		doc.Hosts = append(doc.Hosts, host)
+	}
+}
+
+// exportRealm returns a realm with the realms nested in it
+func (x *exporter) exportRealm(r *server.Realm) *Realm {
+	realm := &Realm{Element: x.element(reflect.ValueOf(r).Elem(), "realm", nil)}
+	for i := range r.NestedRealms {
+		realm.Realms = append(realm.Realms, *x.exportRealm(&r.NestedRealms[i]))
+	}
+	return realm
+}
+
+// exportWeb returns what conf/web.xml changes from the shipped defaults,
+// or nil
+func (x *exporter) exportWeb(app *web.WebApp) *Web {
+	w := &Web{}
+	if app.SessionConfig != nil && app.SessionConfig.SessionTimeout != shippedSessionTimeout {
+		timeout := app.SessionConfig.SessionTimeout
+		w.SessionTimeout = &timeout
+	}
+	if app.WelcomeFileList != nil && !reflect.DeepEqual(app.WelcomeFileList.WelcomeFiles, shippedWelcomeFiles) {
+		w.WelcomeFiles = app.WelcomeFileList.WelcomeFiles
+	}
+
+	for _, s := range app.Servlets {
+		shipped := shippedServlets[s.ServletName]
+		servlet := Servlet{Name: s.ServletName, InitParams: make(Element)}
+		if s.ServletClass != shipped.class {
+			servlet.Class = s.ServletClass
+		}
+		if load := strings.TrimSpace(s.LoadOnStartup); load != shipped.load {
+			if n, err := strconv.Atoi(load); err == nil {
+				servlet.LoadOnStartup = &n
+			}
+		}
+		for _, p := range s.InitParams {
+			x.set(servlet.InitParams, "servlet "+s.ServletName, p.ParamName, p.ParamValue, shipped.params)
+		}
+		if len(servlet.InitParams) == 0 {
+			servlet.InitParams = nil
+			if servlet.Class == "" && servlet.LoadOnStartup == nil {
+				continue
+			}
+		}
+		w.Servlets = append(w.Servlets, servlet)
+	}
+
+	if w.SessionTimeout == nil && w.WelcomeFiles == nil && w.Servlets == nil {
+		return nil
+	}
+	return w
+}
+
+// elements returns the attributes of each element of a slice
+func (x *exporter) elements(list reflect.Value) []Element {
+	var elements []Element
+	for i := 0; i < list.Len(); i++ {
+		elements = append(elements, x.element(list.Index(i), "", nil))
+	}
+	return elements
+}
+
+// element returns the attributes of an element that are set and differ
+// from the defaults. Secrets are named after scope when referenced.
+func (x *exporter) element(v reflect.Value, scope string, defaults map[string]string) Element {
+	e := make(Element)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := v.Field(i)
+		if f.Type() == attrsType {
+			for _, attr := range f.Interface().([]xml.Attr) {
+				x.set(e, scope, attr.Name.Local, attr.Value, defaults)
+			}
+			continue
+		}
+		name, flags, _ := strings.Cut(t.Field(i).Tag.Get("xml"), ",")
+		if !strings.Contains(flags, "attr") || strings.Contains(name, ":") || strings.HasPrefix(name, "xmlns") {
+			continue
+		}
+		x.set(e, scope, name, attrText(f), defaults)
+	}
+	return e
+}
+
+// attrText returns the value of an attribute field as written in the file
+func attrText(f reflect.Value) string {
+	switch f.Kind() {
+	case reflect.Bool:
+		if f.Bool() {
+			return "true"
+		}
+		return ""
+	case reflect.Int, reflect.Int64:
+		if f.Int() != 0 {
+			return strconv.FormatInt(f.Int(), 10)
+		}
+		return ""
+	case reflect.String:
+		return strings.TrimSpace(f.String())
+	}
+	return ""
+}
+
+// set adds a value unless it is empty or the default, handling secrets
+func (x *exporter) set(e Element, scope, name, value string, defaults map[string]string) {
+	if value == "" || defaults[name] == value {
+		return
+	}
+	if placeholder.IsSecret(name) && !placeholder.HasPlaceholder(value) {
+		switch x.secrets {
+		case SecretsRedact:
+			return
+		case SecretsReference:
+			reference := placeholder.ReferenceName(scope, name)
+			x.references[reference] = true
+			value = placeholder.Expression(reference)
+		}
+	}
+	e[name] = value
+}
@@ -0,0 +1,82 @@
+package desired
+
+import (
+	"fmt"
+
+	"github.com/playok/tomcatkit/internal/compare"
+	"github.com/playok/tomcatkit/internal/config"
+)
+
+// Plan is what applying a document changes in an instance
+type Plan struct {
+	Instance *config.TomcatInstance
+	// Changes compare the current configuration (A) with the desired one
+	// (B): OnlyA elements are removed and OnlyB elements added
+	Changes []compare.Difference
+
+	desired *compare.Snapshot
+}
+
+// NewPlan applies a document to the configuration of an instance in memory
+// and compares the result with the files. Nothing is written until Apply.
+func NewPlan(doc *Document, instance *config.TomcatInstance) (*Plan, error) {
+	current := compare.Load(instance)
+	desired := compare.Load(instance)
+
+	if doc.HasServer() {
+		if err := desired.Errors[compare.FileServer]; err != nil {
+			return nil, err
+		}
+		if err := doc.ApplyServer(desired.Server().GetServer()); err != nil {
+			return nil, fmt.Errorf("%s: %w", compare.FileServer, err)
+		}
+	}
+	if len(doc.Resources) > 0 {
+		if err := desired.Errors[compare.FileContext]; err != nil {
+			return nil, err
+		}
+		if err := doc.ApplyContext(desired.Context().GetContext()); err != nil {
+			return nil, fmt.Errorf("%s: %w", compare.FileContext, err)
+		}
+	}
+	if len(doc.Loggers) > 0 {
+		if err := desired.Errors[compare.FileLogging]; err != nil {
+			return nil, err
+		}
+		if err := doc.ApplyLogging(desired.Logging()); err != nil {
+			return nil, fmt.Errorf("%s: %w", compare.FileLogging, err)
+		}
+	}
+	if len(doc.Roles) > 0 || len(doc.Users) > 0 {
+		if err := desired.Errors[compare.FileUsers]; err != nil {
+			return nil, err
+		}
+		if err := doc.ApplyUsers(desired.Users().GetTomcatUsers()); err != nil {
+			return nil, fmt.Errorf("%s: %w", compare.FileUsers, err)
+		}
+	}
+	if doc.Web != nil {
+		if err := desired.Errors[compare.FileWeb]; err != nil {
+			return nil, err
+		}
+		if err := doc.ApplyWeb(desired.Web()); err != nil {
+			return nil, fmt.Errorf("%s: %w", compare.FileWeb, err)
+		}
+	}
+
+	changes := (&compare.Comparison{A: current, B: desired}).Differences()
+	for _, change := range changes {
+		desired.SetModified(change.File)
+	}
+	return &Plan{Instance: instance, Changes: changes, desired: desired}, nil
+}
+
+// Files returns the files the plan changes
+func (p *Plan) Files() []string {
+	return p.desired.Modified()
+}
+
+// Apply writes the changed files. Each file is backed up by its service.
+func (p *Plan) Apply() error {
+	return p.desired.Save()
+}
@@ -0,0 +1,470 @@
+package desired
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BaseProfile is the name of the document that the overlays change
+const BaseProfile = "base"
+
+// profileExtensions are the extensions of profile documents
+var profileExtensions = []string{".yaml", ".yml", ".json"}
+
+// Profiles is a base document with an overlay for each environment, read
+// from a directory: base.yaml and, for example, dev.yaml and prod.yaml.
+// Overlays declare only what differs and are matched with the base by
+// element identity, so "resources: [{name: jdbc/app, url: ...}]" changes
+// the URL of jdbc/app and keeps its other attributes.
+type Profiles struct {
+	Dir  string
+	Base *Document
+
+	overlays map[string]*Document
+}
+
+// LoadProfiles reads the base document and the overlays of a directory
+func LoadProfiles(dir string) (*Profiles, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	p := &Profiles{Dir: dir, overlays: make(map[string]*Document)}
+	files := make(map[string]string)
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || !isProfileExtension(ext) {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if other, ok := files[name]; ok {
+			return nil, fmt.Errorf("profile %s is defined by both %s and %s", name, other, entry.Name())
+		}
+		files[name] = entry.Name()
+
+		doc, err := Load(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if name == BaseProfile {
+			p.Base = doc
+		} else {
+			p.overlays[name] = doc
+		}
+	}
+	if p.Base == nil {
+		return nil, fmt.Errorf("%s has no %s.yaml", dir, BaseProfile)
+	}
+	return p, nil
+}
+
+// isProfileExtension reports whether a file extension is a document's
+func isProfileExtension(ext string) bool {
+	for _, e := range profileExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Names returns the overlay names in sorted order
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.overlays))
+	for name := range p.overlays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Effective returns the document of a profile: the base with the overlay
+// applied. The base profile is the base document itself.
+func (p *Profiles) Effective(name string) (*Document, error) {
+	if name == BaseProfile {
+		return p.Base, nil
+	}
+	overlay, ok := p.overlays[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q (have %s)", name, strings.Join(append([]string{BaseProfile}, p.Names()...), ", "))
+	}
+	return Merge(p.Base, overlay), nil
+}
+
+// Merge returns base changed by overlay. Attributes of the overlay win;
+// list elements are matched by what identifies them and added when the
+// base has no such element. An overlay element that replaces a base
+// element takes its place and keeps its attributes.
+func Merge(base, overlay *Document) *Document {
+	doc := *base
+	doc.Server = mergeElement(base.Server, overlay.Server)
+	if overlay.Service != "" {
+		doc.Service = overlay.Service
+	}
+	doc.Executors = mergeElements(base.Executors, overlay.Executors, "name")
+	doc.Connectors = mergeElements(base.Connectors, overlay.Connectors, "port")
+	doc.Engine = mergeElement(base.Engine, overlay.Engine)
+	doc.Realm = mergeRealm(base.Realm, overlay.Realm)
+	doc.Valves = mergeElements(base.Valves, overlay.Valves, "className")
+	doc.Hosts = mergeHosts(base.Hosts, overlay.Hosts)
+	doc.Resources = mergeElements(base.Resources, overlay.Resources, "name")
+	doc.Loggers = mergeLoggers(base.Loggers, overlay.Loggers)
+	doc.Roles = mergeElements(base.Roles, overlay.Roles, "rolename")
+	doc.Users = mergeElements(base.Users, overlay.Users, "username")
+	doc.Web = mergeWeb(base.Web, overlay.Web)
+	return &doc
+}
+
+// mergeElement returns the attributes of base changed by overlay. An
+// overlay declaring an element the base removes brings it back as declared.
+func mergeElement(base, overlay Element) Element {
+	if len(overlay) == 0 {
+		return base
+	}
+	if overlay.Absent() || base.Absent() {
+		return overlay
+	}
+	merged := make(Element, len(base)+len(overlay))
+	for name, value := range base {
+		merged[name] = value
+	}
+	for name, value := range overlay {
+		merged[name] = value
+	}
+	return merged
+}
+
+// findByKey returns the index of the element with a key, or -1
+func findByKey(list []Element, key, id string) int {
+	for i, e := range list {
+		if e[key] == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeElements merges the elements of two lists by key
+func mergeElements(base, overlay []Element, key string) []Element {
+	if len(overlay) == 0 {
+		return base
+	}
+	merged := append([]Element(nil), base...)
+	for _, e := range overlay {
+		index := findByKey(merged, key, e[key])
+		if index < 0 && e[replacesKey] != "" {
+			index = findByKey(merged, key, e[replacesKey])
+		}
+		if index < 0 {
+			merged = append(merged, e)
+			continue
+		}
+		if e.Absent() {
+			merged[index] = Element{key: e[key], absentKey: "true"}
+			continue
+		}
+		merged[index] = mergeElement(merged[index], e)
+	}
+	return merged
+}
+
+// mergeHosts merges hosts by name. Aliases of the overlay replace those of
+// the base; valves are merged by className.
+func mergeHosts(base, overlay []Host) []Host {
+	if len(overlay) == 0 {
+		return base
+	}
+	merged := append([]Host(nil), base...)
+	for _, h := range overlay {
+		index := findHostByName(merged, h.Element["name"])
+		if index < 0 && h.Element[replacesKey] != "" {
+			index = findHostByName(merged, h.Element[replacesKey])
+		}
+		switch {
+		case index < 0:
+			merged = append(merged, h)
+			continue
+		case merged[index].Absent():
+			merged[index] = h
+			continue
+		case h.Absent():
+			merged[index] = Host{Element: Element{"name": h.Element["name"], absentKey: "true"}}
+			continue
+		}
+		host := merged[index]
+		host.Element = mergeElement(host.Element, h.Element)
+		if h.Aliases != nil {
+			host.Aliases = h.Aliases
+		}
+		host.Valves = mergeElements(host.Valves, h.Valves, "className")
+		merged[index] = host
+	}
+	return merged
+}
+
+// findHostByName returns the index of the host with a name, or -1
+func findHostByName(hosts []Host, name string) int {
+	for i := range hosts {
+		if hosts[i].Element["name"] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeRealm merges realms of the same class; an overlay realm of another
+// class replaces the base realm
+func mergeRealm(base, overlay *Realm) *Realm {
+	if overlay == nil {
+		return base
+	}
+	if base == nil || overlay.Absent() || base.Absent() || base.Element["className"] != overlay.Element["className"] {
+		return overlay
+	}
+	merged := &Realm{Element: mergeElement(base.Element, overlay.Element)}
+	merged.Realms = append([]Realm(nil), base.Realms...)
+	for _, nested := range overlay.Realms {
+		index := -1
+		for i := range merged.Realms {
+			if merged.Realms[i].Element["className"] == nested.Element["className"] {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			merged.Realms = append(merged.Realms, nested)
+		} else {
+			merged.Realms[index] = *mergeRealm(&merged.Realms[index], &nested)
+		}
+	}
+	return merged
+}
+
+// mergeLoggers merges loggers by name; declared fields of the overlay win
+func mergeLoggers(base, overlay []Logger) []Logger {
+	if len(overlay) == 0 {
+		return base
+	}
+	merged := append([]Logger(nil), base...)
+	for _, l := range overlay {
+		index := -1
+		for i := range merged {
+			if merged[i].Name == l.Name {
+				index = i
+				break
+			}
+		}
+		switch {
+		case index < 0:
+			merged = append(merged, l)
+		case l.Absent, merged[index].Absent:
+			merged[index] = l
+		default:
+			logger := merged[index]
+			if l.Level != "" {
+				logger.Level = l.Level
+			}
+			if l.Handlers != nil {
+				logger.Handlers = l.Handlers
+			}
+			if l.UseParentHandlers != nil {
+				logger.UseParentHandlers = l.UseParentHandlers
+			}
+			merged[index] = logger
+		}
+	}
+	return merged
+}
+
+// mergeWeb merges the web.xml sections; servlets are merged by name
+func mergeWeb(base, overlay *Web) *Web {
+	if overlay == nil {
+		return base
+	}
+	if base == nil {
+		return overlay
+	}
+	merged := *base
+	if overlay.SessionTimeout != nil {
+		merged.SessionTimeout = overlay.SessionTimeout
+	}
+	if overlay.WelcomeFiles != nil {
+		merged.WelcomeFiles = overlay.WelcomeFiles
+	}
+	merged.Servlets = append([]Servlet(nil), base.Servlets...)
+	for _, s := range overlay.Servlets {
+		index := -1
+		for i := range merged.Servlets {
+			if merged.Servlets[i].Name == s.Name {
+				index = i
+				break
+			}
+		}
+		switch {
+		case index < 0:
+			merged.Servlets = append(merged.Servlets, s)
+		case s.Absent, merged.Servlets[index].Absent:
+			merged.Servlets[index] = s
+		default:
+			servlet := merged.Servlets[index]
+			if s.Class != "" {
+				servlet.Class = s.Class
+			}
+			if s.LoadOnStartup != nil {
+				servlet.LoadOnStartup = s.LoadOnStartup
+			}
+			servlet.InitParams = mergeElement(servlet.InitParams, s.InitParams)
+			merged.Servlets[index] = servlet
+		}
+	}
+	return &merged
+}
+
+// Setting is one declared value of a document
+type Setting struct {
+	Path  string // e.g. resources[jdbc/app].url
+	Value string
+}
+
+// Settings lists the declared values of a document in document order.
+// List elements are named after what identifies them.
+func (d *Document) Settings() ([]Setting, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	// The YAML node keeps the order of the sections and attributes
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	node, err := yamlNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	var settings []Setting
+	flatten("", node, &settings)
+	return settings, nil
+}
+
+// flatten adds the values below a node
+func flatten(path string, node *yaml.Node, settings *[]Setting) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if value := mappingValue(node, absentKey); value == "true" {
+			*settings = append(*settings, Setting{Path: path, Value: absentKey})
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			child := node.Content[i].Value
+			if child == replacesKey {
+				continue
+			}
+			if path != "" {
+				child = path + "." + child
+			}
+			flatten(child, node.Content[i+1], settings)
+		}
+	case yaml.SequenceNode:
+		var scalars []string
+		for i, item := range node.Content {
+			if item.Kind != yaml.MappingNode {
+				scalars = append(scalars, item.Value)
+				continue
+			}
+			flatten(fmt.Sprintf("%s[%s]", path, identity(item, i)), item, settings)
+		}
+		if scalars != nil {
+			*settings = append(*settings, Setting{Path: path, Value: strings.Join(scalars, ", ")})
+		}
+	default:
+		*settings = append(*settings, Setting{Path: path, Value: node.Value})
+	}
+}
+
+// identity returns what identifies a list element, with class names
+// shortened, or its position. Elements that replace another are named
+// after it, so that they are compared with it.
+func identity(node *yaml.Node, index int) string {
+	if replaced := mappingValue(node, replacesKey); replaced != "" {
+		return replaced
+	}
+	for _, key := range identityKeys {
+		if id := mappingValue(node, key); id != "" {
+			if key == "className" {
+				id = id[strings.LastIndex(id, ".")+1:]
+			}
+			return id
+		}
+	}
+	return fmt.Sprintf("#%d", index+1)
+}
+
+// mappingValue returns the value of a key of a mapping node, or ""
+func mappingValue(node *yaml.Node, key string) string {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// SettingChange is a value that two documents declare differently. A or B
+// is "" when one document does not declare it.
+type SettingChange struct {
+	Path string
+	A, B string
+}
+
+// CompareDocuments returns the values that two documents declare
+// differently, in the order of a followed by the values only b declares
+func CompareDocuments(a, b *Document) ([]SettingChange, error) {
+	settingsA, err := a.Settings()
+	if err != nil {
+		return nil, err
+	}
+	settingsB, err := b.Settings()
+	if err != nil {
+		return nil, err
+	}
+	valuesA, valuesB := settingValues(settingsA), settingValues(settingsB)
+
+	var changes []SettingChange
+	for _, s := range settingsA {
+		if valuesB[s.Path] != s.Value && !underAbsent(s.Path, valuesB) {
+			changes = append(changes, SettingChange{Path: s.Path, A: s.Value, B: valuesB[s.Path]})
+		}
+	}
+	for _, s := range settingsB {
+		if _, ok := valuesA[s.Path]; !ok && !underAbsent(s.Path, valuesA) {
+			changes = append(changes, SettingChange{Path: s.Path, B: s.Value})
+		}
+	}
+	return changes, nil
+}
+
+// settingValues returns the values of settings by path
+func settingValues(settings []Setting) map[string]string {
+	values := make(map[string]string, len(settings))
+	for _, s := range settings {
+		values[s.Path] = s.Value
+	}
+	return values
+}
+
+// underAbsent reports whether a setting belongs to an element that the
+// other document removes, so that the removal is shown once
+func underAbsent(path string, values map[string]string) bool {
+	for i := range path {
+		if path[i] == '.' && values[path[:i]] == absentKey {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package desired
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeProfiles writes profile documents into a directory
+func writeProfiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestEffectiveMergesOverlayByIdentity(t *testing.T) {
+	dir := writeProfiles(t, map[string]string{
+		"base.yaml": `
+resources:
+  - name: jdbc/app
+    url: "jdbc:base"
+    maxTotal: 20
+`,
+		"dev.yaml": `
+resources:
+  - name: jdbc/app
+    url: "jdbc:dev"
+  - name: jdbc/extra
+    url: "jdbc:extra"
+`,
+	})
+	profiles, err := LoadProfiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := profiles.Names(); len(names) != 1 || names[0] != "dev" {
+		t.Fatalf("Names() = %v, want [dev]", names)
+	}
+
+	doc, err := profiles.Effective("dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Resources) != 2 {
+		t.Fatalf("resources = %v, want 2", doc.Resources)
+	}
+	app := doc.Resources[0]
+	if app["url"] != "jdbc:dev" || app["maxTotal"] != "20" {
+		t.Errorf("jdbc/app = %v, want the dev url and the base maxTotal", app)
+	}
+	if _, err := profiles.Effective("prod"); err == nil {
+		t.Errorf("unknown profile accepted")
+	}
+}
+
+func TestLoadProfilesRequiresBase(t *testing.T) {
+	dir := writeProfiles(t, map[string]string{"dev.yaml": "connectors: []\n"})
+	if _, err := LoadProfiles(dir); err == nil {
+		t.Errorf("directory without base.yaml accepted")
+	}
+}
+
+func TestMergeOverlayRestoresAbsentElement(t *testing.T) {
+	yes := true
+	base := &Document{
+		Connectors: []Element{{"port": "8009", absentKey: "true"}},
+		Hosts:      []Host{{Element: Element{"name": "admin", absentKey: "true"}}},
+		Loggers:    []Logger{{Name: "org.apache.catalina", Absent: true}},
+		Web:        &Web{Servlets: []Servlet{{Name: "jsp", Absent: true}}},
+	}
+	overlay := &Document{
+		Connectors: []Element{{"port": "8009", "protocol": "AJP/1.3"}},
+		Hosts:      []Host{{Element: Element{"name": "admin", "appBase": "admin"}}},
+		Loggers:    []Logger{{Name: "org.apache.catalina", Level: "FINE", UseParentHandlers: &yes}},
+		Web:        &Web{Servlets: []Servlet{{Name: "jsp", Class: "org.apache.jasper.servlet.JspServlet"}}},
+	}
+
+	merged := Merge(base, overlay)
+	if c := merged.Connectors[0]; c.Absent() || c["protocol"] != "AJP/1.3" {
+		t.Errorf("connector = %v, want the overlay's", c)
+	}
+	if h := merged.Hosts[0]; h.Absent() || h.Element["appBase"] != "admin" {
+		t.Errorf("host = %v, want the overlay's", h.Element)
+	}
+	if l := merged.Loggers[0]; l.Absent || l.Level != "FINE" {
+		t.Errorf("logger = %+v, want the overlay's", l)
+	}
+	if s := merged.Web.Servlets[0]; s.Absent || s.Class == "" {
+		t.Errorf("servlet = %+v, want the overlay's", s)
+	}
+}
+
+func TestMergeOverlayRemovesElement(t *testing.T) {
+	base := &Document{Connectors: []Element{{"port": "8009", "protocol": "AJP/1.3"}}}
+	overlay := &Document{Connectors: []Element{{"port": "8009", absentKey: "true"}}}
+
+	merged := Merge(base, overlay)
+	if c := merged.Connectors[0]; !c.Absent() || c["protocol"] != "" {
+		t.Errorf("connector = %v, want only port and absent", c)
+	}
+}
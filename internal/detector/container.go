@@ -0,0 +1,260 @@
+package detector
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/playok/tomcatkit/internal/config"
+)
+
+// containerIDRe finds the container ID in the cgroup of a process, e.g.
+// /system.slice/docker-<id>.scope, /docker/<id>,
+// /machine.slice/libpod-<id>.scope or .../cri-containerd-<id>.scope
+var containerIDRe = regexp.MustCompile(`(docker|libpod|crio|cri-containerd)[-/]([0-9a-f]{64})`)
+
+// runtimeNames maps cgroup prefixes to container runtimes
+var runtimeNames = map[string]string{
+	"docker":         "docker",
+	"libpod":         "podman",
+	"crio":           "cri-o",
+	"cri-containerd": "containerd",
+}
+
+// runtimeAPITimeout limits requests to a container runtime's socket
+const runtimeAPITimeout = 2 * time.Second
+
+// containerOf returns the container a process runs in, judged by its
+// cgroup, or nil for a process on the host
+func containerOf(dir string) *config.ContainerInfo {
+	data, err := os.ReadFile(filepath.Join(dir, "cgroup"))
+	if err != nil {
+		return nil
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if m := containerIDRe.FindStringSubmatch(line); m != nil {
+			return &config.ContainerInfo{Runtime: runtimeNames[m[1]], ID: m[2]}
+		}
+	}
+	return nil
+}
+
+// bindMount is a host directory mounted into a container
+type bindMount struct {
+	Source      string // On the host
+	Destination string // In the container
+}
+
+// mapContainerPaths replaces the container paths of an instance with paths
+// on the host and records them in ctr. The runtime's API is asked for the
+// container's mounts; without it they are worked out from mountinfo.
+func mapContainerPaths(dir string, instance *config.TomcatInstance, ctr *config.ContainerInfo) {
+	ctr.CatalinaHome = instance.CatalinaHome
+	ctr.CatalinaBase = instance.CatalinaBase
+
+	mounts, ok := inspectContainer(ctr)
+	if !ok {
+		mounts = mountInfoBinds(dir)
+	}
+
+	rootDir := filepath.Join(dir, "root")
+	instance.CatalinaHome, _ = hostPath(mounts, ctr.CatalinaHome, rootDir)
+	base, mounted := hostPath(mounts, ctr.CatalinaBase, rootDir)
+	if !mounted {
+		// With only conf mounted, files written through /proc/<pid>/root
+		// still end up in the host directory
+		_, mounted = hostPath(mounts, filepath.Join(ctr.CatalinaBase, "conf"), rootDir)
+	}
+	instance.CatalinaBase = base
+	ctr.Mounted = mounted
+	instance.Container = ctr
+}
+
+// hostPath maps a path in a container to the host through the bind mount
+// with the longest destination containing it. Without one the path is
+// reached through the container's root, /proc/<pid>/root.
+func hostPath(mounts []bindMount, path, rootDir string) (string, bool) {
+	best := -1
+	for i, m := range mounts {
+		if !within(path, m.Destination) {
+			continue
+		}
+		if best < 0 || len(m.Destination) > len(mounts[best].Destination) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		rel, err := filepath.Rel(mounts[best].Destination, path)
+		if err == nil {
+			host := filepath.Join(mounts[best].Source, rel)
+			if _, err := os.Stat(host); err == nil {
+				return host, true
+			}
+		}
+	}
+	return filepath.Join(rootDir, path), false
+}
+
+// within reports whether path is dir or below it
+func within(path, dir string) bool {
+	path, dir = filepath.Clean(path), filepath.Clean(dir)
+	return path == dir || dir == "/" || strings.HasPrefix(path, dir+"/")
+}
+
+// inspectContainer asks Docker or Podman about a container through the
+// Docker-compatible API on their local socket. It fills in the name and
+// image and returns the bind mounts.
+func inspectContainer(ctr *config.ContainerInfo) ([]bindMount, bool) {
+	for _, socket := range runtimeSockets() {
+		if _, err := os.Stat(socket); err != nil {
+			continue
+		}
+		client := &http.Client{
+			Timeout: runtimeAPITimeout,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socket)
+				},
+			},
+		}
+		resp, err := client.Get("http://localhost/containers/" + ctr.ID + "/json")
+		if err != nil {
+			continue
+		}
+		var info struct {
+			Name   string
+			Config struct {
+				Image string
+			}
+			Mounts []struct {
+				Type        string
+				Source      string
+				Destination string
+			}
+		}
+		err = json.NewDecoder(resp.Body).Decode(&info)
+		resp.Body.Close()
+		if err != nil || resp.StatusCode != http.StatusOK {
+			continue
+		}
+
+		ctr.Name = strings.TrimPrefix(info.Name, "/")
+		ctr.Image = info.Config.Image
+		var mounts []bindMount
+		for _, m := range info.Mounts {
+			// Named volumes have their host directory as Source as well
+			if m.Source != "" && (m.Type == "bind" || m.Type == "volume") {
+				mounts = append(mounts, bindMount{Source: m.Source, Destination: m.Destination})
+			}
+		}
+		return mounts, true
+	}
+	return nil, false
+}
+
+// runtimeSockets lists the API sockets of Docker and Podman
+func runtimeSockets() []string {
+	var sockets []string
+	if host, ok := strings.CutPrefix(os.Getenv("DOCKER_HOST"), "unix://"); ok {
+		sockets = append(sockets, host)
+	}
+	sockets = append(sockets, "/var/run/docker.sock", "/run/podman/podman.sock")
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		sockets = append(sockets, filepath.Join(dir, "podman", "podman.sock"))
+	}
+	return sockets
+}
+
+// mountInfo is a line of /proc/<pid>/mountinfo
+type mountInfo struct {
+	dev   string // major:minor of the file system
+	root  string // Directory of the file system that is mounted
+	point string // Where it is mounted
+}
+
+// mountInfoBinds works out the bind mounts of a container: a mount whose
+// file system is also mounted on the host comes from the host directory
+// at the host mount point plus the difference of the roots
+func mountInfoBinds(dir string) []bindMount {
+	host := readMountInfo("/proc/self/mountinfo")
+	var mounts []bindMount
+	for _, m := range readMountInfo(filepath.Join(dir, "mountinfo")) {
+		if m.point == "/" {
+			continue
+		}
+		best := -1
+		for i, h := range host {
+			if h.dev != m.dev || !within(m.root, h.root) {
+				continue
+			}
+			if best < 0 || len(h.root) > len(host[best].root) {
+				best = i
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		rel, err := filepath.Rel(host[best].root, m.root)
+		if err != nil {
+			continue
+		}
+		mounts = append(mounts, bindMount{
+			Source:      filepath.Join(host[best].point, rel),
+			Destination: m.point,
+		})
+	}
+	return mounts
+}
+
+// readMountInfo parses a mountinfo file
+func readMountInfo(path string) []mountInfo {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var mounts []mountInfo
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mounts = append(mounts, mountInfo{
+			dev:   fields[2],
+			root:  unescapeMountPath(fields[3]),
+			point: unescapeMountPath(fields[4]),
+		})
+	}
+	return mounts
+}
+
+// unescapeMountPath decodes the octal escapes (\040 for a space) of
+// mountinfo paths
+func unescapeMountPath(path string) string {
+	if !strings.Contains(path, `\`) {
+		return path
+	}
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if n, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
@@ -2,11 +2,8 @@ package detector
 
 import (
 	"os"
-	"os/exec"
 	"path/filepath"
-	"regexp"
 	"runtime"
-	"strconv"
 	"strings"
 
 	"github.com/playok/tomcatkit/internal/config"
@@ -29,6 +26,13 @@ func (d *Detector) DetectAll() ([]*config.TomcatInstance, error) {
 		instances = append(instances, envInstance)
 	}
 
+	// Check systemd units and distribution package layouts
+	for _, instance := range d.detectDistroInstances() {
+		if !d.isDuplicate(instances, instance) {
+			instances = append(instances, instance)
+		}
+	}
+
 	// Check common installation paths
 	commonPaths := d.getCommonPaths()
 	for _, path := range commonPaths {
@@ -48,9 +52,14 @@ func (d *Detector) DetectAll() ([]*config.TomcatInstance, error) {
 		} else {
 			// Update running status for existing instance
 			for _, existing := range instances {
-				if existing.CatalinaHome == instance.CatalinaHome {
+				if sameInstance(existing, instance) {
 					existing.IsRunning = true
 					existing.PID = instance.PID
+					existing.User = instance.User
+					existing.WorkingDir = instance.WorkingDir
+					existing.JavaHome = instance.JavaHome
+					existing.JavaVersion = instance.JavaVersion
+					existing.StartTime = instance.StartTime
 				}
 			}
 		}
@@ -76,11 +85,12 @@ func (d *Detector) detectFromEnv() *config.TomcatInstance {
 		return nil
 	}
 
-	return &config.TomcatInstance{
+	instance := &config.TomcatInstance{
 		CatalinaHome: catalinaHome,
 		CatalinaBase: catalinaBase,
-		Version:      d.detectVersion(catalinaHome),
 	}
+	d.setVersion(instance)
+	return instance
 }
 
 // getCommonPaths returns common Tomcat installation paths
@@ -151,124 +161,80 @@ func (d *Detector) detectAtPath(path string) *config.TomcatInstance {
 		return nil
 	}
 
-	return &config.TomcatInstance{
+	instance := &config.TomcatInstance{
 		CatalinaHome: path,
 		CatalinaBase: path,
-		Version:      d.detectVersion(path),
 	}
+	d.setVersion(instance)
+	return instance
 }
 
 // isValidTomcatDir checks if a directory is a valid Tomcat installation
 func (d *Detector) isValidTomcatDir(path string) bool {
-	// Check for essential Tomcat files/directories
-	requiredPaths := []string{
-		filepath.Join(path, "conf", "server.xml"),
-		filepath.Join(path, "lib", "catalina.jar"),
-	}
-
-	for _, p := range requiredPaths {
-		if _, err := os.Stat(p); os.IsNotExist(err) {
-			return false
-		}
-	}
-
-	return true
+	return d.isValidHome(path) && d.isValidBase(path)
 }
 
-// detectVersion reads Tomcat version from catalina.jar or version script
-func (d *Detector) detectVersion(catalinaHome string) string {
-	// Try running version.sh or version.bat
-	var versionCmd *exec.Cmd
-	if runtime.GOOS == "windows" {
-		versionCmd = exec.Command(filepath.Join(catalinaHome, "bin", "version.bat"))
-	} else {
-		versionCmd = exec.Command(filepath.Join(catalinaHome, "bin", "version.sh"))
-	}
-
-	if output, err := versionCmd.Output(); err == nil {
-		// Parse version from output
-		re := regexp.MustCompile(`Server version:\s*Apache Tomcat/(\d+\.\d+\.\d+)`)
-		if matches := re.FindStringSubmatch(string(output)); len(matches) > 1 {
-			return matches[1]
-		}
+// isValidHome checks for the Tomcat libraries of a CATALINA_HOME.
+// Symlinks are followed, as packages link lib to /usr/share/java.
+func (d *Detector) isValidHome(path string) bool {
+	if path == "" {
+		return false
 	}
+	_, err := os.Stat(filepath.Join(path, "lib", "catalina.jar"))
+	return err == nil
+}
 
-	// Fallback: try to detect from directory name
-	baseName := filepath.Base(catalinaHome)
-	re := regexp.MustCompile(`(\d+\.\d+\.\d+)`)
-	if matches := re.FindStringSubmatch(baseName); len(matches) > 1 {
-		return matches[1]
+// isValidBase checks for the server.xml of a CATALINA_BASE
+func (d *Detector) isValidBase(path string) bool {
+	if path == "" {
+		return false
 	}
-
-	return "unknown"
+	_, err := os.Stat(filepath.Join(path, "conf", "server.xml"))
+	return err == nil
 }
 
 // detectRunningInstances finds running Tomcat processes
 func (d *Detector) detectRunningInstances() []*config.TomcatInstance {
-	var instances []*config.TomcatInstance
-
-	// Use ps command to find Java processes with Catalina
-	var cmd *exec.Cmd
-	if runtime.GOOS == "windows" {
-		cmd = exec.Command("wmic", "process", "where", "name='java.exe'", "get", "processid,commandline")
-	} else {
-		cmd = exec.Command("ps", "aux")
-	}
-
-	output, err := cmd.Output()
-	if err != nil {
-		return instances
+	instances := d.scanProcesses()
+	for _, instance := range instances {
+		d.setVersion(instance)
 	}
+	return instances
+}
 
-	lines := strings.Split(string(output), "\n")
-	catalinaHomeRe := regexp.MustCompile(`-Dcatalina\.home=([^\s]+)`)
-	catalinaBaseRe := regexp.MustCompile(`-Dcatalina\.base=([^\s]+)`)
-	pidRe := regexp.MustCompile(`^\S+\s+(\d+)`)
-
-	for _, line := range lines {
-		if !strings.Contains(line, "catalina") && !strings.Contains(line, "Catalina") {
-			continue
-		}
-
-		var catalinaHome, catalinaBase string
-		var pid int
-
-		if matches := catalinaHomeRe.FindStringSubmatch(line); len(matches) > 1 {
-			catalinaHome = matches[1]
-		}
-		if matches := catalinaBaseRe.FindStringSubmatch(line); len(matches) > 1 {
-			catalinaBase = matches[1]
-		}
-		if matches := pidRe.FindStringSubmatch(line); len(matches) > 1 {
-			// Parse PID
-			if p, err := strconv.Atoi(matches[1]); err == nil {
-				pid = p
-			}
-		}
-
-		if catalinaHome != "" {
-			if catalinaBase == "" {
-				catalinaBase = catalinaHome
-			}
-			instances = append(instances, &config.TomcatInstance{
-				CatalinaHome: catalinaHome,
-				CatalinaBase: catalinaBase,
-				Version:      d.detectVersion(catalinaHome),
-				IsRunning:    true,
-				PID:          pid,
-			})
+// FindRunning returns the PID of the running Tomcat process that uses
+// catalinaBase, or 0 when there is none
+func (d *Detector) FindRunning(catalinaBase string) int {
+	base := filepath.Clean(catalinaBase)
+	for _, instance := range d.scanProcesses() {
+		if filepath.Clean(instance.CatalinaBase) == base {
+			return instance.PID
 		}
 	}
-
-	return instances
+	return 0
 }
 
 // isDuplicate checks if an instance already exists in the list
 func (d *Detector) isDuplicate(instances []*config.TomcatInstance, instance *config.TomcatInstance) bool {
 	for _, existing := range instances {
-		if existing.CatalinaHome == instance.CatalinaHome {
+		if sameInstance(existing, instance) {
 			return true
 		}
 	}
 	return false
 }
+
+// sameInstance reports whether two instances use the same CATALINA_HOME and
+// CATALINA_BASE, also when one is reached through a symlink
+func sameInstance(a, b *config.TomcatInstance) bool {
+	return resolveSymlinks(a.CatalinaHome) == resolveSymlinks(b.CatalinaHome) &&
+		resolveSymlinks(baseOf(a)) == resolveSymlinks(baseOf(b))
+}
+
+// baseOf returns CATALINA_BASE, which defaults to CATALINA_HOME
+func baseOf(instance *config.TomcatInstance) string {
+	if instance.CatalinaBase == "" {
+		return instance.CatalinaHome
+	}
+	return instance.CatalinaBase
+}
@@ -0,0 +1,284 @@
+package detector
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config"
+)
+
+// unitDirs are searched for systemd units, the administrator's first
+var unitDirs = []string{"/etc/systemd/system", "/lib/systemd/system", "/usr/lib/systemd/system"}
+
+// envFilePatterns are the files distribution packages keep Tomcat's
+// environment in: /etc/default on Debian, /etc/sysconfig on RHEL
+var envFilePatterns = []string{"/etc/default/tomcat*", "/etc/sysconfig/tomcat*", "/etc/tomcat*/tomcat.conf"}
+
+// detectDistroInstances finds instances set up by systemd units and by the
+// environment files of distribution packages, which keep CATALINA_HOME
+// (/usr/share/tomcat9) apart from CATALINA_BASE (/var/lib/tomcat9)
+func (d *Detector) detectDistroInstances() []*config.TomcatInstance {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	distro := distroFamily()
+
+	var instances []*config.TomcatInstance
+	add := func(env map[string]string, service, source string) {
+		home := env["CATALINA_HOME"]
+		base := env["CATALINA_BASE"]
+		if home == "" {
+			home, base = packageDefaults(service, source, base)
+		}
+		if base == "" {
+			base = home
+		}
+		if !d.isValidHome(home) || !d.isValidBase(base) {
+			return
+		}
+		instance := &config.TomcatInstance{
+			CatalinaHome: home,
+			CatalinaBase: base,
+			Layout: &config.DistroLayout{
+				Distro:   distro,
+				Packaged: strings.HasPrefix(home, "/usr/share/"),
+				Service:  service,
+				Source:   source,
+				ConfDir:  resolveSymlinks(filepath.Join(base, "conf")),
+				LogsDir:  resolveSymlinks(filepath.Join(base, "logs")),
+			},
+		}
+		if !d.isDuplicate(instances, instance) {
+			d.setVersion(instance)
+			instances = append(instances, instance)
+		}
+	}
+
+	// Units come first, as they name the service
+	seenFiles := make(map[string]bool)
+	for _, unit := range findTomcatUnits() {
+		name := filepath.Base(unit)
+		env, files := readUnitEnvironment(unit)
+		for _, f := range files {
+			seenFiles[f] = true
+		}
+		if strings.Contains(name, "@.") {
+			// A template's instances each have an environment file
+			// named after them, e.g. /etc/sysconfig/tomcat@app1
+			for _, f := range files {
+				if !strings.Contains(f, "%i") && !strings.Contains(f, "%I") {
+					continue
+				}
+				pattern := strings.NewReplacer("%i", "*", "%I", "*").Replace(f)
+				matches, _ := filepath.Glob(pattern)
+				for _, match := range matches {
+					seenFiles[match] = true
+					instanceEnv := copyEnv(env)
+					readEnvFile(match, instanceEnv)
+					instanceName := strings.TrimPrefix(filepath.Base(match), strings.TrimSuffix(filepath.Base(pattern), "*"))
+					add(instanceEnv, strings.Replace(name, "@.", "@"+instanceName+".", 1), match)
+				}
+			}
+			continue
+		}
+		add(env, name, unit)
+	}
+
+	// Environment files without a unit, e.g. for SysV init scripts
+	for _, pattern := range envFilePatterns {
+		matches, _ := filepath.Glob(pattern)
+		for _, match := range matches {
+			if seenFiles[match] || strings.HasSuffix(match, "~") || strings.HasSuffix(match, ".dpkg-old") {
+				continue
+			}
+			env := make(map[string]string)
+			readEnvFile(match, env)
+			add(env, "", match)
+		}
+	}
+	return instances
+}
+
+// findTomcatUnits lists the Tomcat service units; a unit in /etc hides a
+// unit of the same name in /lib
+func findTomcatUnits() []string {
+	seen := make(map[string]bool)
+	var units []string
+	for _, dir := range unitDirs {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.service"))
+		sort.Strings(matches)
+		for _, unit := range matches {
+			name := filepath.Base(unit)
+			if seen[name] || !strings.Contains(name, "tomcat") {
+				continue
+			}
+			seen[name] = true
+			units = append(units, unit)
+		}
+	}
+	return units
+}
+
+// readUnitEnvironment reads the Environment= settings of a unit and the
+// EnvironmentFile= files it names. It returns the environment and the
+// environment files, with %i left in those of templates.
+func readUnitEnvironment(path string) (map[string]string, []string) {
+	env := make(map[string]string)
+	var files []string
+
+	f, err := os.Open(path)
+	if err != nil {
+		return env, nil
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Environment":
+			for _, assignment := range splitQuoted(value) {
+				if name, v, ok := strings.Cut(assignment, "="); ok {
+					env[name] = v
+				}
+			}
+		case "EnvironmentFile":
+			// A leading "-" means the file is optional
+			file := strings.TrimPrefix(strings.TrimSpace(value), "-")
+			files = append(files, file)
+			if !strings.Contains(file, "%") {
+				readEnvFile(file, env)
+			}
+		}
+	}
+	return env, files
+}
+
+// splitQuoted splits the value of Environment= into assignments, which are
+// separated by spaces and may be double-quoted
+func splitQuoted(value string) []string {
+	var parts []string
+	var b strings.Builder
+	inQuotes := false
+	for _, r := range value {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case (r == ' ' || r == '\t') && !inQuotes:
+			if b.Len() > 0 {
+				parts = append(parts, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() > 0 {
+		parts = append(parts, b.String())
+	}
+	return parts
+}
+
+// readEnvFile reads NAME=value lines of a shell environment file into env.
+// References to variables set before are expanded.
+func readEnvFile(path string, env map[string]string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		if !ok || strings.ContainsAny(name, " \t") {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		env[name] = os.Expand(value, func(v string) string { return env[v] })
+	}
+}
+
+// copyEnv returns a copy of an environment
+func copyEnv(env map[string]string) map[string]string {
+	c := make(map[string]string, len(env))
+	for k, v := range env {
+		c[k] = v
+	}
+	return c
+}
+
+// packageDefaults returns the directories a distribution package uses when
+// its files do not set CATALINA_HOME: /usr/share/<name> with
+// /var/lib/<name> on Debian, /usr/share/tomcat for both on RHEL
+func packageDefaults(service, source, base string) (string, string) {
+	name := strings.TrimSuffix(service, ".service")
+	if name == "" {
+		name = filepath.Base(source)
+		if name == "tomcat.conf" {
+			name = filepath.Base(filepath.Dir(source))
+		}
+	}
+	if i := strings.IndexByte(name, '@'); i >= 0 {
+		name = name[:i]
+	}
+	home := filepath.Join("/usr/share", name)
+	if base == "" {
+		if _, err := os.Stat(filepath.Join("/var/lib", name, "conf")); err == nil {
+			base = filepath.Join("/var/lib", name)
+		}
+	}
+	return home, base
+}
+
+// distroFamily returns "debian", "rhel", "suse" or the ID from
+// /etc/os-release
+func distroFamily() string {
+	data, err := os.ReadFile("/etc/os-release")
+	if err != nil {
+		return ""
+	}
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		if k, v, ok := strings.Cut(line, "="); ok {
+			values[k] = strings.Trim(v, `"`)
+		}
+	}
+	ids := strings.Fields(values["ID"] + " " + values["ID_LIKE"])
+	for _, family := range []string{"debian", "rhel", "fedora", "suse"} {
+		for _, id := range ids {
+			if id == family {
+				if family == "fedora" {
+					return "rhel"
+				}
+				return family
+			}
+		}
+	}
+	return values["ID"]
+}
+
+// resolveSymlinks follows symlinks, as packages link conf to /etc/<name>
+// and logs to /var/log/<name>
+func resolveSymlinks(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
@@ -0,0 +1,300 @@
+package detector
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/playok/tomcatkit/internal/config"
+)
+
+// bootstrapClass is the main class of a Tomcat JVM
+const bootstrapClass = "org.apache.catalina.startup.Bootstrap"
+
+// clockTicks is USER_HZ, the unit of the start time in /proc/<pid>/stat.
+// It is 100 on every Linux architecture Go supports.
+const clockTicks = 100
+
+// scanProcesses lists Tomcat processes without reading their versions
+func (d *Detector) scanProcesses() []*config.TomcatInstance {
+	if runtime.GOOS == "linux" {
+		if instances, err := scanProc(); err == nil {
+			return instances
+		}
+	}
+	return scanPS()
+}
+
+// scanProc finds Tomcat processes in /proc. The command line is read as
+// separate arguments, so paths containing spaces are kept intact.
+func scanProc() ([]*config.TomcatInstance, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	bootTime := readBootTime()
+
+	var instances []*config.TomcatInstance
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		dir := filepath.Join("/proc", entry.Name())
+		data, err := os.ReadFile(filepath.Join(dir, "cmdline"))
+		if err != nil || !bytes.Contains(data, []byte("catalina")) {
+			continue
+		}
+		args := strings.Split(strings.TrimRight(string(data), "\x00"), "\x00")
+
+		catalinaHome := systemProperty(args, "catalina.home")
+		catalinaBase := systemProperty(args, "catalina.base")
+		if catalinaHome == "" && !containsArg(args, bootstrapClass) {
+			continue
+		}
+
+		// The environment is only readable for our own processes or as root
+		env := readEnviron(filepath.Join(dir, "environ"))
+		if catalinaHome == "" {
+			catalinaHome = env["CATALINA_HOME"]
+		}
+		if catalinaBase == "" {
+			catalinaBase = env["CATALINA_BASE"]
+		}
+		if catalinaHome == "" {
+			continue
+		}
+
+		cwd, _ := os.Readlink(filepath.Join(dir, "cwd"))
+		catalinaHome = absFrom(cwd, catalinaHome)
+		if catalinaBase == "" {
+			catalinaBase = catalinaHome
+		}
+		catalinaBase = absFrom(cwd, catalinaBase)
+
+		instance := &config.TomcatInstance{
+			CatalinaHome: catalinaHome,
+			CatalinaBase: catalinaBase,
+			IsRunning:    true,
+			PID:          pid,
+			User:         processUser(dir),
+			WorkingDir:   cwd,
+		}
+		if exe, err := os.Readlink(filepath.Join(dir, "exe")); err == nil && filepath.Base(exe) == "java" {
+			// The JVM is JAVA_HOME/bin/java
+			instance.JavaHome = filepath.Dir(filepath.Dir(exe))
+		}
+		if instance.JavaHome == "" {
+			instance.JavaHome = env["JAVA_HOME"]
+		}
+
+		// Paths of a containerised process are inside the container; its
+		// files are read through /proc/<pid>/root
+		root := ""
+		if ctr := containerOf(dir); ctr != nil {
+			mapContainerPaths(dir, instance, ctr)
+			root = filepath.Join(dir, "root")
+		}
+		if instance.JavaHome != "" {
+			instance.JavaVersion = javaVersion(filepath.Join(root, instance.JavaHome))
+		}
+		if !bootTime.IsZero() {
+			instance.StartTime = processStartTime(dir, bootTime)
+		}
+		instances = append(instances, instance)
+	}
+	return instances, nil
+}
+
+// systemProperty returns the value of a -Dname=value argument
+func systemProperty(args []string, name string) string {
+	prefix := "-D" + name + "="
+	for _, arg := range args {
+		if strings.HasPrefix(arg, prefix) {
+			return strings.TrimPrefix(arg, prefix)
+		}
+	}
+	return ""
+}
+
+// containsArg reports whether an argument appears on a command line
+func containsArg(args []string, want string) bool {
+	for _, arg := range args {
+		if arg == want {
+			return true
+		}
+	}
+	return false
+}
+
+// absFrom makes a path absolute relative to the process's working directory
+func absFrom(cwd, path string) string {
+	if filepath.IsAbs(path) || cwd == "" {
+		return path
+	}
+	return filepath.Join(cwd, path)
+}
+
+// readEnviron parses /proc/<pid>/environ
+func readEnviron(path string) map[string]string {
+	env := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return env
+	}
+	for _, entry := range strings.Split(string(data), "\x00") {
+		if name, value, ok := strings.Cut(entry, "="); ok {
+			env[name] = value
+		}
+	}
+	return env
+}
+
+// processUser returns the name of the real user of a process, or its UID
+// when the name cannot be looked up
+func processUser(dir string) string {
+	f, err := os.Open(filepath.Join(dir, "status"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "Uid:" {
+			continue
+		}
+		if u, err := user.LookupId(fields[1]); err == nil {
+			return u.Username
+		}
+		return fields[1]
+	}
+	return ""
+}
+
+// readBootTime returns the boot time from /proc/stat
+func readBootTime() time.Time {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return time.Time{}
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if secs, ok := strings.CutPrefix(line, "btime "); ok {
+			if n, err := strconv.ParseInt(strings.TrimSpace(secs), 10, 64); err == nil {
+				return time.Unix(n, 0)
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// processStartTime reads the start time of a process from /proc/<pid>/stat
+func processStartTime(dir string, bootTime time.Time) time.Time {
+	data, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return time.Time{}
+	}
+	// The command name in parentheses may contain spaces, so the fields
+	// are counted from the closing parenthesis. starttime is field 22.
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return time.Time{}
+	}
+	fields := strings.Fields(string(data[end+1:]))
+	const startTimeField = 22 - 3 // Fields 1 (pid) and 2 (comm) come before it
+	if len(fields) <= startTimeField {
+		return time.Time{}
+	}
+	ticks, err := strconv.ParseInt(fields[startTimeField], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return bootTime.Add(time.Duration(ticks) * time.Second / clockTicks)
+}
+
+// javaVersion reads JAVA_VERSION from the release file of a JDK or JRE
+func javaVersion(javaHome string) string {
+	data, err := os.ReadFile(filepath.Join(javaHome, "release"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "JAVA_VERSION="); ok {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// scanPS finds Tomcat processes with ps, or wmic on Windows, where /proc
+// is not available
+func scanPS() []*config.TomcatInstance {
+	var instances []*config.TomcatInstance
+
+	// Use ps command to find Java processes with Catalina
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("wmic", "process", "where", "name='java.exe'", "get", "processid,commandline")
+	} else {
+		cmd = exec.Command("ps", "-eo", "pid=,user=,args=")
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		return instances
+	}
+
+	lines := strings.Split(string(output), "\n")
+	catalinaHomeRe := regexp.MustCompile(`-Dcatalina\.home=("[^"]+"|\S+)`)
+	catalinaBaseRe := regexp.MustCompile(`-Dcatalina\.base=("[^"]+"|\S+)`)
+	psRe := regexp.MustCompile(`^\s*(\d+)\s+(\S+)`)
+	wmicPIDRe := regexp.MustCompile(`(\d+)\s*$`)
+
+	for _, line := range lines {
+		if !strings.Contains(line, "catalina") && !strings.Contains(line, "Catalina") {
+			continue
+		}
+
+		var catalinaHome, catalinaBase, owner string
+		var pid int
+
+		if matches := catalinaHomeRe.FindStringSubmatch(line); len(matches) > 1 {
+			catalinaHome = strings.Trim(matches[1], `"`)
+		}
+		if matches := catalinaBaseRe.FindStringSubmatch(line); len(matches) > 1 {
+			catalinaBase = strings.Trim(matches[1], `"`)
+		}
+		if runtime.GOOS == "windows" {
+			if matches := wmicPIDRe.FindStringSubmatch(line); len(matches) > 1 {
+				pid, _ = strconv.Atoi(matches[1])
+			}
+		} else if matches := psRe.FindStringSubmatch(line); len(matches) > 2 {
+			pid, _ = strconv.Atoi(matches[1])
+			owner = matches[2]
+		}
+
+		if catalinaHome != "" {
+			if catalinaBase == "" {
+				catalinaBase = catalinaHome
+			}
+			instances = append(instances, &config.TomcatInstance{
+				CatalinaHome: catalinaHome,
+				CatalinaBase: catalinaBase,
+				IsRunning:    true,
+				PID:          pid,
+				User:         owner,
+			})
+		}
+	}
+
+	return instances
+}
@@ -0,0 +1,349 @@
+package detector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/playok/tomcatkit/internal/config"
+)
+
+// DefaultSearchDepth is how many directory levels below a search root are
+// scanned when the settings give no depth
+const DefaultSearchDepth = 4
+
+// progressInterval limits how often scan progress is reported
+const progressInterval = 100 * time.Millisecond
+
+// skipDirs are never descended into
+var skipDirs = map[string]bool{
+	"/proc": true, "/sys": true, "/dev": true, "/run": true,
+	"node_modules": true, ".git": true, ".cache": true,
+}
+
+// DefaultSearchRoots returns the directories scanned when the settings
+// name none
+func DefaultSearchRoots() []string {
+	var roots []string
+	switch runtime.GOOS {
+	case "windows":
+		roots = []string{`C:\Program Files`, `C:\`}
+	case "darwin":
+		roots = []string{"/opt", "/usr/local", "/Library"}
+	default:
+		roots = []string{"/opt", "/srv", "/usr/local", "/usr/share", "/var/lib"}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		roots = append(roots, home)
+	}
+	return roots
+}
+
+// ScanOptions configure Scan
+type ScanOptions struct {
+	Roots    []string
+	MaxDepth int // Levels below each root; 0 uses DefaultSearchDepth
+	Workers  int // Goroutines reading directories; 0 uses the CPU count
+	// Progress is called from the scanning goroutines now and then, and
+	// once more with Done set when the scan ends
+	Progress func(ScanProgress)
+}
+
+// ScanProgress reports how far a scan got
+type ScanProgress struct {
+	Dirs    int    // Directories read so far
+	Found   int    // Tomcat directories found so far
+	Current string // A directory being read
+	Done    bool
+}
+
+// found is a directory holding conf/server.xml
+type found struct {
+	path   string
+	isHome bool // lib/catalina.jar exists as well
+}
+
+// Scan searches the roots for Tomcat: directories with conf/server.xml and
+// lib/catalina.jar are installations, directories with only conf/server.xml
+// are CATALINA_BASEs, which are paired with the CATALINA_HOME their
+// setenv.sh names or else the nearest installation found. Symlinks are not
+// followed. The scan stops early when ctx is cancelled.
+func (d *Detector) Scan(ctx context.Context, opts ScanOptions) []*config.TomcatInstance {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = DefaultSearchDepth
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+
+	var (
+		mu      sync.Mutex
+		results []found
+		dirs    atomic.Int64
+		current atomic.Value
+		wg      sync.WaitGroup
+	)
+
+	report := func(done bool) {
+		if opts.Progress == nil {
+			return
+		}
+		mu.Lock()
+		n := len(results)
+		mu.Unlock()
+		cur, _ := current.Load().(string)
+		opts.Progress(ScanProgress{Dirs: int(dirs.Load()), Found: n, Current: cur, Done: done})
+	}
+
+	// visit reads one directory and returns the subdirectories to read next
+	visit := func(dir scanDir) []scanDir {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		current.Store(dir.path)
+		entries, err := os.ReadDir(dir.path)
+		dirs.Add(1)
+		if err != nil {
+			return nil
+		}
+
+		if hasEntry(entries, "conf") && fileExists(filepath.Join(dir.path, "conf", "server.xml")) {
+			mu.Lock()
+			results = append(results, found{path: dir.path, isHome: d.isValidHome(dir.path)})
+			mu.Unlock()
+			// Tomcat directories are not searched for further ones
+			return nil
+		}
+
+		if dir.depth >= opts.MaxDepth {
+			return nil
+		}
+		var subs []scanDir
+		for _, entry := range entries {
+			// Type is from the directory entry, so symlinks are skipped
+			if !entry.IsDir() {
+				continue
+			}
+			sub := filepath.Join(dir.path, entry.Name())
+			if skipDirs[entry.Name()] || skipDirs[sub] {
+				continue
+			}
+			subs = append(subs, scanDir{path: sub, depth: dir.depth + 1})
+		}
+		return subs
+	}
+
+	queue := newScanQueue()
+	for _, root := range uniqueRoots(opts.Roots) {
+		queue.push([]scanDir{{path: root}})
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				report(false)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				dir, ok := queue.pop()
+				if !ok {
+					return
+				}
+				queue.push(visit(dir))
+				queue.done()
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+
+	instances := d.pairScanResults(results)
+	report(true)
+	return instances
+}
+
+// scanDir is a directory waiting to be read
+type scanDir struct {
+	path  string
+	depth int // Levels below its root
+}
+
+// scanQueue hands directories to the scan workers. It is drained once it
+// is empty and no directory is being read, as only those add more.
+type scanQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	dirs    []scanDir
+	pending int // Directories queued or being read
+}
+
+func newScanQueue() *scanQueue {
+	q := &scanQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push queues directories to read
+func (q *scanQueue) push(dirs []scanDir) {
+	if len(dirs) == 0 {
+		return
+	}
+	q.mu.Lock()
+	q.dirs = append(q.dirs, dirs...)
+	q.pending += len(dirs)
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// pop waits for a directory to read. It returns false once the queue is
+// drained.
+func (q *scanQueue) pop() (scanDir, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.dirs) == 0 && q.pending > 0 {
+		q.cond.Wait()
+	}
+	if len(q.dirs) == 0 {
+		return scanDir{}, false
+	}
+	// Last in, first out keeps the queue as short as a depth-first walk
+	dir := q.dirs[len(q.dirs)-1]
+	q.dirs = q.dirs[:len(q.dirs)-1]
+	return dir, true
+}
+
+// done marks a popped directory as read, after its subdirectories were
+// pushed
+func (q *scanQueue) done() {
+	q.mu.Lock()
+	q.pending--
+	drained := q.pending == 0
+	q.mu.Unlock()
+	if drained {
+		q.cond.Broadcast()
+	}
+}
+
+// pairScanResults turns scan results into instances
+func (d *Detector) pairScanResults(results []found) []*config.TomcatInstance {
+	sort.Slice(results, func(i, j int) bool { return results[i].path < results[j].path })
+
+	var homes []string
+	for _, r := range results {
+		if r.isHome {
+			homes = append(homes, r.path)
+		}
+	}
+
+	var instances []*config.TomcatInstance
+	for _, r := range results {
+		home := r.path
+		if !r.isHome {
+			home = homeForBase(r.path, homes)
+			if home == "" {
+				continue
+			}
+		}
+		instance := &config.TomcatInstance{CatalinaHome: home, CatalinaBase: r.path}
+		if !d.isDuplicate(instances, instance) {
+			d.setVersion(instance)
+			instances = append(instances, instance)
+		}
+	}
+	return instances
+}
+
+// homeForBase returns the CATALINA_HOME of a CATALINA_BASE: the one its
+// setenv.sh sets, else the installation sharing the longest path prefix
+// below the root, else the package default (/usr/share/tomcat9 for
+// /var/lib/tomcat9). It returns "" when none of them is an installation.
+func homeForBase(base string, homes []string) string {
+	env := make(map[string]string)
+	readEnvFile(filepath.Join(base, "bin", "setenv.sh"), env)
+	if home := env["CATALINA_HOME"]; home != "" && fileExists(filepath.Join(home, "lib", "catalina.jar")) {
+		return home
+	}
+
+	// Sharing only "/" says nothing about belonging together
+	best, bestLen := "", commonPrefixLen(base, string(filepath.Separator))
+	for _, home := range homes {
+		if n := commonPrefixLen(base, home); n > bestLen {
+			best, bestLen = home, n
+		}
+	}
+	if best != "" {
+		return best
+	}
+	if home, _ := packageDefaults("", base, base); fileExists(filepath.Join(home, "lib", "catalina.jar")) {
+		return home
+	}
+	return ""
+}
+
+// commonPrefixLen counts the leading path elements two paths share
+func commonPrefixLen(a, b string) int {
+	pa := strings.Split(filepath.Clean(a), string(filepath.Separator))
+	pb := strings.Split(filepath.Clean(b), string(filepath.Separator))
+	n := 0
+	for n < len(pa) && n < len(pb) && pa[n] == pb[n] {
+		n++
+	}
+	return n
+}
+
+// uniqueRoots drops empty roots and roots below other roots
+func uniqueRoots(roots []string) []string {
+	var cleaned []string
+	for _, root := range roots {
+		root = strings.TrimSpace(root)
+		if root == "" {
+			continue
+		}
+		cleaned = append(cleaned, filepath.Clean(root))
+	}
+	sort.Strings(cleaned)
+
+	var unique []string
+	for _, root := range cleaned {
+		if len(unique) > 0 && within(root, unique[len(unique)-1]) {
+			continue
+		}
+		unique = append(unique, root)
+	}
+	return unique
+}
+
+// hasEntry reports whether a directory listing contains a name
+func hasEntry(entries []os.DirEntry, name string) bool {
+	for _, entry := range entries {
+		if entry.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fileExists reports whether a regular file exists
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,15 @@
+package detector
+
+import "testing"
+
+func TestHomeForBaseNeedsSharedPrefix(t *testing.T) {
+	homes := []string{"/opt/apache-tomcat-9.0.80", "/srv/tomcat/home"}
+
+	if got := homeForBase("/srv/tomcat/app1", homes); got != "/srv/tomcat/home" {
+		t.Errorf("homeForBase(/srv/tomcat/app1) = %q, want /srv/tomcat/home", got)
+	}
+	// Shares only the root with every home and has no package default
+	if got := homeForBase("/var/lib/tomcatkit-test-base", homes); got != "" {
+		t.Errorf("homeForBase(/var/lib/tomcatkit-test-base) = %q, want none", got)
+	}
+}
@@ -0,0 +1,122 @@
+package detector
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config"
+)
+
+// serverInfoPath is the resource in catalina.jar that holds the version
+const serverInfoPath = "org/apache/catalina/util/ServerInfo.properties"
+
+// ReadVersion reads the version of the Tomcat in CATALINA_HOME from
+// lib/catalina.jar, falling back to RELEASE-NOTES. Nothing is executed.
+func ReadVersion(catalinaHome string) (config.TomcatVersion, error) {
+	jarPath := filepath.Join(catalinaHome, "lib", "catalina.jar")
+	v, jarErr := readServerInfo(jarPath)
+	if jarErr == nil {
+		return v, nil
+	}
+	v, err := readReleaseNotes(filepath.Join(catalinaHome, "RELEASE-NOTES"))
+	if err == nil {
+		return v, nil
+	}
+	return config.TomcatVersion{}, jarErr
+}
+
+// readServerInfo reads ServerInfo.properties from catalina.jar.
+// server.number is preferred, as server.info is often changed to hide the
+// version from clients.
+func readServerInfo(jarPath string) (config.TomcatVersion, error) {
+	zr, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return config.TomcatVersion{}, fmt.Errorf("failed to open %s: %w", jarPath, err)
+	}
+	defer zr.Close()
+
+	f, err := zr.Open(serverInfoPath)
+	if err != nil {
+		return config.TomcatVersion{}, fmt.Errorf("failed to read %s: %w", serverInfoPath, err)
+	}
+	defer f.Close()
+	props := readProperties(f)
+
+	for _, key := range []string{"server.number", "server.info"} {
+		if v, ok := config.ParseTomcatVersion(props[key]); ok {
+			// server.number has four parts and no pre-release marker
+			if key == "server.number" {
+				if info, ok := config.ParseTomcatVersion(props["server.info"]); ok && info.Compare(v.Major, v.Minor, v.Patch) == 0 {
+					v.Suffix = info.Suffix
+				}
+			}
+			v.Built = props["server.built"]
+			return v, nil
+		}
+	}
+	return config.TomcatVersion{}, fmt.Errorf("no version in %s", serverInfoPath)
+}
+
+// readProperties parses simple key=value lines of a properties file
+func readProperties(r io.Reader) map[string]string {
+	props := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			props[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return props
+}
+
+// readReleaseNotes reads the "Apache Tomcat Version x.y.z" heading
+func readReleaseNotes(path string) (config.TomcatVersion, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return config.TomcatVersion{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "Apache Tomcat Version"); ok {
+			if v, ok := config.ParseTomcatVersion(rest); ok {
+				return v, nil
+			}
+		}
+	}
+	return config.TomcatVersion{}, fmt.Errorf("no version in %s", path)
+}
+
+// DetectVersion returns the version of the Tomcat in CATALINA_HOME as text,
+// taken from the directory name when catalina.jar and RELEASE-NOTES give
+// none, or "unknown"
+func (d *Detector) DetectVersion(catalinaHome string) string {
+	return d.DetectVersionInfo(catalinaHome).String()
+}
+
+// DetectVersionInfo reads the version of the Tomcat in CATALINA_HOME,
+// falling back to the directory name. The result is zero when it is unknown.
+func (d *Detector) DetectVersionInfo(catalinaHome string) config.TomcatVersion {
+	if v, err := ReadVersion(catalinaHome); err == nil {
+		return v
+	}
+	v, _ := config.ParseTomcatVersion(filepath.Base(catalinaHome))
+	return v
+}
+
+// setVersion sets Version and VersionInfo of an instance
+func (d *Detector) setVersion(instance *config.TomcatInstance) {
+	instance.VersionInfo = d.DetectVersionInfo(instance.CatalinaHome)
+	instance.Version = instance.VersionInfo.String()
+}
@@ -0,0 +1,661 @@
+// Package docker generates a container build context for an instance: a
+// Dockerfile based on the official Tomcat image, the instance's
+// configuration with ports and secrets read from environment variables, and
+// an entrypoint that refuses to start while a secret is missing.
+package docker
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/config/catalina"
+	"github.com/playok/tomcatkit/internal/config/connector"
+	"github.com/playok/tomcatkit/internal/config/jndi"
+	"github.com/playok/tomcatkit/internal/config/jvm"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/config/realm"
+	"github.com/playok/tomcatkit/internal/config/server"
+	"github.com/playok/tomcatkit/internal/detector"
+	"github.com/playok/tomcatkit/internal/lifecycle"
+)
+
+// ImageHome is CATALINA_HOME and CATALINA_BASE in the official images
+const ImageHome = "/usr/local/tomcat"
+
+// Names in the build context
+const (
+	Dockerfile     = "Dockerfile"
+	EntrypointFile = "docker-entrypoint.sh"
+	// DefaultImage is the repository of the official images, tagged with
+	// the version of the instance
+	DefaultImage = "tomcat"
+)
+
+var attrsType = reflect.TypeOf([]xml.Attr(nil))
+
+// Options describe the build context to generate
+type Options struct {
+	Instance *config.TomcatInstance
+	Image    string // Base image; empty uses the official image of the detected version
+}
+
+// Variable is an environment variable the configuration reads
+type Variable struct {
+	Name    string
+	Default string // Empty for secrets, which have to be given to the container
+	Secret  bool
+	Usage   string // Where it is used, e.g. "conf/server.xml Connector port"
+}
+
+// Result describes a generated build context
+type Result struct {
+	Dir       string
+	Image     string
+	Files     []string // Relative to Dir
+	Variables []Variable
+	Notes     []string // What could not be carried over and needs a look
+}
+
+// generator collects the build context of an instance
+type generator struct {
+	home, base string
+	dir        string
+	result     *Result
+}
+
+// Validate checks that a build context can be generated into dir
+func (o Options) Validate(dir string) error {
+	if o.Instance == nil || (o.Instance.CatalinaHome == "" && o.Instance.CatalinaBase == "") {
+		return fmt.Errorf("CATALINA_HOME or CATALINA_BASE is required")
+	}
+	if dir == "" {
+		return fmt.Errorf("an output directory is required")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%s already exists and is not empty", dir)
+	}
+	return nil
+}
+
+// Generate writes a build context for the instance into dir, which must
+// not exist or be empty. The configuration is copied and rewritten there;
+// the instance itself is not changed.
+func Generate(o Options, dir string) (*Result, error) {
+	if err := o.Validate(dir); err != nil {
+		return nil, err
+	}
+	home, base := o.Instance.CatalinaHome, o.Instance.CatalinaBase
+	if base == "" {
+		base = home
+	} else if home == "" {
+		home = base
+	}
+	g := &generator{
+		home:   filepath.Clean(home),
+		base:   filepath.Clean(base),
+		dir:    dir,
+		result: &Result{Dir: dir, Image: o.Image},
+	}
+	if g.result.Image == "" {
+		g.result.Image = g.image()
+	}
+
+	if err := g.copyTree("conf"); err != nil {
+		return nil, err
+	}
+	// Libraries of a separate CATALINA_BASE are not in the image; those of
+	// CATALINA_HOME are Tomcat's own
+	if g.base != g.home {
+		if err := g.copyTree("lib"); err != nil {
+			return nil, err
+		}
+	}
+
+	srv, err := g.rewriteServer()
+	if err != nil {
+		return nil, err
+	}
+	if err := g.rewriteContext(); err != nil {
+		return nil, err
+	}
+	if err := g.rewriteUsers(); err != nil {
+		return nil, err
+	}
+	sourceOpt, err := g.environmentPropertySource()
+	if err != nil {
+		return nil, err
+	}
+	// A fresh copy has nothing worth restoring
+	if err := os.RemoveAll(filepath.Join(dir, "conf", "backup")); err != nil {
+		return nil, fmt.Errorf("failed to remove backups: %w", err)
+	}
+	if entries, err := os.ReadDir(filepath.Join(dir, "conf", "Catalina")); err == nil && len(entries) > 0 {
+		g.note("conf/Catalina is copied as it is; move the secrets of context descriptors to ${...} references by hand")
+	}
+
+	optsVar, opts := g.jvmOptions()
+	if sourceOpt != "" {
+		opts = append(opts, sourceOpt)
+	}
+	if err := g.write(Dockerfile, g.dockerfile(srv, optsVar, opts), 0644); err != nil {
+		return nil, err
+	}
+	if err := g.write(EntrypointFile, g.entrypoint(), 0755); err != nil {
+		return nil, err
+	}
+	return g.result, nil
+}
+
+// image returns the official image of the instance's version
+func (g *generator) image() string {
+	v, err := detector.ReadVersion(g.home)
+	if err != nil || v.IsZero() {
+		g.note("the Tomcat version could not be detected; the image uses %s:latest", DefaultImage)
+		return DefaultImage + ":latest"
+	}
+	return DefaultImage + ":" + v.String()
+}
+
+// note records something the user has to look at
+func (g *generator) note(format string, args ...interface{}) {
+	g.result.Notes = append(g.result.Notes, fmt.Sprintf(format, args...))
+}
+
+// variable records an environment variable once
+func (g *generator) variable(v Variable) {
+	for _, existing := range g.result.Variables {
+		if existing.Name == v.Name {
+			return
+		}
+	}
+	g.result.Variables = append(g.result.Variables, v)
+}
+
+// secrets returns the names of the secret variables, sorted
+func (r *Result) secrets() []string {
+	var names []string
+	for _, v := range r.Variables {
+		if v.Secret {
+			names = append(names, v.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// copyTree copies a directory of CATALINA_BASE, leaving out tomcatkit
+// backups. A missing directory is skipped. The directory may be a symlink,
+// as in distribution packages where conf points to /etc/tomcat*, and so may
+// the files in it.
+func (g *generator) copyTree(name string) error {
+	src := filepath.Join(g.base, name)
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	root, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", src, err)
+	}
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		sub, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel := filepath.Join(name, sub)
+		if info.IsDir() {
+			if rel == filepath.Join("conf", "backup") {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(filepath.Join(g.dir, rel), 0755)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			if info, err = os.Stat(path); err != nil {
+				return nil
+			}
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if err := copyFile(path, filepath.Join(g.dir, rel), info.Mode().Perm()); err != nil {
+			return err
+		}
+		g.result.Files = append(g.result.Files, rel)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy %s: %w", name, err)
+	}
+	return nil
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// write adds a generated file to the build context
+func (g *generator) write(name, content string, perm os.FileMode) error {
+	if err := os.MkdirAll(g.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", g.dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(g.dir, name), []byte(content), perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	g.result.Files = append(g.result.Files, name)
+	return nil
+}
+
+// rewriteServer moves the ports and secrets of the copied server.xml to
+// environment variables. It returns the rewritten configuration.
+func (g *generator) rewriteServer() (*server.Server, error) {
+	cs := server.NewConfigService(g.dir)
+	if err := cs.Load(); err != nil {
+		return nil, err
+	}
+	srv := cs.GetServer()
+	// Secrets first: the ports name their connectors
+	g.parameterise("conf/server.xml", reflect.ValueOf(srv), "server")
+	g.parameterisePorts(srv)
+	if err := cs.Save(); err != nil {
+		return nil, err
+	}
+	return srv, nil
+}
+
+// rewriteContext moves the secrets of the copied context.xml
+func (g *generator) rewriteContext() error {
+	if _, err := os.Stat(filepath.Join(g.dir, "conf", "context.xml")); err != nil {
+		return nil
+	}
+	cs := jndi.NewContextService(g.dir)
+	if err := cs.Load(); err != nil {
+		return err
+	}
+	g.parameterise("conf/context.xml", reflect.ValueOf(cs.GetContext()), "")
+	return cs.Save()
+}
+
+// rewriteUsers moves the passwords of the copied tomcat-users.xml
+func (g *generator) rewriteUsers() error {
+	if _, err := os.Stat(filepath.Join(g.dir, "conf", "tomcat-users.xml")); err != nil {
+		return nil
+	}
+	us := realm.NewUsersService(g.dir)
+	if err := us.Load(); err != nil {
+		return err
+	}
+	g.parameterise("conf/tomcat-users.xml", reflect.ValueOf(us.GetTomcatUsers()), "")
+	return us.Save()
+}
+
+// environmentPropertySource makes Tomcat resolve ${...} from environment
+// variables. Without a catalina.properties of its own the instance keeps
+// the image's, and the property is returned as a JVM option instead.
+func (g *generator) environmentPropertySource() (string, error) {
+	if len(g.result.Variables) == 0 {
+		return "", nil
+	}
+	if _, err := os.Stat(filepath.Join(g.dir, "conf", "catalina.properties")); err != nil {
+		return "-D" + placeholder.PropertySourceKey + "=" + placeholder.EnvironmentPropertySource, nil
+	}
+	cs := catalina.NewConfigService(g.dir)
+	if err := cs.Load(); err != nil {
+		return "", err
+	}
+	if source, ok := cs.GetProperty(placeholder.PropertySourceKey); ok && source != placeholder.EnvironmentPropertySource {
+		g.note("catalina.properties sets %s=%s; the ${...} references are only resolved from environment variables with %s",
+			placeholder.PropertySourceKey, source, placeholder.EnvironmentPropertySource)
+		return "", nil
+	}
+	cs.SetProperty(placeholder.PropertySourceKey, placeholder.EnvironmentPropertySource)
+	return "", cs.Save()
+}
+
+// scope returns what names the secrets of an element, or parent when the
+// element has no name of its own
+func scope(v reflect.Value, parent string) string {
+	if !v.CanAddr() {
+		return parent
+	}
+	switch e := v.Addr().Interface().(type) {
+	case *server.Connector:
+		return "connector " + string(e.Port)
+	case *server.Resource:
+		return e.Name
+	case *jndi.Resource:
+		return e.Name
+	case *server.Realm:
+		return "realm"
+	case *server.Host:
+		return "host " + e.Name
+	case *realm.User:
+		return "user " + e.Username
+	}
+	return parent
+}
+
+// parameterise walks a configuration, replacing secrets with references
+// to environment variables and paths into the instance with paths into
+// the image
+func (g *generator) parameterise(file string, v reflect.Value, parent string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			g.parameterise(file, v.Elem(), parent)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			g.parameterise(file, v.Index(i), parent)
+		}
+	case reflect.Struct:
+		s := scope(v, parent)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			f := v.Field(i)
+			if f.Type() == attrsType {
+				attrs := f.Interface().([]xml.Attr)
+				for j := range attrs {
+					attrs[j].Value = g.value(file, s, attrs[j].Name.Local, attrs[j].Value)
+				}
+				continue
+			}
+			name, flags, _ := strings.Cut(t.Field(i).Tag.Get("xml"), ",")
+			if strings.Contains(flags, "attr") {
+				if f.Kind() == reflect.String && !strings.HasPrefix(name, "xmlns") {
+					f.SetString(g.value(file, s, name, f.String()))
+				}
+				continue
+			}
+			g.parameterise(file, f, s)
+		}
+	}
+}
+
+// value returns an attribute as written into the image
+func (g *generator) value(file, scope, name, value string) string {
+	if value == "" || placeholder.HasPlaceholder(value) {
+		return value
+	}
+	if placeholder.IsSecret(name) {
+		ref := placeholder.ReferenceName(scope, name)
+		g.variable(Variable{Name: ref, Secret: true, Usage: file + " " + name})
+		return placeholder.Expression(ref)
+	}
+	return g.imagePath(value)
+}
+
+// imagePath moves a path into CATALINA_BASE or CATALINA_HOME to the image
+func (g *generator) imagePath(value string) string {
+	for _, dir := range []string{g.base, g.home} {
+		if value == dir || strings.HasPrefix(value, dir+"/") {
+			return ImageHome + strings.TrimPrefix(value, dir)
+		}
+	}
+	return value
+}
+
+// parameterisePorts moves the shutdown port and the connector ports to
+// environment variables: HTTP_PORT, HTTPS_PORT and AJP_PORT, numbered from
+// the second connector of a kind on. Redirect ports follow the connector
+// they point to.
+func (g *generator) parameterisePorts(srv *server.Server) {
+	if port := srv.Port.Int(); port > 0 && !srv.Port.IsPlaceholder() {
+		g.variable(Variable{Name: "SHUTDOWN_PORT", Default: string(srv.Port), Usage: "conf/server.xml Server port"})
+		srv.Port = placeholder.Int(placeholder.Expression("SHUTDOWN_PORT"))
+	}
+
+	variables := make(map[string]string)
+	counts := make(map[string]int)
+	for si := range srv.Services {
+		for ci := range srv.Services[si].Connectors {
+			conn := &srv.Services[si].Connectors[ci]
+			if conn.Port.IsPlaceholder() || conn.Port.Int() <= 0 {
+				continue
+			}
+			kind := "HTTP"
+			if connector.GetConnectorType(conn.Protocol) == connector.ConnectorTypeAJP {
+				kind = "AJP"
+			} else if conn.SSLEnabled.Bool() {
+				kind = "HTTPS"
+			}
+			counts[kind]++
+			name := kind + "_PORT"
+			if counts[kind] > 1 {
+				name += "_" + strconv.Itoa(counts[kind])
+			}
+			variables[string(conn.Port)] = name
+			g.variable(Variable{Name: name, Default: string(conn.Port), Usage: "conf/server.xml Connector port"})
+			conn.Port = placeholder.Int(placeholder.Expression(name))
+		}
+	}
+	for si := range srv.Services {
+		for ci := range srv.Services[si].Connectors {
+			conn := &srv.Services[si].Connectors[ci]
+			if name, ok := variables[string(conn.RedirectPort)]; ok {
+				conn.RedirectPort = placeholder.Int(placeholder.Expression(name))
+			}
+		}
+	}
+}
+
+// jvmOptions returns the options of setenv.sh as set in the image
+func (g *generator) jvmOptions() (string, []string) {
+	svc := jvm.NewConfigService(g.base, jvm.ScriptSh)
+	if err := svc.Load(); err != nil {
+		g.note("bin/setenv.sh could not be read: %v", err)
+		return "", nil
+	}
+	if !svc.Exists() {
+		return "", nil
+	}
+	opts := svc.GetOptions()
+	if opts.JavaHome != "" {
+		g.note("JAVA_HOME of bin/setenv.sh is not carried over; the image brings its own Java")
+	}
+	for _, line := range svc.UnmanagedLines() {
+		if trimmed := strings.TrimSpace(line); !strings.HasPrefix(trimmed, "#") {
+			g.note("bin/setenv.sh line not carried over: %s", trimmed)
+		}
+	}
+
+	var args []string
+	for _, arg := range opts.JVMArgs() {
+		arg = os.Expand(arg, func(name string) string {
+			switch name {
+			case "CATALINA_HOME", "CATALINA_BASE":
+				return ImageHome
+			}
+			return "${" + name + "}"
+		})
+		// Paths are usually the value of a -D or -XX option
+		if name, value, ok := strings.Cut(arg, "="); ok {
+			arg = name + "=" + g.imagePath(value)
+		} else {
+			arg = g.imagePath(arg)
+		}
+		// catalina.sh evaluates the options, so quote them as setenv.sh would
+		args = append(args, jvm.QuoteArg(arg))
+	}
+	return opts.OptsVar, args
+}
+
+// healthCheck is the endpoint of a HealthCheckValve
+type healthCheck struct {
+	https    bool
+	port     string // Variable of the connector
+	path     string
+	hostName string // Set for a valve of a Host other than the default one
+}
+
+// findHealthCheck returns the first HealthCheckValve reachable through an
+// HTTP connector, or nil
+func findHealthCheck(srv *server.Server) *healthCheck {
+	for _, service := range srv.Services {
+		var check *healthCheck
+		for _, conn := range service.Connectors {
+			if connector.GetConnectorType(conn.Protocol) == connector.ConnectorTypeAJP || !conn.Port.IsPlaceholder() {
+				continue
+			}
+			name := strings.TrimSuffix(strings.TrimPrefix(string(conn.Port), "${"), "}")
+			check = &healthCheck{https: conn.SSLEnabled.Bool(), port: name}
+			break
+		}
+		if check == nil {
+			continue
+		}
+		valve := func(valves []server.Valve) *server.Valve {
+			for i := range valves {
+				if valves[i].ClassName == server.ValveHealthCheck {
+					return &valves[i]
+				}
+			}
+			return nil
+		}
+		v := valve(service.Engine.Valves)
+		if v == nil {
+			for _, host := range service.Engine.Hosts {
+				if v = valve(host.Valves); v != nil {
+					if host.Name != service.Engine.DefaultHost {
+						check.hostName = host.Name
+					}
+					break
+				}
+			}
+		}
+		if v == nil {
+			continue
+		}
+		check.path = v.Path
+		if check.path == "" {
+			check.path = lifecycle.DefaultHealthCheckPath
+		}
+		return check
+	}
+	return nil
+}
+
+// dockerfile returns the Dockerfile of the build context
+func (g *generator) dockerfile(srv *server.Server, optsVar string, opts []string) string {
+	var b strings.Builder
+	b.WriteString("# Generated by TomcatKit\n")
+	fmt.Fprintf(&b, "FROM %s\n", g.result.Image)
+
+	b.WriteString("\nCOPY conf/ " + ImageHome + "/conf/\n")
+	if _, err := os.Stat(filepath.Join(g.dir, "lib")); err == nil {
+		b.WriteString("COPY lib/ " + ImageHome + "/lib/\n")
+	}
+	b.WriteString("# Add the applications, e.g.\n")
+	b.WriteString("# COPY app.war " + ImageHome + "/webapps/\n")
+
+	if len(opts) > 0 {
+		fmt.Fprintf(&b, "\nENV %s=%s\n", optsVar, quote(strings.Join(opts, " ")))
+	}
+
+	var ports []Variable
+	for _, v := range g.result.Variables {
+		if !v.Secret {
+			ports = append(ports, v)
+		}
+	}
+	if len(ports) > 0 {
+		b.WriteString("\n# Ports, referenced as ${...} in server.xml\n")
+		for _, v := range ports {
+			fmt.Fprintf(&b, "ENV %s=%s\n", v.Name, v.Default)
+		}
+		for _, v := range ports {
+			if v.Name != "SHUTDOWN_PORT" {
+				fmt.Fprintf(&b, "EXPOSE %s\n", v.Default)
+			}
+		}
+	}
+	if secrets := g.result.secrets(); len(secrets) > 0 {
+		b.WriteString("\n# Secrets are not part of the image; give them to the container:\n")
+		for _, name := range secrets {
+			fmt.Fprintf(&b, "#   %s\n", name)
+		}
+	}
+
+	b.WriteString("\n")
+	if check := findHealthCheck(srv); check != nil {
+		scheme, flags := "http", "-fsS"
+		if check.https {
+			// Certificates are rarely issued for localhost
+			scheme, flags = "https", "-fsSk"
+		}
+		fmt.Fprintf(&b, "HEALTHCHECK --interval=30s --timeout=5s --start-period=60s --retries=3 \\\n")
+		fmt.Fprintf(&b, "  CMD curl %s", flags)
+		if check.hostName != "" {
+			fmt.Fprintf(&b, " -H 'Host: %s'", check.hostName)
+		}
+		fmt.Fprintf(&b, " %s://localhost:${%s}%s || exit 1\n", scheme, check.port, check.path)
+	} else {
+		fmt.Fprintf(&b, "# Add a %s to server.xml for a HEALTHCHECK\n", server.ValveHealthCheck)
+	}
+
+	b.WriteString("\nCOPY " + EntrypointFile + " /usr/local/bin/\n")
+	b.WriteString("ENTRYPOINT [\"" + EntrypointFile + "\"]\n")
+	b.WriteString("CMD [\"catalina.sh\", \"run\"]\n")
+	return b.String()
+}
+
+// entrypoint returns the script that checks the secrets before starting
+// Tomcat
+func (g *generator) entrypoint() string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	b.WriteString("# Generated by TomcatKit\n")
+	b.WriteString("set -e\n")
+	if secrets := g.result.secrets(); len(secrets) > 0 {
+		b.WriteString("\nmissing=\n")
+		fmt.Fprintf(&b, "for name in %s; do\n", strings.Join(secrets, " "))
+		b.WriteString("  eval \"value=\\${$name:-}\"\n")
+		b.WriteString("  [ -n \"$value\" ] || missing=\"$missing $name\"\n")
+		b.WriteString("done\n")
+		b.WriteString("if [ -n \"$missing\" ]; then\n")
+		b.WriteString("  echo \"Missing environment variables:$missing\" >&2\n")
+		b.WriteString("  exit 1\n")
+		b.WriteString("fi\n")
+	}
+	b.WriteString("\nexec \"$@\"\n")
+	return b.String()
+}
+
+// quote returns a value for ENV: double-quoted, with variable references
+// left to the shell that reads it at runtime
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(value) + `"`
+}
@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/playok/tomcatkit/internal/config"
+)
+
+const serverXML = `<Server port="8005" shutdown="SHUTDOWN">
+  <Service name="Catalina">
+    <Connector port="8080" protocol="HTTP/1.1" redirectPort="8443"/>
+    <Engine name="Catalina" defaultHost="localhost">
+      <Host name="localhost" appBase="webapps"/>
+    </Engine>
+  </Service>
+</Server>
+`
+
+const contextXML = `<Context>
+  <Resource name="jdbc/app" auth="Container" type="javax.sql.DataSource"
+            url="jdbc:x" password="s3cret" maxActive="20"
+            jdbcInterceptors="ConnectionState;StatementFinalizer"/>
+</Context>
+`
+
+// packageLayout creates a CATALINA_BASE whose conf is a symlink to a
+// directory elsewhere, as distribution packages install it
+func packageLayout(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	etc := filepath.Join(root, "etc", "tomcat")
+	if err := os.MkdirAll(etc, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"server.xml": serverXML, "context.xml": contextXML} {
+		if err := os.WriteFile(filepath.Join(etc, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	base := filepath.Join(root, "var", "lib", "tomcat")
+	if err := os.MkdirAll(base, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(etc, filepath.Join(base, "conf")); err != nil {
+		t.Fatal(err)
+	}
+	return base
+}
+
+func TestGenerateFollowsSymlinkedConf(t *testing.T) {
+	base := packageLayout(t)
+	out := filepath.Join(t.TempDir(), "out")
+
+	_, err := Generate(Options{Instance: &config.TomcatInstance{CatalinaHome: base, CatalinaBase: base}, Image: "tomcat:test"}, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{filepath.Join("conf", "server.xml"), filepath.Join("conf", "context.xml")} {
+		if _, err := os.Stat(filepath.Join(out, want)); err != nil {
+			t.Errorf("%s not copied: %v", want, err)
+		}
+	}
+}
+
+func TestGenerateKeepsContextPoolAttributes(t *testing.T) {
+	base := packageLayout(t)
+	out := filepath.Join(t.TempDir(), "out")
+
+	if _, err := Generate(Options{Instance: &config.TomcatInstance{CatalinaHome: base, CatalinaBase: base}, Image: "tomcat:test"}, out); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(out, "conf", "context.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	context := string(data)
+	for _, want := range []string{`maxActive="20"`, `jdbcInterceptors="ConnectionState;StatementFinalizer"`} {
+		if !strings.Contains(context, want) {
+			t.Errorf("%s missing from %s", want, context)
+		}
+	}
+	if strings.Contains(context, "s3cret") {
+		t.Errorf("password not moved to a variable in %s", context)
+	}
+}
@@ -0,0 +1,87 @@
+package fleet
+
+import "strings"
+
+// Diff line kinds
+const (
+	DiffSame    = ' '
+	DiffAdded   = '+'
+	DiffRemoved = '-'
+	DiffGap     = '~' // Unchanged lines left out
+)
+
+// DiffLine is a line of a diff
+type DiffLine struct {
+	Kind byte
+	Text string
+}
+
+// diffContext is how many unchanged lines are kept around changes
+const diffContext = 2
+
+// Diff compares two texts line by line. Unchanged lines further than a few
+// lines from a change are replaced by gaps. It returns nil for equal texts.
+func Diff(before, after string) []DiffLine {
+	if before == after {
+		return nil
+	}
+	a := strings.Split(before, "\n")
+	b := strings.Split(after, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []DiffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, DiffLine{DiffSame, a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, DiffLine{DiffRemoved, a[i]})
+			i++
+		default:
+			lines = append(lines, DiffLine{DiffAdded, b[j]})
+			j++
+		}
+	}
+	return trimContext(lines)
+}
+
+// trimContext replaces runs of unchanged lines away from changes by gaps
+func trimContext(lines []DiffLine) []DiffLine {
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.Kind == DiffSame {
+			continue
+		}
+		for k := max(0, i-diffContext); k <= min(len(lines)-1, i+diffContext); k++ {
+			keep[k] = true
+		}
+	}
+
+	var trimmed []DiffLine
+	for i, line := range lines {
+		if keep[i] {
+			trimmed = append(trimmed, line)
+		} else if len(trimmed) == 0 || trimmed[len(trimmed)-1].Kind != DiffGap {
+			trimmed = append(trimmed, DiffLine{Kind: DiffGap})
+		}
+	}
+	return trimmed
+}
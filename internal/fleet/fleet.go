@@ -0,0 +1,130 @@
+package fleet
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/config/server"
+)
+
+// Member is an instance of the fleet with the key facts of its server.xml
+type Member struct {
+	Instance *config.TomcatInstance
+	Entry    *config.InventoryEntry // Name and tag, when inventoried
+	Facts    Facts
+}
+
+// Facts are read from the server.xml of an instance
+type Facts struct {
+	ShutdownPort int
+	Ports        []int // Connector ports in server.xml order
+	Connectors   int
+	TLS          bool // A connector has SSL enabled
+	// TLSExpiry is when the first PEM certificate of the TLS connectors
+	// expires. It is zero when TLS is off or only keystores are used, as
+	// JKS and PKCS12 files are not read.
+	TLSExpiry time.Time
+	Err       error // server.xml could not be read
+}
+
+// Name returns the inventory name or else CATALINA_BASE
+func (m *Member) Name() string {
+	if m.Entry != nil && m.Entry.Name != "" {
+		return m.Entry.Name
+	}
+	return m.Instance.CatalinaBase
+}
+
+// NewMembers inspects instances, sorted by name
+func NewMembers(instances []*config.TomcatInstance, settings *config.SettingsManager) []*Member {
+	members := make([]*Member, 0, len(instances))
+	for _, instance := range instances {
+		m := &Member{Instance: instance, Facts: Inspect(instance)}
+		if settings != nil {
+			m.Entry = settings.FindInventoryEntry(instance)
+		}
+		members = append(members, m)
+	}
+	sort.SliceStable(members, func(i, j int) bool { return members[i].Name() < members[j].Name() })
+	return members
+}
+
+// Inspect reads the facts of an instance
+func Inspect(instance *config.TomcatInstance) Facts {
+	svc := server.NewConfigService(instance.CatalinaBase)
+	svc.SetCatalinaHome(instance.CatalinaHome)
+	if err := svc.Load(); err != nil {
+		return Facts{Err: err}
+	}
+	srv := svc.GetServer()
+	r := svc.Resolver()
+
+	facts := Facts{ShutdownPort: srv.Port.Value(r)}
+	for _, service := range srv.Services {
+		for _, conn := range service.Connectors {
+			facts.Connectors++
+			facts.Ports = append(facts.Ports, conn.Port.Value(r))
+			if !conn.SSLEnabled.Value(r) {
+				continue
+			}
+			facts.TLS = true
+			if expiry, ok := certificateExpiry(instance.CatalinaBase, conn, r); ok {
+				if facts.TLSExpiry.IsZero() || expiry.Before(facts.TLSExpiry) {
+					facts.TLSExpiry = expiry
+				}
+			}
+		}
+	}
+	return facts
+}
+
+// certificateExpiry returns when the first PEM certificate of a connector
+// expires. Relative paths are relative to CATALINA_BASE, as in Tomcat.
+func certificateExpiry(base string, conn server.Connector, r *placeholder.Resolver) (time.Time, bool) {
+	if conn.SSLHostConfig == nil {
+		return time.Time{}, false
+	}
+	for _, cert := range conn.SSLHostConfig.Certificates {
+		if cert.CertificateFile == "" {
+			continue
+		}
+		path, _ := r.Expand(cert.CertificateFile)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(base, path)
+		}
+		if expiry, err := readPEMExpiry(path); err == nil {
+			return expiry, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// readPEMExpiry returns NotAfter of the first certificate in a PEM file
+func readPEMExpiry(path string) (time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return time.Time{}, fmt.Errorf("no certificate in %s", path)
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		return cert.NotAfter, nil
+	}
+}
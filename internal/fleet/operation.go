@@ -0,0 +1,219 @@
+package fleet
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/playok/tomcatkit/internal/config/logging"
+	"github.com/playok/tomcatkit/internal/config/server"
+)
+
+// Operation is a change made to every selected instance. Server changes
+// server.xml and Logging changes logging.properties; either may be nil.
+type Operation struct {
+	Name    string
+	Server  func(srv *server.Server) error
+	Logging func(svc *logging.ConfigService) error
+}
+
+// CompressionOperation is the gzip compression quick template
+func CompressionOperation(minSize int) Operation {
+	return Operation{
+		Name: fmt.Sprintf("gzip compression (min %d bytes)", minSize),
+		Server: func(srv *server.Server) error {
+			if server.EnableCompression(srv, minSize) == 0 {
+				return fmt.Errorf("no HTTP connector")
+			}
+			return nil
+		},
+	}
+}
+
+// HardeningOperation is the security hardening quick template. Each
+// instance gets its own random shutdown command when its port is disabled.
+func HardeningOperation(h server.Hardening) Operation {
+	return Operation{
+		Name: "security hardening",
+		Server: func(srv *server.Server) error {
+			h := h
+			if srv.Port.String() == "-1" {
+				// Already disabled; keep the command so that nothing changes
+				h.ShutdownCommand = srv.Shutdown
+			} else {
+				token := make([]byte, 8)
+				if _, err := rand.Read(token); err != nil {
+					return fmt.Errorf("failed to generate shutdown command: %w", err)
+				}
+				h.ShutdownCommand = "DISABLED_" + strings.ToUpper(hex.EncodeToString(token))
+			}
+			server.HardenSecurity(srv, h)
+			return nil
+		},
+	}
+}
+
+// LoggerLevelOperation sets the level of a logger, adding the logger where
+// logging.properties does not have it yet
+func LoggerLevelOperation(name string, level logging.LogLevel) Operation {
+	return Operation{
+		Name: fmt.Sprintf("%s.level = %s", name, level),
+		Logging: func(svc *logging.ConfigService) error {
+			if name == "" {
+				return fmt.Errorf("logger name is required")
+			}
+			if logger := svc.GetLogger(name); logger != nil {
+				logger.Level = level
+				return nil
+			}
+			svc.AddLogger(&logging.Logger{Name: name, Level: level, UseParentHandlers: true})
+			return nil
+		},
+	}
+}
+
+// EngineValveOperation adds a valve to the Engine, replacing a valve of the
+// same class there
+func EngineValveOperation(valve server.Valve) Operation {
+	return Operation{
+		Name: server.GetValveShortName(valve.ClassName) + " on Engine",
+		Server: func(srv *server.Server) error {
+			server.PutEngineValve(srv, valve)
+			return nil
+		},
+	}
+}
+
+// FileDiff is the planned change of one file
+type FileDiff struct {
+	File  string // Relative to CATALINA_BASE
+	Lines []DiffLine
+}
+
+// Result is the outcome of an operation for one member
+type Result struct {
+	Member  *Member
+	Diffs   []FileDiff
+	Err     error
+	Applied bool
+
+	serverSvc  *server.ConfigService
+	loggingSvc *logging.ConfigService
+}
+
+// Changed reports whether the operation changes any file of the member
+func (r *Result) Changed() bool {
+	return len(r.Diffs) > 0
+}
+
+// Plan makes the change to the configuration of every member in memory and
+// returns the differences. Nothing is written until Apply.
+func Plan(op Operation, members []*Member) []*Result {
+	results := make([]*Result, len(members))
+	var wg sync.WaitGroup
+	for i, m := range members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = plan(op, m)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// plan plans an operation for one member
+func plan(op Operation, m *Member) *Result {
+	result := &Result{Member: m}
+	base := m.Instance.CatalinaBase
+
+	if op.Server != nil {
+		svc := server.NewConfigService(base)
+		svc.SetCatalinaHome(m.Instance.CatalinaHome)
+		if err := svc.Load(); err != nil {
+			result.Err = err
+			return result
+		}
+		// Both sides are rendered, so the diff shows the change and not
+		// the formatting of the original file
+		before, err := svc.Render()
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		if err := op.Server(svc.GetServer()); err != nil {
+			result.Err = err
+			return result
+		}
+		after, err := svc.Render()
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		if lines := Diff(string(before), string(after)); lines != nil {
+			result.Diffs = append(result.Diffs, FileDiff{File: "conf/server.xml", Lines: lines})
+			result.serverSvc = svc
+		}
+	}
+
+	if op.Logging != nil {
+		svc := logging.NewConfigService(base)
+		if err := svc.Load(); err != nil {
+			result.Err = err
+			return result
+		}
+		before := svc.GenerateContent()
+		if err := op.Logging(svc); err != nil {
+			result.Err = err
+			return result
+		}
+		after := svc.GenerateContent()
+		if lines := Diff(withoutTimestamp(before), withoutTimestamp(after)); lines != nil {
+			result.Diffs = append(result.Diffs, FileDiff{File: "conf/logging.properties", Lines: lines})
+			result.loggingSvc = svc
+		}
+	}
+	return result
+}
+
+// withoutTimestamp drops the generation time from the header of
+// logging.properties, which would differ between the renderings
+func withoutTimestamp(content string) string {
+	lines := strings.SplitN(content, "\n", 4)
+	if len(lines) == 4 && strings.HasPrefix(lines[2], "# ") {
+		return lines[0] + "\n" + lines[1] + "\n" + lines[3]
+	}
+	return content
+}
+
+// Apply writes the planned changes of the results without errors. Each
+// file is backed up by its service as usual. When logging.properties of a
+// member cannot be written, its server.xml is restored from that backup so
+// the member is left as it was.
+func Apply(results []*Result) {
+	for _, result := range results {
+		if result.Err != nil || !result.Changed() {
+			continue
+		}
+		if result.serverSvc != nil {
+			if err := result.serverSvc.Save(); err != nil {
+				result.Err = err
+				continue
+			}
+		}
+		if result.loggingSvc != nil {
+			if err := result.loggingSvc.Save(); err != nil {
+				result.Err = err
+				if result.serverSvc != nil {
+					if rerr := result.serverSvc.RestoreBackup(); rerr != nil {
+						result.Err = fmt.Errorf("%w (server.xml left changed: %v)", err, rerr)
+					}
+				}
+				continue
+			}
+		}
+		result.Applied = true
+	}
+}
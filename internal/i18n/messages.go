@@ -37,6 +37,7 @@ var translations = map[Language]map[string]string{
 		"common.parameters":    "Parameters",
 		"common.contexts":      "Contexts",
 		"common.addall":        "Add All",
+		"common.notanumber":    "%s: '%s' is not a number or ${...} expression",
 		"help.title":           "Help",
 		"preview.title":        "XML Preview",
 		"preview.properties":   "Properties Preview",
@@ -250,35 +251,41 @@ var translations = map[Language]map[string]string{
 		"prompt.nohttp":   "No HTTP connector found!",
 
 		// Instance Selection
-		"instance.title":           "Select Tomcat Instance",
-		"instance.recent":          "Recent Instances",
-		"instance.detected":        "Detected Instances",
-		"instance.none":            "No Tomcat installations detected",
-		"instance.manual":          "Enter Path Manually",
-		"instance.manual.desc":     "Specify CATALINA_HOME path",
-		"instance.running":         "Running",
-		"instance.noselected":      "No Tomcat instance selected",
-		"instance.pressT":          "Press 't' to select a Tomcat instance",
-		"instance.path.title":      "Enter Tomcat Path",
-		"instance.path.home":       "CATALINA_HOME",
-		"instance.path.base":       "CATALINA_BASE (optional)",
-		"instance.path.validate":   "Validate & Select",
-		"instance.path.required":   "CATALINA_HOME is required",
-		"instance.path.invalid":    "Invalid path: server.xml not found",
-		"instance.selected":        "Tomcat instance selected successfully",
-		"instance.info":            "Tomcat Instance",
-		"instance.version":         "Version",
-		"instance.status":          "Status",
-		"instance.stopped":         "Stopped",
-		"instance.ready":           "Ready to configure",
-		"instance.path.help.home":  "CATALINA_HOME: Tomcat installation directory (contains bin, lib, conf)",
-		"instance.path.help.base":  "CATALINA_BASE: Instance directory (optional, defaults to CATALINA_HOME)",
-		"instance.path.help.xml":   "The path should contain conf/server.xml",
-		"instance.info.noselected": "No Tomcat instance selected",
-		"instance.info.getstarted": "To get started:",
-		"instance.info.step1":      "Press 't' to select a Tomcat instance",
-		"instance.info.step2":      "Or run with: tomcatkit -home /path/to/tomcat",
-		"instance.info.autodetect": "TomcatKit will auto-detect installed Tomcat instances.",
+		"instance.title":               "Select Tomcat Instance",
+		"instance.recent":              "Recent Instances",
+		"instance.detected":            "Detected Instances",
+		"instance.none":                "No Tomcat installations detected",
+		"instance.manual":              "Enter Path Manually",
+		"instance.manual.desc":         "Specify CATALINA_HOME path",
+		"instance.running":             "Running",
+		"instance.noselected":          "No Tomcat instance selected",
+		"instance.pressT":              "Press 't' to select a Tomcat instance",
+		"instance.path.title":          "Enter Tomcat Path",
+		"instance.path.home":           "CATALINA_HOME",
+		"instance.path.base":           "CATALINA_BASE (optional)",
+		"instance.path.validate":       "Validate & Select",
+		"instance.path.required":       "CATALINA_HOME is required",
+		"instance.path.invalid":        "Invalid path: server.xml not found",
+		"instance.selected":            "Tomcat instance selected successfully",
+		"instance.info":                "Tomcat Instance",
+		"instance.version":             "Version",
+		"instance.status":              "Status",
+		"instance.stopped":             "Stopped",
+		"instance.user":                "User",
+		"instance.started":             "Started",
+		"instance.layout":              "Layout",
+		"instance.container":           "Container",
+		"instance.container.base":      "CATALINA_BASE in container",
+		"instance.container.unmounted": "Not bind-mounted: changes are lost when the container is recreated",
+		"instance.ready":               "Ready to configure",
+		"instance.path.help.home":      "CATALINA_HOME: Tomcat installation directory (contains bin, lib, conf)",
+		"instance.path.help.base":      "CATALINA_BASE: Instance directory (optional, defaults to CATALINA_HOME)",
+		"instance.path.help.xml":       "The path should contain conf/server.xml",
+		"instance.info.noselected":     "No Tomcat instance selected",
+		"instance.info.getstarted":     "To get started:",
+		"instance.info.step1":          "Press 't' to select a Tomcat instance",
+		"instance.info.step2":          "Or run with: tomcatkit -home /path/to/tomcat",
+		"instance.info.autodetect":     "TomcatKit will auto-detect installed Tomcat instances.",
 
 		// Server View
 		"server.title":                      "Server Configuration",
@@ -4337,6 +4344,515 @@ Optional role description.
 Documents the purpose and
 permissions of this role.`,
 
+		// HTTP/2 Upgrade Protocol
+		"connector.http2":                      "HTTP/2",
+		"connector.http2.title":                "HTTP/2 Upgrade Protocol",
+		"connector.http2.enabled":              "Enable HTTP/2",
+		"connector.http2.maxconcurrentstreams": "Max Concurrent Streams",
+		"connector.http2.initialwindowsize":    "Initial Window Size",
+		"connector.http2.readtimeout":          "Read Timeout (ms)",
+		"connector.http2.keepalivetimeout":     "Keep-Alive Timeout (ms)",
+		"connector.http2.overheadcountfactor":  "Overhead Count Factor",
+		"connector.http2.updated":              "HTTP/2 settings updated successfully",
+		"connector.http2.cleartext":            "Connector is not TLS-enabled: HTTP/2 will only be available via h2c upgrade",
+		"qt.http2":                             "HTTP/2 (h2)",
+		"qt.http2.desc":                        "Enable HTTP/2 on an existing TLS connector",
+		"qt.http2.notls":                       "No TLS connector found. Add an HTTPS connector first.",
+		"qt.http2.success":                     "HTTP/2 enabled on port %d!",
+
+		"help.connector.http2": `[::b]HTTP/2 Upgrade Protocol[::-]
+Adds a nested <UpgradeProtocol> element using
+org.apache.coyote.http2.Http2Protocol.
+
+[green]TLS connectors:[-] negotiated via ALPN (h2)
+[green]Plain connectors:[-] HTTP/1.1 Upgrade (h2c)
+
+[yellow]Note:[-] Browsers only use HTTP/2 over TLS.`,
+
+		"help.connector.http2.enabled": `[::b]Enable HTTP/2[::-]
+Adds or removes the Http2Protocol UpgradeProtocol
+element on this connector.
+
+[green]Recommendation:[-]
+• Enable on HTTPS connectors serving browsers
+• Requires Tomcat 8.5+ and ALPN support (Java 9+ or tcnative)`,
+
+		"help.connector.http2.maxconcurrentstreams": `[::b]Max Concurrent Streams[::-]
+Maximum number of active streams allowed per connection.
+
+[yellow]Default:[-] 100
+
+[green]Recommendation:[-]
+• 100-200 for typical web applications
+• Lower values limit per-client resource usage`,
+
+		"help.connector.http2.initialwindowsize": `[::b]Initial Window Size[::-]
+Initial flow-control window (bytes) advertised to clients.
+
+[yellow]Default:[-] 65535
+
+[green]Tip:[-] Larger values improve throughput for large
+responses on high-latency links.`,
+
+		"help.connector.http2.readtimeout": `[::b]Read Timeout[::-]
+Milliseconds to wait for data when a partial frame
+has been received.
+
+[yellow]Default:[-] 5000`,
+
+		"help.connector.http2.keepalivetimeout": `[::b]Keep-Alive Timeout[::-]
+Milliseconds an idle HTTP/2 connection is kept open
+when there are no active streams.
+
+[yellow]Default:[-] 20000
+[yellow]Note:[-] -1 means no timeout.`,
+
+		"help.connector.http2.overheadcountfactor": `[::b]Overhead Count Factor[::-]
+Factor used for HTTP/2 overhead protection
+(mitigates abusive frame patterns).
+
+[yellow]Default:[-] 10
+[yellow]Note:[-] 0 disables overhead protection.`,
+
+		"help.qt.http2": `[::b]HTTP/2 Template[::-]
+
+Enables HTTP/2 on an existing TLS connector by adding:
+
+  <UpgradeProtocol className=
+    "org.apache.coyote.http2.Http2Protocol"/>
+
+[aqua]Requirements:[white]
+  • An HTTPS connector (SSLEnabled="true")
+  • Tomcat 8.5+ with ALPN support
+
+[aqua]Tuning:[white]
+  [yellow]Max Concurrent Streams[white]: streams per connection
+  [yellow]Initial Window Size[white]: flow-control window
+  [yellow]Timeouts[white]: read and keep-alive timeouts`,
+
+		// Connector attribute catalogue
+		"connector.attrs":                   "Attributes",
+		"connector.attrs.title":             "Connector Attributes",
+		"connector.attrs.advanced":          "Show advanced attributes",
+		"connector.attrs.default":           "(default)",
+		"connector.attrs.type":              "Type",
+		"connector.attrs.group":             "Group",
+		"connector.attrs.defaultvalue":      "Default",
+		"connector.attrs.range":             "Range",
+		"connector.attrs.options":           "Options",
+		"connector.attrs.updated":           "Connector attributes updated",
+		"connector.attrs.group.general":     "General",
+		"connector.attrs.group.threads":     "Threads",
+		"connector.attrs.group.timeouts":    "Timeouts",
+		"connector.attrs.group.limits":      "Limits",
+		"connector.attrs.group.request":     "Request Handling",
+		"connector.attrs.group.proxy":       "Proxy & Identity",
+		"connector.attrs.group.compression": "Compression & Sendfile",
+		"connector.attrs.group.ajp":         "AJP",
+		"connector.attrs.group.socket":      "Socket",
+		"help.connector.attrs": `[yellow::b]Connector Attributes[-::-]
+
+Every connector attribute known to TomcatKit, generated from the attribute catalogue.
+
+Leave a field empty (or choose [green](default)[-]) to use the Tomcat default.
+
+Tick [green]Show advanced attributes[-] to also edit socket and low-level tuning options.`,
+		"help.connattr.port":                             "TCP port the connector listens on. Use 0 for a random free port.",
+		"help.connattr.protocol":                         "Protocol handler: HTTP/1.1, AJP/1.3 or a fully qualified handler class.",
+		"help.connattr.address":                          "IP address to bind to. Empty binds to all addresses.",
+		"help.connattr.redirectPort":                     "Port requests are redirected to when a security constraint requires SSL.",
+		"help.connattr.scheme":                           "Scheme returned by request.getScheme(), e.g. https behind a TLS proxy.",
+		"help.connattr.secure":                           "Value returned by request.isSecure().",
+		"help.connattr.executor":                         "Name of a shared Executor to use instead of the internal thread pool.",
+		"help.connattr.bindOnInit":                       "Bind the socket when the connector is initialised rather than started.",
+		"help.connattr.throwOnFailure":                   "Fail Tomcat startup if this connector cannot start.",
+		"help.connattr.maxThreads":                       "Maximum number of request processing threads.",
+		"help.connattr.minSpareThreads":                  "Minimum number of threads always kept running.",
+		"help.connattr.acceptCount":                      "Queue length for incoming connections when all threads are busy.",
+		"help.connattr.maxConnections":                   "Maximum connections accepted and processed at once. -1 disables the limit.",
+		"help.connattr.useVirtualThreads":                "Use virtual threads for request processing (Tomcat 11+).",
+		"help.connattr.threadPriority":                   "Priority of the request processing threads (1-10).",
+		"help.connattr.acceptorThreadPriority":           "Priority of the acceptor thread (1-10).",
+		"help.connattr.processorCache":                   "Number of idle processors kept for reuse. -1 means unlimited.",
+		"help.connattr.executorTerminationTimeoutMillis": "Time to wait for internal executor threads to stop (ms).",
+		"help.connattr.connectionTimeout":                "Milliseconds to wait for the request line after accepting a connection.",
+		"help.connattr.keepAliveTimeout":                 "Milliseconds to wait for another request on a keep-alive connection.",
+		"help.connattr.asyncTimeout":                     "Default timeout for asynchronous requests (ms).",
+		"help.connattr.disableUploadTimeout":             "Keep the normal connectionTimeout while reading request bodies.",
+		"help.connattr.connectionUploadTimeout":          "Timeout used during uploads when disableUploadTimeout is false (ms).",
+		"help.connattr.selectorTimeout":                  "Poller select() timeout in milliseconds.",
+		"help.connattr.maxKeepAliveRequests":             "Requests served per keep-alive connection. -1 is unlimited, 1 disables keep-alive.",
+		"help.connattr.maxHttpHeaderSize":                "Maximum size of request and response headers in bytes.",
+		"help.connattr.maxPostSize":                      "Maximum POST size parsed as parameters in bytes. -1 disables the limit.",
+		"help.connattr.maxSavePostSize":                  "Maximum POST size saved during FORM or CLIENT-CERT authentication.",
+		"help.connattr.maxParameterCount":                "Maximum number of request parameters. -1 disables the limit.",
+		"help.connattr.maxCookieCount":                   "Maximum number of cookies per request. -1 disables the limit.",
+		"help.connattr.maxSwallowSize":                   "Maximum request body bytes swallowed for aborted uploads.",
+		"help.connattr.maxTrailerSize":                   "Maximum size of chunked trailer headers in bytes.",
+		"help.connattr.maxExtensionSize":                 "Maximum size of chunk extensions in bytes.",
+		"help.connattr.URIEncoding":                      "Character encoding used to decode the URI.",
+		"help.connattr.useBodyEncodingForURI":            "Decode the query string with the request body encoding.",
+		"help.connattr.enableLookups":                    "Perform DNS lookups for request.getRemoteHost(). Slows requests.",
+		"help.connattr.allowTrace":                       "Allow the HTTP TRACE method.",
+		"help.connattr.parseBodyMethods":                 "HTTP methods whose bodies are parsed as form parameters.",
+		"help.connattr.relaxedPathChars":                 "Characters allowed unencoded in the URI path, e.g. [] |.",
+		"help.connattr.relaxedQueryChars":                "Characters allowed unencoded in the query string.",
+		"help.connattr.encodedSolidusHandling":           "How %2F in the path is handled: reject, decode or passthrough.",
+		"help.connattr.rejectIllegalHeader":              "Reject requests containing invalid header names or values.",
+		"help.connattr.allowHostHeaderMismatch":          "Allow a Host header that differs from the request line host.",
+		"help.connattr.restrictedUserAgents":             "Regex of user agents that must not use keep-alive.",
+		"help.connattr.useIPVHosts":                      "Select the virtual host by the IP address the request arrived on.",
+		"help.connattr.discardFacades":                   "Discard request/response facades after each request.",
+		"help.connattr.proxyName":                        "Server name reported when running behind a proxy.",
+		"help.connattr.proxyPort":                        "Server port reported when running behind a proxy.",
+		"help.connattr.server":                           "Overrides the Server response header.",
+		"help.connattr.serverRemoveAppProvidedValues":    "Remove Server headers set by applications.",
+		"help.connattr.xpoweredBy":                       "Send the X-Powered-By response header.",
+		"help.connattr.compression":                      "GZIP compression: off, on, force or a minimum size in bytes.",
+		"help.connattr.compressionMinSize":               "Minimum response size before compression is used (bytes).",
+		"help.connattr.compressibleMimeType":             "Comma-separated MIME types eligible for compression.",
+		"help.connattr.noCompressionUserAgents":          "Regex of user agents that never receive compressed responses.",
+		"help.connattr.noCompressionStrongETag":          "Skip compression for resources with a strong ETag.",
+		"help.connattr.useSendfile":                      "Use sendfile for static content when available.",
+		"help.connattr.secret":                           "Shared secret required from the AJP client.",
+		"help.connattr.secretRequired":                   "Refuse to start unless a secret is configured.",
+		"help.connattr.allowedRequestAttributesPattern":  "Regex of additional AJP request attributes that are accepted.",
+		"help.connattr.packetSize":                       "Maximum AJP packet size in bytes (8192-65536). Must match the proxy.",
+		"help.connattr.ajpFlush":                         "Send a flush packet when the response is flushed.",
+		"help.connattr.tomcatAuthentication":             "Let Tomcat authenticate. Set false to trust the proxy user.",
+		"help.connattr.tomcatAuthorization":              "Authorise the proxy-authenticated user against the Realm.",
+		"help.connattr.socket.rxBufSize":                 "Socket receive buffer size in bytes.",
+		"help.connattr.socket.txBufSize":                 "Socket send buffer size in bytes.",
+		"help.connattr.socket.appReadBufSize":            "Application read buffer size in bytes.",
+		"help.connattr.socket.appWriteBufSize":           "Application write buffer size in bytes.",
+		"help.connattr.socket.directBuffer":              "Use direct (off-heap) byte buffers.",
+		"help.connattr.socket.tcpNoDelay":                "Set TCP_NODELAY on sockets.",
+		"help.connattr.socket.soKeepAlive":               "Set SO_KEEPALIVE on sockets.",
+		"help.connattr.socket.soReuseAddress":            "Set SO_REUSEADDR on sockets.",
+		"help.connattr.socket.soLingerOn":                "Enable SO_LINGER on sockets.",
+		"help.connattr.socket.soLingerTime":              "SO_LINGER time in seconds.",
+		"help.connattr.socket.soTimeout":                 "SO_TIMEOUT in milliseconds.",
+		"help.connattr.socket.bufferPool":                "Number of NIO buffers cached for reuse. -1 is unlimited.",
+		"help.connattr.socket.processorCache":            "Number of socket processors cached for reuse. -1 is unlimited.",
+
+		// Port conflict detection
+		"ports.conflict.duplicate": "Port %d is also used by %s",
+		"ports.conflict.bound":     "Port %d is already bound by %s",
+		"ports.conflict.instance":  "Port %d is also configured in %s",
+
+		// Capacity planner
+		"qt.capacity":                       "Capacity Planner",
+		"qt.capacity.desc":                  "Size threads, connections and DB pools from expected load",
+		"capacity.rps":                      "Requests per second",
+		"capacity.latency":                  "Average latency (ms)",
+		"capacity.cpus":                     "CPU cores",
+		"capacity.memory":                   "Memory for Tomcat (MB)",
+		"capacity.dbtime":                   "Time holding a DB connection (%)",
+		"capacity.reasoning":                "Reasoning",
+		"capacity.changes":                  "Planned Changes",
+		"capacity.recommended":              "Recommended values",
+		"capacity.why":                      "Why",
+		"capacity.nocontext":                "conf/context.xml not found - DataSource pools are not changed",
+		"capacity.nochanges":                "No changes needed",
+		"capacity.applied":                  "Capacity plan applied (%d changes)",
+		"capacity.reason.concurrency":       "Little's law: %.0f req/s × %.0f ms = %.1f requests in flight on average.",
+		"capacity.reason.maxthreads":        "maxThreads: %.1f × %.1f headroom = %d threads.",
+		"capacity.reason.maxthreads.min":    "Raised to the minimum of %d threads so short bursts do not queue.",
+		"capacity.reason.maxthreads.memory": "Capped by memory: %d MB allows about %d threads at 2 MB each using half the memory.",
+		"capacity.reason.minspare":          "minSpareThreads = %d keeps enough threads warm for the average load.",
+		"capacity.reason.maxconnections":    "NIO connectors park idle keep-alive connections without a thread, so allow %d connections per worker: maxConnections = %d.",
+		"capacity.reason.acceptcount":       "acceptCount = %d queues bursts in the OS once maxConnections is reached.",
+		"capacity.reason.cpu":               "CPU demand ≈ %.1f cores of %d available (time outside the database is treated as CPU).",
+		"capacity.reason.pool":              "DB pool: %d%% of request time holds a connection, so %.1f connections are in use on average; maxTotal = %d with headroom.",
+		"capacity.reason.pool.cap":          "Pool capped at maxThreads (%d): only worker threads can borrow connections.",
+		"capacity.reason.poolidle":          "minIdle = %d covers the average DB load, maxIdle = %d absorbs bursts without connection churn.",
+		"capacity.reason.poolwait":          "maxWaitMillis = %d (4× average latency, 1-30 s) fails fast when the pool is exhausted.",
+		"capacity.warn.memory":              "The load needs %d threads but memory only allows %d. Add memory or more instances.",
+		"capacity.warn.cpu":                 "Expected CPU demand (%.1f cores) exceeds the %d CPUs. Latency will rise under this load.",
+
+		"connector.wiring":                  "Executor Wiring",
+		"connector.wiring.desc":             "See and change which connectors share which executor",
+		"connector.wiring.title":            "Executor Wiring",
+		"connector.wiring.usedby":           "Used by",
+		"connector.wiring.unused":           "not used by any connector",
+		"connector.wiring.internal":         "Internal pool",
+		"connector.wiring.missing":          "References missing executor '%s'",
+		"connector.wiring.connector":        "Connector",
+		"connector.wiring.useexecutor":      "Use executor '%s'",
+		"connector.wiring.useinternal":      "Use internal pool",
+		"connector.wiring.useinternal.desc": "Detach from the executor and keep its thread limits inline",
+		"connector.wiring.convert":          "Convert to Executor",
+		"connector.wiring.convert.desc":     "Move inline maxThreads/minSpareThreads into a new shared executor",
+		"connector.wiring.assigned":         "Connector %d now uses executor '%s'",
+		"connector.wiring.detached":         "Connector %d now uses its internal pool",
+		"connector.wiring.converted":        "Connector %d converted to executor '%s'",
+		"connector.executor.inuse":          "Executor '%s' is still used by connector(s) on port %s. Move them first in Executor Wiring.",
+		"connector.executor.exists":         "An executor named '%s' already exists",
+		"help.connector.wiring":             "[yellow::b]Executor Wiring[-::-]\n\nShows each shared [green]<Executor>[-] with the connectors that reference it, followed by connectors that run their own internal thread pool.\n\n[yellow]Actions on a connector:[-]\n• [green]Use executor[-] - share an existing pool; inline maxThreads/minSpareThreads are removed because Tomcat ignores them\n• [green]Use internal pool[-] - detach; the executor's limits are copied inline\n• [green]Convert to Executor[-] - turn the inline pool into a new shared executor\n\n[red]Note:[-] An executor cannot be deleted while a connector still references it.",
+
+		"instance.new":          "New Instance",
+		"instance.new.desc":     "Create a new CATALINA_BASE from an existing CATALINA_HOME",
+		"instance.new.title":    "New Instance",
+		"instance.new.base":     "New CATALINA_BASE",
+		"instance.new.offset":   "Port offset (empty = auto)",
+		"instance.new.create":   "Create",
+		"instance.new.creating": "Creating instance and checking ports...",
+		"instance.new.created":  "Instance created",
+		"instance.new.summary":  "Created %s\n\nShutdown: %d\nHTTP: %d\nHTTPS: %d\nAJP: %d",
+		"instance.new.help":     "[yellow]New CATALINA_BASE[-]\n\nCreates bin, conf, lib, logs, temp, webapps and work, copies conf from CATALINA_HOME and writes bin/setenv.sh.\nThe shutdown, HTTP, HTTPS and AJP ports are shifted by a multiple of 100 until they conflict with neither this machine nor other instances.\nThe directory must not exist or be empty.",
+
+		"instance.clone":               "Clone Instance",
+		"instance.clone.desc":          "Copy the current instance's configuration to another CATALINA_BASE",
+		"instance.clone.title":         "Clone Instance",
+		"instance.clone.source":        "Source CATALINA_BASE",
+		"instance.clone.target":        "Target CATALINA_BASE",
+		"instance.clone.preview":       "Preview",
+		"instance.clone.planning":      "Reading configuration and checking ports...",
+		"instance.clone.summary":       "Clone Summary",
+		"instance.clone.offset":        "port offset",
+		"instance.clone.files":         "Files",
+		"instance.clone.overwrite":     "overwrite, backed up",
+		"instance.clone.substitutions": "Substitutions",
+		"instance.clone.none":          "None",
+		"instance.clone.done":          "Cloned %d file(s) to %s",
+		"instance.clone.help":          "[yellow]Clone Instance[-]\n\nCopies server.xml, web.xml, context.xml, tomcat-users.xml, logging.properties, catalina.properties, the setenv scripts and context descriptors.\nEvery port is shifted by the offset (empty = first free multiple of 100) and absolute paths into the source CATALINA_BASE are rewritten.\nAll substitutions are listed for review before anything is written.",
+
+		"menu.jvm":              "JVM Options",
+		"menu.jvm.desc":         "setenv.sh/.bat heap, GC, JMX, system properties",
+		"jvm.title":             "JVM Options",
+		"jvm.script":            "Script",
+		"jvm.script.desc":       "Switch between bin/setenv.sh and bin/setenv.bat",
+		"jvm.script.new":        "will be created",
+		"jvm.general":           "Heap, GC & Paths",
+		"jvm.xx":                "-XX Flags",
+		"jvm.xx.add":            "Add Flag",
+		"jvm.xx.add.desc":       "Add a -XX option",
+		"jvm.xx.required":       "Flag is required",
+		"jvm.props":             "System Properties",
+		"jvm.props.add":         "Add Property",
+		"jvm.props.add.desc":    "Add a -D system property",
+		"jvm.props.name":        "Name",
+		"jvm.props.value":       "Value",
+		"jvm.props.required":    "Property name is required",
+		"jvm.jmx":               "JMX Remote",
+		"jvm.jmx.enable":        "Enable JMX remote",
+		"jvm.jmx.enabled":       "Enabled on port %s",
+		"jvm.jmx.disabled":      "Disabled",
+		"jvm.jmx.port":          "Port",
+		"jvm.jmx.rmiport":       "RMI port",
+		"jvm.jmx.authenticate":  "Authenticate",
+		"jvm.jmx.ssl":           "SSL",
+		"jvm.jmx.hostname":      "RMI hostname",
+		"jvm.jmx.portrequired":  "A port is required when JMX remote is enabled",
+		"jvm.preserved":         "Preserved Content",
+		"jvm.preserved.count":   "%d option(s), %d line(s) kept as written",
+		"jvm.preserved.options": "Other options in %s",
+		"jvm.preserved.lines":   "Other script lines",
+		"jvm.preserved.none":    "None",
+		"jvm.preview":           "Preview",
+		"jvm.preview.desc":      "Show the script as it will be written",
+		"jvm.save.desc":         "Write the setenv script",
+		"jvm.saved":             "Saved %s",
+		"jvm.updated":           "JVM options updated (not saved yet)",
+		"jvm.returnmenu":        "Return to JVM options",
+		"jvm.count":             "%d configured",
+		"jvm.notset":            "Not set",
+		"jvm.gc":                "Garbage collector",
+		"jvm.gc.default":        "JVM default",
+		"jvm.gc.cms":            "ConcMarkSweep (Java 13 and earlier)",
+		"jvm.heap.min":          "Initial heap (-Xms)",
+		"jvm.heap.max":          "Maximum heap (-Xmx)",
+		"jvm.heap.invalid":      "Invalid memory size '%s' (use e.g. 512m or 2g)",
+		"help.jvm.script":       "[yellow::b]setenv Script[-::-]\n\ncatalina.sh sources [green]bin/setenv.sh[-] and catalina.bat calls [green]bin/setenv.bat[-] from CATALINA_BASE (or CATALINA_HOME).\n\nOptions are written to [green]CATALINA_OPTS[-], which is only used when Tomcat starts, not when it stops. An existing script that only uses JAVA_OPTS keeps using it.\n\nLines that are not plain assignments (conditions, functions, other variables) are kept exactly as written.",
+		"help.jvm.general":      "[yellow::b]Heap, GC & Paths[-::-]\n\n[green]JAVA_HOME[-] - JDK used to run Tomcat\n[green]CATALINA_PID[-] - File holding the process ID; needed for 'catalina.sh stop -force'\n[green]-Xms / -Xmx[-] - Initial and maximum heap. Setting both to the same value avoids resizing pauses\n[green]GC[-] - G1 is the default since Java 9; ZGC and Shenandoah target low pause times on large heaps",
+		"help.jvm.xx":           "[yellow::b]-XX Flags[-::-]\n\nAdvanced HotSpot options, entered without the -XX: prefix.\n\n[yellow]Examples:[-]\n• MaxMetaspaceSize=256m\n• +HeapDumpOnOutOfMemoryError\n• HeapDumpPath=/var/log/tomcat\n• +ExitOnOutOfMemoryError\n\nThe collector is chosen under Heap, GC & Paths.",
+		"help.jvm.props":        "[yellow::b]System Properties[-::-]\n\n-Dname=value options available through System.getProperty() and as ${name} in Tomcat configuration files.\n\n[yellow]Common:[-]\n• file.encoding=UTF-8\n• user.timezone=UTC\n• java.security.egd=file:/dev/./urandom",
+		"help.jvm.jmx":          "[yellow::b]JMX Remote[-::-]\n\nExposes MBeans to JConsole, VisualVM and monitoring agents.\n\n[green]RMI port[-] - Fix it to the same value as the port to pass firewalls\n[green]RMI hostname[-] - Address clients connect back to\n\n[red]Warning:[-] With authenticate=false and ssl=false anyone who can reach the port controls the JVM.",
+		"help.jvm.preserved":    "[yellow::b]Preserved Content[-::-]\n\nOptions TomcatKit does not model (e.g. -javaagent, -ea) are written back unchanged after the managed options.\n\nScript lines that are not managed assignments are never modified.",
+
+		// catalina.properties
+		"menu.catprops":            "Catalina Properties",
+		"menu.catprops.desc":       "catalina.properties class loaders, jar scanning, custom properties",
+		"catprops.title":           "catalina.properties",
+		"catprops.count":           "%d entries",
+		"catprops.loaders":         "Class Loaders",
+		"catprops.loaders.desc":    "common: %d, shared: %d entries",
+		"catprops.jarstoskip":      "Jars to Skip",
+		"catprops.jarstoscan":      "Jars to Scan",
+		"catprops.packageaccess":   "Package Access",
+		"catprops.packagedef":      "Package Definition",
+		"catprops.custom":          "Custom Properties",
+		"catprops.custom.add":      "Add Property",
+		"catprops.custom.add.desc": "Add a property for ${...} references",
+		"catprops.custom.key":      "Name",
+		"catprops.custom.value":    "Value",
+		"catprops.custom.required": "Property name is required",
+		"catprops.custom.standard": "%s is a Tomcat property; edit it from its own menu",
+		"catprops.custom.exists":   "Property %s already exists",
+		"catprops.item.add":        "Add Entry",
+		"catprops.item.add.desc":   "Append an entry to the list",
+		"catprops.item.value":      "Entry",
+		"catprops.item.required":   "Entry must not be empty",
+		"catprops.item.comma":      "Entries cannot contain commas; add them one by one",
+		"catprops.preview":         "Preview",
+		"catprops.preview.desc":    "Show catalina.properties as it will be saved",
+		"catprops.save.desc":       "Write catalina.properties (a backup is kept in conf/backup)",
+		"catprops.saved":           "Saved %s (restart Tomcat to apply)",
+		"catprops.returnmenu":      "Return to catalina.properties menu",
+		"help.catprops.loaders":    "[yellow::b]Class Loaders[-::-]\n\n[green]common.loader[-]: classes visible to Tomcat and all web applications.\n[green]server.loader[-]: classes visible to Tomcat only.\n[green]shared.loader[-]: classes shared by all web applications.\n\nEntries are directories, JAR files or [green]*.jar[-] globs and may use ${catalina.base} and ${catalina.home}. Empty server/shared loaders fall back to the common loader.",
+		"help.catprops.jarscan":    "[yellow::b]Jar Scanning[-::-]\n\n[green]jarsToSkip[-] lists JAR name patterns that are not scanned for TLDs, web fragments and annotations. Skipping large library JARs speeds up startup considerably.\n\n[green]jarsToScan[-] lists exceptions that are scanned even when they match a skip pattern.\n\nPatterns use * and ? wildcards and match the file name only.",
+		"help.catprops.packages":   "[yellow::b]Package Security[-::-]\n\nOnly enforced when Tomcat runs with a SecurityManager.\n\n[green]package.access[-]: packages web applications may not access.\n[green]package.definition[-]: packages web applications may not define classes in.\n\nEntries are package prefixes ending with a dot.",
+		"help.catprops.custom":     "[yellow::b]Custom Properties[-::-]\n\nProperties in catalina.properties become system properties at startup, so server.xml and context files can reference them as [green]${name}[-].\n\nThis is a convenient place for ports, host names and paths that differ between instances.",
+
+		// Placeholders
+		"placeholder.resolved":   "Resolved",
+		"placeholder.unresolved": "unresolved: %s",
+
+		// systemd
+		"menu.systemd":            "systemd Service",
+		"menu.systemd.desc":       "Generate a systemd unit file for this instance",
+		"systemd.title":           "systemd Unit",
+		"systemd.pidfile":         "PID File",
+		"systemd.hardening":       "Hardening",
+		"systemd.template":        "Template Unit (tomcat@.service)",
+		"systemd.basesdir":        "Instances Directory",
+		"systemd.output":          "Unit File",
+		"systemd.output.required": "Enter the path of the unit file",
+		"systemd.saved":           "Unit written to %s. Run 'systemctl daemon-reload' to load it.",
+		"help.systemd":            "[yellow::b]systemd Unit[-::-]\n\nGenerates a unit that starts Tomcat with [green]bin/startup.sh[-] as a forking service, so setenv.sh is read as with a manual start.\n\nInstall it in [green]/etc/systemd/system[-], then run:\n  systemctl daemon-reload\n  systemctl enable --now <unit>",
+		"help.systemd.user":       "[yellow::b]User / Group[-::-]\n\nAccount the service runs as. Tomcat should not run as root; the account needs read access to CATALINA_HOME and write access to logs, work, temp and webapps of CATALINA_BASE.",
+		"help.systemd.javahome":   "[yellow::b]JAVA_HOME[-::-]\n\nJDK used to run Tomcat. Taken from setenv.sh or the environment. A JAVA_HOME set in setenv.sh overrides the unit.",
+		"help.systemd.pidfile":    "[yellow::b]PID File[-::-]\n\nPassed as [green]CATALINA_PID[-] and used by systemd to track the forked JVM. Empty uses [green]CATALINA_BASE/temp/tomcat.pid[-].\n\nA CATALINA_PID set in setenv.sh overrides the unit, so it is taken from there when present.",
+		"help.systemd.nofile":     "[yellow::b]LimitNOFILE[-::-]\n\nMaximum open files. Each connection and open JAR uses a descriptor; busy instances need more than the usual default of 1024.\n\n0 or empty keeps the systemd default.",
+		"help.systemd.hardening":  "[yellow::b]Hardening[-::-]\n\nAdds [green]NoNewPrivileges[-], [green]PrivateTmp[-], [green]ProtectSystem=strict[-], [green]ProtectHome[-] and kernel protections.\n\nThe file system is read-only for the service except [green]ReadWritePaths[-]: logs, work, temp, webapps and conf/Catalina of CATALINA_BASE.",
+		"help.systemd.template":   "[yellow::b]Template Unit[-::-]\n\nGenerates [green]tomcat@.service[-], where the instance name is a directory below the instances directory:\n\n  systemctl start tomcat@app1\n\nuses CATALINA_BASE=<instances directory>/app1.",
+		"help.systemd.output":     "[yellow::b]Unit File[-::-]\n\nWhere the unit is written. A directory uses the unit name. An existing file is kept as .bak.\n\nWriting to /etc/systemd/system needs root.",
+
+		// Start / Stop
+		"menu.lifecycle":         "Start / Stop",
+		"menu.lifecycle.desc":    "Start, stop or restart this instance and follow its output",
+		"lifecycle.title":        "Start / Stop",
+		"lifecycle.log":          "Output",
+		"lifecycle.start":        "Start",
+		"lifecycle.start.desc":   "catalina.sh start, then wait for \"Server startup in\" in catalina.out",
+		"lifecycle.run":          "Run in Foreground",
+		"lifecycle.run.desc":     "catalina.sh run as a child of TomcatKit, with its console shown here",
+		"lifecycle.stop":         "Stop",
+		"lifecycle.stop.desc":    "Send the shutdown command to the shutdown port (catalina.sh stop if disabled)",
+		"lifecycle.restart":      "Restart",
+		"lifecycle.restart.desc": "Stop, then start again to apply saved changes",
+		"lifecycle.refresh":      "Refresh Status",
+		"lifecycle.refresh.desc": "Look up the Tomcat process again",
+		"lifecycle.clear":        "Clear Output",
+		"lifecycle.clear.desc":   "Clear the output pane",
+		"lifecycle.starting":     "Starting Tomcat...",
+		"lifecycle.stopping":     "Stopping Tomcat...",
+		"lifecycle.restarting":   "Restarting Tomcat...",
+		"lifecycle.started":      "Tomcat started",
+		"lifecycle.stopped":      "Tomcat stopped",
+
+		// Apply safely
+		"lifecycle.apply":              "Apply Safely",
+		"lifecycle.apply.desc":         "Restart with %d changed file(s), verify health, roll back on failure",
+		"lifecycle.apply.title":        "Apply Changes Safely",
+		"lifecycle.apply.urls":         "Health URLs",
+		"lifecycle.apply.timeout":      "Timeout (seconds)",
+		"lifecycle.apply.changes":      "Changed since the instance was opened",
+		"lifecycle.apply.nochanges":    "No changes",
+		"lifecycle.apply.novalve":      "No HealthCheckValve in server.xml",
+		"lifecycle.applying":           "Applying changes...",
+		"lifecycle.applied":            "Changes applied and verified",
+		"help.lifecycle.apply":         "[yellow::b]Apply Safely[-::-]\n\nRestarts Tomcat with the saved changes. If it does not report startup or a health URL does not answer within the timeout, the previous configuration is restored and Tomcat is restarted.\n\nThe previous files are kept in conf/backup/apply-<time> and every step is logged to logs/tomcatkit-apply-<time>.log.",
+		"help.lifecycle.apply.urls":    "[yellow::b]Health URLs[-::-]\n\nURLs that must answer with a 2xx status after the restart, separated by spaces.\n\nExample: http://localhost:8080/app/health\n\nEndpoints of HealthCheckValves in server.xml are checked as well.",
+		"help.lifecycle.apply.timeout": "[yellow::b]Timeout[-::-]\n\nSeconds Tomcat may take to start and pass the health checks before the changes are rolled back.",
+
+		// Instance scan
+		"instance.scan":       "Scan for Instances",
+		"instance.scan.desc":  "Search directories for Tomcat installations and CATALINA_BASEs",
+		"instance.scanned":    "Found by Scan",
+		"scan.title":          "Scan for Instances",
+		"scan.roots":          "Search Roots",
+		"scan.roots.required": "At least one search root is required",
+		"scan.depth":          "Depth",
+		"scan.start":          "Scan",
+		"scan.running":        "Scanning... (Esc to cancel)",
+		"scan.progress.title": "Progress",
+		"scan.progress":       "Scanned %d directories, found %d",
+		"scan.done":           "Scan finished: %d instances found",
+		"scan.cancelled":      "Scan cancelled",
+		"scan.results":        "Scan Results (%d)",
+		"scan.none.desc":      "Try more search roots or a greater depth",
+		"help.scan.roots":     "[yellow]Search Roots[white]\nDirectories to search, separated by spaces. Symlinks are not followed and /proc, /sys, /dev and /run are skipped.",
+		"help.scan.depth":     "[yellow]Depth[white]\nHow many directory levels below each root are searched. Directories with conf/server.xml and lib/catalina.jar are installations; directories with only conf/server.xml are CATALINA_BASEs.",
+
+		// Instance inventory
+		"inventory.title":         "Inventory",
+		"inventory.add":           "Add to Inventory",
+		"inventory.edit":          "Edit Inventory Entry",
+		"inventory.name":          "Name",
+		"inventory.name.required": "A name is required",
+		"inventory.environment":   "Environment",
+		"inventory.env.all":       "all",
+		"inventory.env.none":      "none",
+		"inventory.color":         "Colour",
+		"inventory.color.default": "default",
+		"inventory.notes":         "Notes",
+		"inventory.saved":         "Inventory entry saved",
+		"inventory.removed":       "Inventory entry removed",
+		"inventory.banner":        "PRODUCTION - changes affect a live instance",
+		"instance.search":         "Search",
+		"instance.search.none":    "No instances match the search",
+		"instance.selector.keys":  "/ search  f environment  e name & tag",
+		"instance.missing":        "conf/server.xml of this instance no longer exists",
+		"help.inventory":          "[yellow]Inventory[white]\nNamed instances are listed first in the instance selector and can be searched by name, path, environment and notes. Instances tagged prod show a red warning banner while they are edited.",
+
+		// Fleet
+		"menu.fleet":            "Fleet",
+		"menu.fleet.desc":       "All known instances at a glance; apply one change to many",
+		"fleet.title":           "Fleet",
+		"fleet.keys":            "Space mark  a all  Enter change  c compare two  r refresh",
+		"fleet.shutdown":        "Shutdown",
+		"fleet.ports":           "Ports",
+		"fleet.connectors":      "Conn",
+		"fleet.tls":             "TLS Expiry",
+		"fleet.tls.keystore":    "keystore",
+		"fleet.tls.expired":     "EXPIRED",
+		"fleet.operations":      "Change %d Instances",
+		"fleet.op.logger":       "Logger Level",
+		"fleet.op.logger.desc":  "Set the level of a logger in logging.properties",
+		"fleet.op.valve":        "Engine Valve",
+		"fleet.op.valve.desc":   "Add a valve to the Engine, replacing one of the same class",
+		"fleet.logger":          "Logger",
+		"fleet.level":           "Level",
+		"fleet.valve":           "Valve",
+		"fleet.valve.allow":     "Allow (RemoteAddrValve)",
+		"fleet.invalid":         "Invalid input",
+		"fleet.preview":         "Preview",
+		"fleet.planning":        "Preparing changes...",
+		"fleet.plan":            "Planned Changes",
+		"fleet.plan.summary":    "[::b]%s[::-]: %d to change, %d unchanged, %d failed",
+		"fleet.plan.production": "%d production instances are included",
+		"fleet.unchanged":       "no change",
+		"fleet.report":          "Result",
+		"fleet.report.summary":  "%s: %d applied, %d failed",
+		"fleet.report.restart":  "Restart the instances for the changes to take effect. Backups are in conf/backup.",
+
+		// Compare
+		"fleet.compare.two":       "Mark exactly two instances with Space to compare them",
+		"compare.title":           "Compare",
+		"compare.keys":            "> copy A→B  < copy B→A  s save  r reload",
+		"compare.setting":         "Setting",
+		"compare.notset":          "(not set)",
+		"compare.absent":          "(absent)",
+		"compare.none":            "No differences: the instances are configured the same",
+		"compare.skipped":         "%s not compared, %c: %v",
+		"compare.modified":        "not saved: %s",
+		"compare.copied":          "Copied %s to %c (not saved yet, press s)",
+		"compare.saved":           "Copied settings saved. Restart the instances to apply them.",
+		"compare.save.confirm":    "Write the copied settings? The files are backed up first.",
+		"compare.save.production": "A production instance will be changed.",
+		"compare.discard":         "Discard the copied settings that are not saved?",
+
 		"help.default": `[gray]Select a field to see help information.[-]`,
 	},
 
@@ -4367,6 +4883,7 @@ permissions of this role.`,
 		"common.disabled":      "비활성화됨",
 		"common.notconfigured": "설정되지 않음",
 		"common.minutes":       "분",
+		"common.notanumber":    "%s: '%s'은(는) 숫자나 ${...} 표현식이 아닙니다",
 		"help.title":           "도움말",
 		"preview.title":        "XML 미리보기",
 		"preview.properties":   "Properties 미리보기",
@@ -4580,35 +5097,41 @@ permissions of this role.`,
 		"prompt.nohttp":   "HTTP 커넥터를 찾을 수 없습니다!",
 
 		// Instance Selection
-		"instance.title":           "Tomcat 인스턴스 선택",
-		"instance.recent":          "최근 인스턴스",
-		"instance.detected":        "감지된 인스턴스",
-		"instance.none":            "Tomcat 설치를 찾을 수 없습니다",
-		"instance.manual":          "경로 직접 입력",
-		"instance.manual.desc":     "CATALINA_HOME 경로 지정",
-		"instance.running":         "실행중",
-		"instance.noselected":      "Tomcat 인스턴스가 선택되지 않았습니다",
-		"instance.pressT":          "'t'를 눌러 Tomcat 인스턴스를 선택하세요",
-		"instance.path.title":      "Tomcat 경로 입력",
-		"instance.path.home":       "CATALINA_HOME",
-		"instance.path.base":       "CATALINA_BASE (선택)",
-		"instance.path.validate":   "검증 및 선택",
-		"instance.path.required":   "CATALINA_HOME은 필수입니다",
-		"instance.path.invalid":    "잘못된 경로: server.xml을 찾을 수 없습니다",
-		"instance.selected":        "Tomcat 인스턴스가 선택되었습니다",
-		"instance.info":            "Tomcat 인스턴스",
-		"instance.version":         "버전",
-		"instance.status":          "상태",
-		"instance.stopped":         "중지됨",
-		"instance.ready":           "설정 준비됨",
-		"instance.path.help.home":  "CATALINA_HOME: Tomcat 설치 디렉토리 (bin, lib, conf 포함)",
-		"instance.path.help.base":  "CATALINA_BASE: 인스턴스 디렉토리 (선택, 기본값은 CATALINA_HOME)",
-		"instance.path.help.xml":   "경로에 conf/server.xml이 있어야 합니다",
-		"instance.info.noselected": "Tomcat 인스턴스가 선택되지 않았습니다",
-		"instance.info.getstarted": "시작하려면:",
-		"instance.info.step1":      "'t'를 눌러 Tomcat 인스턴스를 선택하세요",
-		"instance.info.step2":      "또는 실행: tomcatkit -home /path/to/tomcat",
-		"instance.info.autodetect": "TomcatKit이 설치된 Tomcat 인스턴스를 자동 감지합니다.",
+		"instance.title":               "Tomcat 인스턴스 선택",
+		"instance.recent":              "최근 인스턴스",
+		"instance.detected":            "감지된 인스턴스",
+		"instance.none":                "Tomcat 설치를 찾을 수 없습니다",
+		"instance.manual":              "경로 직접 입력",
+		"instance.manual.desc":         "CATALINA_HOME 경로 지정",
+		"instance.running":             "실행중",
+		"instance.noselected":          "Tomcat 인스턴스가 선택되지 않았습니다",
+		"instance.pressT":              "'t'를 눌러 Tomcat 인스턴스를 선택하세요",
+		"instance.path.title":          "Tomcat 경로 입력",
+		"instance.path.home":           "CATALINA_HOME",
+		"instance.path.base":           "CATALINA_BASE (선택)",
+		"instance.path.validate":       "검증 및 선택",
+		"instance.path.required":       "CATALINA_HOME은 필수입니다",
+		"instance.path.invalid":        "잘못된 경로: server.xml을 찾을 수 없습니다",
+		"instance.selected":            "Tomcat 인스턴스가 선택되었습니다",
+		"instance.info":                "Tomcat 인스턴스",
+		"instance.version":             "버전",
+		"instance.status":              "상태",
+		"instance.stopped":             "중지됨",
+		"instance.user":                "사용자",
+		"instance.started":             "시작 시각",
+		"instance.layout":              "구성 방식",
+		"instance.container":           "컨테이너",
+		"instance.container.base":      "컨테이너 안의 CATALINA_BASE",
+		"instance.container.unmounted": "바인드 마운트 아님: 컨테이너를 다시 만들면 변경 사항이 사라집니다",
+		"instance.ready":               "설정 준비됨",
+		"instance.path.help.home":      "CATALINA_HOME: Tomcat 설치 디렉토리 (bin, lib, conf 포함)",
+		"instance.path.help.base":      "CATALINA_BASE: 인스턴스 디렉토리 (선택, 기본값은 CATALINA_HOME)",
+		"instance.path.help.xml":       "경로에 conf/server.xml이 있어야 합니다",
+		"instance.info.noselected":     "Tomcat 인스턴스가 선택되지 않았습니다",
+		"instance.info.getstarted":     "시작하려면:",
+		"instance.info.step1":          "'t'를 눌러 Tomcat 인스턴스를 선택하세요",
+		"instance.info.step2":          "또는 실행: tomcatkit -home /path/to/tomcat",
+		"instance.info.autodetect":     "TomcatKit이 설치된 Tomcat 인스턴스를 자동 감지합니다.",
 
 		// Server View
 		"server.title":                      "서버 설정",
@@ -7581,6 +8104,515 @@ web.xml 보안 제약 조건과 사용자
 
 이 역할의 목적과 권한을 문서화합니다.`,
 
+		// HTTP/2 Upgrade Protocol
+		"connector.http2":                      "HTTP/2",
+		"connector.http2.title":                "HTTP/2 업그레이드 프로토콜",
+		"connector.http2.enabled":              "HTTP/2 활성화",
+		"connector.http2.maxconcurrentstreams": "최대 동시 스트림",
+		"connector.http2.initialwindowsize":    "초기 윈도우 크기",
+		"connector.http2.readtimeout":          "읽기 타임아웃 (ms)",
+		"connector.http2.keepalivetimeout":     "Keep-Alive 타임아웃 (ms)",
+		"connector.http2.overheadcountfactor":  "오버헤드 카운트 계수",
+		"connector.http2.updated":              "HTTP/2 설정이 업데이트되었습니다",
+		"connector.http2.cleartext":            "TLS가 활성화되지 않은 커넥터입니다: HTTP/2는 h2c 업그레이드로만 사용 가능합니다",
+		"qt.http2":                             "HTTP/2 (h2)",
+		"qt.http2.desc":                        "기존 TLS 커넥터에 HTTP/2 활성화",
+		"qt.http2.notls":                       "TLS 커넥터가 없습니다. 먼저 HTTPS 커넥터를 추가하세요.",
+		"qt.http2.success":                     "포트 %d에 HTTP/2가 활성화되었습니다!",
+
+		"help.connector.http2": `[::b]HTTP/2 업그레이드 프로토콜[::-]
+org.apache.coyote.http2.Http2Protocol을 사용하는
+<UpgradeProtocol> 하위 요소를 추가합니다.
+
+[green]TLS 커넥터:[-] ALPN으로 협상 (h2)
+[green]일반 커넥터:[-] HTTP/1.1 Upgrade (h2c)
+
+[yellow]참고:[-] 브라우저는 TLS에서만 HTTP/2를 사용합니다.`,
+
+		"help.connector.http2.enabled": `[::b]HTTP/2 활성화[::-]
+이 커넥터에 Http2Protocol UpgradeProtocol 요소를
+추가하거나 제거합니다.
+
+[green]권장:[-]
+• 브라우저를 서비스하는 HTTPS 커넥터에서 활성화
+• Tomcat 8.5+ 및 ALPN 지원 필요 (Java 9+ 또는 tcnative)`,
+
+		"help.connector.http2.maxconcurrentstreams": `[::b]최대 동시 스트림[::-]
+연결당 허용되는 최대 활성 스트림 수입니다.
+
+[yellow]기본값:[-] 100
+
+[green]권장:[-]
+• 일반 웹 애플리케이션은 100-200
+• 낮은 값은 클라이언트당 리소스 사용을 제한합니다`,
+
+		"help.connector.http2.initialwindowsize": `[::b]초기 윈도우 크기[::-]
+클라이언트에 알리는 초기 흐름 제어 윈도우(바이트)입니다.
+
+[yellow]기본값:[-] 65535
+
+[green]팁:[-] 지연이 큰 링크에서 큰 응답의 처리량을
+높이려면 값을 늘리세요.`,
+
+		"help.connector.http2.readtimeout": `[::b]읽기 타임아웃[::-]
+부분 프레임을 수신한 후 데이터를 기다리는
+시간(밀리초)입니다.
+
+[yellow]기본값:[-] 5000`,
+
+		"help.connector.http2.keepalivetimeout": `[::b]Keep-Alive 타임아웃[::-]
+활성 스트림이 없을 때 유휴 HTTP/2 연결을
+유지하는 시간(밀리초)입니다.
+
+[yellow]기본값:[-] 20000
+[yellow]참고:[-] -1은 타임아웃 없음을 의미합니다.`,
+
+		"help.connector.http2.overheadcountfactor": `[::b]오버헤드 카운트 계수[::-]
+HTTP/2 오버헤드 보호(악의적인 프레임 패턴 완화)에
+사용되는 계수입니다.
+
+[yellow]기본값:[-] 10
+[yellow]참고:[-] 0은 오버헤드 보호를 비활성화합니다.`,
+
+		"help.qt.http2": `[::b]HTTP/2 템플릿[::-]
+
+기존 TLS 커넥터에 다음을 추가하여 HTTP/2를 활성화합니다:
+
+  <UpgradeProtocol className=
+    "org.apache.coyote.http2.Http2Protocol"/>
+
+[aqua]요구사항:[white]
+  • HTTPS 커넥터 (SSLEnabled="true")
+  • ALPN을 지원하는 Tomcat 8.5+
+
+[aqua]튜닝:[white]
+  [yellow]최대 동시 스트림[white]: 연결당 스트림 수
+  [yellow]초기 윈도우 크기[white]: 흐름 제어 윈도우
+  [yellow]타임아웃[white]: 읽기 및 Keep-Alive 타임아웃`,
+
+		// Connector attribute catalogue
+		"connector.attrs":                   "속성",
+		"connector.attrs.title":             "커넥터 속성",
+		"connector.attrs.advanced":          "고급 속성 표시",
+		"connector.attrs.default":           "(기본값)",
+		"connector.attrs.type":              "유형",
+		"connector.attrs.group":             "그룹",
+		"connector.attrs.defaultvalue":      "기본값",
+		"connector.attrs.range":             "범위",
+		"connector.attrs.options":           "선택지",
+		"connector.attrs.updated":           "커넥터 속성이 업데이트되었습니다",
+		"connector.attrs.group.general":     "일반",
+		"connector.attrs.group.threads":     "스레드",
+		"connector.attrs.group.timeouts":    "타임아웃",
+		"connector.attrs.group.limits":      "제한",
+		"connector.attrs.group.request":     "요청 처리",
+		"connector.attrs.group.proxy":       "프록시 및 식별",
+		"connector.attrs.group.compression": "압축 및 Sendfile",
+		"connector.attrs.group.ajp":         "AJP",
+		"connector.attrs.group.socket":      "소켓",
+		"help.connector.attrs": `[yellow::b]커넥터 속성[-::-]
+
+속성 카탈로그에서 생성된, TomcatKit이 아는 모든 커넥터 속성입니다.
+
+필드를 비워 두거나 [green](기본값)[-]을 선택하면 Tomcat 기본값을 사용합니다.
+
+[green]고급 속성 표시[-]를 선택하면 소켓 및 저수준 튜닝 옵션도 편집할 수 있습니다.`,
+		"help.connattr.port":                             "커넥터가 수신 대기하는 TCP 포트입니다. 0은 임의의 빈 포트입니다.",
+		"help.connattr.protocol":                         "프로토콜 핸들러: HTTP/1.1, AJP/1.3 또는 핸들러 클래스 전체 이름.",
+		"help.connattr.address":                          "바인딩할 IP 주소입니다. 비어 있으면 모든 주소에 바인딩합니다.",
+		"help.connattr.redirectPort":                     "보안 제약으로 SSL이 필요할 때 리다이렉트할 포트입니다.",
+		"help.connattr.scheme":                           "request.getScheme()이 반환하는 스킴 (예: TLS 프록시 뒤의 https).",
+		"help.connattr.secure":                           "request.isSecure()가 반환하는 값입니다.",
+		"help.connattr.executor":                         "내부 스레드 풀 대신 사용할 공유 Executor 이름입니다.",
+		"help.connattr.bindOnInit":                       "시작 시점이 아닌 초기화 시점에 소켓을 바인딩합니다.",
+		"help.connattr.throwOnFailure":                   "이 커넥터를 시작할 수 없으면 Tomcat 시작을 실패시킵니다.",
+		"help.connattr.maxThreads":                       "요청 처리 스레드의 최대 수입니다.",
+		"help.connattr.minSpareThreads":                  "항상 유지되는 최소 스레드 수입니다.",
+		"help.connattr.acceptCount":                      "모든 스레드가 사용 중일 때 들어오는 연결의 대기열 길이입니다.",
+		"help.connattr.maxConnections":                   "동시에 수락하고 처리하는 최대 연결 수입니다. -1은 제한 없음.",
+		"help.connattr.useVirtualThreads":                "요청 처리에 가상 스레드를 사용합니다 (Tomcat 11+).",
+		"help.connattr.threadPriority":                   "요청 처리 스레드의 우선순위 (1-10).",
+		"help.connattr.acceptorThreadPriority":           "acceptor 스레드의 우선순위 (1-10).",
+		"help.connattr.processorCache":                   "재사용을 위해 보관하는 유휴 프로세서 수입니다. -1은 무제한.",
+		"help.connattr.executorTerminationTimeoutMillis": "내부 executor 스레드 종료를 기다리는 시간 (ms).",
+		"help.connattr.connectionTimeout":                "연결 수락 후 요청 라인을 기다리는 시간 (ms).",
+		"help.connattr.keepAliveTimeout":                 "keep-alive 연결에서 다음 요청을 기다리는 시간 (ms).",
+		"help.connattr.asyncTimeout":                     "비동기 요청의 기본 타임아웃 (ms).",
+		"help.connattr.disableUploadTimeout":             "요청 본문을 읽는 동안에도 일반 connectionTimeout을 유지합니다.",
+		"help.connattr.connectionUploadTimeout":          "disableUploadTimeout이 false일 때 업로드 중 사용하는 타임아웃 (ms).",
+		"help.connattr.selectorTimeout":                  "Poller select() 타임아웃 (ms).",
+		"help.connattr.maxKeepAliveRequests":             "keep-alive 연결당 처리할 요청 수. -1은 무제한, 1은 keep-alive 비활성화.",
+		"help.connattr.maxHttpHeaderSize":                "요청 및 응답 헤더의 최대 크기 (바이트).",
+		"help.connattr.maxPostSize":                      "파라미터로 파싱하는 POST 최대 크기 (바이트). -1은 제한 없음.",
+		"help.connattr.maxSavePostSize":                  "FORM 또는 CLIENT-CERT 인증 중 저장하는 POST 최대 크기.",
+		"help.connattr.maxParameterCount":                "요청 파라미터의 최대 개수입니다. -1은 제한 없음.",
+		"help.connattr.maxCookieCount":                   "요청당 최대 쿠키 수입니다. -1은 제한 없음.",
+		"help.connattr.maxSwallowSize":                   "중단된 업로드에서 읽어 버리는 요청 본문의 최대 바이트 수.",
+		"help.connattr.maxTrailerSize":                   "chunked trailer 헤더의 최대 크기 (바이트).",
+		"help.connattr.maxExtensionSize":                 "chunk 확장의 최대 크기 (바이트).",
+		"help.connattr.URIEncoding":                      "URI 디코딩에 사용하는 문자 인코딩입니다.",
+		"help.connattr.useBodyEncodingForURI":            "쿼리 문자열을 요청 본문 인코딩으로 디코딩합니다.",
+		"help.connattr.enableLookups":                    "request.getRemoteHost()를 위해 DNS 조회를 수행합니다. 요청이 느려집니다.",
+		"help.connattr.allowTrace":                       "HTTP TRACE 메서드를 허용합니다.",
+		"help.connattr.parseBodyMethods":                 "본문을 폼 파라미터로 파싱할 HTTP 메서드입니다.",
+		"help.connattr.relaxedPathChars":                 "URI 경로에서 인코딩 없이 허용할 문자 (예: [] |).",
+		"help.connattr.relaxedQueryChars":                "쿼리 문자열에서 인코딩 없이 허용할 문자입니다.",
+		"help.connattr.encodedSolidusHandling":           "경로의 %2F 처리 방식: reject, decode 또는 passthrough.",
+		"help.connattr.rejectIllegalHeader":              "잘못된 헤더 이름이나 값이 있는 요청을 거부합니다.",
+		"help.connattr.allowHostHeaderMismatch":          "요청 라인의 호스트와 다른 Host 헤더를 허용합니다.",
+		"help.connattr.restrictedUserAgents":             "keep-alive를 사용하지 않을 user agent 정규식입니다.",
+		"help.connattr.useIPVHosts":                      "요청이 도착한 IP 주소로 가상 호스트를 선택합니다.",
+		"help.connattr.discardFacades":                   "각 요청 후 요청/응답 facade를 폐기합니다.",
+		"help.connattr.proxyName":                        "프록시 뒤에서 실행할 때 보고할 서버 이름입니다.",
+		"help.connattr.proxyPort":                        "프록시 뒤에서 실행할 때 보고할 서버 포트입니다.",
+		"help.connattr.server":                           "Server 응답 헤더를 재정의합니다.",
+		"help.connattr.serverRemoveAppProvidedValues":    "애플리케이션이 설정한 Server 헤더를 제거합니다.",
+		"help.connattr.xpoweredBy":                       "X-Powered-By 응답 헤더를 전송합니다.",
+		"help.connattr.compression":                      "GZIP 압축: off, on, force 또는 최소 크기(바이트).",
+		"help.connattr.compressionMinSize":               "압축을 적용할 최소 응답 크기 (바이트).",
+		"help.connattr.compressibleMimeType":             "압축 대상 MIME 타입 (쉼표로 구분).",
+		"help.connattr.noCompressionUserAgents":          "압축 응답을 받지 않을 user agent 정규식입니다.",
+		"help.connattr.noCompressionStrongETag":          "strong ETag가 있는 리소스는 압축하지 않습니다.",
+		"help.connattr.useSendfile":                      "가능하면 정적 콘텐츠에 sendfile을 사용합니다.",
+		"help.connattr.secret":                           "AJP 클라이언트에 요구하는 공유 시크릿입니다.",
+		"help.connattr.secretRequired":                   "시크릿이 설정되지 않으면 시작을 거부합니다.",
+		"help.connattr.allowedRequestAttributesPattern":  "허용할 추가 AJP 요청 속성의 정규식입니다.",
+		"help.connattr.packetSize":                       "AJP 패킷 최대 크기 (8192-65536 바이트). 프록시와 일치해야 합니다.",
+		"help.connattr.ajpFlush":                         "응답이 flush될 때 flush 패킷을 전송합니다.",
+		"help.connattr.tomcatAuthentication":             "Tomcat이 인증합니다. false면 프록시 사용자를 신뢰합니다.",
+		"help.connattr.tomcatAuthorization":              "프록시에서 인증된 사용자를 Realm으로 인가합니다.",
+		"help.connattr.socket.rxBufSize":                 "소켓 수신 버퍼 크기 (바이트).",
+		"help.connattr.socket.txBufSize":                 "소켓 송신 버퍼 크기 (바이트).",
+		"help.connattr.socket.appReadBufSize":            "애플리케이션 읽기 버퍼 크기 (바이트).",
+		"help.connattr.socket.appWriteBufSize":           "애플리케이션 쓰기 버퍼 크기 (바이트).",
+		"help.connattr.socket.directBuffer":              "direct (힙 외부) 바이트 버퍼를 사용합니다.",
+		"help.connattr.socket.tcpNoDelay":                "소켓에 TCP_NODELAY를 설정합니다.",
+		"help.connattr.socket.soKeepAlive":               "소켓에 SO_KEEPALIVE를 설정합니다.",
+		"help.connattr.socket.soReuseAddress":            "소켓에 SO_REUSEADDR를 설정합니다.",
+		"help.connattr.socket.soLingerOn":                "소켓에 SO_LINGER를 활성화합니다.",
+		"help.connattr.socket.soLingerTime":              "SO_LINGER 시간 (초).",
+		"help.connattr.socket.soTimeout":                 "SO_TIMEOUT (ms).",
+		"help.connattr.socket.bufferPool":                "재사용을 위해 캐시하는 NIO 버퍼 수입니다. -1은 무제한.",
+		"help.connattr.socket.processorCache":            "재사용을 위해 캐시하는 소켓 프로세서 수입니다. -1은 무제한.",
+
+		// Port conflict detection
+		"ports.conflict.duplicate": "포트 %d는 %s에서도 사용 중입니다",
+		"ports.conflict.bound":     "포트 %d는 이미 %s에 바인딩되어 있습니다",
+		"ports.conflict.instance":  "포트 %d는 %s에도 설정되어 있습니다",
+
+		// Capacity planner
+		"qt.capacity":                       "용량 계획",
+		"qt.capacity.desc":                  "예상 부하로 스레드, 연결, DB 풀 크기를 산정",
+		"capacity.rps":                      "초당 요청 수",
+		"capacity.latency":                  "평균 응답 시간 (ms)",
+		"capacity.cpus":                     "CPU 코어 수",
+		"capacity.memory":                   "Tomcat 메모리 (MB)",
+		"capacity.dbtime":                   "DB 연결 점유 시간 (%)",
+		"capacity.reasoning":                "산정 근거",
+		"capacity.changes":                  "변경 예정 사항",
+		"capacity.recommended":              "권장 값",
+		"capacity.why":                      "이유",
+		"capacity.nocontext":                "conf/context.xml이 없습니다 - DataSource 풀은 변경하지 않습니다",
+		"capacity.nochanges":                "변경할 사항이 없습니다",
+		"capacity.applied":                  "용량 계획이 적용되었습니다 (%d개 변경)",
+		"capacity.reason.concurrency":       "리틀의 법칙: %.0f req/s × %.0f ms = 평균 %.1f개 요청이 동시에 처리됩니다.",
+		"capacity.reason.maxthreads":        "maxThreads: %.1f × 여유율 %.1f = %d 스레드.",
+		"capacity.reason.maxthreads.min":    "짧은 버스트가 대기하지 않도록 최소 %d 스레드로 올렸습니다.",
+		"capacity.reason.maxthreads.memory": "메모리 제한: %d MB로는 메모리의 절반을 사용해 스레드당 2 MB 기준 약 %d 스레드까지 가능합니다.",
+		"capacity.reason.minspare":          "minSpareThreads = %d로 평균 부하에 필요한 스레드를 미리 유지합니다.",
+		"capacity.reason.maxconnections":    "NIO 커넥터는 유휴 keep-alive 연결을 스레드 없이 유지하므로 워커당 %d개 연결을 허용합니다: maxConnections = %d.",
+		"capacity.reason.acceptcount":       "acceptCount = %d는 maxConnections 도달 후 버스트를 OS에서 대기시킵니다.",
+		"capacity.reason.cpu":               "CPU 요구량 ≈ %.1f 코어 / 사용 가능 %d (DB 외 시간은 CPU로 간주).",
+		"capacity.reason.pool":              "DB 풀: 요청 시간의 %d%%가 연결을 점유하므로 평균 %.1f개 연결 사용, 여유를 두어 maxTotal = %d.",
+		"capacity.reason.pool.cap":          "풀을 maxThreads(%d)로 제한: 워커 스레드만 연결을 빌릴 수 있습니다.",
+		"capacity.reason.poolidle":          "minIdle = %d는 평균 DB 부하를, maxIdle = %d는 연결 재생성 없이 버스트를 흡수합니다.",
+		"capacity.reason.poolwait":          "maxWaitMillis = %d (평균 응답 시간의 4배, 1-30초)로 풀 고갈 시 빠르게 실패합니다.",
+		"capacity.warn.memory":              "부하에는 %d 스레드가 필요하지만 메모리로는 %d까지만 가능합니다. 메모리나 인스턴스를 늘리세요.",
+		"capacity.warn.cpu":                 "예상 CPU 요구량(%.1f 코어)이 CPU %d개를 초과합니다. 이 부하에서 응답 시간이 늘어납니다.",
+
+		"connector.wiring":                  "Executor 연결",
+		"connector.wiring.desc":             "어떤 커넥터가 어떤 Executor를 공유하는지 확인하고 변경",
+		"connector.wiring.title":            "Executor 연결",
+		"connector.wiring.usedby":           "사용 중",
+		"connector.wiring.unused":           "사용하는 커넥터 없음",
+		"connector.wiring.internal":         "내부 풀",
+		"connector.wiring.missing":          "존재하지 않는 Executor '%s' 참조",
+		"connector.wiring.connector":        "커넥터",
+		"connector.wiring.useexecutor":      "Executor '%s' 사용",
+		"connector.wiring.useinternal":      "내부 풀 사용",
+		"connector.wiring.useinternal.desc": "Executor에서 분리하고 스레드 한도를 커넥터에 유지",
+		"connector.wiring.convert":          "Executor로 변환",
+		"connector.wiring.convert.desc":     "인라인 maxThreads/minSpareThreads를 새 공유 Executor로 이동",
+		"connector.wiring.assigned":         "커넥터 %d가 Executor '%s'를 사용합니다",
+		"connector.wiring.detached":         "커넥터 %d가 내부 풀을 사용합니다",
+		"connector.wiring.converted":        "커넥터 %d를 Executor '%s'로 변환했습니다",
+		"connector.executor.inuse":          "Executor '%s'는 포트 %s의 커넥터가 아직 사용 중입니다. 먼저 Executor 연결에서 이동하세요.",
+		"connector.executor.exists":         "'%s' 이름의 Executor가 이미 있습니다",
+		"help.connector.wiring":             "[yellow::b]Executor 연결[-::-]\n\n각 공유 [green]<Executor>[-]와 이를 참조하는 커넥터, 그리고 자체 내부 스레드 풀을 사용하는 커넥터를 보여줍니다.\n\n[yellow]커넥터 작업:[-]\n• [green]Executor 사용[-] - 기존 풀 공유. Tomcat이 무시하므로 인라인 maxThreads/minSpareThreads는 제거됩니다\n• [green]내부 풀 사용[-] - 분리. Executor 한도가 인라인으로 복사됩니다\n• [green]Executor로 변환[-] - 인라인 풀을 새 공유 Executor로 변환\n\n[red]참고:[-] 커넥터가 참조하는 Executor는 삭제할 수 없습니다.",
+
+		"instance.new":          "새 인스턴스",
+		"instance.new.desc":     "기존 CATALINA_HOME으로 새 CATALINA_BASE 생성",
+		"instance.new.title":    "새 인스턴스",
+		"instance.new.base":     "새 CATALINA_BASE",
+		"instance.new.offset":   "포트 오프셋 (비우면 자동)",
+		"instance.new.create":   "생성",
+		"instance.new.creating": "인스턴스 생성 및 포트 확인 중...",
+		"instance.new.created":  "인스턴스가 생성되었습니다",
+		"instance.new.summary":  "%s 생성됨\n\nShutdown: %d\nHTTP: %d\nHTTPS: %d\nAJP: %d",
+		"instance.new.help":     "[yellow]새 CATALINA_BASE[-]\n\nbin, conf, lib, logs, temp, webapps, work를 만들고 CATALINA_HOME의 conf를 복사한 뒤 bin/setenv.sh를 작성합니다.\nshutdown, HTTP, HTTPS, AJP 포트는 이 머신 및 다른 인스턴스와 충돌하지 않을 때까지 100 단위로 이동합니다.\n디렉터리는 없거나 비어 있어야 합니다.",
+
+		"instance.clone":               "인스턴스 복제",
+		"instance.clone.desc":          "현재 인스턴스 설정을 다른 CATALINA_BASE로 복사",
+		"instance.clone.title":         "인스턴스 복제",
+		"instance.clone.source":        "원본 CATALINA_BASE",
+		"instance.clone.target":        "대상 CATALINA_BASE",
+		"instance.clone.preview":       "미리보기",
+		"instance.clone.planning":      "설정을 읽고 포트를 확인하는 중...",
+		"instance.clone.summary":       "복제 요약",
+		"instance.clone.offset":        "포트 오프셋",
+		"instance.clone.files":         "파일",
+		"instance.clone.overwrite":     "덮어쓰기, 백업됨",
+		"instance.clone.substitutions": "치환 내역",
+		"instance.clone.none":          "없음",
+		"instance.clone.done":          "%d개 파일을 %s로 복제했습니다",
+		"instance.clone.help":          "[yellow]인스턴스 복제[-]\n\nserver.xml, web.xml, context.xml, tomcat-users.xml, logging.properties, catalina.properties, setenv 스크립트와 컨텍스트 디스크립터를 복사합니다.\n모든 포트는 오프셋만큼 이동하고(비우면 100 단위 중 첫 빈 범위), 원본 CATALINA_BASE를 가리키는 절대 경로는 대상 경로로 바뀝니다.\n쓰기 전에 모든 치환 내역을 확인할 수 있습니다.",
+
+		"menu.jvm":              "JVM 옵션",
+		"menu.jvm.desc":         "setenv.sh/.bat 힙, GC, JMX, 시스템 속성",
+		"jvm.title":             "JVM 옵션",
+		"jvm.script":            "스크립트",
+		"jvm.script.desc":       "bin/setenv.sh와 bin/setenv.bat 간 전환",
+		"jvm.script.new":        "새로 생성됨",
+		"jvm.general":           "힙, GC 및 경로",
+		"jvm.xx":                "-XX 플래그",
+		"jvm.xx.add":            "플래그 추가",
+		"jvm.xx.add.desc":       "-XX 옵션 추가",
+		"jvm.xx.required":       "플래그를 입력하세요",
+		"jvm.props":             "시스템 속성",
+		"jvm.props.add":         "속성 추가",
+		"jvm.props.add.desc":    "-D 시스템 속성 추가",
+		"jvm.props.name":        "이름",
+		"jvm.props.value":       "값",
+		"jvm.props.required":    "속성 이름을 입력하세요",
+		"jvm.jmx":               "JMX 원격",
+		"jvm.jmx.enable":        "JMX 원격 사용",
+		"jvm.jmx.enabled":       "포트 %s에서 사용",
+		"jvm.jmx.disabled":      "사용 안 함",
+		"jvm.jmx.port":          "포트",
+		"jvm.jmx.rmiport":       "RMI 포트",
+		"jvm.jmx.authenticate":  "인증",
+		"jvm.jmx.ssl":           "SSL",
+		"jvm.jmx.hostname":      "RMI 호스트명",
+		"jvm.jmx.portrequired":  "JMX 원격을 사용하려면 포트가 필요합니다",
+		"jvm.preserved":         "보존된 내용",
+		"jvm.preserved.count":   "옵션 %d개, 줄 %d개를 그대로 유지",
+		"jvm.preserved.options": "%s의 기타 옵션",
+		"jvm.preserved.lines":   "기타 스크립트 줄",
+		"jvm.preserved.none":    "없음",
+		"jvm.preview":           "미리보기",
+		"jvm.preview.desc":      "저장될 스크립트 보기",
+		"jvm.save.desc":         "setenv 스크립트 저장",
+		"jvm.saved":             "%s 저장됨",
+		"jvm.updated":           "JVM 옵션이 변경되었습니다 (아직 저장되지 않음)",
+		"jvm.returnmenu":        "JVM 옵션으로 돌아가기",
+		"jvm.count":             "%d개 설정됨",
+		"jvm.notset":            "설정 안 됨",
+		"jvm.gc":                "가비지 컬렉터",
+		"jvm.gc.default":        "JVM 기본값",
+		"jvm.gc.cms":            "ConcMarkSweep (Java 13 이하)",
+		"jvm.heap.min":          "초기 힙 (-Xms)",
+		"jvm.heap.max":          "최대 힙 (-Xmx)",
+		"jvm.heap.invalid":      "잘못된 메모리 크기 '%s' (예: 512m, 2g)",
+		"help.jvm.script":       "[yellow::b]setenv 스크립트[-::-]\n\ncatalina.sh는 CATALINA_BASE(또는 CATALINA_HOME)의 [green]bin/setenv.sh[-]를, catalina.bat는 [green]bin/setenv.bat[-]를 읽습니다.\n\n옵션은 Tomcat 시작 시에만 사용되고 종료 시에는 사용되지 않는 [green]CATALINA_OPTS[-]에 기록됩니다. JAVA_OPTS만 사용하는 기존 스크립트는 그대로 JAVA_OPTS를 사용합니다.\n\n단순 대입이 아닌 줄(조건문, 함수, 다른 변수)은 그대로 유지됩니다.",
+		"help.jvm.general":      "[yellow::b]힙, GC 및 경로[-::-]\n\n[green]JAVA_HOME[-] - Tomcat 실행에 사용할 JDK\n[green]CATALINA_PID[-] - 프로세스 ID 파일. 'catalina.sh stop -force'에 필요\n[green]-Xms / -Xmx[-] - 초기 및 최대 힙. 같은 값으로 설정하면 크기 조정으로 인한 멈춤을 피할 수 있습니다\n[green]GC[-] - Java 9부터 G1이 기본값. ZGC와 Shenandoah는 큰 힙에서 짧은 멈춤 시간을 목표로 합니다",
+		"help.jvm.xx":           "[yellow::b]-XX 플래그[-::-]\n\n고급 HotSpot 옵션이며 -XX: 접두사 없이 입력합니다.\n\n[yellow]예:[-]\n• MaxMetaspaceSize=256m\n• +HeapDumpOnOutOfMemoryError\n• HeapDumpPath=/var/log/tomcat\n• +ExitOnOutOfMemoryError\n\n컬렉터는 힙, GC 및 경로에서 선택합니다.",
+		"help.jvm.props":        "[yellow::b]시스템 속성[-::-]\n\nSystem.getProperty()와 Tomcat 설정 파일의 ${name}으로 사용할 수 있는 -Dname=value 옵션입니다.\n\n[yellow]자주 쓰는 속성:[-]\n• file.encoding=UTF-8\n• user.timezone=UTC\n• java.security.egd=file:/dev/./urandom",
+		"help.jvm.jmx":          "[yellow::b]JMX 원격[-::-]\n\nJConsole, VisualVM 및 모니터링 에이전트에 MBean을 노출합니다.\n\n[green]RMI 포트[-] - 방화벽 통과를 위해 포트와 같은 값으로 고정하세요\n[green]RMI 호스트명[-] - 클라이언트가 다시 접속할 주소\n\n[red]경고:[-] authenticate=false, ssl=false이면 포트에 접근 가능한 누구나 JVM을 제어할 수 있습니다.",
+		"help.jvm.preserved":    "[yellow::b]보존된 내용[-::-]\n\nTomcatKit이 다루지 않는 옵션(-javaagent, -ea 등)은 관리되는 옵션 뒤에 그대로 다시 기록됩니다.\n\n관리되는 대입문이 아닌 스크립트 줄은 수정되지 않습니다.",
+
+		// catalina.properties
+		"menu.catprops":            "Catalina 속성",
+		"menu.catprops.desc":       "catalina.properties 클래스 로더, JAR 스캔, 사용자 속성",
+		"catprops.title":           "catalina.properties",
+		"catprops.count":           "%d개 항목",
+		"catprops.loaders":         "클래스 로더",
+		"catprops.loaders.desc":    "common: %d개, shared: %d개 항목",
+		"catprops.jarstoskip":      "스캔 제외 JAR",
+		"catprops.jarstoscan":      "스캔 포함 JAR",
+		"catprops.packageaccess":   "패키지 접근 제한",
+		"catprops.packagedef":      "패키지 정의 제한",
+		"catprops.custom":          "사용자 속성",
+		"catprops.custom.add":      "속성 추가",
+		"catprops.custom.add.desc": "${...} 참조용 속성 추가",
+		"catprops.custom.key":      "이름",
+		"catprops.custom.value":    "값",
+		"catprops.custom.required": "속성 이름은 필수입니다",
+		"catprops.custom.standard": "%s은(는) Tomcat 속성입니다. 해당 메뉴에서 편집하세요",
+		"catprops.custom.exists":   "속성 %s이(가) 이미 존재합니다",
+		"catprops.item.add":        "항목 추가",
+		"catprops.item.add.desc":   "목록에 항목 추가",
+		"catprops.item.value":      "항목",
+		"catprops.item.required":   "항목은 비어 있을 수 없습니다",
+		"catprops.item.comma":      "항목에 쉼표를 사용할 수 없습니다. 하나씩 추가하세요",
+		"catprops.preview":         "미리보기",
+		"catprops.preview.desc":    "저장될 catalina.properties 보기",
+		"catprops.save.desc":       "catalina.properties 저장 (conf/backup에 백업 유지)",
+		"catprops.saved":           "%s 저장됨 (적용하려면 Tomcat 재시작)",
+		"catprops.returnmenu":      "catalina.properties 메뉴로 돌아가기",
+		"help.catprops.loaders":    "[yellow::b]클래스 로더[-::-]\n\n[green]common.loader[-]: Tomcat과 모든 웹 애플리케이션에서 보이는 클래스.\n[green]server.loader[-]: Tomcat에서만 보이는 클래스.\n[green]shared.loader[-]: 모든 웹 애플리케이션이 공유하는 클래스.\n\n항목은 디렉터리, JAR 파일 또는 [green]*.jar[-] 패턴이며 ${catalina.base}, ${catalina.home}을 사용할 수 있습니다. server/shared 로더가 비어 있으면 common 로더를 사용합니다.",
+		"help.catprops.jarscan":    "[yellow::b]JAR 스캔[-::-]\n\n[green]jarsToSkip[-]은 TLD, 웹 프래그먼트, 어노테이션 스캔에서 제외할 JAR 이름 패턴입니다. 큰 라이브러리 JAR를 제외하면 시작 속도가 크게 빨라집니다.\n\n[green]jarsToScan[-]은 제외 패턴과 일치하더라도 스캔할 예외 목록입니다.\n\n패턴은 * 와 ? 와일드카드를 사용하며 파일 이름에만 일치합니다.",
+		"help.catprops.packages":   "[yellow::b]패키지 보안[-::-]\n\nTomcat이 SecurityManager로 실행될 때만 적용됩니다.\n\n[green]package.access[-]: 웹 애플리케이션이 접근할 수 없는 패키지.\n[green]package.definition[-]: 웹 애플리케이션이 클래스를 정의할 수 없는 패키지.\n\n항목은 점으로 끝나는 패키지 접두사입니다.",
+		"help.catprops.custom":     "[yellow::b]사용자 속성[-::-]\n\ncatalina.properties의 속성은 시작 시 시스템 속성이 되므로 server.xml과 컨텍스트 파일에서 [green]${name}[-]으로 참조할 수 있습니다.\n\n인스턴스마다 다른 포트, 호스트 이름, 경로를 두기에 편리한 곳입니다.",
+
+		// Placeholders
+		"placeholder.resolved":   "해석된 값",
+		"placeholder.unresolved": "해석 불가: %s",
+
+		// systemd
+		"menu.systemd":            "systemd 서비스",
+		"menu.systemd.desc":       "이 인스턴스용 systemd 유닛 파일 생성",
+		"systemd.title":           "systemd 유닛",
+		"systemd.pidfile":         "PID 파일",
+		"systemd.hardening":       "보안 강화",
+		"systemd.template":        "템플릿 유닛 (tomcat@.service)",
+		"systemd.basesdir":        "인스턴스 디렉터리",
+		"systemd.output":          "유닛 파일",
+		"systemd.output.required": "유닛 파일 경로를 입력하세요",
+		"systemd.saved":           "유닛을 %s에 저장했습니다. 'systemctl daemon-reload'로 불러오세요.",
+		"help.systemd":            "[yellow::b]systemd 유닛[-::-]\n\n[green]bin/startup.sh[-]로 Tomcat을 시작하는 forking 서비스 유닛을 생성합니다. 수동 시작과 같이 setenv.sh를 읽습니다.\n\n[green]/etc/systemd/system[-]에 설치한 뒤 실행하세요:\n  systemctl daemon-reload\n  systemctl enable --now <unit>",
+		"help.systemd.user":       "[yellow::b]User / Group[-::-]\n\n서비스를 실행할 계정입니다. Tomcat은 root로 실행하지 않아야 하며, 계정은 CATALINA_HOME 읽기 권한과 CATALINA_BASE의 logs, work, temp, webapps 쓰기 권한이 필요합니다.",
+		"help.systemd.javahome":   "[yellow::b]JAVA_HOME[-::-]\n\nTomcat을 실행할 JDK입니다. setenv.sh 또는 환경 변수에서 가져옵니다. setenv.sh의 JAVA_HOME이 유닛보다 우선합니다.",
+		"help.systemd.pidfile":    "[yellow::b]PID 파일[-::-]\n\n[green]CATALINA_PID[-]로 전달되며 systemd가 fork된 JVM을 추적하는 데 사용합니다. 비워 두면 [green]CATALINA_BASE/temp/tomcat.pid[-]를 사용합니다.\n\nsetenv.sh의 CATALINA_PID가 유닛보다 우선하므로 있으면 그 값을 가져옵니다.",
+		"help.systemd.nofile":     "[yellow::b]LimitNOFILE[-::-]\n\n최대 열린 파일 수입니다. 연결과 열린 JAR마다 디스크립터를 사용하므로 바쁜 인스턴스는 일반 기본값 1024보다 많이 필요합니다.\n\n0 또는 비워 두면 systemd 기본값을 유지합니다.",
+		"help.systemd.hardening":  "[yellow::b]보안 강화[-::-]\n\n[green]NoNewPrivileges[-], [green]PrivateTmp[-], [green]ProtectSystem=strict[-], [green]ProtectHome[-]과 커널 보호 설정을 추가합니다.\n\n[green]ReadWritePaths[-](CATALINA_BASE의 logs, work, temp, webapps, conf/Catalina)를 제외한 파일 시스템은 읽기 전용이 됩니다.",
+		"help.systemd.template":   "[yellow::b]템플릿 유닛[-::-]\n\n인스턴스 이름이 인스턴스 디렉터리 아래 디렉터리를 가리키는 [green]tomcat@.service[-]를 생성합니다:\n\n  systemctl start tomcat@app1\n\n은 CATALINA_BASE=<인스턴스 디렉터리>/app1을 사용합니다.",
+		"help.systemd.output":     "[yellow::b]유닛 파일[-::-]\n\n유닛을 저장할 위치입니다. 디렉터리를 지정하면 유닛 이름을 사용합니다. 기존 파일은 .bak으로 보관합니다.\n\n/etc/systemd/system에 쓰려면 root 권한이 필요합니다.",
+
+		// Start / Stop
+		"menu.lifecycle":         "시작 / 중지",
+		"menu.lifecycle.desc":    "이 인스턴스를 시작, 중지, 재시작하고 출력 확인",
+		"lifecycle.title":        "시작 / 중지",
+		"lifecycle.log":          "출력",
+		"lifecycle.start":        "시작",
+		"lifecycle.start.desc":   "catalina.sh start 후 catalina.out에 \"Server startup in\"이 나올 때까지 대기",
+		"lifecycle.run":          "포그라운드 실행",
+		"lifecycle.run.desc":     "TomcatKit의 자식 프로세스로 catalina.sh run 실행, 콘솔 출력을 여기에 표시",
+		"lifecycle.stop":         "중지",
+		"lifecycle.stop.desc":    "shutdown 포트로 종료 명령 전송 (비활성화 시 catalina.sh stop)",
+		"lifecycle.restart":      "재시작",
+		"lifecycle.restart.desc": "중지 후 다시 시작하여 저장한 변경 사항 적용",
+		"lifecycle.refresh":      "상태 새로고침",
+		"lifecycle.refresh.desc": "Tomcat 프로세스를 다시 확인",
+		"lifecycle.clear":        "출력 지우기",
+		"lifecycle.clear.desc":   "출력 창 비우기",
+		"lifecycle.starting":     "Tomcat 시작 중...",
+		"lifecycle.stopping":     "Tomcat 중지 중...",
+		"lifecycle.restarting":   "Tomcat 재시작 중...",
+		"lifecycle.started":      "Tomcat이 시작되었습니다",
+		"lifecycle.stopped":      "Tomcat이 중지되었습니다",
+
+		// Apply safely
+		"lifecycle.apply":              "안전하게 적용",
+		"lifecycle.apply.desc":         "변경된 파일 %d개로 재시작, 상태 확인, 실패 시 롤백",
+		"lifecycle.apply.title":        "변경 사항 안전하게 적용",
+		"lifecycle.apply.urls":         "상태 확인 URL",
+		"lifecycle.apply.timeout":      "제한 시간(초)",
+		"lifecycle.apply.changes":      "인스턴스를 연 이후 변경된 파일",
+		"lifecycle.apply.nochanges":    "변경 사항 없음",
+		"lifecycle.apply.novalve":      "server.xml에 HealthCheckValve 없음",
+		"lifecycle.applying":           "변경 사항 적용 중...",
+		"lifecycle.applied":            "변경 사항이 적용되고 확인되었습니다",
+		"help.lifecycle.apply":         "[yellow::b]안전하게 적용[-::-]\n\n저장된 변경 사항으로 Tomcat을 재시작합니다. 제한 시간 안에 시작 메시지가 나오지 않거나 상태 확인 URL이 응답하지 않으면 이전 설정을 복원하고 Tomcat을 다시 시작합니다.\n\n이전 파일은 conf/backup/apply-<시간>에 보관되고 모든 단계는 logs/tomcatkit-apply-<시간>.log에 기록됩니다.",
+		"help.lifecycle.apply.urls":    "[yellow::b]상태 확인 URL[-::-]\n\n재시작 후 2xx 상태로 응답해야 하는 URL입니다. 공백으로 구분합니다.\n\n예: http://localhost:8080/app/health\n\nserver.xml의 HealthCheckValve 엔드포인트도 함께 확인합니다.",
+		"help.lifecycle.apply.timeout": "[yellow::b]제한 시간[-::-]\n\n변경 사항을 롤백하기 전까지 Tomcat이 시작하고 상태 확인을 통과할 수 있는 시간(초)입니다.",
+
+		// Instance scan
+		"instance.scan":       "인스턴스 검색",
+		"instance.scan.desc":  "디렉토리에서 Tomcat 설치와 CATALINA_BASE 검색",
+		"instance.scanned":    "검색으로 찾음",
+		"scan.title":          "인스턴스 검색",
+		"scan.roots":          "검색 루트",
+		"scan.roots.required": "검색 루트가 하나 이상 필요합니다",
+		"scan.depth":          "깊이",
+		"scan.start":          "검색",
+		"scan.running":        "검색 중... (Esc로 취소)",
+		"scan.progress.title": "진행 상황",
+		"scan.progress":       "디렉토리 %d개 검색, %d개 발견",
+		"scan.done":           "검색 완료: 인스턴스 %d개 발견",
+		"scan.cancelled":      "검색이 취소되었습니다",
+		"scan.results":        "검색 결과 (%d)",
+		"scan.none.desc":      "검색 루트를 추가하거나 깊이를 늘려 보세요",
+		"help.scan.roots":     "[yellow]검색 루트[white]\n검색할 디렉토리 (공백으로 구분). 심볼릭 링크는 따라가지 않으며 /proc, /sys, /dev, /run은 건너뜁니다.",
+		"help.scan.depth":     "[yellow]깊이[white]\n각 루트 아래 검색할 디렉토리 단계 수. conf/server.xml과 lib/catalina.jar가 있으면 설치, conf/server.xml만 있으면 CATALINA_BASE로 인식합니다.",
+
+		// Instance inventory
+		"inventory.title":         "인벤토리",
+		"inventory.add":           "인벤토리에 추가",
+		"inventory.edit":          "인벤토리 항목 편집",
+		"inventory.name":          "이름",
+		"inventory.name.required": "이름이 필요합니다",
+		"inventory.environment":   "환경",
+		"inventory.env.all":       "전체",
+		"inventory.env.none":      "없음",
+		"inventory.color":         "색상",
+		"inventory.color.default": "기본",
+		"inventory.notes":         "메모",
+		"inventory.saved":         "인벤토리 항목이 저장되었습니다",
+		"inventory.removed":       "인벤토리 항목이 삭제되었습니다",
+		"inventory.banner":        "운영 환경 - 변경 사항이 실서비스 인스턴스에 적용됩니다",
+		"instance.search":         "검색",
+		"instance.search.none":    "검색과 일치하는 인스턴스가 없습니다",
+		"instance.selector.keys":  "/ 검색  f 환경  e 이름·태그",
+		"instance.missing":        "이 인스턴스의 conf/server.xml이 더 이상 존재하지 않습니다",
+		"help.inventory":          "[yellow]인벤토리[white]\n이름을 붙인 인스턴스는 인스턴스 선택 화면 맨 위에 표시되며 이름, 경로, 환경, 메모로 검색할 수 있습니다. prod 태그가 붙은 인스턴스를 편집하는 동안에는 빨간 경고 배너가 표시됩니다.",
+
+		// Fleet
+		"menu.fleet":            "플릿",
+		"menu.fleet.desc":       "알려진 모든 인스턴스를 한눈에 보고 여러 인스턴스에 같은 변경 적용",
+		"fleet.title":           "플릿",
+		"fleet.keys":            "Space 선택  a 전체  Enter 변경  c 두 개 비교  r 새로고침",
+		"fleet.shutdown":        "Shutdown",
+		"fleet.ports":           "포트",
+		"fleet.connectors":      "커넥터",
+		"fleet.tls":             "TLS 만료",
+		"fleet.tls.keystore":    "키스토어",
+		"fleet.tls.expired":     "만료됨",
+		"fleet.operations":      "인스턴스 %d개 변경",
+		"fleet.op.logger":       "로거 레벨",
+		"fleet.op.logger.desc":  "logging.properties의 로거 레벨 설정",
+		"fleet.op.valve":        "Engine 밸브",
+		"fleet.op.valve.desc":   "Engine에 밸브 추가 (같은 클래스의 밸브는 교체)",
+		"fleet.logger":          "로거",
+		"fleet.level":           "레벨",
+		"fleet.valve":           "밸브",
+		"fleet.valve.allow":     "허용 (RemoteAddrValve)",
+		"fleet.invalid":         "잘못된 입력",
+		"fleet.preview":         "미리보기",
+		"fleet.planning":        "변경 사항 준비 중...",
+		"fleet.plan":            "예정된 변경",
+		"fleet.plan.summary":    "[::b]%s[::-]: 변경 %d, 변경 없음 %d, 실패 %d",
+		"fleet.plan.production": "운영 인스턴스 %d개가 포함되어 있습니다",
+		"fleet.unchanged":       "변경 없음",
+		"fleet.report":          "결과",
+		"fleet.report.summary":  "%s: 적용 %d, 실패 %d",
+		"fleet.report.restart":  "변경 사항을 반영하려면 인스턴스를 재시작하세요. 백업은 conf/backup에 있습니다.",
+
+		// Compare
+		"fleet.compare.two":       "비교할 인스턴스 두 개를 Space로 선택하세요",
+		"compare.title":           "비교",
+		"compare.keys":            "> A→B 복사  < B→A 복사  s 저장  r 다시 읽기",
+		"compare.setting":         "설정",
+		"compare.notset":          "(설정 안 됨)",
+		"compare.absent":          "(없음)",
+		"compare.none":            "차이 없음: 두 인스턴스의 설정이 같습니다",
+		"compare.skipped":         "%s 비교 안 함, %c: %v",
+		"compare.modified":        "저장 안 됨: %s",
+		"compare.copied":          "%s을(를) %c에 복사했습니다 (아직 저장 안 됨, s를 누르세요)",
+		"compare.saved":           "복사한 설정을 저장했습니다. 적용하려면 인스턴스를 재시작하세요.",
+		"compare.save.confirm":    "복사한 설정을 저장하시겠습니까? 파일은 먼저 백업됩니다.",
+		"compare.save.production": "운영 인스턴스가 변경됩니다.",
+		"compare.discard":         "저장하지 않은 복사한 설정을 버리시겠습니까?",
+
 		"help.default": `[gray]도움말 정보를 보려면 필드를 선택하세요.[-]`,
 	},
 
@@ -7606,6 +8638,7 @@ web.xml 보안 제약 조건과 사용자
 		"common.error":         "エラー",
 		"common.success":       "成功",
 		"common.loading":       "読み込み中...",
+		"common.notanumber":    "%s: '%s' は数値または ${...} 式ではありません",
 		"common.return":        "メインメニューに戻る",
 		"common.enabled":       "有効",
 		"common.disabled":      "無効",
@@ -7824,35 +8857,41 @@ web.xml 보안 제약 조건과 사용자
 		"prompt.nohttp":   "HTTPコネクタが見つかりません!",
 
 		// Instance Selection
-		"instance.title":           "Tomcatインスタンスを選択",
-		"instance.recent":          "最近のインスタンス",
-		"instance.detected":        "検出されたインスタンス",
-		"instance.none":            "Tomcatインストールが見つかりません",
-		"instance.manual":          "パスを手動入力",
-		"instance.manual.desc":     "CATALINA_HOMEパスを指定",
-		"instance.running":         "実行中",
-		"instance.noselected":      "Tomcatインスタンスが選択されていません",
-		"instance.pressT":          "'t'を押してTomcatインスタンスを選択してください",
-		"instance.path.title":      "Tomcatパスを入力",
-		"instance.path.home":       "CATALINA_HOME",
-		"instance.path.base":       "CATALINA_BASE (オプション)",
-		"instance.path.validate":   "検証して選択",
-		"instance.path.required":   "CATALINA_HOMEは必須です",
-		"instance.path.invalid":    "無効なパス: server.xmlが見つかりません",
-		"instance.selected":        "Tomcatインスタンスが選択されました",
-		"instance.info":            "Tomcatインスタンス",
-		"instance.version":         "バージョン",
-		"instance.status":          "ステータス",
-		"instance.stopped":         "停止中",
-		"instance.ready":           "設定準備完了",
-		"instance.path.help.home":  "CATALINA_HOME: Tomcatインストールディレクトリ (bin, lib, confを含む)",
-		"instance.path.help.base":  "CATALINA_BASE: インスタンスディレクトリ (オプション、デフォルトはCATALINA_HOME)",
-		"instance.path.help.xml":   "パスにはconf/server.xmlが含まれている必要があります",
-		"instance.info.noselected": "Tomcatインスタンスが選択されていません",
-		"instance.info.getstarted": "開始するには:",
-		"instance.info.step1":      "'t'を押してTomcatインスタンスを選択してください",
-		"instance.info.step2":      "または実行: tomcatkit -home /path/to/tomcat",
-		"instance.info.autodetect": "TomcatKitはインストールされたTomcatインスタンスを自動検出します。",
+		"instance.title":               "Tomcatインスタンスを選択",
+		"instance.recent":              "最近のインスタンス",
+		"instance.detected":            "検出されたインスタンス",
+		"instance.none":                "Tomcatインストールが見つかりません",
+		"instance.manual":              "パスを手動入力",
+		"instance.manual.desc":         "CATALINA_HOMEパスを指定",
+		"instance.running":             "実行中",
+		"instance.noselected":          "Tomcatインスタンスが選択されていません",
+		"instance.pressT":              "'t'を押してTomcatインスタンスを選択してください",
+		"instance.path.title":          "Tomcatパスを入力",
+		"instance.path.home":           "CATALINA_HOME",
+		"instance.path.base":           "CATALINA_BASE (オプション)",
+		"instance.path.validate":       "検証して選択",
+		"instance.path.required":       "CATALINA_HOMEは必須です",
+		"instance.path.invalid":        "無効なパス: server.xmlが見つかりません",
+		"instance.selected":            "Tomcatインスタンスが選択されました",
+		"instance.info":                "Tomcatインスタンス",
+		"instance.version":             "バージョン",
+		"instance.status":              "ステータス",
+		"instance.stopped":             "停止中",
+		"instance.user":                "ユーザー",
+		"instance.started":             "起動時刻",
+		"instance.layout":              "レイアウト",
+		"instance.container":           "コンテナ",
+		"instance.container.base":      "コンテナ内の CATALINA_BASE",
+		"instance.container.unmounted": "バインドマウントではありません: コンテナを再作成すると変更は失われます",
+		"instance.ready":               "設定準備完了",
+		"instance.path.help.home":      "CATALINA_HOME: Tomcatインストールディレクトリ (bin, lib, confを含む)",
+		"instance.path.help.base":      "CATALINA_BASE: インスタンスディレクトリ (オプション、デフォルトはCATALINA_HOME)",
+		"instance.path.help.xml":       "パスにはconf/server.xmlが含まれている必要があります",
+		"instance.info.noselected":     "Tomcatインスタンスが選択されていません",
+		"instance.info.getstarted":     "開始するには:",
+		"instance.info.step1":          "'t'を押してTomcatインスタンスを選択してください",
+		"instance.info.step2":          "または実行: tomcatkit -home /path/to/tomcat",
+		"instance.info.autodetect":     "TomcatKitはインストールされたTomcatインスタンスを自動検出します。",
 
 		// Server View
 		"server.title":                      "サーバー設定",
@@ -10725,6 +11764,515 @@ web.xmlセキュリティ制約と
 
 このロールの目的と権限を文書化します。`,
 
+		// HTTP/2 Upgrade Protocol
+		"connector.http2":                      "HTTP/2",
+		"connector.http2.title":                "HTTP/2 アップグレードプロトコル",
+		"connector.http2.enabled":              "HTTP/2 を有効化",
+		"connector.http2.maxconcurrentstreams": "最大同時ストリーム数",
+		"connector.http2.initialwindowsize":    "初期ウィンドウサイズ",
+		"connector.http2.readtimeout":          "読み取りタイムアウト (ms)",
+		"connector.http2.keepalivetimeout":     "Keep-Alive タイムアウト (ms)",
+		"connector.http2.overheadcountfactor":  "オーバーヘッドカウント係数",
+		"connector.http2.updated":              "HTTP/2 設定を更新しました",
+		"connector.http2.cleartext":            "TLS が無効なコネクタです: HTTP/2 は h2c アップグレードでのみ利用できます",
+		"qt.http2":                             "HTTP/2 (h2)",
+		"qt.http2.desc":                        "既存の TLS コネクタで HTTP/2 を有効化",
+		"qt.http2.notls":                       "TLS コネクタがありません。先に HTTPS コネクタを追加してください。",
+		"qt.http2.success":                     "ポート %d で HTTP/2 を有効化しました!",
+
+		"help.connector.http2": `[::b]HTTP/2 アップグレードプロトコル[::-]
+org.apache.coyote.http2.Http2Protocol を使用する
+<UpgradeProtocol> 子要素を追加します。
+
+[green]TLS コネクタ:[-] ALPN でネゴシエート (h2)
+[green]平文コネクタ:[-] HTTP/1.1 Upgrade (h2c)
+
+[yellow]注意:[-] ブラウザは TLS 上でのみ HTTP/2 を使用します。`,
+
+		"help.connector.http2.enabled": `[::b]HTTP/2 を有効化[::-]
+このコネクタに Http2Protocol UpgradeProtocol 要素を
+追加または削除します。
+
+[green]推奨:[-]
+• ブラウザ向けの HTTPS コネクタで有効化
+• Tomcat 8.5+ と ALPN サポートが必要 (Java 9+ または tcnative)`,
+
+		"help.connector.http2.maxconcurrentstreams": `[::b]最大同時ストリーム数[::-]
+接続ごとに許可されるアクティブストリームの最大数です。
+
+[yellow]デフォルト:[-] 100
+
+[green]推奨:[-]
+• 一般的な Web アプリケーションでは 100-200
+• 小さい値はクライアントごとのリソース使用を制限します`,
+
+		"help.connector.http2.initialwindowsize": `[::b]初期ウィンドウサイズ[::-]
+クライアントに通知する初期フロー制御ウィンドウ(バイト)です。
+
+[yellow]デフォルト:[-] 65535
+
+[green]ヒント:[-] 高遅延回線で大きなレスポンスの
+スループットを上げるには値を大きくします。`,
+
+		"help.connector.http2.readtimeout": `[::b]読み取りタイムアウト[::-]
+部分的なフレームを受信した後にデータを待機する
+時間(ミリ秒)です。
+
+[yellow]デフォルト:[-] 5000`,
+
+		"help.connector.http2.keepalivetimeout": `[::b]Keep-Alive タイムアウト[::-]
+アクティブなストリームがない場合にアイドルな
+HTTP/2 接続を維持する時間(ミリ秒)です。
+
+[yellow]デフォルト:[-] 20000
+[yellow]注意:[-] -1 はタイムアウトなしを意味します。`,
+
+		"help.connector.http2.overheadcountfactor": `[::b]オーバーヘッドカウント係数[::-]
+HTTP/2 オーバーヘッド保護(不正なフレームパターンの
+緩和)に使用される係数です。
+
+[yellow]デフォルト:[-] 10
+[yellow]注意:[-] 0 でオーバーヘッド保護を無効化します。`,
+
+		"help.qt.http2": `[::b]HTTP/2 テンプレート[::-]
+
+既存の TLS コネクタに以下を追加して HTTP/2 を有効化します:
+
+  <UpgradeProtocol className=
+    "org.apache.coyote.http2.Http2Protocol"/>
+
+[aqua]要件:[white]
+  • HTTPS コネクタ (SSLEnabled="true")
+  • ALPN をサポートする Tomcat 8.5+
+
+[aqua]チューニング:[white]
+  [yellow]最大同時ストリーム数[white]: 接続ごとのストリーム数
+  [yellow]初期ウィンドウサイズ[white]: フロー制御ウィンドウ
+  [yellow]タイムアウト[white]: 読み取りおよび Keep-Alive タイムアウト`,
+
+		// Connector attribute catalogue
+		"connector.attrs":                   "属性",
+		"connector.attrs.title":             "コネクタ属性",
+		"connector.attrs.advanced":          "詳細属性を表示",
+		"connector.attrs.default":           "(デフォルト)",
+		"connector.attrs.type":              "型",
+		"connector.attrs.group":             "グループ",
+		"connector.attrs.defaultvalue":      "デフォルト",
+		"connector.attrs.range":             "範囲",
+		"connector.attrs.options":           "選択肢",
+		"connector.attrs.updated":           "コネクタ属性を更新しました",
+		"connector.attrs.group.general":     "一般",
+		"connector.attrs.group.threads":     "スレッド",
+		"connector.attrs.group.timeouts":    "タイムアウト",
+		"connector.attrs.group.limits":      "制限",
+		"connector.attrs.group.request":     "リクエスト処理",
+		"connector.attrs.group.proxy":       "プロキシと識別",
+		"connector.attrs.group.compression": "圧縮と Sendfile",
+		"connector.attrs.group.ajp":         "AJP",
+		"connector.attrs.group.socket":      "ソケット",
+		"help.connector.attrs": `[yellow::b]コネクタ属性[-::-]
+
+属性カタログから生成された、TomcatKit が認識するすべてのコネクタ属性です。
+
+フィールドを空にするか [green](デフォルト)[-] を選ぶと Tomcat のデフォルト値が使われます。
+
+[green]詳細属性を表示[-] をオンにすると、ソケットや低レベルのチューニング項目も編集できます。`,
+		"help.connattr.port":                             "コネクタが待ち受ける TCP ポート。0 は空いているポートをランダムに使用します。",
+		"help.connattr.protocol":                         "プロトコルハンドラ: HTTP/1.1、AJP/1.3 またはハンドラクラスの完全名。",
+		"help.connattr.address":                          "バインドする IP アドレス。空の場合はすべてのアドレスにバインドします。",
+		"help.connattr.redirectPort":                     "セキュリティ制約で SSL が必要な場合のリダイレクト先ポート。",
+		"help.connattr.scheme":                           "request.getScheme() が返すスキーム (例: TLS プロキシ配下の https)。",
+		"help.connattr.secure":                           "request.isSecure() が返す値。",
+		"help.connattr.executor":                         "内部スレッドプールの代わりに使う共有 Executor 名。",
+		"help.connattr.bindOnInit":                       "開始時ではなく初期化時にソケットをバインドします。",
+		"help.connattr.throwOnFailure":                   "このコネクタが開始できない場合 Tomcat の起動を失敗させます。",
+		"help.connattr.maxThreads":                       "リクエスト処理スレッドの最大数。",
+		"help.connattr.minSpareThreads":                  "常に維持する最小スレッド数。",
+		"help.connattr.acceptCount":                      "全スレッドが使用中のときの受信接続キューの長さ。",
+		"help.connattr.maxConnections":                   "同時に受け付けて処理する最大接続数。-1 で無制限。",
+		"help.connattr.useVirtualThreads":                "リクエスト処理に仮想スレッドを使用します (Tomcat 11+)。",
+		"help.connattr.threadPriority":                   "リクエスト処理スレッドの優先度 (1-10)。",
+		"help.connattr.acceptorThreadPriority":           "acceptor スレッドの優先度 (1-10)。",
+		"help.connattr.processorCache":                   "再利用のために保持するアイドルプロセッサ数。-1 で無制限。",
+		"help.connattr.executorTerminationTimeoutMillis": "内部 executor スレッドの停止を待つ時間 (ms)。",
+		"help.connattr.connectionTimeout":                "接続受付後、リクエスト行を待つ時間 (ms)。",
+		"help.connattr.keepAliveTimeout":                 "keep-alive 接続で次のリクエストを待つ時間 (ms)。",
+		"help.connattr.asyncTimeout":                     "非同期リクエストのデフォルトタイムアウト (ms)。",
+		"help.connattr.disableUploadTimeout":             "リクエストボディ読み込み中も通常の connectionTimeout を使います。",
+		"help.connattr.connectionUploadTimeout":          "disableUploadTimeout が false の場合のアップロード中のタイムアウト (ms)。",
+		"help.connattr.selectorTimeout":                  "Poller の select() タイムアウト (ms)。",
+		"help.connattr.maxKeepAliveRequests":             "keep-alive 接続あたりのリクエスト数。-1 は無制限、1 は keep-alive 無効。",
+		"help.connattr.maxHttpHeaderSize":                "リクエスト/レスポンスヘッダの最大サイズ (バイト)。",
+		"help.connattr.maxPostSize":                      "パラメータとして解析する POST の最大サイズ (バイト)。-1 で無制限。",
+		"help.connattr.maxSavePostSize":                  "FORM または CLIENT-CERT 認証中に保存する POST の最大サイズ。",
+		"help.connattr.maxParameterCount":                "リクエストパラメータの最大数。-1 で無制限。",
+		"help.connattr.maxCookieCount":                   "リクエストあたりの最大 Cookie 数。-1 で無制限。",
+		"help.connattr.maxSwallowSize":                   "中断されたアップロードで読み捨てるリクエストボディの最大バイト数。",
+		"help.connattr.maxTrailerSize":                   "chunked トレーラヘッダの最大サイズ (バイト)。",
+		"help.connattr.maxExtensionSize":                 "chunk 拡張の最大サイズ (バイト)。",
+		"help.connattr.URIEncoding":                      "URI のデコードに使う文字エンコーディング。",
+		"help.connattr.useBodyEncodingForURI":            "クエリ文字列をリクエストボディのエンコーディングでデコードします。",
+		"help.connattr.enableLookups":                    "request.getRemoteHost() のために DNS 逆引きを行います。遅くなります。",
+		"help.connattr.allowTrace":                       "HTTP TRACE メソッドを許可します。",
+		"help.connattr.parseBodyMethods":                 "ボディをフォームパラメータとして解析する HTTP メソッド。",
+		"help.connattr.relaxedPathChars":                 "URI パスでエンコードなしに許可する文字 (例: [] |)。",
+		"help.connattr.relaxedQueryChars":                "クエリ文字列でエンコードなしに許可する文字。",
+		"help.connattr.encodedSolidusHandling":           "パス中の %2F の扱い: reject、decode または passthrough。",
+		"help.connattr.rejectIllegalHeader":              "不正なヘッダ名や値を含むリクエストを拒否します。",
+		"help.connattr.allowHostHeaderMismatch":          "リクエスト行のホストと異なる Host ヘッダを許可します。",
+		"help.connattr.restrictedUserAgents":             "keep-alive を使わない user agent の正規表現。",
+		"help.connattr.useIPVHosts":                      "リクエストを受けた IP アドレスで仮想ホストを選択します。",
+		"help.connattr.discardFacades":                   "リクエストごとにリクエスト/レスポンスのファサードを破棄します。",
+		"help.connattr.proxyName":                        "プロキシ配下で動作するときに報告するサーバ名。",
+		"help.connattr.proxyPort":                        "プロキシ配下で動作するときに報告するサーバポート。",
+		"help.connattr.server":                           "Server レスポンスヘッダを上書きします。",
+		"help.connattr.serverRemoveAppProvidedValues":    "アプリケーションが設定した Server ヘッダを削除します。",
+		"help.connattr.xpoweredBy":                       "X-Powered-By レスポンスヘッダを送信します。",
+		"help.connattr.compression":                      "GZIP 圧縮: off、on、force または最小サイズ (バイト)。",
+		"help.connattr.compressionMinSize":               "圧縮を行う最小レスポンスサイズ (バイト)。",
+		"help.connattr.compressibleMimeType":             "圧縮対象の MIME タイプ (カンマ区切り)。",
+		"help.connattr.noCompressionUserAgents":          "圧縮レスポンスを返さない user agent の正規表現。",
+		"help.connattr.noCompressionStrongETag":          "strong ETag を持つリソースは圧縮しません。",
+		"help.connattr.useSendfile":                      "可能な場合、静的コンテンツに sendfile を使用します。",
+		"help.connattr.secret":                           "AJP クライアントに要求する共有シークレット。",
+		"help.connattr.secretRequired":                   "シークレットが未設定の場合は起動を拒否します。",
+		"help.connattr.allowedRequestAttributesPattern":  "受け入れる追加 AJP リクエスト属性の正規表現。",
+		"help.connattr.packetSize":                       "AJP パケットの最大サイズ (8192-65536 バイト)。プロキシと一致させてください。",
+		"help.connattr.ajpFlush":                         "レスポンスの flush 時に flush パケットを送信します。",
+		"help.connattr.tomcatAuthentication":             "Tomcat で認証します。false でプロキシのユーザーを信頼します。",
+		"help.connattr.tomcatAuthorization":              "プロキシで認証されたユーザーを Realm で認可します。",
+		"help.connattr.socket.rxBufSize":                 "ソケット受信バッファサイズ (バイト)。",
+		"help.connattr.socket.txBufSize":                 "ソケット送信バッファサイズ (バイト)。",
+		"help.connattr.socket.appReadBufSize":            "アプリケーション読み込みバッファサイズ (バイト)。",
+		"help.connattr.socket.appWriteBufSize":           "アプリケーション書き込みバッファサイズ (バイト)。",
+		"help.connattr.socket.directBuffer":              "direct (ヒープ外) バイトバッファを使用します。",
+		"help.connattr.socket.tcpNoDelay":                "ソケットに TCP_NODELAY を設定します。",
+		"help.connattr.socket.soKeepAlive":               "ソケットに SO_KEEPALIVE を設定します。",
+		"help.connattr.socket.soReuseAddress":            "ソケットに SO_REUSEADDR を設定します。",
+		"help.connattr.socket.soLingerOn":                "ソケットの SO_LINGER を有効にします。",
+		"help.connattr.socket.soLingerTime":              "SO_LINGER 時間 (秒)。",
+		"help.connattr.socket.soTimeout":                 "SO_TIMEOUT (ms)。",
+		"help.connattr.socket.bufferPool":                "再利用のためにキャッシュする NIO バッファ数。-1 で無制限。",
+		"help.connattr.socket.processorCache":            "再利用のためにキャッシュするソケットプロセッサ数。-1 で無制限。",
+
+		// Port conflict detection
+		"ports.conflict.duplicate": "ポート %d は %s でも使用されています",
+		"ports.conflict.bound":     "ポート %d は既に %s がバインドしています",
+		"ports.conflict.instance":  "ポート %d は %s でも設定されています",
+
+		// Capacity planner
+		"qt.capacity":                       "キャパシティプランナー",
+		"qt.capacity.desc":                  "想定負荷からスレッド・接続・DB プールを算出",
+		"capacity.rps":                      "秒間リクエスト数",
+		"capacity.latency":                  "平均レイテンシ (ms)",
+		"capacity.cpus":                     "CPU コア数",
+		"capacity.memory":                   "Tomcat のメモリ (MB)",
+		"capacity.dbtime":                   "DB 接続を保持する時間 (%)",
+		"capacity.reasoning":                "算出根拠",
+		"capacity.changes":                  "予定される変更",
+		"capacity.recommended":              "推奨値",
+		"capacity.why":                      "理由",
+		"capacity.nocontext":                "conf/context.xml がありません - DataSource プールは変更しません",
+		"capacity.nochanges":                "変更は不要です",
+		"capacity.applied":                  "キャパシティプランを適用しました (%d 件の変更)",
+		"capacity.reason.concurrency":       "リトルの法則: %.0f req/s × %.0f ms = 平均 %.1f リクエストが同時処理中。",
+		"capacity.reason.maxthreads":        "maxThreads: %.1f × 余裕率 %.1f = %d スレッド。",
+		"capacity.reason.maxthreads.min":    "短いバーストが待たされないよう最小 %d スレッドに引き上げました。",
+		"capacity.reason.maxthreads.memory": "メモリによる上限: %d MB ではメモリの半分を使い 1 スレッド 2 MB として約 %d スレッドまで。",
+		"capacity.reason.minspare":          "minSpareThreads = %d で平均負荷に必要なスレッドを常に確保します。",
+		"capacity.reason.maxconnections":    "NIO コネクタはアイドルな keep-alive 接続をスレッドなしで保持するため、ワーカーあたり %d 接続を許可: maxConnections = %d。",
+		"capacity.reason.acceptcount":       "acceptCount = %d は maxConnections 到達後のバーストを OS で待機させます。",
+		"capacity.reason.cpu":               "CPU 需要 ≈ %.1f コア / 利用可能 %d (DB 以外の時間を CPU とみなします)。",
+		"capacity.reason.pool":              "DB プール: リクエスト時間の %d%% で接続を保持するため平均 %.1f 接続を使用、余裕を持たせて maxTotal = %d。",
+		"capacity.reason.pool.cap":          "プールを maxThreads (%d) に制限: 接続を借りられるのはワーカースレッドだけです。",
+		"capacity.reason.poolidle":          "minIdle = %d で平均 DB 負荷を、maxIdle = %d で接続の再生成なしにバーストを吸収します。",
+		"capacity.reason.poolwait":          "maxWaitMillis = %d (平均レイテンシの 4 倍、1-30 秒) でプール枯渇時に素早く失敗させます。",
+		"capacity.warn.memory":              "負荷には %d スレッドが必要ですがメモリでは %d までです。メモリかインスタンスを増やしてください。",
+		"capacity.warn.cpu":                 "想定 CPU 需要 (%.1f コア) が CPU %d 個を超えています。この負荷ではレイテンシが増加します。",
+
+		"connector.wiring":                  "Executor 接続",
+		"connector.wiring.desc":             "どのコネクタがどの Executor を共有しているか確認・変更",
+		"connector.wiring.title":            "Executor 接続",
+		"connector.wiring.usedby":           "使用中",
+		"connector.wiring.unused":           "使用しているコネクタなし",
+		"connector.wiring.internal":         "内部プール",
+		"connector.wiring.missing":          "存在しない Executor '%s' を参照",
+		"connector.wiring.connector":        "コネクタ",
+		"connector.wiring.useexecutor":      "Executor '%s' を使用",
+		"connector.wiring.useinternal":      "内部プールを使用",
+		"connector.wiring.useinternal.desc": "Executor から切り離し、スレッド上限をコネクタに保持",
+		"connector.wiring.convert":          "Executor に変換",
+		"connector.wiring.convert.desc":     "インラインの maxThreads/minSpareThreads を新しい共有 Executor に移動",
+		"connector.wiring.assigned":         "コネクタ %d は Executor '%s' を使用します",
+		"connector.wiring.detached":         "コネクタ %d は内部プールを使用します",
+		"connector.wiring.converted":        "コネクタ %d を Executor '%s' に変換しました",
+		"connector.executor.inuse":          "Executor '%s' はポート %s のコネクタがまだ使用しています。先に Executor 接続で移動してください。",
+		"connector.executor.exists":         "'%s' という名前の Executor は既に存在します",
+		"help.connector.wiring":             "[yellow::b]Executor 接続[-::-]\n\n各共有 [green]<Executor>[-] とそれを参照するコネクタ、および独自の内部スレッドプールを使うコネクタを表示します。\n\n[yellow]コネクタの操作:[-]\n• [green]Executor を使用[-] - 既存プールを共有。Tomcat が無視するためインラインの maxThreads/minSpareThreads は削除されます\n• [green]内部プールを使用[-] - 切り離し。Executor の上限がインラインにコピーされます\n• [green]Executor に変換[-] - インラインプールを新しい共有 Executor に変換\n\n[red]注意:[-] コネクタが参照している Executor は削除できません。",
+
+		"instance.new":          "新規インスタンス",
+		"instance.new.desc":     "既存の CATALINA_HOME から新しい CATALINA_BASE を作成",
+		"instance.new.title":    "新規インスタンス",
+		"instance.new.base":     "新しい CATALINA_BASE",
+		"instance.new.offset":   "ポートオフセット (空欄 = 自動)",
+		"instance.new.create":   "作成",
+		"instance.new.creating": "インスタンスを作成し、ポートを確認しています...",
+		"instance.new.created":  "インスタンスを作成しました",
+		"instance.new.summary":  "%s を作成しました\n\nShutdown: %d\nHTTP: %d\nHTTPS: %d\nAJP: %d",
+		"instance.new.help":     "[yellow]新しい CATALINA_BASE[-]\n\nbin, conf, lib, logs, temp, webapps, work を作成し、CATALINA_HOME の conf をコピーして bin/setenv.sh を書き込みます。\nshutdown, HTTP, HTTPS, AJP ポートは、このマシンや他のインスタンスと競合しなくなるまで 100 単位でずらします。\nディレクトリは存在しないか空である必要があります。",
+
+		"instance.clone":               "インスタンスを複製",
+		"instance.clone.desc":          "現在のインスタンスの設定を別の CATALINA_BASE にコピー",
+		"instance.clone.title":         "インスタンスを複製",
+		"instance.clone.source":        "コピー元 CATALINA_BASE",
+		"instance.clone.target":        "コピー先 CATALINA_BASE",
+		"instance.clone.preview":       "プレビュー",
+		"instance.clone.planning":      "設定を読み込み、ポートを確認しています...",
+		"instance.clone.summary":       "複製の概要",
+		"instance.clone.offset":        "ポートオフセット",
+		"instance.clone.files":         "ファイル",
+		"instance.clone.overwrite":     "上書き、バックアップ済み",
+		"instance.clone.substitutions": "置換内容",
+		"instance.clone.none":          "なし",
+		"instance.clone.done":          "%d 個のファイルを %s に複製しました",
+		"instance.clone.help":          "[yellow]インスタンスを複製[-]\n\nserver.xml, web.xml, context.xml, tomcat-users.xml, logging.properties, catalina.properties, setenv スクリプト、コンテキスト記述子をコピーします。\nすべてのポートはオフセット分ずらされ (空欄 = 100 単位で最初の空き範囲)、コピー元 CATALINA_BASE を指す絶対パスは書き換えられます。\n書き込む前にすべての置換内容を確認できます。",
+
+		"menu.jvm":              "JVM オプション",
+		"menu.jvm.desc":         "setenv.sh/.bat のヒープ、GC、JMX、システムプロパティ",
+		"jvm.title":             "JVM オプション",
+		"jvm.script":            "スクリプト",
+		"jvm.script.desc":       "bin/setenv.sh と bin/setenv.bat を切り替え",
+		"jvm.script.new":        "新規作成されます",
+		"jvm.general":           "ヒープ、GC、パス",
+		"jvm.xx":                "-XX フラグ",
+		"jvm.xx.add":            "フラグを追加",
+		"jvm.xx.add.desc":       "-XX オプションを追加",
+		"jvm.xx.required":       "フラグを入力してください",
+		"jvm.props":             "システムプロパティ",
+		"jvm.props.add":         "プロパティを追加",
+		"jvm.props.add.desc":    "-D システムプロパティを追加",
+		"jvm.props.name":        "名前",
+		"jvm.props.value":       "値",
+		"jvm.props.required":    "プロパティ名を入力してください",
+		"jvm.jmx":               "JMX リモート",
+		"jvm.jmx.enable":        "JMX リモートを有効化",
+		"jvm.jmx.enabled":       "ポート %s で有効",
+		"jvm.jmx.disabled":      "無効",
+		"jvm.jmx.port":          "ポート",
+		"jvm.jmx.rmiport":       "RMI ポート",
+		"jvm.jmx.authenticate":  "認証",
+		"jvm.jmx.ssl":           "SSL",
+		"jvm.jmx.hostname":      "RMI ホスト名",
+		"jvm.jmx.portrequired":  "JMX リモートを有効にするにはポートが必要です",
+		"jvm.preserved":         "保持される内容",
+		"jvm.preserved.count":   "オプション %d 個、行 %d 個をそのまま保持",
+		"jvm.preserved.options": "%s のその他のオプション",
+		"jvm.preserved.lines":   "その他のスクリプト行",
+		"jvm.preserved.none":    "なし",
+		"jvm.preview":           "プレビュー",
+		"jvm.preview.desc":      "書き込まれるスクリプトを表示",
+		"jvm.save.desc":         "setenv スクリプトを保存",
+		"jvm.saved":             "%s を保存しました",
+		"jvm.updated":           "JVM オプションを更新しました (未保存)",
+		"jvm.returnmenu":        "JVM オプションに戻る",
+		"jvm.count":             "%d 件設定済み",
+		"jvm.notset":            "未設定",
+		"jvm.gc":                "ガベージコレクタ",
+		"jvm.gc.default":        "JVM デフォルト",
+		"jvm.gc.cms":            "ConcMarkSweep (Java 13 以前)",
+		"jvm.heap.min":          "初期ヒープ (-Xms)",
+		"jvm.heap.max":          "最大ヒープ (-Xmx)",
+		"jvm.heap.invalid":      "無効なメモリサイズ '%s' (例: 512m, 2g)",
+		"help.jvm.script":       "[yellow::b]setenv スクリプト[-::-]\n\ncatalina.sh は CATALINA_BASE (または CATALINA_HOME) の [green]bin/setenv.sh[-] を、catalina.bat は [green]bin/setenv.bat[-] を読み込みます。\n\nオプションは Tomcat の起動時にのみ使われ、停止時には使われない [green]CATALINA_OPTS[-] に書き込まれます。JAVA_OPTS のみを使う既存スクリプトは JAVA_OPTS を使い続けます。\n\n単純な代入ではない行 (条件、関数、他の変数) はそのまま保持されます。",
+		"help.jvm.general":      "[yellow::b]ヒープ、GC、パス[-::-]\n\n[green]JAVA_HOME[-] - Tomcat の実行に使う JDK\n[green]CATALINA_PID[-] - プロセス ID ファイル。'catalina.sh stop -force' に必要\n[green]-Xms / -Xmx[-] - 初期および最大ヒープ。同じ値にするとサイズ変更による停止を避けられます\n[green]GC[-] - Java 9 以降は G1 がデフォルト。ZGC と Shenandoah は大きなヒープで短い停止時間を目指します",
+		"help.jvm.xx":           "[yellow::b]-XX フラグ[-::-]\n\n高度な HotSpot オプションで、-XX: プレフィックスなしで入力します。\n\n[yellow]例:[-]\n• MaxMetaspaceSize=256m\n• +HeapDumpOnOutOfMemoryError\n• HeapDumpPath=/var/log/tomcat\n• +ExitOnOutOfMemoryError\n\nコレクタはヒープ、GC、パスで選択します。",
+		"help.jvm.props":        "[yellow::b]システムプロパティ[-::-]\n\nSystem.getProperty() や Tomcat 設定ファイルの ${name} で使える -Dname=value オプションです。\n\n[yellow]よく使うもの:[-]\n• file.encoding=UTF-8\n• user.timezone=UTC\n• java.security.egd=file:/dev/./urandom",
+		"help.jvm.jmx":          "[yellow::b]JMX リモート[-::-]\n\nJConsole、VisualVM、監視エージェントに MBean を公開します。\n\n[green]RMI ポート[-] - ファイアウォールを通すためポートと同じ値に固定します\n[green]RMI ホスト名[-] - クライアントが接続し直すアドレス\n\n[red]警告:[-] authenticate=false かつ ssl=false の場合、ポートに到達できる誰もが JVM を操作できます。",
+		"help.jvm.preserved":    "[yellow::b]保持される内容[-::-]\n\nTomcatKit が扱わないオプション (-javaagent、-ea など) は管理対象オプションの後にそのまま書き戻されます。\n\n管理対象の代入ではないスクリプト行は変更されません。",
+
+		// catalina.properties
+		"menu.catprops":            "Catalina プロパティ",
+		"menu.catprops.desc":       "catalina.properties のクラスローダー、JAR スキャン、カスタムプロパティ",
+		"catprops.title":           "catalina.properties",
+		"catprops.count":           "%d 件",
+		"catprops.loaders":         "クラスローダー",
+		"catprops.loaders.desc":    "common: %d 件、shared: %d 件",
+		"catprops.jarstoskip":      "スキャン除外 JAR",
+		"catprops.jarstoscan":      "スキャン対象 JAR",
+		"catprops.packageaccess":   "パッケージアクセス制限",
+		"catprops.packagedef":      "パッケージ定義制限",
+		"catprops.custom":          "カスタムプロパティ",
+		"catprops.custom.add":      "プロパティ追加",
+		"catprops.custom.add.desc": "${...} 参照用のプロパティを追加",
+		"catprops.custom.key":      "名前",
+		"catprops.custom.value":    "値",
+		"catprops.custom.required": "プロパティ名は必須です",
+		"catprops.custom.standard": "%s は Tomcat のプロパティです。専用メニューから編集してください",
+		"catprops.custom.exists":   "プロパティ %s は既に存在します",
+		"catprops.item.add":        "項目追加",
+		"catprops.item.add.desc":   "リストに項目を追加",
+		"catprops.item.value":      "項目",
+		"catprops.item.required":   "項目は空にできません",
+		"catprops.item.comma":      "項目にカンマは使えません。1 つずつ追加してください",
+		"catprops.preview":         "プレビュー",
+		"catprops.preview.desc":    "保存される catalina.properties を表示",
+		"catprops.save.desc":       "catalina.properties を保存 (conf/backup にバックアップ)",
+		"catprops.saved":           "%s を保存しました (適用するには Tomcat を再起動)",
+		"catprops.returnmenu":      "catalina.properties メニューに戻る",
+		"help.catprops.loaders":    "[yellow::b]クラスローダー[-::-]\n\n[green]common.loader[-]: Tomcat とすべての Web アプリケーションから見えるクラス。\n[green]server.loader[-]: Tomcat からのみ見えるクラス。\n[green]shared.loader[-]: すべての Web アプリケーションで共有するクラス。\n\n項目はディレクトリ、JAR ファイル、または [green]*.jar[-] パターンで、${catalina.base} と ${catalina.home} を使用できます。server/shared ローダーが空の場合は common ローダーが使われます。",
+		"help.catprops.jarscan":    "[yellow::b]JAR スキャン[-::-]\n\n[green]jarsToSkip[-] は TLD、Web フラグメント、アノテーションのスキャンから除外する JAR 名のパターンです。大きなライブラリ JAR を除外すると起動が大幅に速くなります。\n\n[green]jarsToScan[-] は除外パターンに一致してもスキャンする例外です。\n\nパターンは * と ? のワイルドカードを使い、ファイル名のみに一致します。",
+		"help.catprops.packages":   "[yellow::b]パッケージセキュリティ[-::-]\n\nTomcat が SecurityManager 付きで実行される場合のみ有効です。\n\n[green]package.access[-]: Web アプリケーションがアクセスできないパッケージ。\n[green]package.definition[-]: Web アプリケーションがクラスを定義できないパッケージ。\n\n項目はドットで終わるパッケージ接頭辞です。",
+		"help.catprops.custom":     "[yellow::b]カスタムプロパティ[-::-]\n\ncatalina.properties のプロパティは起動時にシステムプロパティとなるため、server.xml やコンテキストファイルから [green]${name}[-] で参照できます。\n\nインスタンスごとに異なるポート、ホスト名、パスを置くのに便利です。",
+
+		// Placeholders
+		"placeholder.resolved":   "解決後の値",
+		"placeholder.unresolved": "未解決: %s",
+
+		// systemd
+		"menu.systemd":            "systemd サービス",
+		"menu.systemd.desc":       "このインスタンスの systemd ユニットファイルを生成",
+		"systemd.title":           "systemd ユニット",
+		"systemd.pidfile":         "PID ファイル",
+		"systemd.hardening":       "ハードニング",
+		"systemd.template":        "テンプレートユニット (tomcat@.service)",
+		"systemd.basesdir":        "インスタンスディレクトリ",
+		"systemd.output":          "ユニットファイル",
+		"systemd.output.required": "ユニットファイルのパスを入力してください",
+		"systemd.saved":           "ユニットを %s に書き込みました。'systemctl daemon-reload' で読み込んでください。",
+		"help.systemd":            "[yellow::b]systemd ユニット[-::-]\n\n[green]bin/startup.sh[-] で Tomcat を起動する forking サービスのユニットを生成します。手動起動と同じく setenv.sh が読み込まれます。\n\n[green]/etc/systemd/system[-] に配置して実行します:\n  systemctl daemon-reload\n  systemctl enable --now <unit>",
+		"help.systemd.user":       "[yellow::b]User / Group[-::-]\n\nサービスを実行するアカウントです。Tomcat は root で実行しないでください。アカウントには CATALINA_HOME の読み取り権限と、CATALINA_BASE の logs、work、temp、webapps への書き込み権限が必要です。",
+		"help.systemd.javahome":   "[yellow::b]JAVA_HOME[-::-]\n\nTomcat を実行する JDK です。setenv.sh または環境変数から取得します。setenv.sh の JAVA_HOME はユニットより優先されます。",
+		"help.systemd.pidfile":    "[yellow::b]PID ファイル[-::-]\n\n[green]CATALINA_PID[-] として渡され、systemd が fork した JVM を追跡するために使います。空の場合は [green]CATALINA_BASE/temp/tomcat.pid[-] を使います。\n\nsetenv.sh の CATALINA_PID がユニットより優先されるため、設定されていればその値を使います。",
+		"help.systemd.nofile":     "[yellow::b]LimitNOFILE[-::-]\n\n最大オープンファイル数です。接続や開いている JAR ごとにディスクリプタを使うため、負荷の高いインスタンスでは一般的な既定値 1024 では足りません。\n\n0 または空の場合は systemd の既定値のままです。",
+		"help.systemd.hardening":  "[yellow::b]ハードニング[-::-]\n\n[green]NoNewPrivileges[-]、[green]PrivateTmp[-]、[green]ProtectSystem=strict[-]、[green]ProtectHome[-] とカーネル保護を追加します。\n\n[green]ReadWritePaths[-] (CATALINA_BASE の logs、work、temp、webapps、conf/Catalina) 以外のファイルシステムは読み取り専用になります。",
+		"help.systemd.template":   "[yellow::b]テンプレートユニット[-::-]\n\nインスタンス名がインスタンスディレクトリ配下のディレクトリを指す [green]tomcat@.service[-] を生成します:\n\n  systemctl start tomcat@app1\n\nは CATALINA_BASE=<インスタンスディレクトリ>/app1 を使います。",
+		"help.systemd.output":     "[yellow::b]ユニットファイル[-::-]\n\nユニットの書き込み先です。ディレクトリを指定するとユニット名を使います。既存のファイルは .bak として保存されます。\n\n/etc/systemd/system への書き込みには root 権限が必要です。",
+
+		// Start / Stop
+		"menu.lifecycle":         "起動 / 停止",
+		"menu.lifecycle.desc":    "このインスタンスを起動・停止・再起動して出力を確認",
+		"lifecycle.title":        "起動 / 停止",
+		"lifecycle.log":          "出力",
+		"lifecycle.start":        "起動",
+		"lifecycle.start.desc":   "catalina.sh start を実行し、catalina.out に \"Server startup in\" が出るまで待機",
+		"lifecycle.run":          "フォアグラウンド実行",
+		"lifecycle.run.desc":     "TomcatKit の子プロセスとして catalina.sh run を実行し、コンソール出力をここに表示",
+		"lifecycle.stop":         "停止",
+		"lifecycle.stop.desc":    "シャットダウンポートに停止コマンドを送信 (無効な場合は catalina.sh stop)",
+		"lifecycle.restart":      "再起動",
+		"lifecycle.restart.desc": "停止して再び起動し、保存した変更を反映",
+		"lifecycle.refresh":      "状態を更新",
+		"lifecycle.refresh.desc": "Tomcat プロセスを再確認",
+		"lifecycle.clear":        "出力をクリア",
+		"lifecycle.clear.desc":   "出力ペインを消去",
+		"lifecycle.starting":     "Tomcat を起動中...",
+		"lifecycle.stopping":     "Tomcat を停止中...",
+		"lifecycle.restarting":   "Tomcat を再起動中...",
+		"lifecycle.started":      "Tomcat が起動しました",
+		"lifecycle.stopped":      "Tomcat が停止しました",
+
+		// Apply safely
+		"lifecycle.apply":              "安全に適用",
+		"lifecycle.apply.desc":         "変更された %d 個のファイルで再起動し、ヘルスを確認、失敗時はロールバック",
+		"lifecycle.apply.title":        "変更を安全に適用",
+		"lifecycle.apply.urls":         "ヘルスチェック URL",
+		"lifecycle.apply.timeout":      "タイムアウト（秒）",
+		"lifecycle.apply.changes":      "インスタンスを開いてから変更されたファイル",
+		"lifecycle.apply.nochanges":    "変更なし",
+		"lifecycle.apply.novalve":      "server.xml に HealthCheckValve がありません",
+		"lifecycle.applying":           "変更を適用中...",
+		"lifecycle.applied":            "変更を適用し、確認しました",
+		"help.lifecycle.apply":         "[yellow::b]安全に適用[-::-]\n\n保存した変更で Tomcat を再起動します。タイムアウトまでに起動メッセージが出ないか、ヘルスチェック URL が応答しない場合は、以前の設定を復元して Tomcat を再起動します。\n\n以前のファイルは conf/backup/apply-<時刻> に保存され、すべての手順が logs/tomcatkit-apply-<時刻>.log に記録されます。",
+		"help.lifecycle.apply.urls":    "[yellow::b]ヘルスチェック URL[-::-]\n\n再起動後に 2xx ステータスで応答する必要がある URL です。スペースで区切ります。\n\n例: http://localhost:8080/app/health\n\nserver.xml の HealthCheckValve のエンドポイントも確認されます。",
+		"help.lifecycle.apply.timeout": "[yellow::b]タイムアウト[-::-]\n\n変更をロールバックするまでに、Tomcat が起動してヘルスチェックに合格できる秒数です。",
+
+		// Instance scan
+		"instance.scan":       "インスタンスを検索",
+		"instance.scan.desc":  "ディレクトリから Tomcat インストールと CATALINA_BASE を検索",
+		"instance.scanned":    "検索で発見",
+		"scan.title":          "インスタンスを検索",
+		"scan.roots":          "検索ルート",
+		"scan.roots.required": "検索ルートが少なくとも1つ必要です",
+		"scan.depth":          "深さ",
+		"scan.start":          "検索",
+		"scan.running":        "検索中... (Escでキャンセル)",
+		"scan.progress.title": "進行状況",
+		"scan.progress":       "%d ディレクトリを検索、%d 件発見",
+		"scan.done":           "検索完了: %d 件のインスタンスを発見",
+		"scan.cancelled":      "検索をキャンセルしました",
+		"scan.results":        "検索結果 (%d)",
+		"scan.none.desc":      "検索ルートを追加するか深さを増やしてください",
+		"help.scan.roots":     "[yellow]検索ルート[white]\n検索するディレクトリ（スペース区切り）。シンボリックリンクは辿らず、/proc、/sys、/dev、/run はスキップします。",
+		"help.scan.depth":     "[yellow]深さ[white]\n各ルートの下を何階層まで検索するか。conf/server.xml と lib/catalina.jar があればインストール、conf/server.xml のみなら CATALINA_BASE とみなします。",
+
+		// Instance inventory
+		"inventory.title":         "インベントリ",
+		"inventory.add":           "インベントリに追加",
+		"inventory.edit":          "インベントリ項目を編集",
+		"inventory.name":          "名前",
+		"inventory.name.required": "名前が必要です",
+		"inventory.environment":   "環境",
+		"inventory.env.all":       "すべて",
+		"inventory.env.none":      "なし",
+		"inventory.color":         "色",
+		"inventory.color.default": "デフォルト",
+		"inventory.notes":         "メモ",
+		"inventory.saved":         "インベントリ項目を保存しました",
+		"inventory.removed":       "インベントリ項目を削除しました",
+		"inventory.banner":        "本番環境 - 変更は稼働中のインスタンスに影響します",
+		"instance.search":         "検索",
+		"instance.search.none":    "検索に一致するインスタンスはありません",
+		"instance.selector.keys":  "/ 検索  f 環境  e 名前・タグ",
+		"instance.missing":        "このインスタンスの conf/server.xml はもう存在しません",
+		"help.inventory":          "[yellow]インベントリ[white]\n名前を付けたインスタンスはインスタンス選択画面の先頭に表示され、名前・パス・環境・メモで検索できます。prod タグのインスタンスを編集している間は赤い警告バナーが表示されます。",
+
+		// Fleet
+		"menu.fleet":            "フリート",
+		"menu.fleet.desc":       "既知のすべてのインスタンスを一覧し、複数に同じ変更を適用",
+		"fleet.title":           "フリート",
+		"fleet.keys":            "Space 選択  a 全て  Enter 変更  c 2つを比較  r 更新",
+		"fleet.shutdown":        "Shutdown",
+		"fleet.ports":           "ポート",
+		"fleet.connectors":      "コネクタ",
+		"fleet.tls":             "TLS 期限",
+		"fleet.tls.keystore":    "キーストア",
+		"fleet.tls.expired":     "期限切れ",
+		"fleet.operations":      "%d 件のインスタンスを変更",
+		"fleet.op.logger":       "ロガーレベル",
+		"fleet.op.logger.desc":  "logging.properties のロガーレベルを設定",
+		"fleet.op.valve":        "Engine バルブ",
+		"fleet.op.valve.desc":   "Engine にバルブを追加（同じクラスのバルブは置き換え）",
+		"fleet.logger":          "ロガー",
+		"fleet.level":           "レベル",
+		"fleet.valve":           "バルブ",
+		"fleet.valve.allow":     "許可 (RemoteAddrValve)",
+		"fleet.invalid":         "入力が正しくありません",
+		"fleet.preview":         "プレビュー",
+		"fleet.planning":        "変更を準備中...",
+		"fleet.plan":            "予定された変更",
+		"fleet.plan.summary":    "[::b]%s[::-]: 変更 %d、変更なし %d、失敗 %d",
+		"fleet.plan.production": "本番インスタンスが %d 件含まれています",
+		"fleet.unchanged":       "変更なし",
+		"fleet.report":          "結果",
+		"fleet.report.summary":  "%s: 適用 %d、失敗 %d",
+		"fleet.report.restart":  "変更を反映するにはインスタンスを再起動してください。バックアップは conf/backup にあります。",
+
+		// Compare
+		"fleet.compare.two":       "比較するインスタンスを Space でちょうど2つ選択してください",
+		"compare.title":           "比較",
+		"compare.keys":            "> A→B にコピー  < B→A にコピー  s 保存  r 再読込",
+		"compare.setting":         "設定",
+		"compare.notset":          "(未設定)",
+		"compare.absent":          "(なし)",
+		"compare.none":            "差分なし: 両インスタンスの設定は同じです",
+		"compare.skipped":         "%s は比較されません、%c: %v",
+		"compare.modified":        "未保存: %s",
+		"compare.copied":          "%s を %c にコピーしました (未保存、s で保存)",
+		"compare.saved":           "コピーした設定を保存しました。反映するにはインスタンスを再起動してください。",
+		"compare.save.confirm":    "コピーした設定を書き込みますか?ファイルは先にバックアップされます。",
+		"compare.save.production": "本番インスタンスが変更されます。",
+		"compare.discard":         "保存していないコピーした設定を破棄しますか?",
+
 		"help.default": `[gray]フィールドを選択するとヘルプ情報が表示されます。[-]`,
 	},
 }
@@ -0,0 +1,322 @@
+package instance
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/config/server"
+	"github.com/playok/tomcatkit/internal/ports"
+)
+
+// ManagedFiles are the configuration files copied by a clone, relative to
+// CATALINA_BASE. Context descriptors under conf/<Engine>/<Host> are added
+// when present.
+var ManagedFiles = []string{
+	filepath.Join("conf", "server.xml"),
+	filepath.Join("conf", "web.xml"),
+	filepath.Join("conf", "context.xml"),
+	filepath.Join("conf", "tomcat-users.xml"),
+	filepath.Join("conf", "logging.properties"),
+	filepath.Join("conf", "catalina.properties"),
+	filepath.Join("conf", "catalina.policy"),
+	filepath.Join("conf", "jaspic-providers.xml"),
+	filepath.Join("bin", "setenv.sh"),
+	filepath.Join("bin", "setenv.bat"),
+}
+
+// SubstitutionKind tells what a substitution rewrote
+type SubstitutionKind string
+
+const (
+	SubstitutePort SubstitutionKind = "port"
+	SubstitutePath SubstitutionKind = "path"
+)
+
+// Substitution is a single value rewritten while cloning
+type Substitution struct {
+	File string // Relative to CATALINA_BASE
+	Line int
+	Kind SubstitutionKind
+	Old  string
+	New  string
+}
+
+// ClonedFile is a file that a clone will write
+type ClonedFile struct {
+	Path    string // Relative to CATALINA_BASE
+	Content []byte
+	Mode    os.FileMode
+	Exists  bool // The target already has this file and it will be overwritten
+}
+
+// CloneOptions describes a clone of an instance's configuration
+type CloneOptions struct {
+	Source     *config.TomcatInstance
+	TargetBase string
+	PortOffset int // 0 picks the first free multiple of PortStep
+}
+
+// ClonePlan holds everything a clone will write, so it can be reviewed
+// before anything touches the target
+type ClonePlan struct {
+	Source        *config.TomcatInstance
+	Target        *config.TomcatInstance
+	PortOffset    int
+	Files         []ClonedFile
+	Substitutions []Substitution
+}
+
+// portValueRe matches "...port=N" and "...Port="N"" in XML attributes,
+// properties files and JVM options
+var portValueRe = regexp.MustCompile(`(?i)([\w.\-]*port\s*=\s*"?)(\d+)`)
+
+// isProxyPort reports whether a matched "...port=" names the port a proxy
+// in front of Tomcat listens on, like proxyPort. Those are
+// external and stay as they are.
+func isProxyPort(prefix string) bool {
+	name := strings.TrimRight(prefix, "\" \t=")
+	return strings.HasSuffix(strings.ToLower(name), "proxyport")
+}
+
+// PlanClone reads the managed files of the source instance and prepares
+// their rewritten contents for the target
+func PlanClone(opts CloneOptions) (*ClonePlan, error) {
+	if opts.Source == nil || opts.Source.CatalinaBase == "" {
+		return nil, fmt.Errorf("no source instance given")
+	}
+	if opts.TargetBase == "" {
+		return nil, fmt.Errorf("CATALINA_BASE is required")
+	}
+
+	srcBase, err := filepath.Abs(opts.Source.CatalinaBase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", opts.Source.CatalinaBase, err)
+	}
+	dstBase, err := filepath.Abs(opts.TargetBase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", opts.TargetBase, err)
+	}
+	if filepath.Clean(srcBase) == filepath.Clean(dstBase) {
+		return nil, fmt.Errorf("source and target are the same CATALINA_BASE")
+	}
+
+	usages, err := ports.CollectInstance(srcBase)
+	if err != nil {
+		return nil, err
+	}
+	var clonedPorts []ports.Usage
+	for _, u := range usages {
+		if u.Active() && !u.Shared() {
+			clonedPorts = append(clonedPorts, u)
+		}
+	}
+
+	offset := opts.PortOffset
+	if offset == 0 {
+		ownPID, others := ports.Discover(dstBase)
+		others = append(others, ports.Instance{CatalinaBase: srcBase, PID: opts.Source.PID})
+		if offset, err = freeOffset(ports.NewChecker(ownPID, others), clonedPorts, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	portMap, err := clonePortMap(srcBase, clonedPorts, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ClonePlan{
+		Source: opts.Source,
+		Target: &config.TomcatInstance{
+			CatalinaHome: opts.Source.CatalinaHome,
+			CatalinaBase: dstBase,
+			Version:      opts.Source.Version,
+			VersionInfo:  opts.Source.VersionInfo,
+		},
+		PortOffset: offset,
+	}
+
+	files, err := managedFiles(srcBase)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range files {
+		info, err := os.Stat(filepath.Join(srcBase, rel))
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(srcBase, rel))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+
+		content, subs := rewriteFile(rel, data, portMap, srcBase, dstBase)
+		plan.Substitutions = append(plan.Substitutions, subs...)
+
+		_, statErr := os.Stat(filepath.Join(dstBase, rel))
+		plan.Files = append(plan.Files, ClonedFile{
+			Path:    rel,
+			Content: content,
+			Mode:    info.Mode().Perm(),
+			Exists:  statErr == nil,
+		})
+	}
+	return plan, nil
+}
+
+// Apply writes the planned files. Files the target already has are backed
+// up to conf/backup first, like every other configuration save.
+func (p *ClonePlan) Apply() error {
+	base := p.Target.CatalinaBase
+	for _, dir := range BaseDirs {
+		if err := os.MkdirAll(filepath.Join(base, dir), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	backupDir := filepath.Join(base, "conf", "backup")
+	for _, f := range p.Files {
+		path := filepath.Join(base, f.Path)
+		if f.Exists {
+			backupPath := filepath.Join(backupDir, backupName(f.Path))
+			if err := os.MkdirAll(filepath.Dir(backupPath), 0755); err != nil {
+				return fmt.Errorf("failed to create backup directory: %w", err)
+			}
+			if err := copyFile(path, backupPath, 0644); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", f.Path, err)
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", f.Path, err)
+		}
+		if err := os.WriteFile(path, f.Content, f.Mode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// backupName returns where a file is backed up below conf/backup. Files of
+// conf keep their path there, so the descriptors of different hosts do not
+// overwrite each other; the setenv scripts use their name, as the JVM
+// settings do.
+func backupName(rel string) string {
+	if sub, err := filepath.Rel("conf", rel); err == nil && !strings.HasPrefix(sub, "..") {
+		return sub + ".bak"
+	}
+	return filepath.Base(rel) + ".bak"
+}
+
+// managedFiles returns the managed files of an instance plus its context
+// descriptors, relative to CATALINA_BASE
+func managedFiles(base string) ([]string, error) {
+	files := append([]string{}, ManagedFiles...)
+
+	var descriptors []string
+	confDir := filepath.Join(base, "conf")
+	entries, err := os.ReadDir(confDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conf: %w", err)
+	}
+	for _, engine := range entries {
+		if !engine.IsDir() || engine.Name() == "backup" {
+			continue
+		}
+		hosts, _ := os.ReadDir(filepath.Join(confDir, engine.Name()))
+		for _, host := range hosts {
+			if !host.IsDir() {
+				continue
+			}
+			contexts, _ := os.ReadDir(filepath.Join(confDir, engine.Name(), host.Name()))
+			for _, ctx := range contexts {
+				if !ctx.IsDir() && strings.HasSuffix(ctx.Name(), ".xml") {
+					descriptors = append(descriptors, filepath.Join("conf", engine.Name(), host.Name(), ctx.Name()))
+				}
+			}
+		}
+	}
+	sort.Strings(descriptors)
+	return append(files, descriptors...), nil
+}
+
+// clonePortMap maps every source port (plus redirectPort values, which
+// point at HTTPS connectors that may not exist) to its shifted value
+func clonePortMap(srcBase string, usages []ports.Usage, offset int) (map[int]int, error) {
+	portMap := make(map[int]int)
+	add := func(port int) error {
+		if port <= 0 {
+			return nil
+		}
+		if port+offset > 65535 {
+			return fmt.Errorf("port %d shifted by %d is out of range", port, offset)
+		}
+		portMap[port] = port + offset
+		return nil
+	}
+
+	for _, u := range usages {
+		if err := add(u.Port); err != nil {
+			return nil, err
+		}
+	}
+
+	cs := server.NewConfigService(srcBase)
+	if err := cs.Load(); err == nil {
+		for _, svc := range cs.GetServer().Services {
+			for _, conn := range svc.Connectors {
+				if err := add(conn.RedirectPort.Int()); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return portMap, nil
+}
+
+// rewriteFile shifts known ports and moves paths from the source base to
+// the target base, line by line so each change can be reported
+func rewriteFile(rel string, data []byte, portMap map[int]int, srcBase, dstBase string) ([]byte, []Substitution) {
+	var subs []Substitution
+	pathRe := basePathRe(srcBase)
+
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		text := string(line)
+
+		text = portValueRe.ReplaceAllStringFunc(text, func(m string) string {
+			parts := portValueRe.FindStringSubmatch(m)
+			if isProxyPort(parts[1]) {
+				return m
+			}
+			old, _ := strconv.Atoi(parts[2])
+			shifted, ok := portMap[old]
+			if !ok {
+				return m
+			}
+			subs = append(subs, Substitution{File: rel, Line: i + 1, Kind: SubstitutePort, Old: parts[2], New: strconv.Itoa(shifted)})
+			return parts[1] + strconv.Itoa(shifted)
+		})
+
+		text = pathRe.ReplaceAllStringFunc(text, func(m string) string {
+			parts := pathRe.FindStringSubmatch(m)
+			subs = append(subs, Substitution{File: rel, Line: i + 1, Kind: SubstitutePath, Old: srcBase, New: dstBase})
+			return dstBase + parts[1]
+		})
+
+		lines[i] = []byte(text)
+	}
+	return bytes.Join(lines, []byte("\n")), subs
+}
+
+// basePathRe matches the source base as a whole path, so /opt/tomcat
+// does not match inside /opt/tomcat2
+func basePathRe(base string) *regexp.Regexp {
+	return regexp.MustCompile(regexp.QuoteMeta(base) + `([/\\"'\s<>:;,=]|$)`)
+}
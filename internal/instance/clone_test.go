@@ -0,0 +1,64 @@
+package instance
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/playok/tomcatkit/internal/config"
+)
+
+func TestRewriteFileKeepsProxyPort(t *testing.T) {
+	data := []byte(`<Connector port="8080" proxyPort="8080" redirectPort="8443"/>`)
+	portMap := map[int]int{8080: 8180, 8443: 8543}
+
+	content, subs := rewriteFile("conf/server.xml", data, portMap, "/opt/a", "/opt/b")
+	want := `<Connector port="8180" proxyPort="8080" redirectPort="8543"/>`
+	if string(content) != want {
+		t.Errorf("content = %s, want %s", content, want)
+	}
+	if len(subs) != 2 {
+		t.Errorf("substitutions = %+v, want port and redirectPort", subs)
+	}
+}
+
+func TestApplyBacksUpDescriptorsOfEachHost(t *testing.T) {
+	base := t.TempDir()
+	plan := &ClonePlan{Target: &config.TomcatInstance{CatalinaBase: base}}
+	for _, host := range []string{"localhost", "admin"} {
+		rel := filepath.Join("conf", "Catalina", host, "app.xml")
+		if err := os.MkdirAll(filepath.Join(base, filepath.Dir(rel)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(base, rel), []byte("<Context/> "+host), 0644); err != nil {
+			t.Fatal(err)
+		}
+		plan.Files = append(plan.Files, ClonedFile{Path: rel, Content: []byte("<Context/>"), Mode: 0644, Exists: true})
+	}
+
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	for _, host := range []string{"localhost", "admin"} {
+		data, err := os.ReadFile(filepath.Join(base, "conf", "backup", "Catalina", host, "app.xml.bak"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(string(data), host) {
+			t.Errorf("backup of %s holds %q", host, data)
+		}
+	}
+}
+
+func TestBackupName(t *testing.T) {
+	for rel, want := range map[string]string{
+		filepath.Join("conf", "server.xml"):                     "server.xml.bak",
+		filepath.Join("conf", "Catalina", "localhost", "a.xml"): filepath.Join("Catalina", "localhost", "a.xml.bak"),
+		filepath.Join("bin", "setenv.sh"):                       "setenv.sh.bak",
+	} {
+		if got := backupName(rel); got != want {
+			t.Errorf("backupName(%s) = %s, want %s", rel, got, want)
+		}
+	}
+}
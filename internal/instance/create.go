@@ -0,0 +1,375 @@
+package instance
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/config/connector"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/config/server"
+	"github.com/playok/tomcatkit/internal/detector"
+	"github.com/playok/tomcatkit/internal/ports"
+)
+
+// BaseDirs are the directories laid out in a new CATALINA_BASE
+var BaseDirs = []string{"bin", "conf", "lib", "logs", "temp", "webapps", "work"}
+
+// Default Tomcat ports that new instances are offset from
+const (
+	DefaultShutdownPort = 8005
+	DefaultHTTPPort     = 8080
+	DefaultHTTPSPort    = 8443
+	DefaultAJPPort      = 8009
+
+	// PortStep is the offset between instances, so the second instance
+	// gets 8105/8180/8543/8109, the third 8205/8280/8643/8209 and so on
+	PortStep = 100
+	// maxPortAttempts limits how many offsets are tried
+	maxPortAttempts = 50
+)
+
+// Ports are the ports assigned to an instance: the first of each kind in
+// its server.xml, or 0 when it has none
+type Ports struct {
+	Shutdown int
+	HTTP     int
+	HTTPS    int
+	AJP      int
+	// Offset is what every port of CATALINA_HOME's server.xml is shifted by
+	Offset int
+}
+
+// WithOffset returns the default ports shifted by offset
+func WithOffset(offset int) Ports {
+	return Ports{
+		Shutdown: DefaultShutdownPort + offset,
+		HTTP:     DefaultHTTPPort + offset,
+		HTTPS:    DefaultHTTPSPort + offset,
+		AJP:      DefaultAJPPort + offset,
+		Offset:   offset,
+	}
+}
+
+// usages returns the ports as port usages for the conflict checker
+func (p Ports) usages() []ports.Usage {
+	return []ports.Usage{
+		{Port: p.Shutdown, Kind: ports.KindShutdown, Where: "Server"},
+		{Port: p.HTTP, Kind: ports.KindHTTP, Where: "HTTP"},
+		{Port: p.HTTPS, Kind: ports.KindHTTPS, Where: "HTTPS"},
+		{Port: p.AJP, Kind: ports.KindAJP, Where: "AJP"},
+	}
+}
+
+// CreateOptions describes a new CATALINA_BASE
+type CreateOptions struct {
+	CatalinaHome string
+	CatalinaBase string
+	Ports        Ports // Zero value means pick free ports automatically
+}
+
+// CreateResult describes the instance that was created
+type CreateResult struct {
+	Instance *config.TomcatInstance
+	Ports    Ports
+	Files    []string // Files written, relative to CATALINA_BASE
+}
+
+// ValidateHome checks that catalinaHome is a Tomcat installation
+func ValidateHome(catalinaHome string) error {
+	if catalinaHome == "" {
+		return fmt.Errorf("CATALINA_HOME is required")
+	}
+	for _, rel := range []string{filepath.Join("conf", "server.xml"), filepath.Join("lib", "catalina.jar")} {
+		if _, err := os.Stat(filepath.Join(catalinaHome, rel)); err != nil {
+			return fmt.Errorf("%s is not a Tomcat installation: %s not found", catalinaHome, rel)
+		}
+	}
+	return nil
+}
+
+// ValidateBase checks that catalinaBase can be used for a new instance:
+// it must not exist yet or be an empty directory
+func ValidateBase(catalinaBase string) error {
+	if catalinaBase == "" {
+		return fmt.Errorf("CATALINA_BASE is required")
+	}
+	entries, err := os.ReadDir(catalinaBase)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", catalinaBase, err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%s already exists and is not empty", catalinaBase)
+	}
+	return nil
+}
+
+// FreePorts finds the first port offset that moves the ports of
+// CATALINA_HOME's server.xml clear of the live system and of every other
+// detected Tomcat instance. CATALINA_HOME always counts as another
+// instance, even when it was not detected.
+func FreePorts(catalinaHome, catalinaBase string) (Ports, error) {
+	ownPID, others := ports.Discover(catalinaBase)
+	others = append(others, ports.Instance{CatalinaBase: catalinaHome})
+	checker := ports.NewChecker(ownPID, others)
+
+	usages := WithOffset(0).usages()
+	if svc := server.NewConfigService(catalinaHome); svc.Load() == nil {
+		usages = shiftedUsages(ports.Collect(svc.GetServer(), svc.Resolver()))
+	}
+	offset, err := freeOffset(checker, usages, 0)
+	if err != nil {
+		return Ports{}, err
+	}
+	return WithOffset(offset), nil
+}
+
+// shiftedUsages returns the usages applyPorts shifts: the literal shutdown
+// and connector ports
+func shiftedUsages(usages []ports.Usage) []ports.Usage {
+	var shifted []ports.Usage
+	for _, u := range usages {
+		switch u.Kind {
+		case ports.KindShutdown, ports.KindHTTP, ports.KindHTTPS, ports.KindAJP:
+			if u.Active() && u.Text == "" {
+				shifted = append(shifted, u)
+			}
+		}
+	}
+	return shifted
+}
+
+// freeOffset returns the first multiple of PortStep, starting at the
+// given step, that moves every usage clear of any conflict
+func freeOffset(checker *ports.Checker, usages []ports.Usage, start int) (int, error) {
+	for i := start; i < start+maxPortAttempts; i++ {
+		offset := i * PortStep
+		shifted := make([]ports.Usage, 0, len(usages))
+		for _, u := range usages {
+			u.Port += offset
+			shifted = append(shifted, u)
+		}
+		if len(checker.Check(shifted)) == 0 {
+			return offset, nil
+		}
+	}
+	return 0, fmt.Errorf("no free port range found after %d attempts", maxPortAttempts)
+}
+
+// Create lays out a new CATALINA_BASE from an existing CATALINA_HOME. On
+// failure nothing of the new CATALINA_BASE is left behind.
+func Create(opts CreateOptions) (result *CreateResult, err error) {
+	if err := ValidateHome(opts.CatalinaHome); err != nil {
+		return nil, err
+	}
+	base, err := filepath.Abs(opts.CatalinaBase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", opts.CatalinaBase, err)
+	}
+	if err := ValidateBase(base); err != nil {
+		return nil, err
+	}
+
+	assigned := opts.Ports
+	if assigned == (Ports{}) {
+		if assigned, err = FreePorts(opts.CatalinaHome, base); err != nil {
+			return nil, err
+		}
+	}
+
+	// ValidateBase allows an existing empty directory, which is kept
+	existed := true
+	if _, err := os.Stat(base); os.IsNotExist(err) {
+		existed = false
+	}
+	defer func() {
+		if err != nil {
+			removeCreated(base, existed)
+		}
+	}()
+
+	result = &CreateResult{}
+
+	for _, dir := range BaseDirs {
+		if err := os.MkdirAll(filepath.Join(base, dir), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	copied, err := copyConf(filepath.Join(opts.CatalinaHome, "conf"), filepath.Join(base, "conf"))
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, copied...)
+
+	if result.Ports, err = applyPorts(base, assigned.Offset); err != nil {
+		return nil, err
+	}
+
+	setenv := filepath.Join("bin", "setenv.sh")
+	if err := os.WriteFile(filepath.Join(base, setenv), []byte(SetenvTemplate(opts.CatalinaHome, base)), 0755); err != nil {
+		return nil, fmt.Errorf("failed to write setenv.sh: %w", err)
+	}
+	result.Files = append(result.Files, setenv)
+
+	version := detector.NewDetector().DetectVersionInfo(opts.CatalinaHome)
+	result.Instance = &config.TomcatInstance{
+		CatalinaHome: opts.CatalinaHome,
+		CatalinaBase: base,
+		Version:      version.String(),
+		VersionInfo:  version,
+	}
+	return result, nil
+}
+
+// removeCreated removes what Create wrote to a CATALINA_BASE
+func removeCreated(base string, existed bool) {
+	if !existed {
+		os.RemoveAll(base)
+		return
+	}
+	entries, _ := os.ReadDir(base)
+	for _, e := range entries {
+		os.RemoveAll(filepath.Join(base, e.Name()))
+	}
+}
+
+// Register adds an instance to the recent list of the settings
+func Register(settings *config.SettingsManager, inst *config.TomcatInstance) error {
+	if err := settings.Load(); err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	settings.AddRecentInstance(inst)
+	if err := settings.Save(); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+	return nil
+}
+
+// copyConf copies the configuration of CATALINA_HOME. Per-host runtime
+// state (conf/Catalina) and tomcatkit backups are not copied.
+func copyConf(src, dst string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if rel == "Catalina" || rel == "backup" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if err := copyFile(path, filepath.Join(dst, rel), info.Mode().Perm()); err != nil {
+			return err
+		}
+		files = append(files, filepath.Join("conf", rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy conf: %w", err)
+	}
+	return files, nil
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// applyPorts shifts the shutdown, connector and redirect ports of the
+// copied server.xml by offset, so every connector keeps a port of its own.
+// Ports written as ${...} expressions are left alone. It returns the
+// resulting ports.
+func applyPorts(catalinaBase string, offset int) (Ports, error) {
+	cs := server.NewConfigService(catalinaBase)
+	if err := cs.Load(); err != nil {
+		return Ports{}, err
+	}
+
+	var err error
+	shift := func(port placeholder.Int) placeholder.Int {
+		n := port.Int()
+		if n <= 0 || port.IsPlaceholder() || offset == 0 {
+			return port
+		}
+		if n+offset > 65535 {
+			err = fmt.Errorf("port %d shifted by %d is out of range", n, offset)
+			return port
+		}
+		return placeholder.IntOf(n + offset)
+	}
+
+	p := Ports{Offset: offset}
+	srv := cs.GetServer()
+	srv.Port = shift(srv.Port)
+	p.Shutdown = srv.Port.Int()
+	for si := range srv.Services {
+		for ci := range srv.Services[si].Connectors {
+			conn := &srv.Services[si].Connectors[ci]
+			conn.Port = shift(conn.Port)
+			conn.RedirectPort = shift(conn.RedirectPort)
+
+			first := &p.HTTP
+			switch {
+			case connector.GetConnectorType(conn.Protocol) == connector.ConnectorTypeAJP:
+				first = &p.AJP
+			case conn.SSLEnabled.Bool():
+				first = &p.HTTPS
+			}
+			if *first == 0 {
+				*first = conn.Port.Int()
+			}
+		}
+	}
+	if err != nil {
+		return Ports{}, err
+	}
+
+	if err := cs.Save(); err != nil {
+		return Ports{}, err
+	}
+	// A fresh instance has nothing worth restoring
+	return p, os.RemoveAll(filepath.Join(catalinaBase, "conf", "backup"))
+}
+
+// SetenvTemplate returns the bin/setenv.sh written for a new instance
+func SetenvTemplate(catalinaHome, catalinaBase string) string {
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	sb.WriteString("# Instance settings, sourced by catalina.sh\n")
+	sb.WriteString("#\n")
+	sb.WriteString(fmt.Sprintf("# Start with: CATALINA_BASE=%s %s/bin/startup.sh\n", catalinaBase, catalinaHome))
+	sb.WriteString("\n")
+	sb.WriteString("CATALINA_PID=\"$CATALINA_BASE/temp/tomcat.pid\"\n")
+	sb.WriteString("\n")
+	sb.WriteString("# JVM options for this instance\n")
+	sb.WriteString("CATALINA_OPTS=\"$CATALINA_OPTS -Xms512m -Xmx1024m\"\n")
+	return sb.String()
+}
@@ -0,0 +1,151 @@
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultApplyTimeout is how long Tomcat may take to start and answer its
+// health checks after changes are applied
+const DefaultApplyTimeout = 2 * time.Minute
+
+// ErrNoChanges is returned by Apply when the configuration matches the
+// checkpoint
+var ErrNoChanges = errors.New("no configuration changes since the checkpoint")
+
+// ApplyOptions configure Apply
+type ApplyOptions struct {
+	// HealthURLs are checked in addition to the HealthCheckValve endpoints
+	// found in server.xml
+	HealthURLs []string
+	// Timeout covers the startup and the health checks together
+	Timeout time.Duration
+}
+
+// ApplyResult describes what Apply did
+type ApplyResult struct {
+	Changes    []Change
+	BackupDir  string // Copy of the checkpoint, conf/backup/apply-<time>
+	LogFile    string // logs/tomcatkit-apply-<time>.log
+	RolledBack bool
+}
+
+// Apply puts saved configuration changes into effect safely: Tomcat is
+// restarted and must report startup and answer the health URLs within the
+// timeout. Otherwise the checkpoint is restored and Tomcat is restarted
+// with it when it was running before. Everything is logged to a file in
+// CATALINA_BASE/logs. On success the checkpoint is retaken.
+func (c *Controller) Apply(cp *Checkpoint, opts ApplyOptions) (*ApplyResult, error) {
+	if err := c.checkHost(); err != nil {
+		return nil, err
+	}
+	changes, err := cp.Changes()
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, ErrNoChanges
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultApplyTimeout
+	}
+
+	stamp := time.Now().Format("20060102-150405")
+	result := &ApplyResult{
+		Changes:   changes,
+		BackupDir: filepath.Join(c.catalinaBase(), "conf", backupDirName, "apply-"+stamp),
+		LogFile:   filepath.Join(c.catalinaBase(), "logs", "tomcatkit-apply-"+stamp+".log"),
+	}
+
+	if err := os.MkdirAll(filepath.Dir(result.LogFile), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	logFile, err := os.Create(result.LogFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create apply log: %w", err)
+	}
+	defer logFile.Close()
+	c.setLog(logFile)
+	defer c.setLog(nil)
+
+	c.emit("Applying changes to %s (log: %s)", c.catalinaBase(), result.LogFile)
+	for _, change := range changes {
+		c.emit("  %s %s", change.Kind, change.Path)
+	}
+	if err := cp.SaveTo(result.BackupDir); err != nil {
+		c.emit("Aborted: %v", err)
+		return result, err
+	}
+	c.emit("Previous configuration saved to %s", result.BackupDir)
+
+	urls := append(append([]string{}, opts.HealthURLs...), HealthCheckURLs(c.instance.CatalinaHome, c.catalinaBase())...)
+	wasRunning := c.Refresh()
+	if wasRunning {
+		// The running Tomcat listens on the shutdown port of the previous
+		// server.xml, not of the one just saved
+		c.startedWith = result.BackupDir
+	}
+	defer func() { c.startedWith = "" }()
+
+	verifyErr := c.restartAndVerify(urls, opts.Timeout)
+	if verifyErr == nil {
+		if err := cp.Retake(); err != nil {
+			c.emit("Failed to update checkpoint: %v", err)
+		}
+		c.emit("Changes applied")
+		return result, nil
+	}
+
+	c.emit("Verification failed: %v", verifyErr)
+	c.emit("Rolling back")
+	result.RolledBack = true
+	if c.Refresh() {
+		if err := c.Stop(); err != nil {
+			c.emit("Stop failed: %v", err)
+		}
+	}
+	restored, err := cp.Restore()
+	if err != nil {
+		c.emit("Rollback failed: %v", err)
+		return result, fmt.Errorf("%v; rollback failed: %w", verifyErr, err)
+	}
+	for _, change := range restored {
+		if change.Kind == ChangeAdded {
+			c.emit("  removed %s", change.Path)
+		} else {
+			c.emit("  restored %s", change.Path)
+		}
+	}
+
+	if wasRunning {
+		if err := c.restartAndVerify(urls, opts.Timeout); err != nil {
+			c.emit("Previous configuration did not come up either: %v", err)
+			return result, fmt.Errorf("%v; restart after rollback failed: %w", verifyErr, err)
+		}
+	}
+	c.emit("Rolled back to the previous configuration")
+	return result, fmt.Errorf("changes rolled back: %w", verifyErr)
+}
+
+// restartAndVerify restarts Tomcat and waits for the startup message and
+// the health URLs within timeout
+func (c *Controller) restartAndVerify(urls []string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	startTimeout := c.StartTimeout
+	c.StartTimeout = timeout
+	err := c.Restart()
+	c.StartTimeout = startTimeout
+	if err != nil {
+		return err
+	}
+
+	if len(urls) == 0 {
+		c.emit("No health URLs configured; startup message only")
+		return nil
+	}
+	return c.waitForHealth(urls, deadline)
+}
@@ -0,0 +1,194 @@
+package lifecycle
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupDirName is the directory below conf where the config services keep
+// their backups; it is not part of a checkpoint
+const backupDirName = "backup"
+
+// setenvScripts are the scripts in CATALINA_BASE/bin that hold JVM options
+var setenvScripts = []string{"setenv.sh", "setenv.bat"}
+
+// Checkpoint is a copy of an instance's configuration, taken before changes
+// are made so that they can be rolled back when Tomcat fails to come up.
+// It covers the files in conf (including context descriptors below
+// conf/<Engine>/<Host>) and bin/setenv.sh and bin/setenv.bat.
+type Checkpoint struct {
+	Base  string
+	Taken time.Time
+	files map[string][]byte // Path relative to Base -> content
+}
+
+// ChangeKind tells how a file differs from a checkpoint
+type ChangeKind string
+
+const (
+	ChangeModified ChangeKind = "modified"
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+)
+
+// Change is a configuration file that differs from a checkpoint
+type Change struct {
+	Path string // Relative to CATALINA_BASE
+	Kind ChangeKind
+}
+
+// TakeCheckpoint reads the configuration of CATALINA_BASE
+func TakeCheckpoint(base string) (*Checkpoint, error) {
+	cp := &Checkpoint{Base: base}
+	if err := cp.Retake(); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// Retake replaces the checkpoint with the current configuration, e.g.
+// after changes were applied successfully
+func (cp *Checkpoint) Retake() error {
+	files, err := readConfigFiles(cp.Base)
+	if err != nil {
+		return err
+	}
+	cp.files = files
+	cp.Taken = time.Now()
+	return nil
+}
+
+// readConfigFiles reads the files a checkpoint covers
+func readConfigFiles(base string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	confDir := filepath.Join(base, "conf")
+	err := filepath.WalkDir(confDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == confDir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			if path != confDir && d.Name() == backupDirName && filepath.Dir(path) == confDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		files[rel] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	for _, name := range setenvScripts {
+		rel := filepath.Join("bin", name)
+		data, err := os.ReadFile(filepath.Join(base, rel))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		files[rel] = data
+	}
+	return files, nil
+}
+
+// Changes lists the files that differ from the checkpoint, sorted by path
+func (cp *Checkpoint) Changes() ([]Change, error) {
+	current, err := readConfigFiles(cp.Base)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for rel, data := range current {
+		old, ok := cp.files[rel]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Path: rel, Kind: ChangeAdded})
+		case !bytes.Equal(old, data):
+			changes = append(changes, Change{Path: rel, Kind: ChangeModified})
+		}
+	}
+	for rel := range cp.files {
+		if _, ok := current[rel]; !ok {
+			changes = append(changes, Change{Path: rel, Kind: ChangeRemoved})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// SaveTo writes the checkpointed files below dir, keeping their layout, so
+// they can be restored by hand as well
+func (cp *Checkpoint) SaveTo(dir string) error {
+	for rel, data := range cp.files {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create backup directory: %w", err)
+		}
+		if err := os.WriteFile(path, data, fileMode(filepath.Join(cp.Base, rel))); err != nil {
+			return fmt.Errorf("failed to write backup of %s: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+// Restore writes the checkpointed files back and removes files added since.
+// It returns the files it changed.
+func (cp *Checkpoint) Restore() ([]Change, error) {
+	changes, err := cp.Changes()
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		path := filepath.Join(cp.Base, change.Path)
+		switch change.Kind {
+		case ChangeAdded:
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("failed to remove %s: %w", change.Path, err)
+			}
+		default:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return nil, fmt.Errorf("failed to restore %s: %w", change.Path, err)
+			}
+			if err := os.WriteFile(path, cp.files[change.Path], fileMode(path)); err != nil {
+				return nil, fmt.Errorf("failed to restore %s: %w", change.Path, err)
+			}
+		}
+	}
+	return changes, nil
+}
+
+// fileMode returns the mode of an existing file, so restoring keeps
+// e.g. the restricted mode of tomcat-users.xml
+func fileMode(path string) os.FileMode {
+	if info, err := os.Stat(path); err == nil {
+		return info.Mode().Perm()
+	}
+	if strings.HasSuffix(path, ".sh") {
+		return 0755
+	}
+	return 0644
+}
@@ -0,0 +1,478 @@
+package lifecycle
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/config/jvm"
+	"github.com/playok/tomcatkit/internal/config/server"
+	"github.com/playok/tomcatkit/internal/detector"
+)
+
+// StartupMessage is logged by Tomcat once every connector has started
+const StartupMessage = "Server startup in"
+
+// Default timeouts
+const (
+	DefaultStartTimeout = 2 * time.Minute
+	DefaultStopTimeout  = 30 * time.Second
+	// pollInterval is how often catalina.out and the process are checked
+	pollInterval = 500 * time.Millisecond
+	// exitGrace is how long a missing process is tolerated after start,
+	// as catalina.sh writes the PID file only after forking the JVM
+	exitGrace = 5 * time.Second
+)
+
+// Controller starts and stops a Tomcat instance with the scripts in
+// CATALINA_HOME/bin and keeps IsRunning and PID of the instance up to date
+type Controller struct {
+	instance *config.TomcatInstance
+	output   func(line string)
+
+	StartTimeout time.Duration
+	StopTimeout  time.Duration
+
+	mu  sync.Mutex
+	run *exec.Cmd // Foreground process started with "catalina.sh run"
+
+	// startedWith is a directory laid out like CATALINA_BASE whose
+	// conf/server.xml the running Tomcat was started with, when that is no
+	// longer the one in CATALINA_BASE, e.g. the checkpoint while changes are
+	// applied. Stop reads the shutdown port and command from it.
+	startedWith string
+
+	logMu sync.Mutex
+	log   io.Writer // Also receives output while changes are applied
+}
+
+// NewController creates a controller for an instance. Output of the scripts
+// and of catalina.out is passed to output line by line; it may be nil.
+func NewController(instance *config.TomcatInstance, output func(line string)) *Controller {
+	return &Controller{
+		instance:     instance,
+		output:       output,
+		StartTimeout: DefaultStartTimeout,
+		StopTimeout:  DefaultStopTimeout,
+	}
+}
+
+// emit passes a line to the output callback
+func (c *Controller) emit(format string, args ...interface{}) {
+	line := fmt.Sprintf(format, args...)
+	c.logMu.Lock()
+	if c.log != nil {
+		fmt.Fprintf(c.log, "%s %s\n", time.Now().Format("2006-01-02 15:04:05"), line)
+	}
+	c.logMu.Unlock()
+	if c.output != nil {
+		c.output(line)
+	}
+}
+
+// setLog starts or, with nil, stops copying output to w
+func (c *Controller) setLog(w io.Writer) {
+	c.logMu.Lock()
+	c.log = w
+	c.logMu.Unlock()
+}
+
+// catalinaBase returns CATALINA_BASE, which defaults to CATALINA_HOME
+func (c *Controller) catalinaBase() string {
+	if c.instance.CatalinaBase == "" {
+		return c.instance.CatalinaHome
+	}
+	return c.instance.CatalinaBase
+}
+
+// checkHost refuses to run scripts for an instance in a container, whose
+// Tomcat is started and stopped with the container
+func (c *Controller) checkHost() error {
+	if ctr := c.instance.Container; ctr != nil {
+		return fmt.Errorf("Tomcat runs in container %s; restart the container instead", ctr.Label())
+	}
+	return nil
+}
+
+// Script returns the path of catalina.sh, or catalina.bat on Windows
+func (c *Controller) Script() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(c.instance.CatalinaHome, "bin", "catalina.bat")
+	}
+	return filepath.Join(c.instance.CatalinaHome, "bin", "catalina.sh")
+}
+
+// PIDFile returns the file catalina.sh writes the PID to. A CATALINA_PID
+// set in setenv.sh is used as is, since the script overrides the
+// environment; otherwise CATALINA_BASE/temp/tomcat.pid is passed.
+func (c *Controller) PIDFile() string {
+	base := c.catalinaBase()
+	svc := jvm.NewConfigService(base, jvm.ScriptSh)
+	if err := svc.Load(); err == nil && svc.Exists() {
+		if pid := svc.GetOptions().CatalinaPID; pid != "" {
+			return expandCatalinaVars(pid, c.instance.CatalinaHome, base)
+		}
+	}
+	return filepath.Join(base, "temp", "tomcat.pid")
+}
+
+// LogFile returns catalina.out, where "catalina.sh start" sends the console
+func (c *Controller) LogFile() string {
+	return filepath.Join(c.catalinaBase(), "logs", "catalina.out")
+}
+
+// expandCatalinaVars replaces $CATALINA_HOME and $CATALINA_BASE in a value
+// taken from setenv.sh
+func expandCatalinaVars(value, home, base string) string {
+	value = strings.Trim(value, `"'`)
+	return os.Expand(value, func(name string) string {
+		switch name {
+		case "CATALINA_HOME":
+			return home
+		case "CATALINA_BASE":
+			return base
+		}
+		return "$" + name
+	})
+}
+
+// Refresh looks up the running process and updates IsRunning and PID of the
+// instance. The PID file is checked first, then the process list.
+func (c *Controller) Refresh() bool {
+	pid := readPID(c.PIDFile())
+	if !processAlive(pid) {
+		pid = detector.NewDetector().FindRunning(c.catalinaBase())
+	}
+
+	c.mu.Lock()
+	if pid == 0 && c.run != nil && c.run.Process != nil {
+		pid = c.run.Process.Pid
+	}
+	c.mu.Unlock()
+
+	c.instance.IsRunning = pid > 0
+	c.instance.PID = pid
+	return c.instance.IsRunning
+}
+
+// readPID reads a PID file; it returns 0 when there is none
+func readPID(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
+
+// processAlive reports whether a process exists
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// FindProcess opens the process, so it only succeeds while it runs
+		return true
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// command prepares catalina.sh with the instance's environment
+func (c *Controller) command(args ...string) *exec.Cmd {
+	cmd := exec.Command(c.Script(), args...)
+	cmd.Dir = c.catalinaBase()
+	cmd.Env = append(os.Environ(),
+		"CATALINA_HOME="+c.instance.CatalinaHome,
+		"CATALINA_BASE="+c.catalinaBase(),
+		"CATALINA_PID="+c.PIDFile(),
+	)
+	return cmd
+}
+
+// runScript runs catalina.sh and passes its output on. The output goes to a
+// temporary file rather than a pipe, since the JVM forked by "start" would
+// otherwise hold the pipe open and the read would not finish.
+func (c *Controller) runScript(args ...string) error {
+	tmp, err := os.CreateTemp("", "tomcatkit-catalina-*.out")
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	cmd := c.command(args...)
+	cmd.Stdout = tmp
+	cmd.Stderr = tmp
+	c.emit("$ %s %s", c.Script(), strings.Join(args, " "))
+	err = cmd.Run()
+	output, _ := os.ReadFile(tmp.Name())
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		if line != "" {
+			c.emit("%s", line)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("catalina %s failed: %w", args[0], err)
+	}
+	return nil
+}
+
+// Start runs "catalina.sh start" and waits until catalina.out reports
+// that the server has started
+func (c *Controller) Start() error {
+	if err := c.checkHost(); err != nil {
+		return err
+	}
+	if c.Refresh() {
+		return fmt.Errorf("Tomcat is already running (PID %d)", c.instance.PID)
+	}
+	if _, err := os.Stat(c.Script()); err != nil {
+		return fmt.Errorf("failed to find startup script: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.PIDFile()), 0755); err != nil {
+		return fmt.Errorf("failed to create PID file directory: %w", err)
+	}
+
+	// Only output written after this start is watched
+	var offset int64
+	if info, err := os.Stat(c.LogFile()); err == nil {
+		offset = info.Size()
+	}
+
+	c.startedWith = ""
+	if err := c.runScript("start"); err != nil {
+		return err
+	}
+	return c.waitForStartup(offset)
+}
+
+// waitForStartup follows catalina.out from offset until the startup message
+// appears, the process exits or the start timeout passes
+func (c *Controller) waitForStartup(offset int64) error {
+	c.emit("Waiting for %q in %s", StartupMessage, c.LogFile())
+	started := time.Now()
+	var partial string
+	for time.Since(started) < c.StartTimeout {
+		time.Sleep(pollInterval)
+
+		data, newOffset := readFrom(c.LogFile(), offset)
+		offset = newOffset
+		lines := strings.Split(partial+string(data), "\n")
+		partial = lines[len(lines)-1]
+		for _, line := range lines[:len(lines)-1] {
+			c.emit("%s", strings.TrimRight(line, "\r"))
+			if strings.Contains(line, StartupMessage) {
+				c.Refresh()
+				return nil
+			}
+		}
+
+		if time.Since(started) > exitGrace && !c.Refresh() {
+			return fmt.Errorf("Tomcat exited during startup; see %s", c.LogFile())
+		}
+	}
+	c.Refresh()
+	return fmt.Errorf("Tomcat did not report startup within %s", c.StartTimeout)
+}
+
+// readFrom returns the bytes of a file after offset and the new offset. A
+// file that was truncated or rotated is read from the beginning.
+func readFrom(path string, offset int64) ([]byte, int64) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, offset
+	}
+	defer f.Close()
+
+	if info, err := f.Stat(); err == nil && info.Size() < offset {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset
+	}
+	data, _ := io.ReadAll(f)
+	return data, offset + int64(len(data))
+}
+
+// Run starts Tomcat in the foreground with "catalina.sh run" as a child of
+// this process. Its console output is passed on until it exits; Run returns
+// once the startup message appears.
+func (c *Controller) Run() error {
+	if err := c.checkHost(); err != nil {
+		return err
+	}
+	if c.Refresh() {
+		return fmt.Errorf("Tomcat is already running (PID %d)", c.instance.PID)
+	}
+
+	cmd := c.command("run")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to start Tomcat: %w", err)
+	}
+	cmd.Stderr = cmd.Stdout
+
+	c.emit("$ %s run", c.Script())
+	c.startedWith = ""
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start Tomcat: %w", err)
+	}
+	c.mu.Lock()
+	c.run = cmd
+	c.mu.Unlock()
+
+	startedCh := make(chan struct{})
+	exited := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		signalled := false
+		for scanner.Scan() {
+			line := scanner.Text()
+			c.emit("%s", line)
+			if !signalled && strings.Contains(line, StartupMessage) {
+				signalled = true
+				close(startedCh)
+			}
+		}
+		err := cmd.Wait()
+		c.mu.Lock()
+		c.run = nil
+		c.mu.Unlock()
+		c.emit("Tomcat exited")
+		exited <- err
+	}()
+
+	select {
+	case <-startedCh:
+		c.Refresh()
+		return nil
+	case err := <-exited:
+		c.Refresh()
+		if err != nil {
+			return fmt.Errorf("Tomcat exited during startup: %w", err)
+		}
+		return fmt.Errorf("Tomcat exited during startup")
+	case <-time.After(c.StartTimeout):
+		c.Refresh()
+		return fmt.Errorf("Tomcat did not report startup within %s", c.StartTimeout)
+	}
+}
+
+// Stop shuts Tomcat down through the shutdown port configured in
+// server.xml, falling back to "catalina.sh stop" when the port is disabled
+// or does not answer, and waits for the process to exit
+func (c *Controller) Stop() error {
+	if err := c.checkHost(); err != nil {
+		return err
+	}
+	if !c.Refresh() {
+		return fmt.Errorf("Tomcat is not running")
+	}
+	pid := c.instance.PID
+
+	config := c.catalinaBase()
+	stopArgs := []string{"stop"}
+	if c.startedWith != "" {
+		config = c.startedWith
+		stopArgs = append(stopArgs, "-config", filepath.Join(config, "conf", "server.xml"))
+	}
+	if err := c.sendShutdown(config); err != nil {
+		c.emit("Shutdown port: %v", err)
+		if err := c.runScript(stopArgs...); err != nil {
+			// A "catalina.sh run" child writes no PID file, so the script
+			// may not find it; it is ours to terminate
+			if !c.terminateRun() {
+				return err
+			}
+		}
+	}
+
+	c.emit("Waiting for PID %d to exit", pid)
+	deadline := time.Now().Add(c.StopTimeout)
+	for time.Now().Before(deadline) {
+		if !processAlive(pid) {
+			c.Refresh()
+			c.startedWith = ""
+			c.emit("Tomcat stopped")
+			return nil
+		}
+		time.Sleep(pollInterval)
+	}
+	c.Refresh()
+	return fmt.Errorf("Tomcat (PID %d) did not stop within %s", pid, c.StopTimeout)
+}
+
+// terminateRun sends SIGTERM to the process started by Run, if any
+func (c *Controller) terminateRun() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.run == nil || c.run.Process == nil {
+		return false
+	}
+	if err := c.run.Process.Signal(syscall.SIGTERM); err != nil {
+		return false
+	}
+	c.emit("Sent SIGTERM to PID %d", c.run.Process.Pid)
+	return true
+}
+
+// sendShutdown sends the shutdown command of the server.xml in config to
+// the shutdown port, which is how "catalina.sh stop" asks Tomcat to stop
+func (c *Controller) sendShutdown(config string) error {
+	svc := server.NewConfigService(config)
+	svc.SetCatalinaHome(c.instance.CatalinaHome)
+	if err := svc.Load(); err != nil {
+		return err
+	}
+	srv := svc.GetServer()
+	port, err := srv.Port.Resolve(svc.Resolver())
+	if err != nil {
+		return err
+	}
+	if port <= 0 {
+		return fmt.Errorf("disabled (port %d)", port)
+	}
+
+	address := net.JoinHostPort("localhost", strconv.Itoa(port))
+	conn, err := net.DialTimeout("tcp", address, 5*time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(srv.Shutdown)); err != nil {
+		return err
+	}
+	c.emit("Sent shutdown command to %s", address)
+	return nil
+}
+
+// Restart stops Tomcat when it is running and starts it again
+func (c *Controller) Restart() error {
+	if c.Refresh() {
+		if err := c.Stop(); err != nil {
+			return err
+		}
+	}
+	return c.Start()
+}
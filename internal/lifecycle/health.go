@@ -0,0 +1,149 @@
+package lifecycle
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/config/server"
+)
+
+// DefaultHealthCheckPath is where HealthCheckValve answers when its path
+// attribute is not set
+const DefaultHealthCheckPath = "/health"
+
+// healthRequestTimeout limits a single health request
+const healthRequestTimeout = 5 * time.Second
+
+// HealthCheckURLs returns the endpoints of the HealthCheckValves configured
+// in server.xml. They are requested on localhost through the first HTTP
+// connector of the valve's Service.
+func HealthCheckURLs(catalinaHome, catalinaBase string) []string {
+	svc := server.NewConfigService(catalinaBase)
+	svc.SetCatalinaHome(catalinaHome)
+	if err := svc.Load(); err != nil {
+		return nil
+	}
+	r := svc.Resolver()
+
+	var urls []string
+	for _, service := range svc.GetServer().Services {
+		origin := connectorOrigin(service.Connectors, r)
+		if origin == "" {
+			continue
+		}
+		add := func(valves []server.Valve, host string) {
+			for _, valve := range valves {
+				if valve.ClassName != server.ValveHealthCheck {
+					continue
+				}
+				path := valve.Path
+				if path == "" {
+					path = DefaultHealthCheckPath
+				}
+				u := origin + path
+				// A valve of a Host other than the default one only answers
+				// for that host name
+				if host != "" && host != service.Engine.DefaultHost {
+					u += "#host=" + host
+				}
+				urls = append(urls, u)
+			}
+		}
+		add(service.Engine.Valves, "")
+		for _, host := range service.Engine.Hosts {
+			add(host.Valves, host.Name)
+		}
+	}
+	return urls
+}
+
+// connectorOrigin returns scheme://localhost:port of the first HTTP connector
+func connectorOrigin(connectors []server.Connector, r *placeholder.Resolver) string {
+	for _, conn := range connectors {
+		if strings.Contains(strings.ToUpper(conn.Protocol), "AJP") {
+			continue
+		}
+		port, err := conn.Port.Resolve(r)
+		if err != nil || port <= 0 {
+			continue
+		}
+		scheme := "http"
+		if conn.SSLEnabled.Value(r) {
+			scheme = "https"
+		}
+		host := "localhost"
+		if conn.Address != "" && conn.Address != "0.0.0.0" && conn.Address != "::" {
+			host = conn.Address
+		}
+		return scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port))
+	}
+	return ""
+}
+
+// waitForHealth requests every URL until each answers with a 2xx status or
+// the deadline passes
+func (c *Controller) waitForHealth(urls []string, deadline time.Time) error {
+	client := &http.Client{
+		Timeout: healthRequestTimeout,
+		Transport: &http.Transport{
+			// Local instances commonly use self-signed certificates; only
+			// the status of the endpoint matters here
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	for _, u := range urls {
+		c.emit("Checking %s", u)
+		var lastErr error
+		for {
+			lastErr = checkURL(client, u)
+			if lastErr == nil {
+				c.emit("%s is healthy", u)
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("health check %s failed: %w", u, lastErr)
+			}
+			time.Sleep(pollInterval)
+		}
+	}
+	return nil
+}
+
+// checkURL requests a health URL once. A "#host=name" fragment sets the
+// Host header, for valves of virtual hosts.
+func checkURL(client *http.Client, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := strings.TrimPrefix(u.Fragment, "host=")
+	u.Fragment = ""
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	if host != "" {
+		req.Host = host
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package ports
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/playok/tomcatkit/internal/detector"
+)
+
+// ConflictKind describes why a port conflicts
+type ConflictKind string
+
+const (
+	ConflictDuplicate ConflictKind = "duplicate" // Used twice in the same instance
+	ConflictBound     ConflictKind = "bound"     // Bound by another process on this machine
+	ConflictInstance  ConflictKind = "instance"  // Configured by another Tomcat instance
+)
+
+// Conflict is a port clash found by the checker
+type Conflict struct {
+	Usage  Usage
+	Kind   ConflictKind
+	Detail string
+}
+
+// String returns a one-line description of the conflict
+func (c Conflict) String() string {
+	switch c.Kind {
+	case ConflictDuplicate:
+		return fmt.Sprintf("port %d (%s, %s) is also used by %s", c.Usage.Port, c.Usage.Kind, c.Usage.Where, c.Detail)
+	case ConflictBound:
+		return fmt.Sprintf("port %d (%s, %s) is already bound by %s", c.Usage.Port, c.Usage.Kind, c.Usage.Where, c.Detail)
+	default:
+		return fmt.Sprintf("port %d (%s, %s) is also configured in %s", c.Usage.Port, c.Usage.Kind, c.Usage.Where, c.Detail)
+	}
+}
+
+// Instance identifies another Tomcat instance to compare against
+type Instance struct {
+	CatalinaBase string
+	PID          int
+}
+
+// Checker compares ports against the live system and other instances.
+// The system state is captured once when the checker is created.
+type Checker struct {
+	ownPID     int
+	tomcatPIDs map[int]bool
+	sockets    []Socket
+	others     map[string][]Usage
+}
+
+// NewChecker snapshots bound sockets and the ports of other instances
+func NewChecker(ownPID int, others []Instance) *Checker {
+	c := &Checker{
+		ownPID:     ownPID,
+		tomcatPIDs: make(map[int]bool),
+		others:     make(map[string][]Usage),
+	}
+	if ownPID > 0 {
+		c.tomcatPIDs[ownPID] = true
+	}
+
+	c.sockets, _ = Listening()
+
+	for _, inst := range others {
+		if inst.PID > 0 {
+			c.tomcatPIDs[inst.PID] = true
+		}
+		if usages, err := CollectInstance(inst.CatalinaBase); err == nil {
+			c.others[inst.CatalinaBase] = usages
+		}
+	}
+	return c
+}
+
+// Discover finds the running PID of catalinaBase and all other detected instances
+func Discover(catalinaBase string) (int, []Instance) {
+	instances, err := detector.NewDetector().DetectAll()
+	if err != nil {
+		return 0, nil
+	}
+
+	self := cleanPath(catalinaBase)
+	ownPID := 0
+	seen := make(map[string]bool)
+	var others []Instance
+	for _, inst := range instances {
+		base := cleanPath(inst.CatalinaBase)
+		if base == self {
+			if inst.IsRunning {
+				ownPID = inst.PID
+			}
+			continue
+		}
+		if seen[base] {
+			continue
+		}
+		seen[base] = true
+		others = append(others, Instance{CatalinaBase: inst.CatalinaBase, PID: inst.PID})
+	}
+	return ownPID, others
+}
+
+// CheckUsage checks a single port against the rest of the instance
+// (own, excluding u itself), the live system and other instances
+func (c *Checker) CheckUsage(u Usage, own []Usage) []Conflict {
+	if !u.Active() {
+		return nil
+	}
+
+	var conflicts []Conflict
+	for _, other := range own {
+		if other.Where == u.Where || !other.Active() || other.Port != u.Port || other.UDP != u.UDP {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{Usage: u, Kind: ConflictDuplicate, Detail: other.Where})
+	}
+
+	for _, s := range c.sockets {
+		if s.Port != u.Port || s.UDP != u.UDP {
+			continue
+		}
+		// A running instance owns its own ports; when the owner is not
+		// visible we cannot tell, so only report it for stopped instances.
+		if s.PID == c.ownPID && c.ownPID > 0 || s.PID == 0 && c.ownPID > 0 {
+			continue
+		}
+		if u.Shared() && (s.PID == 0 || c.tomcatPIDs[s.PID]) {
+			continue
+		}
+		owner := "an unknown process"
+		if s.PID > 0 {
+			owner = fmt.Sprintf("%s (pid %d)", s.Process, s.PID)
+		}
+		conflicts = append(conflicts, Conflict{Usage: u, Kind: ConflictBound, Detail: owner})
+		break
+	}
+
+	for base, usages := range c.others {
+		for _, other := range usages {
+			if !other.Active() || other.Port != u.Port || other.UDP != u.UDP {
+				continue
+			}
+			if u.Shared() && other.Shared() {
+				continue
+			}
+			conflicts = append(conflicts, Conflict{Usage: u, Kind: ConflictInstance, Detail: fmt.Sprintf("%s (%s)", base, other.Where)})
+		}
+	}
+
+	return conflicts
+}
+
+// Check checks every port of an instance. A port used twice is reported
+// once, on the first of the two usages.
+func (c *Checker) Check(usages []Usage) []Conflict {
+	var conflicts []Conflict
+	for i, u := range usages {
+		conflicts = append(conflicts, c.CheckUsage(u, usages[i+1:])...)
+	}
+	return conflicts
+}
+
+func cleanPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,20 @@
+package ports
+
+import "testing"
+
+func TestCheckReportsDuplicateOnce(t *testing.T) {
+	c := &Checker{tomcatPIDs: make(map[int]bool), others: make(map[string][]Usage)}
+	usages := []Usage{
+		{Port: 8080, Kind: KindHTTP, Where: ConnectorWhere("Catalina", 0)},
+		{Port: 8080, Kind: KindHTTP, Where: ConnectorWhere("Catalina", 1)},
+		{Port: 8009, Kind: KindAJP, Where: ConnectorWhere("Catalina", 2)},
+	}
+
+	conflicts := c.Check(usages)
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %v, want one", conflicts)
+	}
+	if got := conflicts[0]; got.Kind != ConflictDuplicate || got.Usage.Where != usages[0].Where || got.Detail != usages[1].Where {
+		t.Errorf("conflict = %+v, want %s duplicated by %s", got, usages[0].Where, usages[1].Where)
+	}
+}
@@ -0,0 +1,141 @@
+package ports
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Socket is a port currently bound on the machine
+type Socket struct {
+	Port    int
+	UDP     bool
+	Inode   string
+	PID     int    // 0 when the owner is not visible (e.g. another user's process)
+	Process string // Command name of the owning process, if known
+}
+
+// Listening returns the TCP ports in LISTEN state and bound UDP ports.
+// It reads /proc/net and is only supported on Linux; other platforms
+// return an empty list.
+func Listening() ([]Socket, error) {
+	if runtime.GOOS != "linux" {
+		return nil, nil
+	}
+
+	var sockets []Socket
+	sources := []struct {
+		file  string
+		udp   bool
+		state string
+	}{
+		{"/proc/net/tcp", false, "0A"},
+		{"/proc/net/tcp6", false, "0A"},
+		{"/proc/net/udp", true, "07"},
+		{"/proc/net/udp6", true, "07"},
+	}
+
+	seen := make(map[string]bool)
+	for _, src := range sources {
+		entries, err := readProcNet(src.file, src.state)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, s := range entries {
+			s.UDP = src.udp
+			key := strconv.Itoa(s.Port) + "/" + strconv.FormatBool(s.UDP) + "/" + s.Inode
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			sockets = append(sockets, s)
+		}
+	}
+
+	resolveOwners(sockets)
+	return sockets, nil
+}
+
+// readProcNet parses a /proc/net/{tcp,udp}[6] table
+func readProcNet(path, state string) ([]Socket, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var sockets []Socket
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 || fields[3] != state {
+			continue
+		}
+		local := fields[1]
+		idx := strings.LastIndex(local, ":")
+		if idx < 0 {
+			continue
+		}
+		port, err := strconv.ParseInt(local[idx+1:], 16, 32)
+		if err != nil {
+			continue
+		}
+		sockets = append(sockets, Socket{Port: int(port), Inode: fields[9]})
+	}
+	return sockets, scanner.Err()
+}
+
+// resolveOwners maps socket inodes to owning processes via /proc/<pid>/fd
+func resolveOwners(sockets []Socket) {
+	if len(sockets) == 0 {
+		return
+	}
+
+	byInode := make(map[string][]int)
+	for i, s := range sockets {
+		byInode["socket:["+s.Inode+"]"] = append(byInode["socket:["+s.Inode+"]"], i)
+	}
+
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return
+	}
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join("/proc", proc.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+		var comm string
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil {
+				continue
+			}
+			indexes, ok := byInode[link]
+			if !ok {
+				continue
+			}
+			if comm == "" {
+				if data, err := os.ReadFile(filepath.Join("/proc", proc.Name(), "comm")); err == nil {
+					comm = strings.TrimSpace(string(data))
+				}
+			}
+			for _, i := range indexes {
+				sockets[i].PID = pid
+				sockets[i].Process = comm
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package ports
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/playok/tomcatkit/internal/config/connector"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/config/server"
+)
+
+// Kind identifies what a port is used for
+type Kind string
+
+const (
+	KindShutdown   Kind = "shutdown"
+	KindHTTP       Kind = "http"
+	KindHTTPS      Kind = "https"
+	KindAJP        Kind = "ajp"
+	KindReceiver   Kind = "cluster-receiver"
+	KindMembership Kind = "cluster-membership"
+	KindJMX        Kind = "jmx"
+)
+
+// Usage is a single port used by a Tomcat instance
+type Usage struct {
+	Port  int
+	Text  string // Port as written when it is a ${...} expression
+	Kind  Kind
+	Where string // Location in the configuration, e.g. "Catalina/Connector[0]"
+	UDP   bool
+}
+
+// Shared reports whether the port is expected to be shared between
+// instances (multicast membership is joined by every cluster member)
+func (u Usage) Shared() bool {
+	return u.Kind == KindMembership
+}
+
+// Active reports whether the port is actually bound (-1 disables the
+// shutdown port and 0 picks a random free port). Unresolved ${...}
+// expressions are not active either.
+func (u Usage) Active() bool {
+	return u.Port > 0
+}
+
+// ConnectorWhere returns the location label used for a connector
+func ConnectorWhere(serviceName string, index int) string {
+	return fmt.Sprintf("%s/Connector[%d]", serviceName, index)
+}
+
+// Collect returns every port configured in server.xml. Ports written as
+// ${...} expressions are resolved with r; unresolved ones are reported
+// with port 0 and their text.
+func Collect(srv *server.Server, r *placeholder.Resolver) []Usage {
+	if srv == nil {
+		return nil
+	}
+
+	usages := []Usage{newUsage(srv.Port, r, KindShutdown, "Server")}
+	for _, svc := range srv.Services {
+		for i, conn := range svc.Connectors {
+			kind := KindHTTP
+			if connector.GetConnectorType(conn.Protocol) == connector.ConnectorTypeAJP {
+				kind = KindAJP
+			} else if conn.SSLEnabled.Value(r) {
+				kind = KindHTTPS
+			}
+			usages = append(usages, newUsage(conn.Port, r, kind, ConnectorWhere(svc.Name, i)))
+		}
+
+		cluster := svc.Engine.Cluster
+		if cluster == nil || cluster.Channel == nil {
+			continue
+		}
+		if rcv := cluster.Channel.Receiver; rcv != nil {
+			port := rcv.Port
+			if port == "" {
+				port = placeholder.IntOf(4000)
+			}
+			usages = append(usages, newUsage(port, r, KindReceiver, svc.Name+"/Cluster/Receiver"))
+		}
+		if m := cluster.Channel.Membership; m != nil {
+			port := m.Port
+			if port == "" {
+				port = placeholder.IntOf(45564)
+			}
+			u := newUsage(port, r, KindMembership, svc.Name+"/Cluster/Membership")
+			u.UDP = true
+			usages = append(usages, u)
+		}
+	}
+	return usages
+}
+
+// newUsage resolves a port attribute
+func newUsage(port placeholder.Int, r *placeholder.Resolver, kind Kind, where string) Usage {
+	u := Usage{Port: port.Value(r), Kind: kind, Where: where}
+	if port.IsPlaceholder() {
+		u.Text = string(port)
+	}
+	return u
+}
+
+var jmxPortRe = regexp.MustCompile(`-Dcom\.sun\.management\.jmxremote\.(port|rmi\.port)=(\d+)`)
+
+// CollectJMX returns JMX ports configured in bin/setenv.sh or bin/setenv.bat
+func CollectJMX(catalinaBase string) []Usage {
+	var usages []Usage
+	seen := make(map[int]bool)
+	for _, name := range []string{"setenv.sh", "setenv.bat"} {
+		path := filepath.Join(catalinaBase, "bin", name)
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			for _, m := range jmxPortRe.FindAllStringSubmatch(scanner.Text(), -1) {
+				port, err := strconv.Atoi(m[2])
+				if err != nil || seen[port] {
+					continue
+				}
+				seen[port] = true
+				usages = append(usages, Usage{Port: port, Kind: KindJMX, Where: "bin/" + name})
+			}
+		}
+		file.Close()
+	}
+	return usages
+}
+
+// CollectInstance loads server.xml of an instance and returns all its ports
+func CollectInstance(catalinaBase string) ([]Usage, error) {
+	svc := server.NewConfigService(catalinaBase)
+	if err := svc.Load(); err != nil {
+		return nil, err
+	}
+	return append(Collect(svc.GetServer(), svc.Resolver()), CollectJMX(catalinaBase)...), nil
+}
@@ -0,0 +1,288 @@
+package systemd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/config/jvm"
+)
+
+// UnitDir is where administrator-provided units are installed
+const UnitDir = "/etc/systemd/system"
+
+// Defaults for generated units
+const (
+	DefaultUser        = "tomcat"
+	DefaultGroup       = "tomcat"
+	DefaultLimitNOFILE = 65536
+	// defaultPIDFile is relative to CATALINA_BASE
+	defaultPIDFile = "temp/tomcat.pid"
+)
+
+// WritableDirs are the CATALINA_BASE directories Tomcat writes to at
+// runtime. They stay writable when ProtectSystem makes the rest of the
+// file system read-only. webapps is included because the default Host
+// unpacks WAR files there, and conf/Catalina because deployment writes
+// context descriptors there.
+var WritableDirs = []string{"logs", "work", "temp", "webapps", filepath.Join("conf", "Catalina")}
+
+// optionalWritableDirs are WritableDirs that Tomcat creates on its own, so a
+// missing one must not keep the service from starting
+var optionalWritableDirs = map[string]bool{filepath.Join("conf", "Catalina"): true}
+
+// Options describe the unit to generate
+type Options struct {
+	Instance    *config.TomcatInstance
+	User        string
+	Group       string
+	JavaHome    string
+	PIDFile     string // Empty uses CATALINA_BASE/temp/tomcat.pid
+	LimitNOFILE int    // 0 leaves the systemd default
+	Hardening   bool   // NoNewPrivileges, ProtectSystem=strict and friends
+
+	// Template generates tomcat@.service, where the instance name (%i)
+	// is a directory below BasesDir used as CATALINA_BASE
+	Template bool
+	BasesDir string
+}
+
+// DefaultOptions returns options for an instance. JAVA_HOME and
+// CATALINA_PID are taken from the instance's setenv.sh when it sets them,
+// as catalina.sh reads the script after the unit's environment.
+func DefaultOptions(inst *config.TomcatInstance) Options {
+	opts := Options{
+		Instance:    inst,
+		User:        DefaultUser,
+		Group:       DefaultGroup,
+		JavaHome:    os.Getenv("JAVA_HOME"),
+		LimitNOFILE: DefaultLimitNOFILE,
+		Hardening:   true,
+	}
+	if inst == nil {
+		return opts
+	}
+
+	svc := jvm.NewConfigService(inst.CatalinaBase, jvm.ScriptSh)
+	if err := svc.Load(); err == nil && svc.Exists() {
+		setenv := svc.GetOptions()
+		if setenv.JavaHome != "" {
+			opts.JavaHome = expandCatalinaVars(setenv.JavaHome, inst)
+		}
+		if setenv.CatalinaPID != "" {
+			opts.PIDFile = expandCatalinaVars(setenv.CatalinaPID, inst)
+		}
+	}
+	if inst.CatalinaBase != "" {
+		opts.BasesDir = filepath.Dir(inst.CatalinaBase)
+	}
+	return opts
+}
+
+// expandCatalinaVars replaces $CATALINA_HOME and $CATALINA_BASE in a value
+// taken from setenv.sh
+func expandCatalinaVars(value string, inst *config.TomcatInstance) string {
+	value = strings.Trim(value, `"'`)
+	return os.Expand(value, func(name string) string {
+		switch name {
+		case "CATALINA_HOME":
+			return inst.CatalinaHome
+		case "CATALINA_BASE":
+			return inst.CatalinaBase
+		}
+		return "$" + name
+	})
+}
+
+// UnitName returns the file name of the unit, e.g. "tomcat.service",
+// "tomcat-app1.service" for a separate CATALINA_BASE or "tomcat@.service"
+func (o Options) UnitName() string {
+	if o.Template {
+		return "tomcat@.service"
+	}
+	if o.Instance == nil || o.Instance.CatalinaBase == "" || o.Instance.CatalinaBase == o.Instance.CatalinaHome {
+		return "tomcat.service"
+	}
+	return "tomcat-" + unitNameEscape(filepath.Base(o.Instance.CatalinaBase)) + ".service"
+}
+
+// unitNameEscape replaces characters systemd does not allow in unit names
+func unitNameEscape(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// Validate checks that the unit can be generated
+func (o Options) Validate() error {
+	if o.Instance == nil || o.Instance.CatalinaHome == "" {
+		return fmt.Errorf("CATALINA_HOME is required")
+	}
+	if !filepath.IsAbs(o.Instance.CatalinaHome) {
+		return fmt.Errorf("CATALINA_HOME must be an absolute path: %s", o.Instance.CatalinaHome)
+	}
+	if o.Template {
+		if o.BasesDir == "" || !filepath.IsAbs(o.BasesDir) {
+			return fmt.Errorf("a template unit needs the absolute directory holding the instances")
+		}
+	} else if o.Instance.CatalinaBase != "" && !filepath.IsAbs(o.Instance.CatalinaBase) {
+		return fmt.Errorf("CATALINA_BASE must be an absolute path: %s", o.Instance.CatalinaBase)
+	}
+	if o.JavaHome != "" && !filepath.IsAbs(o.JavaHome) {
+		return fmt.Errorf("JAVA_HOME must be an absolute path: %s", o.JavaHome)
+	}
+	if o.PIDFile != "" && !filepath.IsAbs(o.PIDFile) {
+		return fmt.Errorf("PID file must be an absolute path: %s", o.PIDFile)
+	}
+	if o.LimitNOFILE < 0 {
+		return fmt.Errorf("LimitNOFILE must not be negative")
+	}
+	return nil
+}
+
+// catalinaBase returns CATALINA_BASE as written in the unit
+func (o Options) catalinaBase() string {
+	if o.Template {
+		return filepath.Join(o.BasesDir, "%i")
+	}
+	if o.Instance.CatalinaBase == "" {
+		return o.Instance.CatalinaHome
+	}
+	return o.Instance.CatalinaBase
+}
+
+// pidFile returns the PID file as written in the unit
+func (o Options) pidFile() string {
+	if o.PIDFile != "" && !o.Template {
+		return o.PIDFile
+	}
+	return filepath.Join(o.catalinaBase(), defaultPIDFile)
+}
+
+// Generate returns the unit file. Tomcat is started with startup.sh as a
+// forking service, so setenv.sh is read exactly as with a manual start.
+func Generate(o Options) (string, error) {
+	if err := o.Validate(); err != nil {
+		return "", err
+	}
+
+	home := o.Instance.CatalinaHome
+	base := o.catalinaBase()
+	pid := o.pidFile()
+
+	var b strings.Builder
+	b.WriteString("# Generated by TomcatKit\n")
+	b.WriteString("[Unit]\n")
+	if o.Template {
+		b.WriteString("Description=Apache Tomcat (%i)\n")
+	} else {
+		fmt.Fprintf(&b, "Description=Apache Tomcat (%s)\n", base)
+	}
+	b.WriteString("After=network.target\n")
+	b.WriteString("\n[Service]\n")
+	b.WriteString("Type=forking\n")
+	if o.User != "" {
+		fmt.Fprintf(&b, "User=%s\n", o.User)
+	}
+	if o.Group != "" {
+		fmt.Fprintf(&b, "Group=%s\n", o.Group)
+	}
+	if o.JavaHome != "" {
+		fmt.Fprintf(&b, "Environment=%s\n", quote("JAVA_HOME="+o.JavaHome))
+	}
+	fmt.Fprintf(&b, "Environment=%s\n", quote("CATALINA_HOME="+home))
+	fmt.Fprintf(&b, "Environment=%s\n", quote("CATALINA_BASE="+base))
+	fmt.Fprintf(&b, "Environment=%s\n", quote("CATALINA_PID="+pid))
+	fmt.Fprintf(&b, "PIDFile=%s\n", pid)
+	fmt.Fprintf(&b, "ExecStart=%s\n", filepath.Join(home, "bin", "startup.sh"))
+	fmt.Fprintf(&b, "ExecStop=%s\n", filepath.Join(home, "bin", "shutdown.sh"))
+	// The JVM exits with 143 when it is stopped with SIGTERM
+	b.WriteString("SuccessExitStatus=143\n")
+	b.WriteString("TimeoutStopSec=30\n")
+	b.WriteString("Restart=on-failure\n")
+	if o.LimitNOFILE > 0 {
+		fmt.Fprintf(&b, "LimitNOFILE=%d\n", o.LimitNOFILE)
+	}
+
+	if o.Hardening {
+		b.WriteString("\n# Hardening\n")
+		b.WriteString("NoNewPrivileges=true\n")
+		b.WriteString("PrivateTmp=true\n")
+		b.WriteString("ProtectSystem=strict\n")
+		if !underHome(home) && !underHome(base) {
+			b.WriteString("ProtectHome=true\n")
+		}
+		b.WriteString("ProtectKernelTunables=true\n")
+		b.WriteString("ProtectKernelModules=true\n")
+		b.WriteString("ProtectControlGroups=true\n")
+		b.WriteString("RestrictSUIDSGID=true\n")
+		b.WriteString("LockPersonality=true\n")
+		for _, dir := range o.writablePaths() {
+			fmt.Fprintf(&b, "ReadWritePaths=%s\n", dir)
+		}
+	}
+
+	b.WriteString("\n[Install]\n")
+	b.WriteString("WantedBy=multi-user.target\n")
+	return b.String(), nil
+}
+
+// writablePaths returns the directories the service writes to
+func (o Options) writablePaths() []string {
+	base := o.catalinaBase()
+	paths := make([]string, 0, len(WritableDirs)+1)
+	for _, dir := range WritableDirs {
+		path := filepath.Join(base, dir)
+		if optionalWritableDirs[dir] {
+			// systemd ignores a missing path prefixed with "-"
+			path = "-" + path
+		}
+		paths = append(paths, path)
+	}
+	// A PID file outside temp needs its own directory to be writable
+	if pidDir := filepath.Dir(o.pidFile()); pidDir != filepath.Join(base, "temp") {
+		paths = append(paths, pidDir)
+	}
+	return paths
+}
+
+// underHome reports whether a path is hidden by ProtectHome
+func underHome(path string) bool {
+	for _, dir := range []string{"/home", "/root", "/run/user"} {
+		if path == dir || strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// quote wraps an assignment in double quotes when it contains spaces
+func quote(assignment string) string {
+	if !strings.ContainsAny(assignment, " \t\"\\") {
+		return assignment
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(assignment)
+	return `"` + escaped + `"`
+}
+
+// Write saves a unit file. An existing file is kept as <path>.bak.
+func Write(path, content string) error {
+	if data, err := os.ReadFile(path); err == nil {
+		if err := os.WriteFile(path+".bak", data, 0644); err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write unit file: %w", err)
+	}
+	return nil
+}
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/playok/tomcatkit/internal/config"
 	"github.com/playok/tomcatkit/internal/config/server"
 	"github.com/playok/tomcatkit/internal/detector"
 	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/playok/tomcatkit/internal/lifecycle"
 	"github.com/playok/tomcatkit/internal/tui/views"
 	"github.com/rivo/tview"
 )
@@ -29,6 +31,10 @@ type App struct {
 	statusBar       *tview.TextView
 	infoPanel       *tview.TextView
 	instance        *config.TomcatInstance
+	checkpoint      *lifecycle.Checkpoint
+	root            *tview.Flex              // Banner above the pages
+	banner          *tview.TextView          // Production warning
+	scanned         []*config.TomcatInstance // Found by the last instance scan
 	settingsManager *config.SettingsManager
 }
 
@@ -170,6 +176,31 @@ func (a *App) setupUI() {
 		a.showWebMenu()
 	})
 
+	// JVM options
+	a.mainMenu.AddItem("[::b]"+i18n.T("menu.jvm")+"[::-]", i18n.T("menu.jvm.desc"), 'm', func() {
+		a.showJVMMenu()
+	})
+
+	// catalina.properties
+	a.mainMenu.AddItem("[::b]"+i18n.T("menu.catprops")+"[::-]", i18n.T("menu.catprops.desc"), 'p', func() {
+		a.showCatalinaPropsMenu()
+	})
+
+	// Start / Stop
+	a.mainMenu.AddItem("[::b]"+i18n.T("menu.lifecycle")+"[::-]", i18n.T("menu.lifecycle.desc"), 'r', func() {
+		a.showLifecycleMenu()
+	})
+
+	// systemd unit
+	a.mainMenu.AddItem("[::b]"+i18n.T("menu.systemd")+"[::-]", i18n.T("menu.systemd.desc"), 'y', func() {
+		a.showSystemdMenu()
+	})
+
+	// Fleet
+	a.mainMenu.AddItem("[::b]"+i18n.T("menu.fleet")+"[::-]", i18n.T("menu.fleet.desc"), 'f', func() {
+		a.showFleet()
+	})
+
 	// Separator
 	a.mainMenu.AddItem("─────────────────────────", "", 0, nil)
 
@@ -219,7 +250,18 @@ func (a *App) setupUI() {
 		return event
 	})
 
-	a.app.SetRoot(a.pages, true)
+	// The production warning stays visible on every page
+	a.banner = tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetDynamicColors(true)
+	a.banner.SetBackgroundColor(tcell.ColorRed)
+	a.banner.SetTextColor(tcell.ColorWhite)
+	a.root = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(a.banner, 0, 0, false).
+		AddItem(a.pages, 0, 1, true)
+
+	a.app.SetRoot(a.root, true)
 }
 
 // updateInstanceInfo updates the info panel with current instance details
@@ -233,26 +275,85 @@ func (a *App) updateInstanceInfo() {
 			i18n.T("instance.info.autodetect"))
 		a.infoPanel.SetText(noInstanceText)
 		a.infoPanel.SetBorder(true).SetTitle(" " + i18n.T("instance.info") + " ").SetBorderColor(tcell.ColorYellow)
+		a.checkpoint = nil
+		a.updateBanner()
 		return
 	}
 
+	// Changes are compared with the configuration found when the instance
+	// was opened, so that they can be applied safely and rolled back
+	if a.checkpoint == nil || a.checkpoint.Base != a.instance.CatalinaBase {
+		a.checkpoint, _ = lifecycle.TakeCheckpoint(a.instance.CatalinaBase)
+	}
+
 	status := "[red]" + i18n.T("instance.stopped") + "[-]"
 	if a.instance.IsRunning {
 		status = fmt.Sprintf("[green]%s[-] (PID: %d)", i18n.T("instance.running"), a.instance.PID)
 	}
 
-	info := fmt.Sprintf("[::b]%s[::-]\n\n[yellow]%s:[-]       %s\n[yellow]CATALINA_HOME:[-] %s\n[yellow]CATALINA_BASE:[-] %s\n[yellow]%s:[-]        %s\n\n[green]%s[-]",
-		i18n.T("instance.info"),
+	versionText := a.instance.Version
+	if v := a.instance.ParsedVersion(); !v.IsZero() {
+		versionText = fmt.Sprintf("%s (Tomcat %s)", v, v.Line())
+		if v.Built != "" {
+			versionText = fmt.Sprintf("%s (Tomcat %s, %s)", v, v.Line(), v.Built)
+		}
+	}
+
+	// Details of the running process, when the detector found them
+	process := ""
+	if a.instance.IsRunning {
+		if a.instance.User != "" {
+			process += fmt.Sprintf("\n[yellow]%s:[-] %s", i18n.T("instance.user"), a.instance.User)
+		}
+		if a.instance.JavaVersion != "" {
+			process += fmt.Sprintf("\n[yellow]Java:[-] %s (%s)", a.instance.JavaVersion, a.instance.JavaHome)
+		}
+		if !a.instance.StartTime.IsZero() {
+			process += fmt.Sprintf("\n[yellow]%s:[-] %s", i18n.T("instance.started"), a.instance.StartTime.Format("2006-01-02 15:04:05"))
+		}
+	}
+	if layout := a.instance.Layout; layout != nil {
+		process += fmt.Sprintf("\n[yellow]%s:[-] %s", i18n.T("instance.layout"), layout.Description())
+		if layout.ConfDir != filepath.Join(a.instance.CatalinaBase, "conf") {
+			process += fmt.Sprintf("\n[yellow]conf:[-] %s", layout.ConfDir)
+		}
+		if layout.LogsDir != filepath.Join(a.instance.CatalinaBase, "logs") {
+			process += fmt.Sprintf("\n[yellow]logs:[-] %s", layout.LogsDir)
+		}
+	}
+	if ctr := a.instance.Container; ctr != nil {
+		process += fmt.Sprintf("\n[yellow]%s:[-] [fuchsia]%s[-] %s", i18n.T("instance.container"), tview.Escape(ctr.Label()), tview.Escape(ctr.Image))
+		process += fmt.Sprintf("\n[yellow]%s:[-] %s", i18n.T("instance.container.base"), ctr.CatalinaBase)
+		if !ctr.Mounted {
+			process += "\n[red]" + i18n.T("instance.container.unmounted") + "[-]"
+		}
+	}
+
+	// Name, tag and notes from the inventory
+	heading := "[::b]" + i18n.T("instance.info") + "[::-]"
+	if a.settingsManager != nil {
+		if entry := a.settingsManager.FindInventoryEntry(a.instance); entry != nil {
+			heading = inventoryName(entry) + environmentBadge(entry.Environment)
+			if entry.Notes != "" {
+				process += "\n\n[gray]" + tview.Escape(entry.Notes) + "[-]"
+			}
+		}
+	}
+
+	info := fmt.Sprintf("%s\n\n[yellow]%s:[-]       %s\n[yellow]CATALINA_HOME:[-] %s\n[yellow]CATALINA_BASE:[-] %s\n[yellow]%s:[-]        %s%s\n\n[green]%s[-]",
+		heading,
 		i18n.T("instance.version"),
-		a.instance.Version,
+		versionText,
 		a.instance.CatalinaHome,
 		a.instance.CatalinaBase,
 		i18n.T("instance.status"),
 		status,
+		process,
 		i18n.T("instance.ready"))
 
 	a.infoPanel.SetText(info)
 	a.infoPanel.SetBorder(true).SetTitle(" " + i18n.T("instance.info") + " ").SetBorderColor(tcell.ColorYellow)
+	a.updateBanner()
 
 	// Save to settings
 	if a.settingsManager != nil {
@@ -323,6 +424,31 @@ func (a *App) rebuildMainMenu() {
 		a.showWebMenu()
 	})
 
+	// JVM options
+	a.mainMenu.AddItem("[::b]"+i18n.T("menu.jvm")+"[::-]", i18n.T("menu.jvm.desc"), 'm', func() {
+		a.showJVMMenu()
+	})
+
+	// catalina.properties
+	a.mainMenu.AddItem("[::b]"+i18n.T("menu.catprops")+"[::-]", i18n.T("menu.catprops.desc"), 'p', func() {
+		a.showCatalinaPropsMenu()
+	})
+
+	// Start / Stop
+	a.mainMenu.AddItem("[::b]"+i18n.T("menu.lifecycle")+"[::-]", i18n.T("menu.lifecycle.desc"), 'r', func() {
+		a.showLifecycleMenu()
+	})
+
+	// systemd unit
+	a.mainMenu.AddItem("[::b]"+i18n.T("menu.systemd")+"[::-]", i18n.T("menu.systemd.desc"), 'y', func() {
+		a.showSystemdMenu()
+	})
+
+	// Fleet
+	a.mainMenu.AddItem("[::b]"+i18n.T("menu.fleet")+"[::-]", i18n.T("menu.fleet.desc"), 'f', func() {
+		a.showFleet()
+	})
+
 	// Separator
 	a.mainMenu.AddItem("─────────────────────────", "", 0, nil)
 
@@ -349,83 +475,296 @@ func (a *App) showInstanceSelector() {
 
 	list := tview.NewList().ShowSecondaryText(true)
 
-	// Add recent instances from settings first
-	if a.settingsManager != nil {
-		recentInstances := a.settingsManager.GetRecentInstances()
-		if len(recentInstances) > 0 {
-			list.AddItem("[::b]"+i18n.T("instance.recent")+"[::-]", "─────────────────────", 0, nil)
-			for _, recent := range recentInstances {
+	// Search and environment filter above the list
+	search := tview.NewInputField().
+		SetLabel(i18n.T("instance.search") + ": ").
+		SetFieldWidth(30)
+	envFilter := tview.NewDropDown().SetLabel("  " + i18n.T("inventory.environment") + ": ")
+	var envOptions []string
+	for _, env := range config.Environments() {
+		envOptions = append(envOptions, environmentLabel(env, true))
+	}
+	envFilter.SetOptions(envOptions, nil)
+	envFilter.SetCurrentOption(0)
+
+	// items maps list indices to their instances, for editing inventory
+	// entries of the highlighted item
+	var items map[int]*config.TomcatInstance
+
+	fill := func() {
+		query := strings.TrimSpace(search.GetText())
+		envIndex, _ := envFilter.GetCurrentOption()
+		env := config.EnvNone
+		if envIndex > 0 {
+			env = config.Environments()[envIndex]
+		}
+		filtering := query != "" || env != config.EnvNone
+
+		list.Clear()
+		items = make(map[int]*config.TomcatInstance)
+		add := func(instance *config.TomcatInstance) {
+			items[list.GetItemCount()] = instance
+			a.addInstanceItem(list, instance)
+		}
+
+		// Named instances from the inventory first
+		inventoried := make(map[string]bool)
+		if a.settingsManager != nil {
+			entries := a.settingsManager.FilterInventory(query, env)
+			if len(entries) > 0 {
+				list.AddItem("[::b]"+i18n.T("inventory.title")+"[::-]", "─────────────────────", 0, nil)
+				for _, entry := range entries {
+					inventoried[entry.Key()] = true
+					instance := entry.Instance()
+					// Prefer the detected instance, which knows whether it runs
+					for _, detected := range instances {
+						if config.InstanceKey(detected.CatalinaHome, detected.CatalinaBase) == entry.Key() {
+							instance = detected
+							break
+						}
+					}
+					if instance.VersionInfo.IsZero() {
+						instance.VersionInfo = d.DetectVersionInfo(instance.CatalinaHome)
+						instance.Version = instance.VersionInfo.String()
+					}
+					add(instance)
+				}
+				list.AddItem("", "", 0, nil) // Spacer
+			}
+			for _, entry := range a.settingsManager.GetInventory() {
+				inventoried[entry.Key()] = true
+			}
+		}
+		known := func(instance *config.TomcatInstance) bool {
+			return inventoried[config.InstanceKey(instance.CatalinaHome, instance.CatalinaBase)]
+		}
+
+		// Add recent instances from settings
+		if a.settingsManager != nil && env == config.EnvNone {
+			first := true
+			for _, recent := range a.settingsManager.GetRecentInstances() {
 				inst := recent // capture for closure
+				if known(&inst) || !a.instanceMatches(&inst, query, env) {
+					continue
+				}
 				// Check if path still exists
 				serverXml := filepath.Join(inst.CatalinaBase, "conf", "server.xml")
-				if _, err := os.Stat(serverXml); err == nil {
-					list.AddItem(
-						fmt.Sprintf("[yellow]%s[-]", inst.CatalinaHome),
-						fmt.Sprintf("%s: %s", i18n.T("instance.version"), inst.Version),
-						0,
-						func() {
-							a.instance = &inst
-							a.updateInstanceInfo()
-							a.pages.SwitchToPage("main")
-							a.app.SetFocus(a.mainMenu)
-						},
-					)
+				if _, err := os.Stat(serverXml); err != nil {
+					continue
+				}
+				if first {
+					list.AddItem("[::b]"+i18n.T("instance.recent")+"[::-]", "─────────────────────", 0, nil)
+					first = false
 				}
+				secondary := fmt.Sprintf("%s: %s", i18n.T("instance.version"), inst.Version)
+				if inst.CatalinaBase != "" && inst.CatalinaBase != inst.CatalinaHome {
+					secondary += "  CATALINA_BASE: " + inst.CatalinaBase
+				}
+				items[list.GetItemCount()] = &inst
+				list.AddItem(
+					fmt.Sprintf("[yellow]%s[-]", inst.CatalinaHome),
+					secondary,
+					0,
+					func() {
+						a.instance = &inst
+						a.updateInstanceInfo()
+						a.pages.SwitchToPage("main")
+						a.app.SetFocus(a.mainMenu)
+					},
+				)
+			}
+			if !first {
+				list.AddItem("", "", 0, nil) // Spacer
 			}
-			list.AddItem("", "", 0, nil) // Spacer
 		}
-	}
 
-	// Add detected instances
-	if len(instances) > 0 {
-		list.AddItem("[::b]"+i18n.T("instance.detected")+"[::-]", "─────────────────────", 0, nil)
-		for _, inst := range instances {
-			instance := inst // capture for closure
-			status := ""
-			if instance.IsRunning {
-				status = " [green](" + i18n.T("instance.running") + ")[-]"
+		// Add detected instances
+		var detected []*config.TomcatInstance
+		for _, instance := range instances {
+			if !known(instance) && a.instanceMatches(instance, query, env) {
+				detected = append(detected, instance)
 			}
-			list.AddItem(
-				fmt.Sprintf("Tomcat %s%s", instance.Version, status),
-				instance.CatalinaHome,
-				0,
-				func() {
-					a.instance = instance
-					a.updateInstanceInfo()
-					a.pages.SwitchToPage("main")
-					a.app.SetFocus(a.mainMenu)
-				},
-			)
-		}
-	} else {
-		list.AddItem("[gray]"+i18n.T("instance.none")+"[-]", i18n.T("instance.manual.desc"), 0, nil)
-	}
+		}
+		if len(detected) > 0 {
+			list.AddItem("[::b]"+i18n.T("instance.detected")+"[::-]", "─────────────────────", 0, nil)
+			for _, instance := range detected {
+				add(instance)
+			}
+		} else if filtering {
+			list.AddItem("[gray]"+i18n.T("instance.search.none")+"[-]", "", 0, nil)
+		} else if len(inventoried) == 0 {
+			list.AddItem("[gray]"+i18n.T("instance.none")+"[-]", i18n.T("instance.manual.desc"), 0, nil)
+		}
 
-	list.AddItem("", "", 0, nil) // Spacer
+		// Add instances found by the last scan that detection missed
+		var scanned []*config.TomcatInstance
+		for _, instance := range a.scanned {
+			if known(instance) || !a.instanceMatches(instance, query, env) {
+				continue
+			}
+			isDetected := false
+			for _, other := range instances {
+				if other.CatalinaHome == instance.CatalinaHome && other.CatalinaBase == instance.CatalinaBase {
+					isDetected = true
+					break
+				}
+			}
+			if !isDetected {
+				scanned = append(scanned, instance)
+			}
+		}
+		if len(scanned) > 0 {
+			list.AddItem("", "", 0, nil) // Spacer
+			list.AddItem("[::b]"+i18n.T("instance.scanned")+"[::-]", "─────────────────────", 0, nil)
+			for _, instance := range scanned {
+				add(instance)
+			}
+		}
 
-	list.AddItem("[green]► "+i18n.T("instance.manual")+"[-]", i18n.T("instance.manual.desc"), 'm', func() {
-		a.showManualPathInput()
-	})
+		list.AddItem("", "", 0, nil) // Spacer
 
-	list.AddItem("[red]"+i18n.T("common.cancel")+"[-]", i18n.T("common.return"), 0, func() {
-		a.pages.SwitchToPage("main")
-		a.app.SetFocus(a.mainMenu)
+		list.AddItem("[green]⌕ "+i18n.T("instance.scan")+"[-]", i18n.T("instance.scan.desc"), 's', func() {
+			a.showInstanceScan()
+		})
+
+		list.AddItem("[green]► "+i18n.T("instance.manual")+"[-]", i18n.T("instance.manual.desc"), 'm', func() {
+			a.showManualPathInput()
+		})
+
+		list.AddItem("[green]+ "+i18n.T("instance.new")+"[-]", i18n.T("instance.new.desc"), 'n', func() {
+			a.showNewInstanceWizard()
+		})
+
+		if a.instance != nil {
+			list.AddItem("[green]⧉ "+i18n.T("instance.clone")+"[-]", i18n.T("instance.clone.desc"), 'c', func() {
+				a.showCloneInstance()
+			})
+		}
+
+		list.AddItem("[red]"+i18n.T("common.cancel")+"[-]", i18n.T("common.return"), 0, func() {
+			a.pages.SwitchToPage("main")
+			a.app.SetFocus(a.mainMenu)
+		})
+	}
+	fill()
+
+	search.SetChangedFunc(func(string) { fill() })
+	search.SetDoneFunc(func(tcell.Key) { a.app.SetFocus(list) })
+	envFilter.SetSelectedFunc(func(string, int) {
+		fill()
+		a.app.SetFocus(list)
 	})
+	envFilter.SetDoneFunc(func(tcell.Key) { a.app.SetFocus(list) })
+
+	filterBar := tview.NewFlex().
+		AddItem(search, 0, 1, false).
+		AddItem(envFilter, 0, 1, false)
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(filterBar, 1, 0, false).
+		AddItem(list, 0, 1, true)
+	layout.SetBorder(true).
+		SetTitle(" " + i18n.T("instance.title") + "  [gray]" + i18n.T("instance.selector.keys") + "[-] ").
+		SetBorderColor(tcell.ColorGreen)
 
-	list.SetBorder(true).SetTitle(" " + i18n.T("instance.title") + " ").SetBorderColor(tcell.ColorGreen)
 	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEscape {
+		switch {
+		case event.Key() == tcell.KeyEscape:
 			a.pages.SwitchToPage("main")
 			a.app.SetFocus(a.mainMenu)
 			return nil
+		case event.Key() == tcell.KeyTab:
+			a.app.SetFocus(search)
+			return nil
+		case event.Rune() == '/':
+			a.app.SetFocus(search)
+			return nil
+		case event.Rune() == 'f':
+			a.app.SetFocus(envFilter)
+			return nil
+		case event.Rune() == 'e':
+			if instance := items[list.GetCurrentItem()]; instance != nil {
+				a.showInventoryEditor(instance, a.showInstanceSelector)
+			}
+			return nil
 		}
 		return event
 	})
 
-	a.pages.AddAndSwitchToPage("instance-selector", list, true)
+	a.pages.AddAndSwitchToPage("instance-selector", layout, true)
 	a.app.SetFocus(list)
 }
 
+// instanceMatches reports whether an instance passes the selector's search
+// and environment filter, judged by its inventory entry and its paths
+func (a *App) instanceMatches(instance *config.TomcatInstance, query string, env config.Environment) bool {
+	var entry *config.InventoryEntry
+	if a.settingsManager != nil {
+		entry = a.settingsManager.FindInventoryEntry(instance)
+	}
+	if env != config.EnvNone && (entry == nil || entry.Environment != env) {
+		return false
+	}
+	if entry != nil && entry.Matches(query) {
+		return true
+	}
+	query = strings.ToLower(query)
+	for _, field := range []string{instance.CatalinaHome, instance.CatalinaBase, instance.Version} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// addInstanceItem adds an instance to the selector, under its inventory
+// name when it has one
+func (a *App) addInstanceItem(list *tview.List, instance *config.TomcatInstance) {
+	status := ""
+	secondary := instance.CatalinaHome
+	if instance.CatalinaBase != "" && instance.CatalinaBase != instance.CatalinaHome {
+		secondary += "  CATALINA_BASE: " + instance.CatalinaBase
+	}
+	if instance.IsRunning {
+		status = " [green](" + i18n.T("instance.running") + ")[-]"
+		secondary += fmt.Sprintf("  PID %d", instance.PID)
+		if instance.User != "" {
+			secondary += " " + instance.User
+		}
+	}
+	if layout := instance.Layout; layout != nil {
+		secondary += "  (" + layout.Description() + ")"
+	}
+	if ctr := instance.Container; ctr != nil {
+		status += " [fuchsia][" + tview.Escape(ctr.Label()) + "][-]"
+		if !ctr.Mounted {
+			secondary += "  " + i18n.T("instance.container.unmounted")
+		}
+	}
+
+	title := fmt.Sprintf("Tomcat %s%s", instance.Version, status)
+	if a.settingsManager != nil {
+		if entry := a.settingsManager.FindInventoryEntry(instance); entry != nil {
+			title = inventoryName(entry) + environmentBadge(entry.Environment) + "  [gray]Tomcat " + instance.Version + "[-]" + status
+			if entry.Notes != "" {
+				secondary += "  — " + tview.Escape(firstLine(entry.Notes))
+			}
+		}
+	}
+
+	list.AddItem(title, secondary, 0, func() {
+		if _, err := os.Stat(filepath.Join(instance.CatalinaBase, "conf", "server.xml")); err != nil {
+			a.setStatus("[red]" + i18n.T("instance.missing") + "[-]")
+			return
+		}
+		a.instance = instance
+		a.updateInstanceInfo()
+		a.pages.SwitchToPage("main")
+		a.app.SetFocus(a.mainMenu)
+	})
+}
+
 // showManualPathInput shows a form for manual path entry
 func (a *App) showManualPathInput() {
 	form := tview.NewForm()
@@ -458,21 +797,13 @@ func (a *App) showManualPathInput() {
 		}
 
 		// Detect version
-		d := detector.NewDetector()
-		version := "unknown"
-		if instances, _ := d.DetectAll(); instances != nil {
-			for _, inst := range instances {
-				if inst.CatalinaHome == catalinaHome {
-					version = inst.Version
-					break
-				}
-			}
-		}
+		version := detector.NewDetector().DetectVersionInfo(catalinaHome)
 
 		a.instance = &config.TomcatInstance{
 			CatalinaHome: catalinaHome,
 			CatalinaBase: catalinaBase,
-			Version:      version,
+			Version:      version.String(),
+			VersionInfo:  version,
 		}
 		a.updateInstanceInfo()
 		a.setStatus("[green]" + i18n.T("instance.selected") + "[-]")
@@ -526,6 +857,7 @@ func (a *App) showServerMenu() {
 
 	// Create and load server configuration service
 	configService := server.NewConfigService(a.instance.CatalinaBase)
+	configService.SetCatalinaHome(a.instance.CatalinaHome)
 	if err := configService.Load(); err != nil {
 		a.showMessage("Error", fmt.Sprintf("Failed to load server.xml:\n%v", err))
 		return
@@ -547,6 +879,7 @@ func (a *App) showConnectorMenu() {
 
 	// Create and load server configuration service
 	configService := server.NewConfigService(a.instance.CatalinaBase)
+	configService.SetCatalinaHome(a.instance.CatalinaHome)
 	if err := configService.Load(); err != nil {
 		a.showMessage("Error", fmt.Sprintf("Failed to load server.xml:\n%v", err))
 		return
@@ -568,6 +901,7 @@ func (a *App) showSecurityMenu() {
 
 	// Create and load server configuration service
 	configService := server.NewConfigService(a.instance.CatalinaBase)
+	configService.SetCatalinaHome(a.instance.CatalinaHome)
 	if err := configService.Load(); err != nil {
 		a.showMessage("Error", fmt.Sprintf("Failed to load server.xml:\n%v", err))
 		return
@@ -603,6 +937,7 @@ func (a *App) showHostContextMenu() {
 
 	// Create and load server configuration service
 	configService := server.NewConfigService(a.instance.CatalinaBase)
+	configService.SetCatalinaHome(a.instance.CatalinaHome)
 	if err := configService.Load(); err != nil {
 		a.showMessage("Error", fmt.Sprintf("Failed to load server.xml:\n%v", err))
 		return
@@ -624,6 +959,7 @@ func (a *App) showValveMenu() {
 
 	// Create and load server configuration service
 	configService := server.NewConfigService(a.instance.CatalinaBase)
+	configService.SetCatalinaHome(a.instance.CatalinaHome)
 	if err := configService.Load(); err != nil {
 		a.showMessage("Error", fmt.Sprintf("Failed to load server.xml:\n%v", err))
 		return
@@ -645,6 +981,7 @@ func (a *App) showClusterMenu() {
 
 	// Create and load server configuration service
 	configService := server.NewConfigService(a.instance.CatalinaBase)
+	configService.SetCatalinaHome(a.instance.CatalinaHome)
 	if err := configService.Load(); err != nil {
 		a.showMessage("Error", fmt.Sprintf("Failed to load server.xml:\n%v", err))
 		return
@@ -675,6 +1012,74 @@ func (a *App) showLoggingMenu() {
 	}
 }
 
+func (a *App) showJVMMenu() {
+	if a.instance == nil {
+		a.showMessage("Error", "Please select a Tomcat instance first.\n\nPress 't' from the main menu to detect and select an instance.")
+		return
+	}
+
+	// Create and show JVM options view
+	jvmView := views.NewJVMView(a.app, a.pages, a.statusBar, a.instance.CatalinaBase, func() {
+		a.pages.SwitchToPage("main")
+		a.app.SetFocus(a.mainMenu)
+	})
+	if err := jvmView.Load(); err != nil {
+		a.showMessage("Error", fmt.Sprintf("Failed to load JVM options:\n%v", err))
+		return
+	}
+}
+
+func (a *App) showCatalinaPropsMenu() {
+	if a.instance == nil {
+		a.showMessage("Error", "Please select a Tomcat instance first.\n\nPress 't' from the main menu to detect and select an instance.")
+		return
+	}
+
+	// Create and show catalina.properties view
+	catalinaPropsView := views.NewCatalinaPropsView(a.app, a.pages, a.statusBar, a.instance.CatalinaBase, func() {
+		a.pages.SwitchToPage("main")
+		a.app.SetFocus(a.mainMenu)
+	})
+	if err := catalinaPropsView.Load(); err != nil {
+		a.showMessage("Error", fmt.Sprintf("Failed to load catalina.properties:\n%v", err))
+		return
+	}
+}
+
+func (a *App) showLifecycleMenu() {
+	if a.instance == nil {
+		a.showMessage("Error", "Please select a Tomcat instance first.\n\nPress 't' from the main menu to detect and select an instance.")
+		return
+	}
+
+	// Create and show start/stop view
+	lifecycleView := views.NewLifecycleView(a.app, a.pages, a.statusBar, a.instance, a.checkpoint, a.settingsManager, a.updateInstanceInfo, func() {
+		a.pages.SwitchToPage("main")
+		a.app.SetFocus(a.mainMenu)
+	})
+	if err := lifecycleView.Load(); err != nil {
+		a.showMessage("Error", fmt.Sprintf("Failed to open start/stop view:\n%v", err))
+		return
+	}
+}
+
+func (a *App) showSystemdMenu() {
+	if a.instance == nil {
+		a.showMessage("Error", "Please select a Tomcat instance first.\n\nPress 't' from the main menu to detect and select an instance.")
+		return
+	}
+
+	// Create and show systemd unit view
+	systemdView := views.NewSystemdView(a.app, a.pages, a.statusBar, a.instance, func() {
+		a.pages.SwitchToPage("main")
+		a.app.SetFocus(a.mainMenu)
+	})
+	if err := systemdView.Load(); err != nil {
+		a.showMessage("Error", fmt.Sprintf("Failed to generate systemd unit:\n%v", err))
+		return
+	}
+}
+
 func (a *App) showContextMenu() {
 	if a.instance == nil {
 		a.showMessage("Error", "Please select a Tomcat instance first.\n\nPress 't' from the main menu to detect and select an instance.")
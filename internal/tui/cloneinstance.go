@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/playok/tomcatkit/internal/instance"
+	"github.com/rivo/tview"
+)
+
+// showCloneInstance copies the current instance's configuration to another CATALINA_BASE
+func (a *App) showCloneInstance() {
+	if a.instance == nil {
+		a.setStatus("[red]" + i18n.T("instance.noselected") + "[-]")
+		return
+	}
+	source := a.instance
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("instance.clone.source"), source.CatalinaBase, 50, nil, nil)
+	form.GetFormItem(0).(*tview.InputField).SetDisabled(true)
+	form.AddInputField(i18n.T("instance.clone.target"), "", 50, nil, nil)
+	form.AddInputField(i18n.T("instance.new.offset"), "", 10, func(text string, lastChar rune) bool {
+		return lastChar >= '0' && lastChar <= '9'
+	}, nil)
+
+	planning := false
+	form.AddButton("[white:green]"+i18n.T("instance.clone.preview")+"[-:-]", func() {
+		if planning {
+			return
+		}
+		opts := instance.CloneOptions{
+			Source:     source,
+			TargetBase: strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText()),
+		}
+		opts.PortOffset, _ = strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText())
+
+		// Picking a free offset scans the system, so plan in the background
+		planning = true
+		a.setStatus("[yellow]" + i18n.T("instance.clone.planning") + "[-]")
+		go func() {
+			plan, err := instance.PlanClone(opts)
+			a.app.QueueUpdateDraw(func() {
+				planning = false
+				if err != nil {
+					a.setStatus("[red]" + err.Error() + "[-]")
+					return
+				}
+				a.setStatus("")
+				a.showClonePlan(plan)
+			})
+		}()
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		a.showInstanceSelector()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("instance.clone.title") + " ").SetBorderColor(tcell.ColorGreen)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			a.showInstanceSelector()
+			return nil
+		}
+		return event
+	})
+
+	helpText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true).
+		SetText(i18n.T("instance.clone.help"))
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(helpText, 8, 0, false)
+
+	a.pages.AddAndSwitchToPage("clone-instance", layout, true)
+	a.app.SetFocus(form)
+}
+
+// showClonePlan lists every file and substitution of a clone before writing
+func (a *App) showClonePlan(plan *instance.ClonePlan) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[yellow::b]%s[-::-] → [green::b]%s[-::-]  [gray](%s %+d)[-]\n\n",
+		plan.Source.CatalinaBase, plan.Target.CatalinaBase, i18n.T("instance.clone.offset"), plan.PortOffset))
+
+	sb.WriteString("[::b]" + i18n.T("instance.clone.files") + "[::-]\n")
+	for _, f := range plan.Files {
+		note := ""
+		if f.Exists {
+			note = " [red](" + i18n.T("instance.clone.overwrite") + ")[-]"
+		}
+		sb.WriteString(fmt.Sprintf("  %s%s\n", f.Path, note))
+	}
+
+	sb.WriteString("\n[::b]" + i18n.T("instance.clone.substitutions") + "[::-]\n")
+	if len(plan.Substitutions) == 0 {
+		sb.WriteString("  [gray]" + i18n.T("instance.clone.none") + "[-]\n")
+	}
+	for _, s := range plan.Substitutions {
+		sb.WriteString(fmt.Sprintf("  %s:%d  [aqua]%s[-]  [red]%s[-] → [green]%s[-]\n", s.File, s.Line, s.Kind, s.Old, s.New))
+	}
+
+	summary := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(sb.String())
+	summary.SetBorder(true).SetTitle(" " + i18n.T("instance.clone.summary") + " ").SetBorderColor(tcell.ColorDarkCyan)
+
+	buttons := tview.NewForm()
+	buttons.AddButton("[white:green]"+i18n.T("common.apply")+"[-:-]", func() {
+		if err := plan.Apply(); err != nil {
+			a.setStatus("[red]" + err.Error() + "[-]")
+			return
+		}
+		if a.settingsManager != nil {
+			a.settingsManager.AddRecentInstance(plan.Target)
+			a.settingsManager.Save()
+		}
+		a.setStatus("[green]" + fmt.Sprintf(i18n.T("instance.clone.done"), len(plan.Files), plan.Target.CatalinaBase) + "[-]")
+		a.pages.SwitchToPage("main")
+		a.app.SetFocus(a.mainMenu)
+	})
+	buttons.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		a.showCloneInstance()
+	})
+	buttons.SetButtonBackgroundColor(tcell.ColorDefault)
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summary, 0, 1, false).
+		AddItem(buttons, 3, 0, true)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			a.showCloneInstance()
+			return nil
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			// Scroll the summary while the buttons keep focus
+			summary.InputHandler()(event, nil)
+			return nil
+		}
+		return event
+	})
+
+	a.pages.AddAndSwitchToPage("clone-plan", layout, true)
+	a.app.SetFocus(buttons)
+}
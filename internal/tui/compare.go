@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/compare"
+	"github.com/playok/tomcatkit/internal/fleet"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/rivo/tview"
+)
+
+// Widest setting and value shown in the table, so that both values fit
+// into 80 columns; the detail pane shows them in full
+const (
+	compareSettingWidth = 40
+	compareValueWidth   = 18
+)
+
+// showCompare shows the configuration differences between two members and
+// lets the user copy single settings from one to the other
+func (a *App) showCompare(memberA, memberB *fleet.Member) {
+	c := compare.New(memberA.Instance, memberB.Instance)
+	members := []*fleet.Member{memberA, memberB}
+	var diffs []compare.Difference
+
+	header := tview.NewTextView().SetDynamicColors(true)
+	detail := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	detail.SetBorder(true).SetBorderColor(tcell.ColorDarkCyan)
+	table := tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(header, 2, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(detail, 6, 0, false)
+
+	updateHeader := func() {
+		var lines []string
+		for i, m := range members {
+			line := fmt.Sprintf("[::b]%c[::-] %s%s  [gray]%s[-]", 'A'+i, tview.Escape(m.Name()), memberBadge(m), m.Instance.CatalinaBase)
+			if files := c.Snapshot(compare.Side(i)).Modified(); len(files) > 0 {
+				line += "  [yellow]" + fmt.Sprintf(i18n.T("compare.modified"), strings.Join(files, ", ")) + "[-]"
+			}
+			lines = append(lines, line)
+		}
+		for _, file := range compare.Files {
+			for i, s := range []*compare.Snapshot{c.A, c.B} {
+				if err := s.Errors[file]; err != nil {
+					lines = append(lines, "[red]"+tview.Escape(fmt.Sprintf(i18n.T("compare.skipped"), file, 'A'+i, err))+"[-]")
+				}
+			}
+		}
+		header.SetText(strings.Join(lines, "\n"))
+		layout.ResizeItem(header, len(lines), 0)
+	}
+
+	showDetail := func(row int) {
+		if row < 1 || row > len(diffs) {
+			detail.SetText("")
+			return
+		}
+		d := diffs[row-1]
+		detail.SetText(fmt.Sprintf("[aqua]%s[-]  %s\n[::b]A[::-] %s\n[::b]B[::-] %s",
+			d.File, tview.Escape(d.Setting()), compareCellText(d, compare.SideA), compareCellText(d, compare.SideB)))
+	}
+
+	fill := func(row int) {
+		diffs = c.Differences()
+		table.Clear()
+		headers := []string{i18n.T("compare.setting"), "A", "B"}
+		for col, text := range headers {
+			table.SetCell(0, col, tview.NewTableCell("[::b]"+text).SetSelectable(false).SetTextColor(tcell.ColorYellow))
+		}
+		for i, d := range diffs {
+			table.SetCell(i+1, 0, tview.NewTableCell(compareSettingText(d)).SetMaxWidth(compareSettingWidth).SetExpansion(1))
+			table.SetCell(i+1, 1, tview.NewTableCell(compareCellText(d, compare.SideA)).SetMaxWidth(compareValueWidth))
+			table.SetCell(i+1, 2, tview.NewTableCell(compareCellText(d, compare.SideB)).SetMaxWidth(compareValueWidth))
+		}
+		if len(diffs) == 0 {
+			table.SetCell(1, 0, tview.NewTableCell("[green]"+i18n.T("compare.none")+"[-]").SetSelectable(false))
+		}
+		updateHeader()
+
+		row = min(max(row, 1), len(diffs))
+		if row > 0 {
+			table.Select(row, 0)
+		}
+		showDetail(row)
+	}
+	table.SetSelectionChangedFunc(func(row, column int) {
+		showDetail(row)
+	})
+
+	modified := func() bool {
+		return len(c.A.Modified()) > 0 || len(c.B.Modified()) > 0
+	}
+	back := func() {
+		a.pages.SwitchToPage("fleet")
+	}
+	confirmDiscard := func(then func()) {
+		if !modified() {
+			then()
+			return
+		}
+		a.showCompareConfirm(i18n.T("compare.discard"), then)
+	}
+
+	copySetting := func(to compare.Side) {
+		row, _ := table.GetSelection()
+		if row < 1 || row > len(diffs) {
+			return
+		}
+		d := diffs[row-1]
+		c.Copy(d, to)
+		a.setStatus("[green]" + tview.Escape(fmt.Sprintf(i18n.T("compare.copied"), d.Setting(), 'A'+int(to))) + "[-]")
+		fill(row)
+	}
+
+	save := func() {
+		if !modified() {
+			return
+		}
+		var lines []string
+		production := false
+		for i, m := range members {
+			files := c.Snapshot(compare.Side(i)).Modified()
+			if len(files) == 0 {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%c %s: %s", 'A'+i, m.Name(), strings.Join(files, ", ")))
+			if m.Entry != nil && m.Entry.IsProduction() {
+				production = true
+			}
+		}
+		message := i18n.T("compare.save.confirm") + "\n\n" + strings.Join(lines, "\n")
+		if production {
+			message += "\n\n" + i18n.T("compare.save.production")
+		}
+		a.showCompareConfirm(message, func() {
+			for i, s := range []*compare.Snapshot{c.A, c.B} {
+				if err := s.Save(); err != nil {
+					a.setStatus(fmt.Sprintf("[red]%c: %s[-]", 'A'+i, tview.Escape(err.Error())))
+					updateHeader()
+					return
+				}
+			}
+			a.setStatus("[green]" + i18n.T("compare.saved") + "[-]")
+			updateHeader()
+		})
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			confirmDiscard(back)
+			return nil
+		case event.Rune() == '>':
+			copySetting(compare.SideB)
+			return nil
+		case event.Rune() == '<':
+			copySetting(compare.SideA)
+			return nil
+		case event.Rune() == 's':
+			save()
+			return nil
+		case event.Rune() == 'r':
+			confirmDiscard(func() { a.showCompare(memberA, memberB) })
+			return nil
+		}
+		return event
+	})
+
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s  [gray]%s[-] ", i18n.T("compare.title"), i18n.T("compare.keys"))).
+		SetBorderColor(tcell.ColorGreen)
+	fill(1)
+
+	a.pages.AddAndSwitchToPage("compare", layout, true)
+	a.app.SetFocus(table)
+}
+
+// compareSettingText shows the setting name and the element it belongs to;
+// the detail pane shows the file and the whole path
+func compareSettingText(d compare.Difference) string {
+	element := tview.Escape(d.Element())
+	if d.Name == "" {
+		return element
+	}
+	return "[aqua]" + tview.Escape(d.Name) + "[-] [gray]" + element + "[-]"
+}
+
+// compareCellText shows one side of a difference
+func compareCellText(d compare.Difference, side compare.Side) string {
+	value := d.A
+	if side == compare.SideB {
+		value = d.B
+	}
+	switch {
+	case d.Kind == compare.OnlyA && side == compare.SideB,
+		d.Kind == compare.OnlyB && side == compare.SideA:
+		return "[gray]" + i18n.T("compare.absent") + "[-]"
+	case value == "":
+		return "[gray]" + i18n.T("compare.notset") + "[-]"
+	}
+	return tview.Escape(value)
+}
+
+// showCompareConfirm asks before saving or discarding copied settings
+func (a *App) showCompareConfirm(message string, yes func()) {
+	modal := tview.NewModal().
+		SetText(message).
+		AddButtons([]string{i18n.T("common.yes"), i18n.T("common.no")}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.pages.RemovePage("compare-confirm")
+			a.pages.SwitchToPage("compare")
+			if buttonIndex == 0 {
+				yes()
+			}
+		})
+	modal.SetBorder(true).SetTitle(" " + i18n.T("common.confirm") + " ")
+	a.pages.AddAndSwitchToPage("compare-confirm", modal, true)
+}
@@ -0,0 +1,529 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/config/logging"
+	"github.com/playok/tomcatkit/internal/config/server"
+	"github.com/playok/tomcatkit/internal/detector"
+	"github.com/playok/tomcatkit/internal/fleet"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/rivo/tview"
+)
+
+// tlsWarning is how soon before expiry certificates are shown in red
+const tlsWarning = 30 * 24 * time.Hour
+
+// knownInstances returns every instance tomcatkit knows of: inventoried,
+// detected, scanned and recent ones, each once
+func (a *App) knownInstances() []*config.TomcatInstance {
+	detected, _ := detector.NewDetector().DetectAll()
+
+	seen := make(map[string]bool)
+	var instances []*config.TomcatInstance
+	add := func(instance *config.TomcatInstance) {
+		key := config.InstanceKey(instance.CatalinaHome, instance.CatalinaBase)
+		if seen[key] {
+			return
+		}
+		if _, err := os.Stat(filepath.Join(instance.CatalinaBase, "conf", "server.xml")); err != nil {
+			return
+		}
+		seen[key] = true
+		instances = append(instances, instance)
+	}
+
+	// Detected instances first, as they know whether they run
+	for _, instance := range detected {
+		add(instance)
+	}
+	for _, instance := range a.scanned {
+		add(instance)
+	}
+	if a.settingsManager != nil {
+		d := detector.NewDetector()
+		for _, entry := range a.settingsManager.GetInventory() {
+			instance := entry.Instance()
+			instance.VersionInfo = d.DetectVersionInfo(instance.CatalinaHome)
+			instance.Version = instance.VersionInfo.String()
+			add(instance)
+		}
+		for _, recent := range a.settingsManager.GetRecentInstances() {
+			instance := recent
+			add(&instance)
+		}
+	}
+	return instances
+}
+
+// showFleet lists all known instances with the key facts of their
+// configuration, for making the same change to several at once
+func (a *App) showFleet() {
+	members := fleet.NewMembers(a.knownInstances(), a.settingsManager)
+	selected := make(map[int]bool)
+
+	table := tview.NewTable().
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	headers := []string{"", i18n.T("inventory.name"), i18n.T("inventory.environment"), i18n.T("instance.version"),
+		i18n.T("instance.status"), i18n.T("fleet.shutdown"), i18n.T("fleet.ports"), i18n.T("fleet.connectors"), i18n.T("fleet.tls")}
+	for col, header := range headers {
+		table.SetCell(0, col, tview.NewTableCell("[::b]"+header).SetSelectable(false).SetTextColor(tcell.ColorYellow))
+	}
+
+	mark := func(row int) {
+		text := tview.Escape("[ ]")
+		if selected[row-1] {
+			text = "[green]" + tview.Escape("[x]") + "[-]"
+		}
+		table.SetCell(row, 0, tview.NewTableCell(text))
+	}
+
+	for i, m := range members {
+		row := i + 1
+		mark(row)
+		name := tview.Escape(m.Name())
+		env := ""
+		if m.Entry != nil {
+			name = inventoryName(m.Entry)
+			env = strings.TrimSpace(environmentBadge(m.Entry.Environment))
+		}
+		state := "[red]" + i18n.T("instance.stopped") + "[-]"
+		if m.Instance.IsRunning {
+			state = fmt.Sprintf("[green]%s[-] %d", i18n.T("instance.running"), m.Instance.PID)
+		}
+		table.SetCell(row, 1, tview.NewTableCell(name).SetExpansion(1))
+		table.SetCell(row, 2, tview.NewTableCell(env))
+		table.SetCell(row, 3, tview.NewTableCell(m.Instance.Version))
+		table.SetCell(row, 4, tview.NewTableCell(state))
+
+		facts := m.Facts
+		if facts.Err != nil {
+			table.SetCell(row, 5, tview.NewTableCell("[red]"+tview.Escape(facts.Err.Error())+"[-]"))
+			continue
+		}
+		var ports []string
+		for _, port := range facts.Ports {
+			ports = append(ports, strconv.Itoa(port))
+		}
+		table.SetCell(row, 5, tview.NewTableCell(strconv.Itoa(facts.ShutdownPort)))
+		table.SetCell(row, 6, tview.NewTableCell(strings.Join(ports, ",")))
+		table.SetCell(row, 7, tview.NewTableCell(strconv.Itoa(facts.Connectors)).SetAlign(tview.AlignRight))
+		table.SetCell(row, 8, tview.NewTableCell(tlsText(facts)))
+	}
+
+	// The highlighted member is used when none is marked
+	chosen := func() []*fleet.Member {
+		var chosen []*fleet.Member
+		for i, m := range members {
+			if selected[i] {
+				chosen = append(chosen, m)
+			}
+		}
+		if len(chosen) == 0 {
+			if row, _ := table.GetSelection(); row > 0 && row <= len(members) {
+				chosen = append(chosen, members[row-1])
+			}
+		}
+		return chosen
+	}
+
+	table.SetSelectedFunc(func(row, column int) {
+		if targets := chosen(); len(targets) > 0 {
+			a.showFleetOperations(targets)
+		}
+	})
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			a.pages.SwitchToPage("main")
+			a.app.SetFocus(a.mainMenu)
+			return nil
+		case event.Rune() == ' ':
+			if row, _ := table.GetSelection(); row > 0 && row <= len(members) {
+				if selected[row-1] {
+					delete(selected, row-1)
+				} else {
+					selected[row-1] = true
+				}
+				mark(row)
+				if row < len(members) {
+					table.Select(row+1, 0)
+				}
+			}
+			return nil
+		case event.Rune() == 'a':
+			// Mark all, or clear all when all are marked
+			all := len(selected) == len(members)
+			for i := range members {
+				if all {
+					delete(selected, i)
+				} else {
+					selected[i] = true
+				}
+				mark(i + 1)
+			}
+			return nil
+		case event.Rune() == 'c':
+			var marked []*fleet.Member
+			for i, m := range members {
+				if selected[i] {
+					marked = append(marked, m)
+				}
+			}
+			if len(marked) != 2 {
+				a.setStatus("[yellow]" + i18n.T("fleet.compare.two") + "[-]")
+				return nil
+			}
+			a.showCompare(marked[0], marked[1])
+			return nil
+		case event.Rune() == 'r':
+			a.showFleet()
+			return nil
+		}
+		return event
+	})
+
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s (%d)  [gray]%s[-] ", i18n.T("fleet.title"), len(members), i18n.T("fleet.keys"))).
+		SetBorderColor(tcell.ColorGreen)
+	if len(members) > 0 {
+		table.Select(1, 0)
+	}
+
+	a.pages.AddAndSwitchToPage("fleet", table, true)
+	a.app.SetFocus(table)
+}
+
+// tlsText shows when the TLS certificates of a member expire
+func tlsText(facts fleet.Facts) string {
+	switch {
+	case !facts.TLS:
+		return "[gray]-[-]"
+	case facts.TLSExpiry.IsZero():
+		return "[gray]" + i18n.T("fleet.tls.keystore") + "[-]"
+	case time.Until(facts.TLSExpiry) < 0:
+		return "[red::b]" + i18n.T("fleet.tls.expired") + " " + facts.TLSExpiry.Format("2006-01-02") + "[-::-]"
+	case time.Until(facts.TLSExpiry) < tlsWarning:
+		return "[red]" + facts.TLSExpiry.Format("2006-01-02") + "[-]"
+	}
+	return facts.TLSExpiry.Format("2006-01-02")
+}
+
+// showFleetOperations lets the user choose the change for the members
+func (a *App) showFleetOperations(members []*fleet.Member) {
+	list := tview.NewList().ShowSecondaryText(true)
+	list.AddItem("[::b]"+i18n.T("qt.gzip")+"[::-]", i18n.T("qt.gzip.desc"), 'g', func() {
+		a.showFleetCompressionForm(members)
+	})
+	list.AddItem("[::b]"+i18n.T("qt.security")+"[::-]", i18n.T("qt.security.desc"), 'h', func() {
+		a.showFleetHardeningForm(members)
+	})
+	list.AddItem("[::b]"+i18n.T("fleet.op.logger")+"[::-]", i18n.T("fleet.op.logger.desc"), 'l', func() {
+		a.showFleetLoggerForm(members)
+	})
+	list.AddItem("[::b]"+i18n.T("fleet.op.valve")+"[::-]", i18n.T("fleet.op.valve.desc"), 'v', func() {
+		a.showFleetValveForm(members)
+	})
+	list.AddItem(i18n.T("common.back"), i18n.T("common.return"), 'b', func() {
+		a.pages.SwitchToPage("fleet")
+	})
+
+	list.SetBorder(true).
+		SetTitle(" " + fmt.Sprintf(i18n.T("fleet.operations"), len(members)) + " ").
+		SetBorderColor(tcell.ColorGreen)
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			a.pages.SwitchToPage("fleet")
+			return nil
+		}
+		return event
+	})
+
+	a.pages.AddAndSwitchToPage("fleet-operations", list, true)
+	a.app.SetFocus(list)
+}
+
+// showFleetForm shows the form of an operation with a Preview button that
+// plans the operation built by the form
+func (a *App) showFleetForm(members []*fleet.Member, title string, form *tview.Form, operation func() (fleet.Operation, bool)) {
+	planning := false
+	form.AddButton("[white:green]"+i18n.T("fleet.preview")+"[-:-]", func() {
+		op, ok := operation()
+		if !ok || planning {
+			return
+		}
+		planning = true
+		a.setStatus("[yellow]" + i18n.T("fleet.planning") + "[-]")
+		go func() {
+			results := fleet.Plan(op, members)
+			a.app.QueueUpdateDraw(func() {
+				planning = false
+				a.setStatus("")
+				a.showFleetPlan(op, results)
+			})
+		}()
+	})
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		a.showFleetOperations(members)
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + title + " ").SetBorderColor(tcell.ColorGreen)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			a.showFleetOperations(members)
+			return nil
+		}
+		return event
+	})
+
+	a.pages.AddAndSwitchToPage("fleet-form", form, true)
+	a.app.SetFocus(form)
+}
+
+// showFleetCompressionForm asks for the settings of the gzip template
+func (a *App) showFleetCompressionForm(members []*fleet.Member) {
+	form := tview.NewForm()
+	form.AddInputField("Min Compression Size (bytes)", "2048", 10, func(text string, lastChar rune) bool {
+		return lastChar >= '0' && lastChar <= '9'
+	}, nil)
+
+	a.showFleetForm(members, i18n.T("qt.gzip"), form, func() (fleet.Operation, bool) {
+		minSize, err := strconv.Atoi(form.GetFormItem(0).(*tview.InputField).GetText())
+		if err != nil {
+			a.setStatus("[red]" + i18n.T("fleet.invalid") + "[-]")
+			return fleet.Operation{}, false
+		}
+		return fleet.CompressionOperation(minSize), true
+	})
+}
+
+// showFleetHardeningForm asks which parts of the hardening template to apply
+func (a *App) showFleetHardeningForm(members []*fleet.Member) {
+	form := tview.NewForm()
+	form.AddCheckbox("Disable Shutdown Port", true, nil)
+	form.AddCheckbox("Remove Server Info from Errors", true, nil)
+	form.AddCheckbox("Add Security Listener", true, nil)
+
+	a.showFleetForm(members, i18n.T("qt.security"), form, func() (fleet.Operation, bool) {
+		return fleet.HardeningOperation(server.Hardening{
+			DisableShutdown:  form.GetFormItem(0).(*tview.Checkbox).IsChecked(),
+			HideServerInfo:   form.GetFormItem(1).(*tview.Checkbox).IsChecked(),
+			SecurityListener: form.GetFormItem(2).(*tview.Checkbox).IsChecked(),
+		}), true
+	})
+}
+
+// showFleetLoggerForm asks for a logger and its level
+func (a *App) showFleetLoggerForm(members []*fleet.Member) {
+	var levels []string
+	for _, level := range logging.AvailableLogLevels() {
+		levels = append(levels, string(level))
+	}
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("fleet.logger"), logging.CommonLoggers[0], 60, nil, nil)
+	form.GetFormItem(0).(*tview.InputField).SetAutocompleteFunc(func(text string) []string {
+		var matches []string
+		for _, name := range logging.CommonLoggers {
+			if strings.Contains(name, text) {
+				matches = append(matches, name)
+			}
+		}
+		return matches
+	})
+	form.AddDropDown(i18n.T("fleet.level"), levels, 5, nil) // FINE
+
+	a.showFleetForm(members, i18n.T("fleet.op.logger"), form, func() (fleet.Operation, bool) {
+		name := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		if name == "" {
+			a.setStatus("[red]" + i18n.T("fleet.invalid") + "[-]")
+			return fleet.Operation{}, false
+		}
+		_, level := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+		return fleet.LoggerLevelOperation(name, logging.LogLevel(level)), true
+	})
+}
+
+// showFleetValveForm offers the valve presets of the valve view
+func (a *App) showFleetValveForm(members []*fleet.Member) {
+	presets := []server.Valve{
+		server.DefaultAccessLogValve(),
+		server.DefaultRemoteAddrValve(),
+		server.DefaultRemoteIpValve(),
+		server.DefaultErrorReportValve(),
+		server.DefaultStuckThreadDetectionValve(),
+	}
+	var names []string
+	for _, valve := range presets {
+		names = append(names, server.GetValveShortName(valve.ClassName))
+	}
+
+	form := tview.NewForm()
+	form.AddDropDown(i18n.T("fleet.valve"), names, 0, nil)
+	form.AddInputField(i18n.T("fleet.valve.allow"), presets[1].Allow, 50, nil, nil)
+
+	a.showFleetForm(members, i18n.T("fleet.op.valve"), form, func() (fleet.Operation, bool) {
+		index, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+		valve := presets[index]
+		if valve.ClassName == server.ValveRemoteAddr {
+			valve.Allow = strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText())
+		}
+		return fleet.EngineValveOperation(valve), true
+	})
+}
+
+// showFleetPlan shows the diff of every member before anything is written
+func (a *App) showFleetPlan(op fleet.Operation, results []*fleet.Result) {
+	var sb strings.Builder
+	changed, failed, production := 0, 0, 0
+	for _, result := range results {
+		m := result.Member
+		if m.Entry != nil && m.Entry.IsProduction() {
+			production++
+		}
+		sb.WriteString(fmt.Sprintf("[::b]%s[::-]%s  [gray]%s[-]\n", tview.Escape(m.Name()), memberBadge(m), m.Instance.CatalinaBase))
+		switch {
+		case result.Err != nil:
+			failed++
+			sb.WriteString("  [red]" + tview.Escape(result.Err.Error()) + "[-]\n\n")
+			continue
+		case !result.Changed():
+			sb.WriteString("  [gray]" + i18n.T("fleet.unchanged") + "[-]\n\n")
+			continue
+		}
+		changed++
+		for _, diff := range result.Diffs {
+			sb.WriteString("  [aqua]" + diff.File + "[-]\n")
+			for _, line := range diff.Lines {
+				text := tview.Escape(line.Text)
+				switch line.Kind {
+				case fleet.DiffAdded:
+					sb.WriteString("  [green]+ " + text + "[-]\n")
+				case fleet.DiffRemoved:
+					sb.WriteString("  [red]- " + text + "[-]\n")
+				case fleet.DiffGap:
+					sb.WriteString("  [gray]  ...[-]\n")
+				default:
+					sb.WriteString("    " + text + "\n")
+				}
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	header := fmt.Sprintf(i18n.T("fleet.plan.summary"), tview.Escape(op.Name), changed, len(results)-changed-failed, failed)
+	if production > 0 {
+		header += "\n[white:red] " + fmt.Sprintf(i18n.T("fleet.plan.production"), production) + " [-:-]"
+	}
+
+	summary := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(header + "\n\n" + sb.String())
+	summary.SetBorder(true).SetTitle(" " + i18n.T("fleet.plan") + " ").SetBorderColor(tcell.ColorDarkCyan)
+
+	back := func() { a.showFleetOperations(membersOf(results)) }
+
+	buttons := tview.NewForm()
+	if changed > 0 {
+		buttons.AddButton("[white:green]"+i18n.T("common.apply")+"[-:-]", func() {
+			fleet.Apply(results)
+			a.showFleetReport(op, results)
+		})
+	}
+	buttons.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", back)
+	buttons.SetButtonBackgroundColor(tcell.ColorDefault)
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(summary, 0, 1, false).
+		AddItem(buttons, 3, 0, true)
+
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			back()
+			return nil
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			// Scroll the diffs while the buttons keep focus
+			summary.InputHandler()(event, nil)
+			return nil
+		}
+		return event
+	})
+
+	a.pages.AddAndSwitchToPage("fleet-plan", layout, true)
+	a.app.SetFocus(buttons)
+}
+
+// showFleetReport shows what happened to every member
+func (a *App) showFleetReport(op fleet.Operation, results []*fleet.Result) {
+	var sb strings.Builder
+	applied, failed := 0, 0
+	for _, result := range results {
+		m := result.Member
+		status := "[gray]" + i18n.T("fleet.unchanged") + "[-]"
+		switch {
+		case result.Err != nil:
+			failed++
+			status = "[red]✗ " + tview.Escape(result.Err.Error()) + "[-]"
+		case result.Applied:
+			applied++
+			var files []string
+			for _, diff := range result.Diffs {
+				files = append(files, diff.File)
+			}
+			status = "[green]✓ " + strings.Join(files, ", ") + "[-]"
+		}
+		sb.WriteString(fmt.Sprintf("%s%s  %s\n", tview.Escape(m.Name()), memberBadge(m), status))
+	}
+
+	header := fmt.Sprintf(i18n.T("fleet.report.summary"), tview.Escape(op.Name), applied, failed)
+	report := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(header + "\n\n" + sb.String() + "\n[gray]" + i18n.T("fleet.report.restart") + "[-]")
+	report.SetBorder(true).SetTitle(" " + i18n.T("fleet.report") + " ").SetBorderColor(tcell.ColorGreen)
+	report.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyEnter {
+			a.showFleet()
+			return nil
+		}
+		return event
+	})
+
+	if failed > 0 {
+		a.setStatus("[red]" + header + "[-]")
+	} else {
+		a.setStatus("[green]" + header + "[-]")
+	}
+	a.pages.AddAndSwitchToPage("fleet-report", report, true)
+	a.app.SetFocus(report)
+}
+
+// memberBadge returns the environment badge of an inventoried member
+func memberBadge(m *fleet.Member) string {
+	if m.Entry == nil {
+		return ""
+	}
+	return environmentBadge(m.Entry.Environment)
+}
+
+// membersOf returns the members of results
+func membersOf(results []*fleet.Result) []*fleet.Member {
+	members := make([]*fleet.Member, len(results))
+	for i, result := range results {
+		members[i] = result.Member
+	}
+	return members
+}
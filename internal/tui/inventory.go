@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/rivo/tview"
+)
+
+// showInventoryEditor names and tags an instance in the inventory
+func (a *App) showInventoryEditor(instance *config.TomcatInstance, onDone func()) {
+	if a.settingsManager == nil {
+		return
+	}
+	entry := a.settingsManager.FindInventoryEntry(instance)
+	isNew := entry == nil
+	if isNew {
+		entry = &config.InventoryEntry{
+			CatalinaHome: instance.CatalinaHome,
+			CatalinaBase: instance.CatalinaBase,
+		}
+	}
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("inventory.name"), entry.Name, 40, nil, nil)
+
+	envIndex := 0
+	var envOptions []string
+	for i, env := range config.Environments() {
+		envOptions = append(envOptions, environmentLabel(env, false))
+		if env == entry.Environment {
+			envIndex = i
+		}
+	}
+	form.AddDropDown(i18n.T("inventory.environment"), envOptions, envIndex, nil)
+
+	colorIndex := 0
+	var colorOptions []string
+	for i, color := range config.InventoryColors {
+		if color == "" {
+			colorOptions = append(colorOptions, i18n.T("inventory.color.default"))
+		} else {
+			colorOptions = append(colorOptions, "["+color+"]"+color+"[-]")
+		}
+		if color == entry.Color {
+			colorIndex = i
+		}
+	}
+	form.AddDropDown(i18n.T("inventory.color"), colorOptions, colorIndex, nil)
+	form.AddTextArea(i18n.T("inventory.notes"), entry.Notes, 60, 4, 0, nil)
+
+	form.AddButton("[white:green]"+i18n.T("common.save")+"[-:-]", func() {
+		entry.Name = strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		if entry.Name == "" {
+			a.setStatus("[red]" + i18n.T("inventory.name.required") + "[-]")
+			return
+		}
+		index, _ := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
+		entry.Environment = config.Environments()[index]
+		index, _ = form.GetFormItem(2).(*tview.DropDown).GetCurrentOption()
+		entry.Color = config.InventoryColors[index]
+		entry.Notes = strings.TrimSpace(form.GetFormItem(3).(*tview.TextArea).GetText())
+
+		a.settingsManager.PutInventoryEntry(*entry)
+		if err := a.settingsManager.Save(); err != nil {
+			a.setStatus("[red]" + err.Error() + "[-]")
+			return
+		}
+		a.setStatus("[green]" + i18n.T("inventory.saved") + "[-]")
+		a.updateBanner()
+		onDone()
+	})
+
+	if !isNew {
+		form.AddButton("[white:red]"+i18n.T("common.remove")+"[-:-]", func() {
+			a.settingsManager.RemoveInventoryEntry(instance)
+			if err := a.settingsManager.Save(); err != nil {
+				a.setStatus("[red]" + err.Error() + "[-]")
+				return
+			}
+			a.setStatus("[green]" + i18n.T("inventory.removed") + "[-]")
+			a.updateBanner()
+			onDone()
+		})
+	}
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", onDone)
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	title := i18n.T("inventory.add")
+	if !isNew {
+		title = i18n.T("inventory.edit")
+	}
+	form.SetBorder(true).SetTitle(" " + title + " ").SetBorderColor(tcell.ColorGreen)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			onDone()
+			return nil
+		}
+		return event
+	})
+
+	paths := "[yellow]CATALINA_HOME:[-] " + instance.CatalinaHome + "\n[yellow]CATALINA_BASE:[-] " + instance.CatalinaBase +
+		"\n\n" + i18n.T("help.inventory")
+	helpText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true).
+		SetText(paths)
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(helpText, 7, 0, false)
+
+	a.pages.AddAndSwitchToPage("inventory-editor", layout, true)
+	a.app.SetFocus(form)
+}
+
+// updateBanner shows the production warning above every page while a
+// prod-tagged instance is selected
+func (a *App) updateBanner() {
+	var entry *config.InventoryEntry
+	if a.settingsManager != nil {
+		entry = a.settingsManager.FindInventoryEntry(a.instance)
+	}
+	if entry == nil || !entry.IsProduction() {
+		a.banner.SetText("")
+		a.root.ResizeItem(a.banner, 0, 0)
+		return
+	}
+	a.banner.SetText("⚠ " + i18n.T("inventory.banner") + ": " + tview.Escape(entry.Name) + " ⚠")
+	a.root.ResizeItem(a.banner, 1, 0)
+}
+
+// inventoryName returns the name of an entry in its colour
+func inventoryName(entry *config.InventoryEntry) string {
+	name := "[::b]" + tview.Escape(entry.Name) + "[::-]"
+	if entry.Color != "" {
+		name = "[" + entry.Color + "]" + name + "[-]"
+	}
+	return name
+}
+
+// environmentBadge returns a coloured tag for an environment, or nothing
+func environmentBadge(env config.Environment) string {
+	switch env {
+	case config.EnvProd:
+		return " [white:red] PROD [-:-]"
+	case config.EnvStage:
+		return " [black:yellow] STAGE [-:-]"
+	case config.EnvDev:
+		return " [black:green] DEV [-:-]"
+	}
+	return ""
+}
+
+// environmentLabel names an environment in the filter and the editor,
+// where EnvNone means all environments or none
+func environmentLabel(env config.Environment, filter bool) string {
+	if env == config.EnvNone {
+		if filter {
+			return i18n.T("inventory.env.all")
+		}
+		return i18n.T("inventory.env.none")
+	}
+	return string(env)
+}
+
+// firstLine returns the first line of text
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
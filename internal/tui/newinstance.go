@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/playok/tomcatkit/internal/instance"
+	"github.com/rivo/tview"
+)
+
+// showNewInstanceWizard lays out a new CATALINA_BASE from an existing CATALINA_HOME
+func (a *App) showNewInstanceWizard() {
+	form := tview.NewForm()
+
+	defaultHome := os.Getenv("CATALINA_HOME")
+	if a.instance != nil {
+		defaultHome = a.instance.CatalinaHome
+	}
+
+	form.AddInputField(i18n.T("instance.path.home"), defaultHome, 50, nil, nil)
+	form.AddInputField(i18n.T("instance.new.base"), "", 50, nil, nil)
+	form.AddInputField(i18n.T("instance.new.offset"), "", 10, func(text string, lastChar rune) bool {
+		return lastChar >= '0' && lastChar <= '9'
+	}, nil)
+
+	creating := false
+	form.AddButton("[white:green]"+i18n.T("instance.new.create")+"[-:-]", func() {
+		if creating {
+			return
+		}
+		opts := instance.CreateOptions{
+			CatalinaHome: strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText()),
+			CatalinaBase: strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText()),
+		}
+		if offset := form.GetFormItem(2).(*tview.InputField).GetText(); offset != "" {
+			n, _ := strconv.Atoi(offset)
+			opts.Ports = instance.WithOffset(n)
+		}
+
+		if err := instance.ValidateHome(opts.CatalinaHome); err != nil {
+			a.setStatus("[red]" + err.Error() + "[-]")
+			return
+		}
+		if err := instance.ValidateBase(opts.CatalinaBase); err != nil {
+			a.setStatus("[red]" + err.Error() + "[-]")
+			return
+		}
+
+		// Finding free ports scans the system, so create in the background
+		creating = true
+		a.setStatus("[yellow]" + i18n.T("instance.new.creating") + "[-]")
+		go func() {
+			result, err := instance.Create(opts)
+			a.app.QueueUpdateDraw(func() {
+				creating = false
+				if err != nil {
+					a.setStatus("[red]" + err.Error() + "[-]")
+					return
+				}
+
+				// Selecting the instance also adds it to the recent list
+				a.instance = result.Instance
+				a.updateInstanceInfo()
+				a.setStatus("[green]" + i18n.T("instance.new.created") + "[-]")
+				a.showMessage(i18n.T("instance.new.title"), fmt.Sprintf(i18n.T("instance.new.summary"),
+					result.Instance.CatalinaBase,
+					result.Ports.Shutdown, result.Ports.HTTP, result.Ports.HTTPS, result.Ports.AJP))
+			})
+		}()
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		a.showInstanceSelector()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("instance.new.title") + " ").SetBorderColor(tcell.ColorGreen)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			a.showInstanceSelector()
+			return nil
+		}
+		return event
+	})
+
+	helpText := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true).
+		SetText(i18n.T("instance.new.help"))
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(helpText, 8, 0, false)
+
+	a.pages.AddAndSwitchToPage("new-instance", layout, true)
+	a.app.SetFocus(form)
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/detector"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/rivo/tview"
+)
+
+// showInstanceScan searches the configured roots for Tomcat directories,
+// showing progress as it goes
+func (a *App) showInstanceScan() {
+	roots := detector.DefaultSearchRoots()
+	depth := detector.DefaultSearchDepth
+	if a.settingsManager != nil {
+		if saved := a.settingsManager.GetSearchRoots(); len(saved) > 0 {
+			roots = saved
+		}
+		if saved := a.settingsManager.GetSearchDepth(); saved > 0 {
+			depth = saved
+		}
+	}
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("scan.roots"), strings.Join(roots, " "), 60, nil, nil)
+	form.AddInputField(i18n.T("scan.depth"), strconv.Itoa(depth), 5, func(text string, lastChar rune) bool {
+		return lastChar >= '0' && lastChar <= '9'
+	}, nil)
+
+	progress := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true).
+		SetText(i18n.T("help.scan.roots") + "\n\n" + i18n.T("help.scan.depth"))
+	progress.SetBorder(true).SetTitle(" " + i18n.T("scan.progress.title") + " ").SetBorderColor(tcell.ColorDarkCyan)
+
+	var cancel context.CancelFunc
+	leave := func() {
+		if cancel != nil {
+			cancel()
+		}
+		a.showInstanceSelector()
+	}
+
+	form.AddButton("[white:green]"+i18n.T("scan.start")+"[-:-]", func() {
+		if cancel != nil {
+			return
+		}
+		opts := detector.ScanOptions{
+			Roots: strings.Fields(form.GetFormItem(0).(*tview.InputField).GetText()),
+		}
+		opts.MaxDepth, _ = strconv.Atoi(form.GetFormItem(1).(*tview.InputField).GetText())
+		if len(opts.Roots) == 0 {
+			a.setStatus("[red]" + i18n.T("scan.roots.required") + "[-]")
+			return
+		}
+		if a.settingsManager != nil {
+			a.settingsManager.SetSearchRoots(opts.Roots)
+			a.settingsManager.SetSearchDepth(opts.MaxDepth)
+			a.settingsManager.Save()
+		}
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		opts.Progress = func(p detector.ScanProgress) {
+			a.app.QueueUpdateDraw(func() {
+				text := fmt.Sprintf(i18n.T("scan.progress"), p.Dirs, p.Found)
+				if !p.Done && p.Current != "" {
+					text += "\n[gray]" + tview.Escape(p.Current) + "[-]"
+				}
+				progress.SetText(text)
+			})
+		}
+		a.setStatus("[yellow]" + i18n.T("scan.running") + "[-]")
+
+		go func() {
+			found := detector.NewDetector().Scan(ctx, opts)
+			cancelled := ctx.Err() != nil
+			a.app.QueueUpdateDraw(func() {
+				if cancelled {
+					// The view was left; keep what was found anyway
+					a.scanned = found
+					return
+				}
+				cancel()
+				a.scanned = found
+				a.setStatus("[green]" + fmt.Sprintf(i18n.T("scan.done"), len(found)) + "[-]")
+				a.showScanResults(found)
+			})
+		}()
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", leave)
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("scan.title") + " ").SetBorderColor(tcell.ColorGreen)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			if cancel != nil {
+				a.setStatus("[yellow]" + i18n.T("scan.cancelled") + "[-]")
+			}
+			leave()
+			return nil
+		}
+		return event
+	})
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 9, 0, true).
+		AddItem(progress, 0, 1, false)
+
+	a.pages.AddAndSwitchToPage("instance-scan", layout, true)
+	a.app.SetFocus(form)
+}
+
+// showScanResults lists the instances a scan found for selection
+func (a *App) showScanResults(found []*config.TomcatInstance) {
+	list := tview.NewList().ShowSecondaryText(true)
+	for _, instance := range found {
+		a.addInstanceItem(list, instance)
+	}
+	if len(found) == 0 {
+		list.AddItem("[gray]"+i18n.T("instance.none")+"[-]", i18n.T("scan.none.desc"), 0, nil)
+	}
+
+	list.AddItem("", "", 0, nil) // Spacer
+	list.AddItem("[green]⌕ "+i18n.T("instance.scan")+"[-]", i18n.T("instance.scan.desc"), 's', func() {
+		a.showInstanceScan()
+	})
+	list.AddItem("[red]"+i18n.T("common.back")+"[-]", i18n.T("common.return"), 0, func() {
+		a.showInstanceSelector()
+	})
+
+	list.SetBorder(true).
+		SetTitle(" " + fmt.Sprintf(i18n.T("scan.results"), len(found)) + " ").
+		SetBorderColor(tcell.ColorGreen)
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			a.showInstanceSelector()
+			return nil
+		}
+		return event
+	})
+
+	a.pages.AddAndSwitchToPage("scan-results", list, true)
+	a.app.SetFocus(list)
+}
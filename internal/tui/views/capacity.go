@@ -0,0 +1,183 @@
+package views
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/capacity"
+	"github.com/playok/tomcatkit/internal/config/jndi"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/rivo/tview"
+)
+
+// showCapacityPlanner shows the thread pool and connection capacity planner
+func (v *QuickTemplatesView) showCapacityPlanner() {
+	cfg := v.configService.GetServer()
+
+	// DataSources live in conf/context.xml; the planner still works without it
+	ctxService := jndi.NewContextService(v.catalinaBase)
+	ctxLoaded := ctxService.Load() == nil
+
+	form := tview.NewForm()
+
+	reasoning := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true).
+		SetScrollable(true)
+	reasoning.SetBorder(true).SetTitle(" " + i18n.T("capacity.reasoning") + " ").SetBorderColor(tcell.ColorBlue)
+
+	changesView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	changesView.SetBorder(true).SetTitle(" " + i18n.T("capacity.changes") + " ").SetBorderColor(tcell.ColorBlue)
+
+	var plan *capacity.Plan
+	var serverChanges, dsChanges []capacity.Change
+
+	readInput := func() capacity.Input {
+		atoi := func(i int) int {
+			n, _ := strconv.Atoi(form.GetFormItem(i).(*tview.InputField).GetText())
+			return n
+		}
+		return capacity.Input{
+			RequestsPerSec: float64(atoi(0)),
+			AvgLatencyMs:   float64(atoi(1)),
+			CPUs:           atoi(2),
+			MemoryMB:       atoi(3),
+			DBTimePercent:  atoi(4),
+		}
+	}
+
+	// recalculate rebuilds the plan, its reasoning and the change preview
+	recalculate := func() {
+		if form.GetFormItemCount() < 5 {
+			return
+		}
+
+		var err error
+		plan, err = capacity.Calculate(readInput())
+		if err != nil {
+			serverChanges, dsChanges = nil, nil
+			reasoning.SetText("[red]" + err.Error() + "[-]")
+			changesView.SetText("")
+			return
+		}
+
+		var sb strings.Builder
+		sb.WriteString("[yellow::b]" + i18n.T("capacity.recommended") + "[-::-]\n")
+		sb.WriteString(fmt.Sprintf("  maxThreads      = [green]%d[-]\n", plan.MaxThreads))
+		sb.WriteString(fmt.Sprintf("  minSpareThreads = [green]%d[-]\n", plan.MinSpareThreads))
+		sb.WriteString(fmt.Sprintf("  acceptCount     = [green]%d[-]\n", plan.AcceptCount))
+		sb.WriteString(fmt.Sprintf("  maxConnections  = [green]%d[-]\n", plan.MaxConnections))
+		sb.WriteString(fmt.Sprintf("  DB maxTotal     = [green]%d[-]\n", plan.DBMaxTotal))
+		sb.WriteString(fmt.Sprintf("  DB maxIdle      = [green]%d[-]\n", plan.DBMaxIdle))
+		sb.WriteString(fmt.Sprintf("  DB minIdle      = [green]%d[-]\n", plan.DBMinIdle))
+		sb.WriteString(fmt.Sprintf("  DB maxWait (ms) = [green]%d[-]\n\n", plan.DBMaxWaitMillis))
+
+		sb.WriteString("[yellow::b]" + i18n.T("capacity.why") + "[-::-]\n")
+		for _, r := range plan.Reasons {
+			sb.WriteString("• " + fmt.Sprintf(i18n.T(r.Key), r.Args...) + "\n")
+		}
+		for _, w := range plan.Warnings {
+			sb.WriteString("[red]⚠ " + fmt.Sprintf(i18n.T(w.Key), w.Args...) + "[-]\n")
+		}
+		reasoning.SetText(sb.String())
+
+		serverChanges = plan.ServerChanges(cfg, v.configService.Resolver())
+		dsChanges = nil
+		if ctxLoaded {
+			dsChanges = plan.DataSourceChanges(ctxService.GetResources())
+		}
+
+		var cb strings.Builder
+		cb.WriteString("[::b]server.xml[::-]\n")
+		writeCapacityChanges(&cb, serverChanges)
+		cb.WriteString("\n[::b]context.xml[::-]\n")
+		if !ctxLoaded {
+			cb.WriteString("  [gray]" + i18n.T("capacity.nocontext") + "[-]\n")
+		} else {
+			writeCapacityChanges(&cb, dsChanges)
+		}
+		changesView.SetText(cb.String())
+	}
+
+	form.AddInputField(i18n.T("capacity.rps"), "100", 10, acceptDigits, func(text string) {
+		recalculate()
+	})
+	form.AddInputField(i18n.T("capacity.latency"), "200", 10, acceptDigits, func(text string) {
+		recalculate()
+	})
+	form.AddInputField(i18n.T("capacity.cpus"), strconv.Itoa(runtime.NumCPU()), 10, acceptDigits, func(text string) {
+		recalculate()
+	})
+	form.AddInputField(i18n.T("capacity.memory"), "2048", 10, acceptDigits, func(text string) {
+		recalculate()
+	})
+	form.AddInputField(i18n.T("capacity.dbtime"), "50", 10, acceptDigits, func(text string) {
+		recalculate()
+	})
+
+	form.AddButton("[white:green]"+i18n.T("common.apply")+"[-:-]", func() {
+		if plan == nil {
+			return
+		}
+		if len(serverChanges) == 0 && len(dsChanges) == 0 {
+			v.setStatus("[yellow]" + i18n.T("capacity.nochanges") + "[-]")
+			return
+		}
+
+		if err := capacity.Save(v.configService, serverChanges, ctxService, dsChanges); err != nil {
+			v.setStatus("[red]Error saving: " + err.Error() + "[-]")
+			return
+		}
+
+		v.setStatus("[green]" + fmt.Sprintf(i18n.T("capacity.applied"), len(serverChanges)+len(dsChanges)) + "[-]")
+		v.showMainMenu()
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		v.showMainMenu()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("qt.capacity") + " ")
+
+	recalculate()
+
+	leftPane := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 13, 0, true).
+		AddItem(changesView, 0, 1, false)
+
+	flex := tview.NewFlex().
+		AddItem(leftPane, 0, 1, true).
+		AddItem(reasoning, 0, 1, false)
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showMainMenu()
+			return nil
+		}
+		return event
+	})
+
+	v.pages.AddAndSwitchToPage("capacity-planner", flex, true)
+}
+
+// writeCapacityChanges renders planned changes as "target attr: old → new"
+func writeCapacityChanges(sb *strings.Builder, changes []capacity.Change) {
+	if len(changes) == 0 {
+		sb.WriteString("  [gray]" + i18n.T("capacity.nochanges") + "[-]\n")
+		return
+	}
+	for _, c := range changes {
+		old := c.Old
+		if old == "" {
+			old = i18n.T("connector.attrs.default")
+		}
+		sb.WriteString(fmt.Sprintf("  %-28s %-16s [red]%s[-] → [green]%d[-]\n", c.Target, c.Attribute, old, c.New))
+	}
+}
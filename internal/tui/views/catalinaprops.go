@@ -0,0 +1,378 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/catalina"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/rivo/tview"
+)
+
+// CatalinaPropsView provides TUI for conf/catalina.properties
+type CatalinaPropsView struct {
+	app           *tview.Application
+	pages         *tview.Pages
+	mainPages     *tview.Pages
+	statusBar     *tview.TextView
+	onReturn      func()
+	catalinaBase  string
+	configService *catalina.ConfigService
+}
+
+// NewCatalinaPropsView creates a new catalina.properties view
+func NewCatalinaPropsView(app *tview.Application, mainPages *tview.Pages, statusBar *tview.TextView, catalinaBase string, onReturn func()) *CatalinaPropsView {
+	return &CatalinaPropsView{
+		app:          app,
+		mainPages:    mainPages,
+		statusBar:    statusBar,
+		onReturn:     onReturn,
+		catalinaBase: catalinaBase,
+	}
+}
+
+// Load initializes the view
+func (v *CatalinaPropsView) Load() error {
+	v.configService = catalina.NewConfigService(v.catalinaBase)
+	if err := v.configService.Load(); err != nil {
+		return err
+	}
+
+	v.pages = tview.NewPages()
+	v.showMainMenu()
+
+	v.mainPages.AddAndSwitchToPage("catalinaprops", v.pages, true)
+	return nil
+}
+
+// showMainMenu displays the catalina.properties main menu
+func (v *CatalinaPropsView) showMainMenu() {
+	helpPanel := NewDynamicHelpPanel()
+
+	count := func(key string) string {
+		return fmt.Sprintf(i18n.T("catprops.count"), len(v.configService.GetList(key)))
+	}
+
+	list := tview.NewList().
+		AddItem(i18n.T("catprops.loaders"), fmt.Sprintf(i18n.T("catprops.loaders.desc"), len(v.configService.GetList(catalina.KeyCommonLoader)), len(v.configService.GetList(catalina.KeySharedLoader))), 'l', func() {
+			v.showLoaders()
+		}).
+		AddItem(i18n.T("catprops.jarstoskip"), count(catalina.KeyJarsToSkip), 'k', func() {
+			v.showListEditor(catalina.KeyJarsToSkip, i18n.T("catprops.jarstoskip"), "help.catprops.jarscan", v.showMainMenu)
+		}).
+		AddItem(i18n.T("catprops.jarstoscan"), count(catalina.KeyJarsToScan), 'n', func() {
+			v.showListEditor(catalina.KeyJarsToScan, i18n.T("catprops.jarstoscan"), "help.catprops.jarscan", v.showMainMenu)
+		}).
+		AddItem(i18n.T("catprops.packageaccess"), count(catalina.KeyPackageAccess), 'a', func() {
+			v.showListEditor(catalina.KeyPackageAccess, i18n.T("catprops.packageaccess"), "help.catprops.packages", v.showMainMenu)
+		}).
+		AddItem(i18n.T("catprops.packagedef"), count(catalina.KeyPackageDefinition), 'd', func() {
+			v.showListEditor(catalina.KeyPackageDefinition, i18n.T("catprops.packagedef"), "help.catprops.packages", v.showMainMenu)
+		}).
+		AddItem(i18n.T("catprops.custom"), fmt.Sprintf(i18n.T("catprops.count"), len(v.configService.CustomProperties())), 'c', func() {
+			v.showCustomProperties()
+		}).
+		AddItem(i18n.T("catprops.preview"), i18n.T("catprops.preview.desc"), 'p', func() {
+			v.showPreview()
+		}).
+		AddItem(i18n.T("common.save"), i18n.T("catprops.save.desc"), 's', func() {
+			v.saveConfiguration()
+		}).
+		AddItem(i18n.T("common.back"), i18n.T("common.return"), 'b', func() {
+			v.mainPages.RemovePage("catalinaprops")
+			v.onReturn()
+		})
+
+	list.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+		switch index {
+		case 0:
+			helpPanel.SetHelpKey("help.catprops.loaders")
+		case 1, 2:
+			helpPanel.SetHelpKey("help.catprops.jarscan")
+		case 3, 4:
+			helpPanel.SetHelpKey("help.catprops.packages")
+		case 5:
+			helpPanel.SetHelpKey("help.catprops.custom")
+		default:
+			helpPanel.SetText("")
+		}
+	})
+
+	helpPanel.SetHelpKey("help.catprops.loaders")
+
+	list.SetBorder(true).SetTitle(" " + i18n.T("catprops.title") + " ")
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.mainPages.RemovePage("catalinaprops")
+			v.onReturn()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(list, 0, 2, true).
+		AddItem(helpPanel, 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("menu", flex, true)
+	v.setStatus(i18n.T("catprops.title") + ": " + v.configService.GetFilePath())
+}
+
+// showLoaders lists the common, server and shared class loaders
+func (v *CatalinaPropsView) showLoaders() {
+	list := tview.NewList()
+	for _, key := range catalina.LoaderKeys {
+		k := key
+		list.AddItem(k, fmt.Sprintf(i18n.T("catprops.count"), len(v.configService.GetList(k))), 0, func() {
+			v.showListEditor(k, k, "help.catprops.loaders", v.showLoaders)
+		})
+	}
+	list.AddItem(i18n.T("common.back"), i18n.T("catprops.returnmenu"), 'b', func() {
+		v.showMainMenu()
+	})
+
+	list.SetBorder(true).SetTitle(" " + i18n.T("catprops.loaders") + " ")
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showMainMenu()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(list, 0, 2, true).
+		AddItem(HelpPanel("help.catprops.loaders"), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("loaders", flex, true)
+}
+
+// showListEditor lists the items of a comma-separated property
+func (v *CatalinaPropsView) showListEditor(key, title, helpKey string, onBack func()) {
+	items := v.configService.GetList(key)
+
+	list := tview.NewList()
+	for i, item := range items {
+		idx := i
+		list.AddItem(tview.Escape(item), "", 0, func() {
+			v.showListItemForm(key, title, helpKey, idx, onBack)
+		})
+	}
+
+	list.AddItem("[green]+ "+i18n.T("catprops.item.add")+"[-]", i18n.T("catprops.item.add.desc"), 'a', func() {
+		v.showListItemForm(key, title, helpKey, -1, onBack)
+	})
+	list.AddItem(i18n.T("common.back"), i18n.T("catprops.returnmenu"), 'b', func() {
+		onBack()
+	})
+
+	list.SetBorder(true).SetTitle(fmt.Sprintf(" %s (%d) ", title, len(items)))
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			onBack()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(list, 0, 2, true).
+		AddItem(HelpPanel(helpKey), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("list-editor", flex, true)
+}
+
+// showListItemForm adds (index -1) or edits an item of a list property
+func (v *CatalinaPropsView) showListItemForm(key, title, helpKey string, index int, onBack func()) {
+	items := v.configService.GetList(key)
+	back := func() {
+		v.showListEditor(key, title, helpKey, onBack)
+	}
+
+	current := ""
+	if index >= 0 {
+		current = items[index]
+	}
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("catprops.item.value"), current, 60, nil, nil)
+
+	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		value := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		if value == "" {
+			v.setStatus("[red]" + i18n.T("catprops.item.required") + "[-]")
+			return
+		}
+		if strings.Contains(value, ",") && !catalina.IsLoaderKey(key) {
+			v.setStatus("[red]" + i18n.T("catprops.item.comma") + "[-]")
+			return
+		}
+		if index >= 0 {
+			items[index] = value
+		} else {
+			items = append(items, value)
+		}
+		v.configService.SetList(key, items)
+		back()
+	})
+
+	if index >= 0 {
+		form.AddButton("[white:red]"+i18n.T("common.delete")+"[-:-]", func() {
+			items = append(items[:index], items[index+1:]...)
+			v.configService.SetList(key, items)
+			back()
+		})
+	}
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		back()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + title + " ")
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			back()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(form, 0, 2, true).
+		AddItem(HelpPanel(helpKey), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("list-item-form", flex, true)
+}
+
+// showCustomProperties lists properties not defined by Tomcat
+func (v *CatalinaPropsView) showCustomProperties() {
+	list := tview.NewList()
+	for _, prop := range v.configService.CustomProperties() {
+		p := prop
+		list.AddItem(tview.Escape(p.Key), tview.Escape(p.Value), 0, func() {
+			v.showCustomPropertyForm(p.Key)
+		})
+	}
+
+	list.AddItem("[green]+ "+i18n.T("catprops.custom.add")+"[-]", i18n.T("catprops.custom.add.desc"), 'a', func() {
+		v.showCustomPropertyForm("")
+	})
+	list.AddItem(i18n.T("common.back"), i18n.T("catprops.returnmenu"), 'b', func() {
+		v.showMainMenu()
+	})
+
+	list.SetBorder(true).SetTitle(" " + i18n.T("catprops.custom") + " ")
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showMainMenu()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(list, 0, 2, true).
+		AddItem(HelpPanel("help.catprops.custom"), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("custom", flex, true)
+}
+
+// showCustomPropertyForm adds (empty key) or edits a custom property
+func (v *CatalinaPropsView) showCustomPropertyForm(key string) {
+	value, _ := v.configService.GetProperty(key)
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("catprops.custom.key"), key, 40, nil, nil)
+	form.AddInputField(i18n.T("catprops.custom.value"), value, 60, nil, nil)
+
+	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		newKey := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		newValue := form.GetFormItem(1).(*tview.InputField).GetText()
+		if newKey == "" {
+			v.setStatus("[red]" + i18n.T("catprops.custom.required") + "[-]")
+			return
+		}
+		if catalina.IsStandardKey(newKey) {
+			v.setStatus("[red]" + fmt.Sprintf(i18n.T("catprops.custom.standard"), newKey) + "[-]")
+			return
+		}
+		if newKey != key {
+			if _, exists := v.configService.GetProperty(newKey); exists {
+				v.setStatus("[red]" + fmt.Sprintf(i18n.T("catprops.custom.exists"), newKey) + "[-]")
+				return
+			}
+			if key != "" {
+				v.configService.RemoveProperty(key)
+			}
+		}
+		v.configService.SetProperty(newKey, newValue)
+		v.showCustomProperties()
+	})
+
+	if key != "" {
+		form.AddButton("[white:red]"+i18n.T("common.delete")+"[-:-]", func() {
+			v.configService.RemoveProperty(key)
+			v.showCustomProperties()
+		})
+	}
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		v.showCustomProperties()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("catprops.custom") + " ")
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showCustomProperties()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(form, 0, 2, true).
+		AddItem(HelpPanel("help.catprops.custom"), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("custom-form", flex, true)
+}
+
+// showPreview shows catalina.properties as it will be written
+func (v *CatalinaPropsView) showPreview() {
+	textView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(tview.Escape(v.configService.GetProperties().String()))
+	textView.SetBorder(true).SetTitle(" catalina.properties ").SetBorderColor(tcell.ColorBlue)
+
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyEnter {
+			v.showMainMenu()
+			return nil
+		}
+		return event
+	})
+
+	v.pages.AddAndSwitchToPage("preview", textView, true)
+	v.app.SetFocus(textView)
+}
+
+// saveConfiguration writes catalina.properties
+func (v *CatalinaPropsView) saveConfiguration() {
+	if err := v.configService.Save(); err != nil {
+		v.setStatus("[red]Error saving: " + err.Error() + "[-]")
+		return
+	}
+	v.showMainMenu()
+	v.setStatus("[green]" + fmt.Sprintf(i18n.T("catprops.saved"), v.configService.GetFilePath()) + "[-]")
+}
+
+// setStatus updates the status bar
+func (v *CatalinaPropsView) setStatus(message string) {
+	if v.statusBar != nil {
+		v.statusBar.SetText(" " + message)
+	}
+}
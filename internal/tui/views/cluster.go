@@ -2,11 +2,12 @@ package views
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
 	"github.com/playok/tomcatkit/internal/config/server"
 	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/playok/tomcatkit/internal/ports"
 	"github.com/rivo/tview"
 )
 
@@ -22,6 +23,7 @@ type ClusterView struct {
 
 // NewClusterView creates a new cluster view
 func NewClusterView(app *tview.Application, mainPages *tview.Pages, configService *server.ConfigService, statusBar *tview.TextView, onReturn func()) *ClusterView {
+	preparePortChecker(configService.GetCatalinaBase())
 	return &ClusterView{
 		app:           app,
 		pages:         tview.NewPages(),
@@ -218,12 +220,13 @@ func (v *ClusterView) showClusterSettings() {
 
 	form.AddInputField("Channel Send Options", cluster.ChannelSendOptions, 10, nil, nil)
 	form.AddInputField("Channel Start Options", cluster.ChannelStartOptions, 10, nil, nil)
-	form.AddCheckbox("Notify Lifecycle Listener On Failure", cluster.NotifyLifecycleListenerOnFailure, nil)
+	form.AddCheckbox("Notify Lifecycle Listener On Failure", cluster.NotifyLifecycleListenerOnFailure.Value(v.configService.Resolver()), nil)
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
 		cluster.ChannelSendOptions = form.GetFormItemByLabel("Channel Send Options").(*tview.InputField).GetText()
 		cluster.ChannelStartOptions = form.GetFormItemByLabel("Channel Start Options").(*tview.InputField).GetText()
-		cluster.NotifyLifecycleListenerOnFailure = form.GetFormItemByLabel("Notify Lifecycle Listener On Failure").(*tview.Checkbox).IsChecked()
+		cluster.NotifyLifecycleListenerOnFailure = cluster.NotifyLifecycleListenerOnFailure.With(form.GetFormItemByLabel("Notify Lifecycle Listener On Failure").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
 
 		if err := v.configService.Save(); err != nil {
 			v.setStatus(fmt.Sprintf("[red]Failed to save: %v[-]", err))
@@ -276,22 +279,29 @@ func (v *ClusterView) showManagerSettings() {
 	}
 	form.AddDropDown("Manager Type", []string{"DeltaManager", "BackupManager"}, managerIdx, nil)
 
-	form.AddCheckbox("Expire Sessions On Shutdown", cluster.Manager.ExpireSessionsOnShutdown, nil)
-	form.AddCheckbox("Notify Listeners On Replication", cluster.Manager.NotifyListenersOnReplication, nil)
+	form.AddCheckbox("Expire Sessions On Shutdown", cluster.Manager.ExpireSessionsOnShutdown.Value(v.configService.Resolver()), nil)
+	form.AddCheckbox("Notify Listeners On Replication", cluster.Manager.NotifyListenersOnReplication.Value(v.configService.Resolver()), nil)
 
 	// DeltaManager specific
-	form.AddInputField("State Transfer Timeout (ms)", strconv.Itoa(cluster.Manager.StateTransferTimeout), 10, nil, nil)
-	form.AddCheckbox("Send All Sessions", cluster.Manager.SendAllSessions, nil)
-	form.AddInputField("Send All Sessions Size", strconv.Itoa(cluster.Manager.SendAllSessionsSize), 10, nil, nil)
+	form.AddInputField("State Transfer Timeout (ms)", cluster.Manager.StateTransferTimeout.Text(), 10, nil, nil)
+	form.AddCheckbox("Send All Sessions", cluster.Manager.SendAllSessions.Value(v.configService.Resolver()), nil)
+	form.AddInputField("Send All Sessions Size", cluster.Manager.SendAllSessionsSize.Text(), 10, nil, nil)
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		var ints formInts
 		idx, _ := form.GetFormItemByLabel("Manager Type").(*tview.DropDown).GetCurrentOption()
 		cluster.Manager.ClassName = managerTypes[idx]
-		cluster.Manager.ExpireSessionsOnShutdown = form.GetFormItemByLabel("Expire Sessions On Shutdown").(*tview.Checkbox).IsChecked()
-		cluster.Manager.NotifyListenersOnReplication = form.GetFormItemByLabel("Notify Listeners On Replication").(*tview.Checkbox).IsChecked()
-		cluster.Manager.StateTransferTimeout, _ = strconv.Atoi(form.GetFormItemByLabel("State Transfer Timeout (ms)").(*tview.InputField).GetText())
-		cluster.Manager.SendAllSessions = form.GetFormItemByLabel("Send All Sessions").(*tview.Checkbox).IsChecked()
-		cluster.Manager.SendAllSessionsSize, _ = strconv.Atoi(form.GetFormItemByLabel("Send All Sessions Size").(*tview.InputField).GetText())
+		cluster.Manager.ExpireSessionsOnShutdown = cluster.Manager.ExpireSessionsOnShutdown.With(form.GetFormItemByLabel("Expire Sessions On Shutdown").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		cluster.Manager.NotifyListenersOnReplication = cluster.Manager.NotifyListenersOnReplication.With(form.GetFormItemByLabel("Notify Listeners On Replication").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		cluster.Manager.StateTransferTimeout = ints.Int(form.GetFormItemByLabel("State Transfer Timeout (ms)"), cluster.Manager.StateTransferTimeout)
+		cluster.Manager.SendAllSessions = cluster.Manager.SendAllSessions.With(form.GetFormItemByLabel("Send All Sessions").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		cluster.Manager.SendAllSessionsSize = ints.Int(form.GetFormItemByLabel("Send All Sessions Size"), cluster.Manager.SendAllSessionsSize)
+
+		if ints.err != nil {
+			v.setStatus("[red]" + ints.err.Error() + "[-]")
+			return
+		}
 
 		if err := v.configService.Save(); err != nil {
 			v.setStatus(fmt.Sprintf("[red]Failed to save: %v[-]", err))
@@ -330,9 +340,9 @@ func (v *ClusterView) showMembershipSettings() {
 		cluster.Channel.Membership = &server.Membership{
 			ClassName: server.MembershipMcastService,
 			Address:   "228.0.0.4",
-			Port:      45564,
-			Frequency: 500,
-			DropTime:  3000,
+			Port:      placeholder.IntOf(45564),
+			Frequency: placeholder.IntOf(500),
+			DropTime:  placeholder.IntOf(3000),
 		}
 	}
 
@@ -340,25 +350,37 @@ func (v *ClusterView) showMembershipSettings() {
 	form := tview.NewForm()
 
 	form.AddInputField("Multicast Address", m.Address, 20, nil, nil)
-	form.AddInputField("Multicast Port", strconv.Itoa(m.Port), 10, nil, nil)
-	form.AddInputField("Frequency (ms)", strconv.Itoa(m.Frequency), 10, nil, nil)
-	form.AddInputField("Drop Time (ms)", strconv.Itoa(m.DropTime), 10, nil, nil)
+	form.AddInputField("Multicast Port", m.Port.Text(), 10, nil, nil)
+	portField := form.GetFormItemByLabel("Multicast Port").(*tview.InputField)
+	portField.SetChangedFunc(func(text string) {
+		v.checkPort(portField, ports.KindMembership, "Membership")
+	})
+	v.checkPort(portField, ports.KindMembership, "Membership")
+	form.AddInputField("Frequency (ms)", m.Frequency.Text(), 10, nil, nil)
+	form.AddInputField("Drop Time (ms)", m.DropTime.Text(), 10, nil, nil)
 	form.AddInputField("Bind Address", m.Bind, 20, nil, nil)
-	form.AddCheckbox("Recovery Enabled", m.RecoveryEnabled, nil)
-	form.AddInputField("Recovery Counter", strconv.Itoa(m.RecoveryCounter), 10, nil, nil)
-	form.AddInputField("Recovery Sleep Time (ms)", strconv.Itoa(m.RecoverySleepTime), 10, nil, nil)
-	form.AddCheckbox("Local Loopback Disabled", m.LocalLoopbackDisabled, nil)
+	form.AddCheckbox("Recovery Enabled", m.RecoveryEnabled.Value(v.configService.Resolver()), nil)
+	form.AddInputField("Recovery Counter", m.RecoveryCounter.Text(), 10, nil, nil)
+	form.AddInputField("Recovery Sleep Time (ms)", m.RecoverySleepTime.Text(), 10, nil, nil)
+	form.AddCheckbox("Local Loopback Disabled", m.LocalLoopbackDisabled.Value(v.configService.Resolver()), nil)
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		var ints formInts
 		m.Address = form.GetFormItemByLabel("Multicast Address").(*tview.InputField).GetText()
-		m.Port, _ = strconv.Atoi(form.GetFormItemByLabel("Multicast Port").(*tview.InputField).GetText())
-		m.Frequency, _ = strconv.Atoi(form.GetFormItemByLabel("Frequency (ms)").(*tview.InputField).GetText())
-		m.DropTime, _ = strconv.Atoi(form.GetFormItemByLabel("Drop Time (ms)").(*tview.InputField).GetText())
+		m.Port = ints.Int(form.GetFormItemByLabel("Multicast Port"), m.Port)
+		m.Frequency = ints.Int(form.GetFormItemByLabel("Frequency (ms)"), m.Frequency)
+		m.DropTime = ints.Int(form.GetFormItemByLabel("Drop Time (ms)"), m.DropTime)
 		m.Bind = form.GetFormItemByLabel("Bind Address").(*tview.InputField).GetText()
-		m.RecoveryEnabled = form.GetFormItemByLabel("Recovery Enabled").(*tview.Checkbox).IsChecked()
-		m.RecoveryCounter, _ = strconv.Atoi(form.GetFormItemByLabel("Recovery Counter").(*tview.InputField).GetText())
-		m.RecoverySleepTime, _ = strconv.Atoi(form.GetFormItemByLabel("Recovery Sleep Time (ms)").(*tview.InputField).GetText())
-		m.LocalLoopbackDisabled = form.GetFormItemByLabel("Local Loopback Disabled").(*tview.Checkbox).IsChecked()
+		m.RecoveryEnabled = m.RecoveryEnabled.With(form.GetFormItemByLabel("Recovery Enabled").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		m.RecoveryCounter = ints.Int(form.GetFormItemByLabel("Recovery Counter"), m.RecoveryCounter)
+		m.RecoverySleepTime = ints.Int(form.GetFormItemByLabel("Recovery Sleep Time (ms)"), m.RecoverySleepTime)
+		m.LocalLoopbackDisabled = m.LocalLoopbackDisabled.With(form.GetFormItemByLabel("Local Loopback Disabled").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+
+		if ints.err != nil {
+			v.setStatus("[red]" + ints.err.Error() + "[-]")
+			return
+		}
 
 		if err := v.configService.Save(); err != nil {
 			v.setStatus(fmt.Sprintf("[red]Failed to save: %v[-]", err))
@@ -397,10 +419,10 @@ func (v *ClusterView) showReceiverSettings() {
 		cluster.Channel.Receiver = &server.Receiver{
 			ClassName:       server.ReceiverNioReceiver,
 			Address:         "auto",
-			Port:            4000,
-			AutoBind:        100,
-			SelectorTimeout: 5000,
-			MaxThreads:      6,
+			Port:            placeholder.IntOf(4000),
+			AutoBind:        placeholder.IntOf(100),
+			SelectorTimeout: placeholder.IntOf(5000),
+			MaxThreads:      placeholder.IntOf(6),
 		}
 	}
 
@@ -415,16 +437,23 @@ func (v *ClusterView) showReceiverSettings() {
 	form.AddDropDown("Receiver Type", receiverTypes, receiverIdx, nil)
 
 	form.AddInputField("Address", r.Address, 20, nil, nil)
-	form.AddInputField("Port", strconv.Itoa(r.Port), 10, nil, nil)
-	form.AddInputField("Auto Bind Range", strconv.Itoa(r.AutoBind), 10, nil, nil)
-	form.AddInputField("Selector Timeout (ms)", strconv.Itoa(r.SelectorTimeout), 10, nil, nil)
-	form.AddInputField("Max Threads", strconv.Itoa(r.MaxThreads), 10, nil, nil)
-	form.AddInputField("Min Threads", strconv.Itoa(r.MinThreads), 10, nil, nil)
-	form.AddInputField("RX Buffer Size", strconv.Itoa(r.RxBufSize), 10, nil, nil)
-	form.AddInputField("TX Buffer Size", strconv.Itoa(r.TxBufSize), 10, nil, nil)
-	form.AddInputField("Timeout (ms)", strconv.Itoa(r.Timeout), 10, nil, nil)
-
+	form.AddInputField("Port", r.Port.Text(), 10, nil, nil)
+	portField := form.GetFormItemByLabel("Port").(*tview.InputField)
+	portField.SetChangedFunc(func(text string) {
+		v.checkPort(portField, ports.KindReceiver, "Receiver")
+	})
+	v.checkPort(portField, ports.KindReceiver, "Receiver")
+	form.AddInputField("Auto Bind Range", r.AutoBind.Text(), 10, nil, nil)
+	form.AddInputField("Selector Timeout (ms)", r.SelectorTimeout.Text(), 10, nil, nil)
+	form.AddInputField("Max Threads", r.MaxThreads.Text(), 10, nil, nil)
+	form.AddInputField("Min Threads", r.MinThreads.Text(), 10, nil, nil)
+	form.AddInputField("RX Buffer Size", r.RxBufSize.Text(), 10, nil, nil)
+	form.AddInputField("TX Buffer Size", r.TxBufSize.Text(), 10, nil, nil)
+	form.AddInputField("Timeout (ms)", r.Timeout.Text(), 10, nil, nil)
+
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		var ints formInts
 		idx, _ := form.GetFormItemByLabel("Receiver Type").(*tview.DropDown).GetCurrentOption()
 		if idx == 0 {
 			r.ClassName = server.ReceiverNioReceiver
@@ -432,14 +461,19 @@ func (v *ClusterView) showReceiverSettings() {
 			r.ClassName = server.ReceiverBioReceiver
 		}
 		r.Address = form.GetFormItemByLabel("Address").(*tview.InputField).GetText()
-		r.Port, _ = strconv.Atoi(form.GetFormItemByLabel("Port").(*tview.InputField).GetText())
-		r.AutoBind, _ = strconv.Atoi(form.GetFormItemByLabel("Auto Bind Range").(*tview.InputField).GetText())
-		r.SelectorTimeout, _ = strconv.Atoi(form.GetFormItemByLabel("Selector Timeout (ms)").(*tview.InputField).GetText())
-		r.MaxThreads, _ = strconv.Atoi(form.GetFormItemByLabel("Max Threads").(*tview.InputField).GetText())
-		r.MinThreads, _ = strconv.Atoi(form.GetFormItemByLabel("Min Threads").(*tview.InputField).GetText())
-		r.RxBufSize, _ = strconv.Atoi(form.GetFormItemByLabel("RX Buffer Size").(*tview.InputField).GetText())
-		r.TxBufSize, _ = strconv.Atoi(form.GetFormItemByLabel("TX Buffer Size").(*tview.InputField).GetText())
-		r.Timeout, _ = strconv.Atoi(form.GetFormItemByLabel("Timeout (ms)").(*tview.InputField).GetText())
+		r.Port = ints.Int(form.GetFormItemByLabel("Port"), r.Port)
+		r.AutoBind = ints.Int(form.GetFormItemByLabel("Auto Bind Range"), r.AutoBind)
+		r.SelectorTimeout = ints.Int(form.GetFormItemByLabel("Selector Timeout (ms)"), r.SelectorTimeout)
+		r.MaxThreads = ints.Int(form.GetFormItemByLabel("Max Threads"), r.MaxThreads)
+		r.MinThreads = ints.Int(form.GetFormItemByLabel("Min Threads"), r.MinThreads)
+		r.RxBufSize = ints.Int(form.GetFormItemByLabel("RX Buffer Size"), r.RxBufSize)
+		r.TxBufSize = ints.Int(form.GetFormItemByLabel("TX Buffer Size"), r.TxBufSize)
+		r.Timeout = ints.Int(form.GetFormItemByLabel("Timeout (ms)"), r.Timeout)
+
+		if ints.err != nil {
+			v.setStatus("[red]" + ints.err.Error() + "[-]")
+			return
+		}
 
 		if err := v.configService.Save(); err != nil {
 			v.setStatus(fmt.Sprintf("[red]Failed to save: %v[-]", err))
@@ -492,28 +526,35 @@ func (v *ClusterView) showSenderSettings() {
 	t := cluster.Channel.Sender.Transport
 	form := tview.NewForm()
 
-	form.AddInputField("RX Buffer Size", strconv.Itoa(t.RxBufSize), 10, nil, nil)
-	form.AddInputField("TX Buffer Size", strconv.Itoa(t.TxBufSize), 10, nil, nil)
-	form.AddCheckbox("Direct Buffer", t.DirectBuffer, nil)
-	form.AddInputField("Keep Alive Count", strconv.Itoa(t.KeepAliveCount), 10, nil, nil)
-	form.AddInputField("Keep Alive Time (ms)", strconv.Itoa(t.KeepAliveTime), 10, nil, nil)
-	form.AddInputField("Timeout (ms)", strconv.Itoa(t.Timeout), 10, nil, nil)
-	form.AddInputField("Max Retry Attempts", strconv.Itoa(t.MaxRetryAttempts), 10, nil, nil)
-	form.AddCheckbox("TCP No Delay", t.TcpNoDelay, nil)
-	form.AddCheckbox("SO Keep Alive", t.SoKeepAlive, nil)
-	form.AddCheckbox("Throw On Failed Ack", t.ThrowOnFailedAck, nil)
-
+	form.AddInputField("RX Buffer Size", t.RxBufSize.Text(), 10, nil, nil)
+	form.AddInputField("TX Buffer Size", t.TxBufSize.Text(), 10, nil, nil)
+	form.AddCheckbox("Direct Buffer", t.DirectBuffer.Value(v.configService.Resolver()), nil)
+	form.AddInputField("Keep Alive Count", t.KeepAliveCount.Text(), 10, nil, nil)
+	form.AddInputField("Keep Alive Time (ms)", t.KeepAliveTime.Text(), 10, nil, nil)
+	form.AddInputField("Timeout (ms)", t.Timeout.Text(), 10, nil, nil)
+	form.AddInputField("Max Retry Attempts", t.MaxRetryAttempts.Text(), 10, nil, nil)
+	form.AddCheckbox("TCP No Delay", t.TcpNoDelay.Value(v.configService.Resolver()), nil)
+	form.AddCheckbox("SO Keep Alive", t.SoKeepAlive.Value(v.configService.Resolver()), nil)
+	form.AddCheckbox("Throw On Failed Ack", t.ThrowOnFailedAck.Value(v.configService.Resolver()), nil)
+
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
-		t.RxBufSize, _ = strconv.Atoi(form.GetFormItemByLabel("RX Buffer Size").(*tview.InputField).GetText())
-		t.TxBufSize, _ = strconv.Atoi(form.GetFormItemByLabel("TX Buffer Size").(*tview.InputField).GetText())
-		t.DirectBuffer = form.GetFormItemByLabel("Direct Buffer").(*tview.Checkbox).IsChecked()
-		t.KeepAliveCount, _ = strconv.Atoi(form.GetFormItemByLabel("Keep Alive Count").(*tview.InputField).GetText())
-		t.KeepAliveTime, _ = strconv.Atoi(form.GetFormItemByLabel("Keep Alive Time (ms)").(*tview.InputField).GetText())
-		t.Timeout, _ = strconv.Atoi(form.GetFormItemByLabel("Timeout (ms)").(*tview.InputField).GetText())
-		t.MaxRetryAttempts, _ = strconv.Atoi(form.GetFormItemByLabel("Max Retry Attempts").(*tview.InputField).GetText())
-		t.TcpNoDelay = form.GetFormItemByLabel("TCP No Delay").(*tview.Checkbox).IsChecked()
-		t.SoKeepAlive = form.GetFormItemByLabel("SO Keep Alive").(*tview.Checkbox).IsChecked()
-		t.ThrowOnFailedAck = form.GetFormItemByLabel("Throw On Failed Ack").(*tview.Checkbox).IsChecked()
+		var ints formInts
+		t.RxBufSize = ints.Int(form.GetFormItemByLabel("RX Buffer Size"), t.RxBufSize)
+		t.TxBufSize = ints.Int(form.GetFormItemByLabel("TX Buffer Size"), t.TxBufSize)
+		t.DirectBuffer = t.DirectBuffer.With(form.GetFormItemByLabel("Direct Buffer").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		t.KeepAliveCount = ints.Int(form.GetFormItemByLabel("Keep Alive Count"), t.KeepAliveCount)
+		t.KeepAliveTime = ints.Int(form.GetFormItemByLabel("Keep Alive Time (ms)"), t.KeepAliveTime)
+		t.Timeout = ints.Int(form.GetFormItemByLabel("Timeout (ms)"), t.Timeout)
+		t.MaxRetryAttempts = ints.Int(form.GetFormItemByLabel("Max Retry Attempts"), t.MaxRetryAttempts)
+		t.TcpNoDelay = t.TcpNoDelay.With(form.GetFormItemByLabel("TCP No Delay").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		t.SoKeepAlive = t.SoKeepAlive.With(form.GetFormItemByLabel("SO Keep Alive").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		t.ThrowOnFailedAck = t.ThrowOnFailedAck.With(form.GetFormItemByLabel("Throw On Failed Ack").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+
+		if ints.err != nil {
+			v.setStatus("[red]" + ints.err.Error() + "[-]")
+			return
+		}
 
 		if err := v.configService.Save(); err != nil {
 			v.setStatus(fmt.Sprintf("[red]Failed to save: %v[-]", err))
@@ -622,19 +663,19 @@ func (v *ClusterView) showInterceptorForm(interceptor *server.Interceptor, isNew
 	// Add fields based on interceptor type
 	switch interceptor.ClassName {
 	case server.InterceptorTcpFailureDetector:
-		form.AddInputField("Connect Timeout (ms)", strconv.Itoa(interceptor.ConnectTimeout), 10, nil, nil)
-		form.AddCheckbox("Perform Send Test", interceptor.PerformSendTest, nil)
-		form.AddCheckbox("Perform Read Test", interceptor.PerformReadTest, nil)
-		form.AddInputField("Read Test Timeout (ms)", strconv.Itoa(interceptor.ReadTestTimeout), 10, nil, nil)
-		form.AddInputField("Remove Suspects Timeout (ms)", strconv.Itoa(interceptor.RemoveSuspectsTimeout), 10, nil, nil)
+		form.AddInputField("Connect Timeout (ms)", interceptor.ConnectTimeout.Text(), 10, nil, nil)
+		form.AddCheckbox("Perform Send Test", interceptor.PerformSendTest.Value(v.configService.Resolver()), nil)
+		form.AddCheckbox("Perform Read Test", interceptor.PerformReadTest.Value(v.configService.Resolver()), nil)
+		form.AddInputField("Read Test Timeout (ms)", interceptor.ReadTestTimeout.Text(), 10, nil, nil)
+		form.AddInputField("Remove Suspects Timeout (ms)", interceptor.RemoveSuspectsTimeout.Text(), 10, nil, nil)
 
 	case server.InterceptorMessageDispatch:
-		form.AddInputField("Max Queue Size", strconv.Itoa(interceptor.MaxQueueSize), 10, nil, nil)
-		form.AddCheckbox("Optional Queue", interceptor.OptionalQueue, nil)
-		form.AddCheckbox("Always Send", interceptor.AlwaysSend, nil)
+		form.AddInputField("Max Queue Size", interceptor.MaxQueueSize.Text(), 10, nil, nil)
+		form.AddCheckbox("Optional Queue", interceptor.OptionalQueue.Value(v.configService.Resolver()), nil)
+		form.AddCheckbox("Always Send", interceptor.AlwaysSend.Value(v.configService.Resolver()), nil)
 
 	case server.InterceptorThroughput:
-		form.AddInputField("Interval (seconds)", strconv.Itoa(interceptor.Interval), 10, nil, nil)
+		form.AddInputField("Interval (seconds)", interceptor.Interval.Text(), 10, nil, nil)
 
 	case server.InterceptorEncrypt:
 		form.AddInputField("Encryption Algorithm", interceptor.EncryptionAlgorithm, 30, nil, nil)
@@ -642,23 +683,25 @@ func (v *ClusterView) showInterceptorForm(interceptor *server.Interceptor, isNew
 		form.AddInputField("Encryption Key File", interceptor.EncryptionKeyFile, 50, nil, nil)
 	}
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		var ints formInts
 		// Extract values based on type
 		switch interceptor.ClassName {
 		case server.InterceptorTcpFailureDetector:
-			interceptor.ConnectTimeout = GetFormInt(form, "Connect Timeout (ms)")
-			interceptor.PerformSendTest = GetFormBool(form, "Perform Send Test")
-			interceptor.PerformReadTest = GetFormBool(form, "Perform Read Test")
-			interceptor.ReadTestTimeout = GetFormInt(form, "Read Test Timeout (ms)")
-			interceptor.RemoveSuspectsTimeout = GetFormInt(form, "Remove Suspects Timeout (ms)")
+			interceptor.ConnectTimeout = ints.Int(form.GetFormItemByLabel("Connect Timeout (ms)"), interceptor.ConnectTimeout)
+			interceptor.PerformSendTest = interceptor.PerformSendTest.With(GetFormBool(form, "Perform Send Test"), v.configService.Resolver())
+			interceptor.PerformReadTest = interceptor.PerformReadTest.With(GetFormBool(form, "Perform Read Test"), v.configService.Resolver())
+			interceptor.ReadTestTimeout = ints.Int(form.GetFormItemByLabel("Read Test Timeout (ms)"), interceptor.ReadTestTimeout)
+			interceptor.RemoveSuspectsTimeout = ints.Int(form.GetFormItemByLabel("Remove Suspects Timeout (ms)"), interceptor.RemoveSuspectsTimeout)
 
 		case server.InterceptorMessageDispatch:
-			interceptor.MaxQueueSize = GetFormInt(form, "Max Queue Size")
-			interceptor.OptionalQueue = GetFormBool(form, "Optional Queue")
-			interceptor.AlwaysSend = GetFormBool(form, "Always Send")
+			interceptor.MaxQueueSize = ints.Int(form.GetFormItemByLabel("Max Queue Size"), interceptor.MaxQueueSize)
+			interceptor.OptionalQueue = interceptor.OptionalQueue.With(GetFormBool(form, "Optional Queue"), v.configService.Resolver())
+			interceptor.AlwaysSend = interceptor.AlwaysSend.With(GetFormBool(form, "Always Send"), v.configService.Resolver())
 
 		case server.InterceptorThroughput:
-			interceptor.Interval = GetFormInt(form, "Interval (seconds)")
+			interceptor.Interval = ints.Int(form.GetFormItemByLabel("Interval (seconds)"), interceptor.Interval)
 
 		case server.InterceptorEncrypt:
 			interceptor.EncryptionAlgorithm = GetFormText(form, "Encryption Algorithm")
@@ -666,6 +709,11 @@ func (v *ClusterView) showInterceptorForm(interceptor *server.Interceptor, isNew
 			interceptor.EncryptionKeyFile = GetFormText(form, "Encryption Key File")
 		}
 
+		if ints.err != nil {
+			v.setStatus("[red]" + ints.err.Error() + "[-]")
+			return
+		}
+
 		if isNew {
 			cluster := v.getCluster()
 			if cluster != nil && cluster.Channel != nil {
@@ -750,25 +798,32 @@ func (v *ClusterView) showDeployerSettings() {
 			TempDir:      "/tmp/war-temp/",
 			DeployDir:    "/tmp/war-deploy/",
 			WatchDir:     "/tmp/war-listen/",
-			WatchEnabled: false,
+			WatchEnabled: "",
 		}
 	}
 
 	d := cluster.Deployer
 	form := tview.NewForm()
 
-	form.AddCheckbox("Watch Enabled", d.WatchEnabled, nil)
+	form.AddCheckbox("Watch Enabled", d.WatchEnabled.Value(v.configService.Resolver()), nil)
 	form.AddInputField("Temp Dir", d.TempDir, 50, nil, nil)
 	form.AddInputField("Deploy Dir", d.DeployDir, 50, nil, nil)
 	form.AddInputField("Watch Dir", d.WatchDir, 50, nil, nil)
-	form.AddInputField("Process Deploy Frequency", strconv.Itoa(d.ProcessDeployFrequency), 10, nil, nil)
+	form.AddInputField("Process Deploy Frequency", d.ProcessDeployFrequency.Text(), 10, nil, nil)
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
-		d.WatchEnabled = form.GetFormItemByLabel("Watch Enabled").(*tview.Checkbox).IsChecked()
+		var ints formInts
+		d.WatchEnabled = d.WatchEnabled.With(form.GetFormItemByLabel("Watch Enabled").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
 		d.TempDir = form.GetFormItemByLabel("Temp Dir").(*tview.InputField).GetText()
 		d.DeployDir = form.GetFormItemByLabel("Deploy Dir").(*tview.InputField).GetText()
 		d.WatchDir = form.GetFormItemByLabel("Watch Dir").(*tview.InputField).GetText()
-		d.ProcessDeployFrequency, _ = strconv.Atoi(form.GetFormItemByLabel("Process Deploy Frequency").(*tview.InputField).GetText())
+		d.ProcessDeployFrequency = ints.Int(form.GetFormItemByLabel("Process Deploy Frequency"), d.ProcessDeployFrequency)
+
+		if ints.err != nil {
+			v.setStatus("[red]" + ints.err.Error() + "[-]")
+			return
+		}
 
 		if err := v.configService.Save(); err != nil {
 			v.setStatus(fmt.Sprintf("[red]Failed to save: %v[-]", err))
@@ -806,6 +861,23 @@ func (v *ClusterView) showDeployerSettings() {
 	v.app.SetFocus(form)
 }
 
+// checkPort flags a conflicting cluster port field and reports it in the status bar
+func (v *ClusterView) checkPort(field *tview.InputField, kind ports.Kind, element string) {
+	where := element
+	if cluster := v.getCluster(); cluster != nil {
+		// Label it as ports.Collect does, under the service owning the cluster
+		for _, svc := range v.configService.GetConfig().Services {
+			if svc.Engine.Cluster == cluster {
+				where = svc.Name + "/Cluster/" + element
+				break
+			}
+		}
+	}
+	if msg := checkPortField(field, v.configService, kind, where); msg != "" {
+		v.setStatus("[yellow]" + msg + "[-]")
+	}
+}
+
 // setStatus updates the status bar
 func (v *ClusterView) setStatus(message string) {
 	if v.statusBar != nil {
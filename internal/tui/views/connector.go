@@ -1,13 +1,17 @@
 package views
 
 import (
+	"encoding/xml"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/playok/tomcatkit/internal/config/connector"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
 	"github.com/playok/tomcatkit/internal/config/server"
 	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/playok/tomcatkit/internal/ports"
 	"github.com/rivo/tview"
 )
 
@@ -68,6 +72,16 @@ var addSSLConnectorHelpKeysByIndex = []string{
 	"help.connector.clientauth",        // 9: Client Auth
 }
 
+// HTTP/2 upgrade protocol help keys (by form field index)
+var http2UpgradeHelpKeysByIndex = []string{
+	"help.connector.http2.enabled",              // 0: Enabled
+	"help.connector.http2.maxconcurrentstreams", // 1: Max Concurrent Streams
+	"help.connector.http2.initialwindowsize",    // 2: Initial Window Size
+	"help.connector.http2.readtimeout",          // 3: Read Timeout
+	"help.connector.http2.keepalivetimeout",     // 4: Keep-Alive Timeout
+	"help.connector.http2.overheadcountfactor",  // 5: Overhead Count Factor
+}
+
 // ConnectorView handles connector configuration UI
 type ConnectorView struct {
 	app           *tview.Application
@@ -79,6 +93,7 @@ type ConnectorView struct {
 
 // NewConnectorView creates a new connector configuration view
 func NewConnectorView(app *tview.Application, pages *tview.Pages, configService *server.ConfigService, statusBar *tview.TextView, onBack func()) *ConnectorView {
+	preparePortChecker(configService.GetCatalinaBase())
 	return &ConnectorView{
 		app:           app,
 		pages:         pages,
@@ -137,6 +152,14 @@ func (v *ConnectorView) Show() {
 		func() { v.showExecutors() },
 	)
 
+	// Executor wiring
+	list.AddItem(
+		"[::b]"+i18n.T("connector.wiring")+"[::-]",
+		i18n.T("connector.wiring.desc"),
+		'w',
+		func() { v.showExecutorWiring() },
+	)
+
 	list.AddItem("[-:-:-] [white:red] "+i18n.T("common.back")+" [-:-:-]", i18n.T("common.return"), 'b', v.onBack)
 
 	// Update help panel when selection changes
@@ -150,6 +173,8 @@ func (v *ConnectorView) Show() {
 			helpPanel.SetHelpKey("help.connector.https")
 		case 3:
 			helpPanel.SetHelpKey("help.server.executor")
+		case 4:
+			helpPanel.SetHelpKey("help.connector.wiring")
 		default:
 			helpPanel.SetText("")
 		}
@@ -173,7 +198,7 @@ func (v *ConnectorView) countConnectorsByType(services []server.Service, connTyp
 	count := 0
 	for _, svc := range services {
 		for _, conn := range svc.Connectors {
-			if connector.GetConnectorType(conn.Protocol) == connType && !conn.SSLEnabled {
+			if connector.GetConnectorType(conn.Protocol) == connType && !conn.SSLEnabled.Value(v.configService.Resolver()) {
 				count++
 			}
 		}
@@ -185,7 +210,7 @@ func (v *ConnectorView) countSSLConnectors(services []server.Service) int {
 	count := 0
 	for _, svc := range services {
 		for _, conn := range svc.Connectors {
-			if conn.SSLEnabled {
+			if conn.SSLEnabled.Value(v.configService.Resolver()) {
 				count++
 			}
 		}
@@ -201,12 +226,15 @@ func (v *ConnectorView) showHTTPConnectors() {
 	for svcIdx, svc := range services {
 		for connIdx, conn := range svc.Connectors {
 			connType := connector.GetConnectorType(conn.Protocol)
-			if connType == connector.ConnectorTypeHTTP && !conn.SSLEnabled {
+			if connType == connector.ConnectorTypeHTTP && !conn.SSLEnabled.Value(v.configService.Resolver()) {
 				si, ci := svcIdx, connIdx
 				protocol := connector.GetProtocolDescription(conn.Protocol)
+				if connector.HasHTTP2(&conn) {
+					protocol += " + h2c"
+				}
 				list.AddItem(
-					fmt.Sprintf("Port [yellow]%d[-] - %s", conn.Port, protocol),
-					fmt.Sprintf("Service: %s, Threads: %d-%d", svc.Name, conn.MinSpareThreads, conn.MaxThreads),
+					fmt.Sprintf("Port [yellow]%s[-] - %s", conn.Port, protocol),
+					fmt.Sprintf("Service: %s, Threads: %s-%s", svc.Name, conn.MinSpareThreads, conn.MaxThreads),
 					0,
 					func() { v.showConnectorDetail(si, ci) },
 				)
@@ -242,7 +270,7 @@ func (v *ConnectorView) showAJPConnectors() {
 					secretStatus = "Secret Set"
 				}
 				list.AddItem(
-					fmt.Sprintf("Port [yellow]%d[-] - %s", conn.Port, protocol),
+					fmt.Sprintf("Port [yellow]%s[-] - %s", conn.Port, protocol),
 					fmt.Sprintf("Service: %s, %s", svc.Name, secretStatus),
 					0,
 					func() { v.showAJPConnectorDetail(si, ci) },
@@ -271,14 +299,18 @@ func (v *ConnectorView) showSSLConnectors() {
 	services := v.configService.GetServices()
 	for svcIdx, svc := range services {
 		for connIdx, conn := range svc.Connectors {
-			if conn.SSLEnabled {
+			if conn.SSLEnabled.Value(v.configService.Resolver()) {
 				si, ci := svcIdx, connIdx
 				keystoreInfo := "Keystore not configured"
 				if conn.KeystoreFile != "" {
 					keystoreInfo = fmt.Sprintf("Keystore: %s", conn.KeystoreFile)
 				}
+				scheme := "HTTPS"
+				if connector.HasHTTP2(&conn) {
+					scheme = "HTTPS + h2"
+				}
 				list.AddItem(
-					fmt.Sprintf("Port [yellow]%d[-] - HTTPS", conn.Port),
+					fmt.Sprintf("Port [yellow]%s[-] - %s", conn.Port, scheme),
 					fmt.Sprintf("Service: %s, %s", svc.Name, keystoreInfo),
 					0,
 					func() { v.showSSLConnectorDetail(si, ci) },
@@ -343,27 +375,28 @@ func (v *ConnectorView) showConnectorDetail(serviceIndex, connectorIndex int) {
 			MinSpareThreads:   conn.MinSpareThreads,
 			AcceptCount:       conn.AcceptCount,
 			Executor:          conn.Executor,
+			UpgradeProtocols:  conn.UpgradeProtocols,
 		}
 
 		// Get current form values
 		if port, err := strconv.Atoi(form.GetFormItem(0).(*tview.InputField).GetText()); err == nil {
-			tempConn.Port = port
+			tempConn.Port = placeholder.IntOf(port)
 		}
 		_, tempConn.Protocol = form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
 		if timeout, err := strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText()); err == nil {
-			tempConn.ConnectionTimeout = timeout
+			tempConn.ConnectionTimeout = placeholder.IntOf(timeout)
 		}
 		if redirect, err := strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText()); err == nil {
-			tempConn.RedirectPort = redirect
+			tempConn.RedirectPort = placeholder.IntOf(redirect)
 		}
 		if maxThreads, err := strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText()); err == nil {
-			tempConn.MaxThreads = maxThreads
+			tempConn.MaxThreads = placeholder.IntOf(maxThreads)
 		}
 		if minThreads, err := strconv.Atoi(form.GetFormItem(5).(*tview.InputField).GetText()); err == nil {
-			tempConn.MinSpareThreads = minThreads
+			tempConn.MinSpareThreads = placeholder.IntOf(minThreads)
 		}
 		if acceptCount, err := strconv.Atoi(form.GetFormItem(6).(*tview.InputField).GetText()); err == nil {
-			tempConn.AcceptCount = acceptCount
+			tempConn.AcceptCount = placeholder.IntOf(acceptCount)
 		}
 		tempConn.Executor = form.GetFormItem(7).(*tview.InputField).GetText()
 
@@ -371,27 +404,28 @@ func (v *ConnectorView) showConnectorDetail(serviceIndex, connectorIndex int) {
 	}
 
 	// Basic settings with change handlers
-	form.AddInputField(i18n.T("connector.port"), strconv.Itoa(conn.Port), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.port"), conn.Port.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
+		v.checkPort(form.GetFormItem(0).(*tview.InputField), ports.KindHTTP, ports.ConnectorWhere(svc.Name, connectorIndex))
 	})
 	form.AddDropDown(i18n.T("connector.protocol"), connector.AvailableHTTPProtocols(), v.getProtocolIndex(conn.Protocol, connector.AvailableHTTPProtocols()), func(text string, index int) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("connector.timeout"), strconv.Itoa(conn.ConnectionTimeout), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.timeout"), conn.ConnectionTimeout.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("connector.redirect"), strconv.Itoa(conn.RedirectPort), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.redirect"), conn.RedirectPort.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
 
 	// Thread settings
-	form.AddInputField(i18n.T("connector.maxthreads"), strconv.Itoa(conn.MaxThreads), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.maxthreads"), conn.MaxThreads.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("connector.minthreads"), strconv.Itoa(conn.MinSpareThreads), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.minthreads"), conn.MinSpareThreads.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("connector.acceptcount"), strconv.Itoa(conn.AcceptCount), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.acceptcount"), conn.AcceptCount.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
 
@@ -401,19 +435,27 @@ func (v *ConnectorView) showConnectorDetail(serviceIndex, connectorIndex int) {
 	})
 
 	formReady = true
+	v.checkPort(form.GetFormItem(0).(*tview.InputField), ports.KindHTTP, ports.ConnectorWhere(svc.Name, connectorIndex))
 	updatePreview()
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
-		conn.Port, _ = strconv.Atoi(form.GetFormItem(0).(*tview.InputField).GetText())
+		var ints formInts
+		conn.Port = ints.Int(form.GetFormItem(0), conn.Port)
 		_, protocol := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
 		conn.Protocol = protocol
-		conn.ConnectionTimeout, _ = strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText())
-		conn.RedirectPort, _ = strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText())
-		conn.MaxThreads, _ = strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText())
-		conn.MinSpareThreads, _ = strconv.Atoi(form.GetFormItem(5).(*tview.InputField).GetText())
-		conn.AcceptCount, _ = strconv.Atoi(form.GetFormItem(6).(*tview.InputField).GetText())
+		conn.ConnectionTimeout = ints.Int(form.GetFormItem(2), conn.ConnectionTimeout)
+		conn.RedirectPort = ints.Int(form.GetFormItem(3), conn.RedirectPort)
+		conn.MaxThreads = ints.Int(form.GetFormItem(4), conn.MaxThreads)
+		conn.MinSpareThreads = ints.Int(form.GetFormItem(5), conn.MinSpareThreads)
+		conn.AcceptCount = ints.Int(form.GetFormItem(6), conn.AcceptCount)
 		conn.Executor = form.GetFormItem(7).(*tview.InputField).GetText()
 
+		if ints.err != nil {
+			v.showError(ints.err.Error())
+			return
+		}
+
 		v.configService.UpdateService(serviceIndex, *svc)
 		if err := v.configService.Save(); err != nil {
 			v.showError(fmt.Sprintf("Failed to save: %v", err))
@@ -424,6 +466,14 @@ func (v *ConnectorView) showConnectorDetail(serviceIndex, connectorIndex int) {
 		v.showHTTPConnectors()
 	})
 
+	form.AddButton("[black:aqua]"+i18n.T("connector.http2")+"[-:-]", func() {
+		v.showHTTP2Settings(serviceIndex, connectorIndex, v.showHTTPConnectors)
+	})
+
+	form.AddButton("[white:blue]"+i18n.T("connector.attrs")+"[-:-]", func() {
+		v.showConnectorAttributes(serviceIndex, connectorIndex, v.showHTTPConnectors)
+	})
+
 	form.AddButton("[white:red]"+i18n.T("common.delete")+"[-:-]", func() {
 		v.showConfirm(i18n.T("connector.delete.title"), fmt.Sprintf(i18n.T("connector.delete.confirm"), conn.Port), func(confirmed bool) {
 			if confirmed {
@@ -444,7 +494,7 @@ func (v *ConnectorView) showConnectorDetail(serviceIndex, connectorIndex int) {
 	})
 
 	form.SetButtonBackgroundColor(tcell.ColorDefault)
-	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s - %s %d ", i18n.T("connector.http"), i18n.T("connector.port"), conn.Port)).SetBorderColor(tcell.ColorDarkCyan)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s - %s %s ", i18n.T("connector.http"), i18n.T("connector.port"), conn.Port)).SetBorderColor(tcell.ColorDarkCyan)
 
 	// Initial preview and help
 	updatePreview()
@@ -528,14 +578,14 @@ func (v *ConnectorView) showAJPConnectorDetail(serviceIndex, connectorIndex int)
 		}
 
 		if port, err := strconv.Atoi(form.GetFormItem(0).(*tview.InputField).GetText()); err == nil {
-			tempConn.Port = port
+			tempConn.Port = placeholder.IntOf(port)
 		}
 		_, tempConn.Protocol = form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
 		if redirect, err := strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText()); err == nil {
-			tempConn.RedirectPort = redirect
+			tempConn.RedirectPort = placeholder.IntOf(redirect)
 		}
 		secretReqIdx, _ := form.GetFormItem(3).(*tview.DropDown).GetCurrentOption()
-		tempConn.SecretRequired = secretReqIdx == 0
+		tempConn.SecretRequired = conn.SecretRequired.With(secretReqIdx == 0, v.configService.Resolver())
 		tempConn.Secret = form.GetFormItem(4).(*tview.InputField).GetText()
 		tempConn.Executor = form.GetFormItem(5).(*tview.InputField).GetText()
 
@@ -543,19 +593,20 @@ func (v *ConnectorView) showAJPConnectorDetail(serviceIndex, connectorIndex int)
 	}
 
 	// Basic settings
-	form.AddInputField(i18n.T("connector.port"), strconv.Itoa(conn.Port), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.port"), conn.Port.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
+		v.checkPort(form.GetFormItem(0).(*tview.InputField), ports.KindAJP, ports.ConnectorWhere(svc.Name, connectorIndex))
 	})
 	form.AddDropDown(i18n.T("connector.protocol"), connector.AvailableAJPProtocols(), v.getProtocolIndex(conn.Protocol, connector.AvailableAJPProtocols()), func(text string, index int) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("connector.redirect"), strconv.Itoa(conn.RedirectPort), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.redirect"), conn.RedirectPort.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
 
 	// Security settings
 	secretRequired := i18n.T("common.no")
-	if conn.SecretRequired {
+	if conn.SecretRequired.Value(v.configService.Resolver()) {
 		secretRequired = i18n.T("common.yes")
 	}
 	form.AddDropDown(i18n.T("connector.secretrequired"), []string{i18n.T("common.yes"), i18n.T("common.no")}, indexOf(secretRequired, []string{i18n.T("common.yes"), i18n.T("common.no")}), func(text string, index int) {
@@ -571,18 +622,26 @@ func (v *ConnectorView) showAJPConnectorDetail(serviceIndex, connectorIndex int)
 	})
 
 	formReady = true
+	v.checkPort(form.GetFormItem(0).(*tview.InputField), ports.KindAJP, ports.ConnectorWhere(svc.Name, connectorIndex))
 	updatePreview()
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
-		conn.Port, _ = strconv.Atoi(form.GetFormItem(0).(*tview.InputField).GetText())
+		var ints formInts
+		conn.Port = ints.Int(form.GetFormItem(0), conn.Port)
 		_, protocol := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
 		conn.Protocol = protocol
-		conn.RedirectPort, _ = strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText())
+		conn.RedirectPort = ints.Int(form.GetFormItem(2), conn.RedirectPort)
 		secretReqIdx, _ := form.GetFormItem(3).(*tview.DropDown).GetCurrentOption()
-		conn.SecretRequired = secretReqIdx == 0
+		conn.SecretRequired = conn.SecretRequired.With(secretReqIdx == 0, v.configService.Resolver())
 		conn.Secret = form.GetFormItem(4).(*tview.InputField).GetText()
 		conn.Executor = form.GetFormItem(5).(*tview.InputField).GetText()
 
+		if ints.err != nil {
+			v.showError(ints.err.Error())
+			return
+		}
+
 		v.configService.UpdateService(serviceIndex, *svc)
 		if err := v.configService.Save(); err != nil {
 			v.showError(fmt.Sprintf("Failed to save: %v", err))
@@ -593,6 +652,10 @@ func (v *ConnectorView) showAJPConnectorDetail(serviceIndex, connectorIndex int)
 		v.showAJPConnectors()
 	})
 
+	form.AddButton("[white:blue]"+i18n.T("connector.attrs")+"[-:-]", func() {
+		v.showConnectorAttributes(serviceIndex, connectorIndex, v.showAJPConnectors)
+	})
+
 	form.AddButton("[white:red]"+i18n.T("common.delete")+"[-:-]", func() {
 		v.showConfirm(i18n.T("connector.delete.title"), fmt.Sprintf(i18n.T("connector.delete.ajp.confirm"), conn.Port), func(confirmed bool) {
 			if confirmed {
@@ -613,7 +676,7 @@ func (v *ConnectorView) showAJPConnectorDetail(serviceIndex, connectorIndex int)
 	})
 
 	form.SetButtonBackgroundColor(tcell.ColorDefault)
-	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s - %s %d ", i18n.T("connector.ajp"), i18n.T("connector.port"), conn.Port)).SetBorderColor(tcell.ColorDarkCyan)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s - %s %s ", i18n.T("connector.ajp"), i18n.T("connector.port"), conn.Port)).SetBorderColor(tcell.ColorDarkCyan)
 
 	// Initial preview and help
 	updatePreview()
@@ -677,27 +740,28 @@ func (v *ConnectorView) showSSLConnectorDetail(serviceIndex, connectorIndex int)
 			ConnectionTimeout: conn.ConnectionTimeout,
 			MaxThreads:        conn.MaxThreads,
 			MinSpareThreads:   conn.MinSpareThreads,
-			SSLEnabled:        true,
+			SSLEnabled:        placeholder.BoolOf(true),
 			Scheme:            "https",
-			Secure:            true,
+			Secure:            placeholder.BoolOf(true),
 			SSLProtocol:       conn.SSLProtocol,
 			KeystoreFile:      conn.KeystoreFile,
 			KeystoreType:      conn.KeystoreType,
 			ClientAuth:        conn.ClientAuth,
+			UpgradeProtocols:  conn.UpgradeProtocols,
 		}
 
 		if port, err := strconv.Atoi(form.GetFormItem(0).(*tview.InputField).GetText()); err == nil {
-			tempConn.Port = port
+			tempConn.Port = placeholder.IntOf(port)
 		}
 		_, tempConn.Protocol = form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
 		if timeout, err := strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText()); err == nil {
-			tempConn.ConnectionTimeout = timeout
+			tempConn.ConnectionTimeout = placeholder.IntOf(timeout)
 		}
 		if maxThreads, err := strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText()); err == nil {
-			tempConn.MaxThreads = maxThreads
+			tempConn.MaxThreads = placeholder.IntOf(maxThreads)
 		}
 		if minThreads, err := strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText()); err == nil {
-			tempConn.MinSpareThreads = minThreads
+			tempConn.MinSpareThreads = placeholder.IntOf(minThreads)
 		}
 		_, tempConn.SSLProtocol = form.GetFormItem(5).(*tview.DropDown).GetCurrentOption()
 		tempConn.KeystoreFile = form.GetFormItem(6).(*tview.InputField).GetText()
@@ -709,21 +773,22 @@ func (v *ConnectorView) showSSLConnectorDetail(serviceIndex, connectorIndex int)
 	}
 
 	// Basic settings
-	form.AddInputField(i18n.T("connector.port"), strconv.Itoa(conn.Port), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.port"), conn.Port.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
+		v.checkPort(form.GetFormItem(0).(*tview.InputField), ports.KindHTTPS, ports.ConnectorWhere(svc.Name, connectorIndex))
 	})
 	form.AddDropDown(i18n.T("connector.protocol"), connector.AvailableHTTPProtocols(), v.getProtocolIndex(conn.Protocol, connector.AvailableHTTPProtocols()), func(text string, index int) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("connector.timeout"), strconv.Itoa(conn.ConnectionTimeout), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.timeout"), conn.ConnectionTimeout.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
 
 	// Thread settings
-	form.AddInputField(i18n.T("connector.maxthreads"), strconv.Itoa(conn.MaxThreads), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.maxthreads"), conn.MaxThreads.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("connector.minthreads"), strconv.Itoa(conn.MinSpareThreads), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.minthreads"), conn.MinSpareThreads.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
 
@@ -743,21 +808,29 @@ func (v *ConnectorView) showSSLConnectorDetail(serviceIndex, connectorIndex int)
 	})
 
 	formReady = true
+	v.checkPort(form.GetFormItem(0).(*tview.InputField), ports.KindHTTPS, ports.ConnectorWhere(svc.Name, connectorIndex))
 	updatePreview()
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
-		conn.Port, _ = strconv.Atoi(form.GetFormItem(0).(*tview.InputField).GetText())
+		var ints formInts
+		conn.Port = ints.Int(form.GetFormItem(0), conn.Port)
 		_, protocol := form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
 		conn.Protocol = protocol
-		conn.ConnectionTimeout, _ = strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText())
-		conn.MaxThreads, _ = strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText())
-		conn.MinSpareThreads, _ = strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText())
+		conn.ConnectionTimeout = ints.Int(form.GetFormItem(2), conn.ConnectionTimeout)
+		conn.MaxThreads = ints.Int(form.GetFormItem(3), conn.MaxThreads)
+		conn.MinSpareThreads = ints.Int(form.GetFormItem(4), conn.MinSpareThreads)
 		_, conn.SSLProtocol = form.GetFormItem(5).(*tview.DropDown).GetCurrentOption()
 		conn.KeystoreFile = form.GetFormItem(6).(*tview.InputField).GetText()
 		conn.KeystorePass = form.GetFormItem(7).(*tview.InputField).GetText()
 		_, conn.KeystoreType = form.GetFormItem(8).(*tview.DropDown).GetCurrentOption()
 		_, conn.ClientAuth = form.GetFormItem(9).(*tview.DropDown).GetCurrentOption()
 
+		if ints.err != nil {
+			v.showError(ints.err.Error())
+			return
+		}
+
 		v.configService.UpdateService(serviceIndex, *svc)
 		if err := v.configService.Save(); err != nil {
 			v.showError(fmt.Sprintf("Failed to save: %v", err))
@@ -768,6 +841,14 @@ func (v *ConnectorView) showSSLConnectorDetail(serviceIndex, connectorIndex int)
 		v.showSSLConnectors()
 	})
 
+	form.AddButton("[black:aqua]"+i18n.T("connector.http2")+"[-:-]", func() {
+		v.showHTTP2Settings(serviceIndex, connectorIndex, v.showSSLConnectors)
+	})
+
+	form.AddButton("[white:blue]"+i18n.T("connector.attrs")+"[-:-]", func() {
+		v.showConnectorAttributes(serviceIndex, connectorIndex, v.showSSLConnectors)
+	})
+
 	form.AddButton("[white:red]"+i18n.T("common.delete")+"[-:-]", func() {
 		v.showConfirm(i18n.T("connector.delete.title"), fmt.Sprintf(i18n.T("connector.delete.ssl.confirm"), conn.Port), func(confirmed bool) {
 			if confirmed {
@@ -788,7 +869,7 @@ func (v *ConnectorView) showSSLConnectorDetail(serviceIndex, connectorIndex int)
 	})
 
 	form.SetButtonBackgroundColor(tcell.ColorDefault)
-	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s - %s %d ", i18n.T("connector.ssl"), i18n.T("connector.port"), conn.Port)).SetBorderColor(tcell.ColorDarkCyan)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s - %s %s ", i18n.T("connector.ssl"), i18n.T("connector.port"), conn.Port)).SetBorderColor(tcell.ColorDarkCyan)
 
 	// Initial preview
 	updatePreview()
@@ -803,6 +884,360 @@ func (v *ConnectorView) showSSLConnectorDetail(serviceIndex, connectorIndex int)
 	v.app.SetFocus(form)
 }
 
+// showHTTP2Settings shows the HTTP/2 UpgradeProtocol form for a connector
+func (v *ConnectorView) showHTTP2Settings(serviceIndex, connectorIndex int, onDone func()) {
+	svc := v.configService.GetService(serviceIndex)
+	if svc == nil || connectorIndex >= len(svc.Connectors) {
+		return
+	}
+
+	conn := &svc.Connectors[connectorIndex]
+
+	upgrade := connector.DefaultHTTP2UpgradeProtocol()
+	enabled := false
+	if existing := connector.FindHTTP2UpgradeProtocol(conn); existing != nil {
+		upgrade = *existing
+		enabled = true
+	}
+
+	form := tview.NewForm()
+	preview := NewPreviewPanel()
+	formReady := false
+
+	// Help panel on the right
+	helpPanel := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	helpPanel.SetBorder(true).SetTitle(" " + i18n.T("help.title") + " ").SetBorderColor(tcell.ColorBlue)
+
+	// Function to update help text based on focused field index
+	lastFocusedIndex := -1
+	updateHelp := func(index int) {
+		if index >= 0 && index < len(http2UpgradeHelpKeysByIndex) {
+			helpPanel.SetText(i18n.T(http2UpgradeHelpKeysByIndex[index]))
+		} else {
+			helpPanel.SetText(i18n.T("help.connector.http2"))
+		}
+	}
+
+	// readForm builds the upgrade protocol from the current form values
+	readForm := func() (bool, server.UpgradeProtocol, error) {
+		var ints formInts
+		up := server.UpgradeProtocol{ClassName: connector.ProtocolHTTP2}
+		on := form.GetFormItem(0).(*tview.Checkbox).IsChecked()
+		up.MaxConcurrentStreams = ints.Int(form.GetFormItem(1), up.MaxConcurrentStreams)
+		up.InitialWindowSize = ints.Int(form.GetFormItem(2), up.InitialWindowSize)
+		up.ReadTimeout = ints.Int(form.GetFormItem(3), up.ReadTimeout)
+		up.KeepAliveTimeout = ints.Int(form.GetFormItem(4), up.KeepAliveTimeout)
+		up.OverheadCountFactor = ints.Int(form.GetFormItem(5), up.OverheadCountFactor)
+		return on, up, ints.err
+	}
+
+	// Function to update preview
+	updatePreview := func() {
+		if !formReady {
+			return
+		}
+		tempConn := *conn
+		tempConn.KeystorePass = ""
+		tempConn.UpgradeProtocols = append([]server.UpgradeProtocol(nil), conn.UpgradeProtocols...)
+		on, up, _ := readForm()
+		if on {
+			connector.EnableHTTP2(&tempConn, up)
+		} else {
+			connector.DisableHTTP2(&tempConn)
+		}
+		preview.SetXMLPreview(GenerateConnectorXML(&tempConn))
+	}
+
+	form.AddCheckbox(i18n.T("connector.http2.enabled"), enabled, func(checked bool) {
+		updatePreview()
+	})
+	form.AddInputField(i18n.T("connector.http2.maxconcurrentstreams"), upgrade.MaxConcurrentStreams.Text(), 10, acceptIntAttr, func(text string) {
+		updatePreview()
+	})
+	form.AddInputField(i18n.T("connector.http2.initialwindowsize"), upgrade.InitialWindowSize.Text(), 10, acceptIntAttr, func(text string) {
+		updatePreview()
+	})
+	form.AddInputField(i18n.T("connector.http2.readtimeout"), upgrade.ReadTimeout.Text(), 10, acceptIntAttr, func(text string) {
+		updatePreview()
+	})
+	form.AddInputField(i18n.T("connector.http2.keepalivetimeout"), upgrade.KeepAliveTimeout.Text(), 10, acceptIntAttr, func(text string) {
+		updatePreview()
+	})
+	form.AddInputField(i18n.T("connector.http2.overheadcountfactor"), upgrade.OverheadCountFactor.Text(), 10, acceptIntAttr, func(text string) {
+		updatePreview()
+	})
+
+	formReady = true
+
+	addResolvedValues(form, v.configService.Resolver())
+	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		on, up, err := readForm()
+		if err != nil {
+			v.showError(err.Error())
+			return
+		}
+		if on {
+			connector.EnableHTTP2(conn, up)
+		} else {
+			connector.DisableHTTP2(conn)
+		}
+
+		v.configService.UpdateService(serviceIndex, *svc)
+		if err := v.configService.Save(); err != nil {
+			v.showError(fmt.Sprintf("Failed to save: %v", err))
+			return
+		}
+
+		v.setStatus("[green]" + i18n.T("connector.http2.updated") + "[-]")
+		onDone()
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		onDone()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s - %s %s ", i18n.T("connector.http2.title"), i18n.T("connector.port"), conn.Port)).SetBorderColor(tcell.ColorDarkCyan)
+
+	// Initial preview and help
+	updatePreview()
+	updateHelp(0)
+	if !conn.SSLEnabled.Value(v.configService.Resolver()) {
+		v.setStatus("[yellow]" + i18n.T("connector.http2.cleartext") + "[-]")
+	}
+
+	// Handle key events and update help on navigation
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			onDone()
+			return nil
+		}
+		// Update help after navigation
+		go func() {
+			v.app.QueueUpdateDraw(func() {
+				idx, _ := form.GetFocusedItemIndex()
+				if idx != lastFocusedIndex {
+					lastFocusedIndex = idx
+					updateHelp(idx)
+				}
+			})
+		}()
+		return event
+	})
+
+	// Create layout: left side (form + preview), right side (help)
+	leftPane := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 2, true).
+		AddItem(preview, 0, 1, false)
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(leftPane, 0, 2, true).
+		AddItem(helpPanel, 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("http2-upgrade-detail", layout, true)
+	v.app.SetFocus(form)
+}
+
+// attributeHelpText builds the help panel text for a catalogue attribute
+func attributeHelpText(spec connector.AttributeSpec) string {
+	text := fmt.Sprintf("[yellow::b]%s[-::-]\n\n%s\n\n", spec.Name, i18n.T(spec.HelpKey()))
+	text += fmt.Sprintf("[::b]%s:[::-] %s\n", i18n.T("connector.attrs.type"), spec.Kind)
+	text += fmt.Sprintf("[::b]%s:[::-] %s\n", i18n.T("connector.attrs.group"), i18n.T("connector.attrs.group."+spec.Group))
+	if spec.Default != "" {
+		text += fmt.Sprintf("[::b]%s:[::-] %s\n", i18n.T("connector.attrs.defaultvalue"), spec.Default)
+	}
+	if r := spec.RangeString(); r != "" {
+		text += fmt.Sprintf("[::b]%s:[::-] %s\n", i18n.T("connector.attrs.range"), r)
+	}
+	if len(spec.Options) > 0 && spec.Kind == connector.AttrEnum {
+		text += fmt.Sprintf("[::b]%s:[::-] %s\n", i18n.T("connector.attrs.options"), strings.Join(spec.Options, ", "))
+	}
+	return text
+}
+
+// showConnectorAttributes shows a form generated from the connector attribute
+// catalogue, with advanced attributes hidden until requested
+func (v *ConnectorView) showConnectorAttributes(serviceIndex, connectorIndex int, onDone func()) {
+	svc := v.configService.GetService(serviceIndex)
+	if svc == nil || connectorIndex >= len(svc.Connectors) {
+		return
+	}
+
+	conn := &svc.Connectors[connectorIndex]
+	connType := connector.GetConnectorType(conn.Protocol)
+
+	// Edits are collected on a working copy until saved
+	work := *conn
+	work.ExtraAttrs = append([]xml.Attr(nil), conn.ExtraAttrs...)
+	advanced := false
+
+	form := tview.NewForm()
+	preview := NewPreviewPanel()
+	formReady := false
+	var specs []connector.AttributeSpec
+
+	// Help panel on the right
+	helpPanel := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWordWrap(true)
+	helpPanel.SetBorder(true).SetTitle(" " + i18n.T("help.title") + " ").SetBorderColor(tcell.ColorBlue)
+
+	// Form item 0 is the advanced toggle, attributes follow in catalogue order
+	lastFocusedIndex := -1
+	updateHelp := func(index int) {
+		if index >= 1 && index-1 < len(specs) {
+			helpPanel.SetText(attributeHelpText(specs[index-1]))
+		} else {
+			helpPanel.SetText(i18n.T("help.connector.attrs"))
+		}
+	}
+
+	// readForm applies the form values to target, validating each one
+	readForm := func(target *server.Connector) error {
+		for i, spec := range specs {
+			var value string
+			switch item := form.GetFormItem(i + 1).(type) {
+			case *tview.InputField:
+				value = strings.TrimSpace(item.GetText())
+			case *tview.DropDown:
+				if idx, text := item.GetCurrentOption(); idx > 0 {
+					value = text
+				}
+			}
+			if err := spec.Validate(value); err != nil {
+				return err
+			}
+			if err := target.SetAttribute(spec.Name, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Function to update preview
+	updatePreview := func() {
+		if !formReady {
+			return
+		}
+		tempConn := work
+		tempConn.KeystorePass = ""
+		tempConn.ExtraAttrs = append([]xml.Attr(nil), work.ExtraAttrs...)
+		readForm(&tempConn)
+		preview.SetXMLPreview(GenerateConnectorXML(&tempConn))
+	}
+
+	var build func()
+	build = func() {
+		formReady = false
+		form.Clear(true)
+		specs = connector.AttributesFor(connType, advanced)
+
+		form.AddCheckbox(i18n.T("connector.attrs.advanced"), advanced, func(checked bool) {
+			if err := readForm(&work); err != nil {
+				v.setStatus("[red]" + err.Error() + "[-]")
+			}
+			advanced = checked
+			go v.app.QueueUpdateDraw(func() {
+				build()
+				form.SetFocus(0)
+				v.app.SetFocus(form)
+			})
+		})
+
+		for _, spec := range specs {
+			current := work.GetAttribute(spec.Name)
+			switch spec.Kind {
+			case connector.AttrBool, connector.AttrEnum:
+				options := append([]string{i18n.T("connector.attrs.default")}, spec.Options...)
+				form.AddDropDown(spec.Name, options, indexOf(current, options), func(text string, index int) {
+					updatePreview()
+				})
+			default:
+				field := tview.NewInputField().
+					SetLabel(spec.Name).
+					SetText(current).
+					SetFieldWidth(30).
+					SetPlaceholder(spec.Default).
+					SetChangedFunc(func(text string) {
+						updatePreview()
+					})
+				if spec.Kind == connector.AttrInt {
+					field.SetAcceptanceFunc(acceptNumber)
+				}
+				form.AddFormItem(field)
+			}
+		}
+
+		form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+			if err := readForm(&work); err != nil {
+				v.showError(err.Error())
+				return
+			}
+
+			*conn = work
+			v.configService.UpdateService(serviceIndex, *svc)
+			if err := v.configService.Save(); err != nil {
+				v.showError(fmt.Sprintf("Failed to save: %v", err))
+				return
+			}
+
+			v.setStatus("[green]" + i18n.T("connector.attrs.updated") + "[-]")
+			onDone()
+		})
+
+		form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+			onDone()
+		})
+
+		formReady = true
+		lastFocusedIndex = -1
+		updatePreview()
+		updateHelp(0)
+	}
+
+	build()
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s - %s %s ", i18n.T("connector.attrs.title"), i18n.T("connector.port"), conn.Port)).SetBorderColor(tcell.ColorDarkCyan)
+
+	// Handle key events and update help on navigation
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			onDone()
+			return nil
+		}
+		// Update help after navigation
+		go func() {
+			v.app.QueueUpdateDraw(func() {
+				idx, _ := form.GetFocusedItemIndex()
+				if idx != lastFocusedIndex {
+					lastFocusedIndex = idx
+					updateHelp(idx)
+				}
+			})
+		}()
+		return event
+	})
+
+	// Create layout: left side (form + preview), right side (help)
+	leftPane := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 2, true).
+		AddItem(preview, 0, 1, false)
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexColumn).
+		AddItem(leftPane, 0, 2, true).
+		AddItem(helpPanel, 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("connector-attributes", layout, true)
+	v.app.SetFocus(form)
+}
+
 // showAddConnector shows form to add a new connector
 func (v *ConnectorView) showAddConnector(connType connector.ConnectorType) {
 	var defaultConn server.Connector
@@ -859,22 +1294,30 @@ func (v *ConnectorView) showAddConnector(connType connector.ConnectorType) {
 		}
 	}
 
+	portKind := ports.KindHTTP
+	if connType == connector.ConnectorTypeAJP {
+		portKind = ports.KindAJP
+	}
+
 	form.AddDropDown(i18n.T("server.service"), serviceNames, 0, nil)
-	form.AddInputField(i18n.T("connector.port"), strconv.Itoa(defaultConn.Port), 10, acceptDigits, nil)
+	form.AddInputField(i18n.T("connector.port"), defaultConn.Port.Text(), 10, acceptIntAttr, func(text string) {
+		v.checkPort(form.GetFormItem(1).(*tview.InputField), portKind, "")
+	})
 	form.AddDropDown(i18n.T("connector.protocol"), protocols, 0, nil)
 
 	if connType == connector.ConnectorTypeHTTP {
-		form.AddInputField(i18n.T("connector.timeout"), strconv.Itoa(defaultConn.ConnectionTimeout), 10, acceptDigits, nil)
-		form.AddInputField(i18n.T("connector.maxthreads"), strconv.Itoa(defaultConn.MaxThreads), 10, acceptDigits, nil)
-		form.AddInputField(i18n.T("connector.minthreads"), strconv.Itoa(defaultConn.MinSpareThreads), 10, acceptDigits, nil)
+		form.AddInputField(i18n.T("connector.timeout"), defaultConn.ConnectionTimeout.Text(), 10, acceptIntAttr, nil)
+		form.AddInputField(i18n.T("connector.maxthreads"), defaultConn.MaxThreads.Text(), 10, acceptIntAttr, nil)
+		form.AddInputField(i18n.T("connector.minthreads"), defaultConn.MinSpareThreads.Text(), 10, acceptIntAttr, nil)
 	} else if connType == connector.ConnectorTypeAJP {
 		form.AddDropDown(i18n.T("connector.secretrequired"), []string{i18n.T("common.yes"), i18n.T("common.no")}, 0, nil)
 		form.AddInputField(i18n.T("connector.secret"), "", 40, nil, nil)
 	}
 
-	form.AddInputField(i18n.T("connector.redirect"), strconv.Itoa(defaultConn.RedirectPort), 10, acceptDigits, nil)
+	form.AddInputField(i18n.T("connector.redirect"), defaultConn.RedirectPort.Text(), 10, acceptIntAttr, nil)
 
 	form.AddButton("[white:green]"+i18n.T("common.add")+"[-:-]", func() {
+		var ints formInts
 		svcIdx, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
 		svc := v.configService.GetService(svcIdx)
 		if svc == nil {
@@ -882,19 +1325,24 @@ func (v *ConnectorView) showAddConnector(connType connector.ConnectorType) {
 		}
 
 		newConn := defaultConn
-		newConn.Port, _ = strconv.Atoi(form.GetFormItem(1).(*tview.InputField).GetText())
+		newConn.Port = ints.Int(form.GetFormItem(1), newConn.Port)
 		_, newConn.Protocol = form.GetFormItem(2).(*tview.DropDown).GetCurrentOption()
 
 		if connType == connector.ConnectorTypeHTTP {
-			newConn.ConnectionTimeout, _ = strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText())
-			newConn.MaxThreads, _ = strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText())
-			newConn.MinSpareThreads, _ = strconv.Atoi(form.GetFormItem(5).(*tview.InputField).GetText())
-			newConn.RedirectPort, _ = strconv.Atoi(form.GetFormItem(6).(*tview.InputField).GetText())
+			newConn.ConnectionTimeout = ints.Int(form.GetFormItem(3), newConn.ConnectionTimeout)
+			newConn.MaxThreads = ints.Int(form.GetFormItem(4), newConn.MaxThreads)
+			newConn.MinSpareThreads = ints.Int(form.GetFormItem(5), newConn.MinSpareThreads)
+			newConn.RedirectPort = ints.Int(form.GetFormItem(6), newConn.RedirectPort)
 		} else if connType == connector.ConnectorTypeAJP {
 			secretReqIdx, _ := form.GetFormItem(3).(*tview.DropDown).GetCurrentOption()
-			newConn.SecretRequired = secretReqIdx == 0
+			newConn.SecretRequired = placeholder.BoolOf(secretReqIdx == 0)
 			newConn.Secret = form.GetFormItem(4).(*tview.InputField).GetText()
-			newConn.RedirectPort, _ = strconv.Atoi(form.GetFormItem(5).(*tview.InputField).GetText())
+			newConn.RedirectPort = ints.Int(form.GetFormItem(5), newConn.RedirectPort)
+		}
+
+		if ints.err != nil {
+			v.showError(ints.err.Error())
+			return
 		}
 
 		svc.Connectors = append(svc.Connectors, newConn)
@@ -994,10 +1442,12 @@ func (v *ConnectorView) showAddSSLConnector() {
 	}
 
 	form.AddDropDown(i18n.T("server.service"), serviceNames, 0, nil)
-	form.AddInputField(i18n.T("connector.port"), strconv.Itoa(defaultConn.Port), 10, acceptDigits, nil)
+	form.AddInputField(i18n.T("connector.port"), defaultConn.Port.Text(), 10, acceptIntAttr, func(text string) {
+		v.checkPort(form.GetFormItem(1).(*tview.InputField), ports.KindHTTPS, "")
+	})
 	form.AddDropDown(i18n.T("connector.protocol"), connector.AvailableHTTPProtocols(), 0, nil)
-	form.AddInputField(i18n.T("connector.timeout"), strconv.Itoa(defaultConn.ConnectionTimeout), 10, acceptDigits, nil)
-	form.AddInputField(i18n.T("connector.maxthreads"), strconv.Itoa(defaultConn.MaxThreads), 10, acceptDigits, nil)
+	form.AddInputField(i18n.T("connector.timeout"), defaultConn.ConnectionTimeout.Text(), 10, acceptIntAttr, nil)
+	form.AddInputField(i18n.T("connector.maxthreads"), defaultConn.MaxThreads.Text(), 10, acceptIntAttr, nil)
 
 	// SSL settings
 	form.AddDropDown(i18n.T("connector.sslprotocol"), connector.SSLProtocols(), 0, nil)
@@ -1007,6 +1457,7 @@ func (v *ConnectorView) showAddSSLConnector() {
 	form.AddDropDown(i18n.T("connector.clientauth"), connector.ClientAuthOptions(), 0, nil)
 
 	form.AddButton("[white:green]"+i18n.T("common.add")+"[-:-]", func() {
+		var ints formInts
 		svcIdx, _ := form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
 		svc := v.configService.GetService(svcIdx)
 		if svc == nil {
@@ -1014,16 +1465,21 @@ func (v *ConnectorView) showAddSSLConnector() {
 		}
 
 		newConn := defaultConn
-		newConn.Port, _ = strconv.Atoi(form.GetFormItem(1).(*tview.InputField).GetText())
+		newConn.Port = ints.Int(form.GetFormItem(1), newConn.Port)
 		_, newConn.Protocol = form.GetFormItem(2).(*tview.DropDown).GetCurrentOption()
-		newConn.ConnectionTimeout, _ = strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText())
-		newConn.MaxThreads, _ = strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText())
+		newConn.ConnectionTimeout = ints.Int(form.GetFormItem(3), newConn.ConnectionTimeout)
+		newConn.MaxThreads = ints.Int(form.GetFormItem(4), newConn.MaxThreads)
 		_, newConn.SSLProtocol = form.GetFormItem(5).(*tview.DropDown).GetCurrentOption()
 		newConn.KeystoreFile = form.GetFormItem(6).(*tview.InputField).GetText()
 		newConn.KeystorePass = form.GetFormItem(7).(*tview.InputField).GetText()
 		_, newConn.KeystoreType = form.GetFormItem(8).(*tview.DropDown).GetCurrentOption()
 		_, newConn.ClientAuth = form.GetFormItem(9).(*tview.DropDown).GetCurrentOption()
 
+		if ints.err != nil {
+			v.showError(ints.err.Error())
+			return
+		}
+
 		svc.Connectors = append(svc.Connectors, newConn)
 		v.configService.UpdateService(svcIdx, *svc)
 
@@ -1083,9 +1539,13 @@ func (v *ConnectorView) showExecutors() {
 	for svcIdx, svc := range services {
 		for execIdx, exec := range svc.Executors {
 			si, ei := svcIdx, execIdx
+			secondary := fmt.Sprintf("Service: %s, Threads: %s-%s, MaxIdle: %sms", svc.Name, exec.MinSpareThreads, exec.MaxThreads, exec.MaxIdleTime)
+			if users := executorUserPorts(&services[svcIdx], exec.Name); users != "" {
+				secondary += fmt.Sprintf(", %s: %s", i18n.T("connector.wiring.usedby"), users)
+			}
 			list.AddItem(
 				fmt.Sprintf("[yellow]%s[-]", exec.Name),
-				fmt.Sprintf("Service: %s, Threads: %d-%d, MaxIdle: %dms", svc.Name, exec.MinSpareThreads, exec.MaxThreads, exec.MaxIdleTime),
+				secondary,
 				0,
 				func() { v.showExecutorDetail(si, ei) },
 			)
@@ -1132,13 +1592,13 @@ func (v *ConnectorView) showExecutorDetail(serviceIndex, executorIndex int) {
 		tempExec.Name = form.GetFormItem(0).(*tview.InputField).GetText()
 		tempExec.NamePrefix = form.GetFormItem(1).(*tview.InputField).GetText()
 		if maxThreads, err := strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText()); err == nil {
-			tempExec.MaxThreads = maxThreads
+			tempExec.MaxThreads = placeholder.IntOf(maxThreads)
 		}
 		if minThreads, err := strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText()); err == nil {
-			tempExec.MinSpareThreads = minThreads
+			tempExec.MinSpareThreads = placeholder.IntOf(minThreads)
 		}
 		if maxIdle, err := strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText()); err == nil {
-			tempExec.MaxIdleTime = maxIdle
+			tempExec.MaxIdleTime = placeholder.IntOf(maxIdle)
 		}
 
 		preview.SetXMLPreview(GenerateExecutorXML(&tempExec))
@@ -1150,22 +1610,41 @@ func (v *ConnectorView) showExecutorDetail(serviceIndex, executorIndex int) {
 	form.AddInputField(i18n.T("connector.executor.nameprefix"), exec.NamePrefix, 30, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("connector.maxthreads"), strconv.Itoa(exec.MaxThreads), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.maxthreads"), exec.MaxThreads.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("connector.minthreads"), strconv.Itoa(exec.MinSpareThreads), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.minthreads"), exec.MinSpareThreads.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("connector.executor.maxidle"), strconv.Itoa(exec.MaxIdleTime), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("connector.executor.maxidle"), exec.MaxIdleTime.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
-		exec.Name = form.GetFormItem(0).(*tview.InputField).GetText()
+		var ints formInts
+		maxThreads := ints.Int(form.GetFormItem(2), exec.MaxThreads)
+		minSpareThreads := ints.Int(form.GetFormItem(3), exec.MinSpareThreads)
+		maxIdleTime := ints.Int(form.GetFormItem(4), exec.MaxIdleTime)
+		if ints.err != nil {
+			v.showError(ints.err.Error())
+			return
+		}
+
+		newName := form.GetFormItem(0).(*tview.InputField).GetText()
+		if newName != exec.Name && connector.FindExecutor(svc, newName) != nil {
+			v.showError(fmt.Sprintf(i18n.T("connector.executor.exists"), newName))
+			return
+		}
+		// Keeps connectors pointing at the renamed executor
+		if err := connector.RenameExecutor(svc, executorIndex, newName); err != nil {
+			v.showError(err.Error())
+			return
+		}
 		exec.NamePrefix = form.GetFormItem(1).(*tview.InputField).GetText()
-		exec.MaxThreads, _ = strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText())
-		exec.MinSpareThreads, _ = strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText())
-		exec.MaxIdleTime, _ = strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText())
+		exec.MaxThreads = maxThreads
+		exec.MinSpareThreads = minSpareThreads
+		exec.MaxIdleTime = maxIdleTime
 
 		v.configService.UpdateService(serviceIndex, *svc)
 		if err := v.configService.Save(); err != nil {
@@ -1178,9 +1657,16 @@ func (v *ConnectorView) showExecutorDetail(serviceIndex, executorIndex int) {
 	})
 
 	form.AddButton("[white:red]"+i18n.T("common.delete")+"[-:-]", func() {
+		if users := executorUserPorts(svc, exec.Name); users != "" {
+			v.showError(fmt.Sprintf(i18n.T("connector.executor.inuse"), exec.Name, users))
+			return
+		}
 		v.showConfirm(i18n.T("connector.executor.delete.title"), fmt.Sprintf(i18n.T("connector.executor.delete.confirm"), exec.Name), func(confirmed bool) {
 			if confirmed {
-				svc.Executors = append(svc.Executors[:executorIndex], svc.Executors[executorIndex+1:]...)
+				if err := connector.RemoveExecutor(svc, executorIndex); err != nil {
+					v.showError(err.Error())
+					return
+				}
 				v.configService.UpdateService(serviceIndex, *svc)
 				if err := v.configService.Save(); err != nil {
 					v.showError(fmt.Sprintf("Failed to save: %v", err))
@@ -1247,9 +1733,9 @@ func (v *ConnectorView) showAddExecutor() {
 		exec := server.Executor{
 			Name:            form.GetFormItem(1).(*tview.InputField).GetText(),
 			NamePrefix:      form.GetFormItem(2).(*tview.InputField).GetText(),
-			MaxThreads:      maxThreads,
-			MinSpareThreads: minSpare,
-			MaxIdleTime:     maxIdle,
+			MaxThreads:      placeholder.IntOf(maxThreads),
+			MinSpareThreads: placeholder.IntOf(minSpare),
+			MaxIdleTime:     placeholder.IntOf(maxIdle),
 		}
 
 		svc.Executors = append(svc.Executors, exec)
@@ -1315,6 +1801,13 @@ func (v *ConnectorView) showConfirm(title, message string, callback func(bool))
 	v.pages.AddAndSwitchToPage("confirm", modal, true)
 }
 
+// checkPort flags a conflicting port field and reports it in the status bar
+func (v *ConnectorView) checkPort(field *tview.InputField, kind ports.Kind, where string) {
+	if msg := checkPortField(field, v.configService, kind, where); msg != "" {
+		v.setStatus("[yellow]" + msg + "[-]")
+	}
+}
+
 func (v *ConnectorView) setStatus(message string) {
 	if v.statusBar != nil {
 		v.statusBar.SetText(fmt.Sprintf(" %s", message))
@@ -0,0 +1,244 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/connector"
+	"github.com/playok/tomcatkit/internal/config/server"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/rivo/tview"
+)
+
+// executorUserPorts returns the ports of connectors using an executor
+func executorUserPorts(svc *server.Service, name string) string {
+	users := connector.ExecutorUsers(svc, name)
+	portList := make([]string, len(users))
+	for i, idx := range users {
+		portList[i] = svc.Connectors[idx].Port.String()
+	}
+	return strings.Join(portList, ", ")
+}
+
+// connectorLabel returns a short "port (type)" label for a connector
+func (v *ConnectorView) connectorLabel(conn *server.Connector) string {
+	kind := "HTTP"
+	if connector.GetConnectorType(conn.Protocol) == connector.ConnectorTypeAJP {
+		kind = "AJP"
+	} else if conn.SSLEnabled.Value(v.configService.Resolver()) {
+		kind = "HTTPS"
+	}
+	return fmt.Sprintf("%s (%s)", conn.Port, kind)
+}
+
+// showExecutorWiring shows which connectors share which executor
+func (v *ConnectorView) showExecutorWiring() {
+	list := tview.NewList().ShowSecondaryText(true)
+
+	services := v.configService.GetServices()
+	for si := range services {
+		svc := v.configService.GetService(si)
+		list.AddItem(fmt.Sprintf("[::b]%s: %s[::-]", i18n.T("server.service"), svc.Name), "", 0, nil)
+
+		for ei := range svc.Executors {
+			exec := &svc.Executors[ei]
+			svcIdx, execIdx := si, ei
+			pool := fmt.Sprintf("%s-%s", exec.MinSpareThreads, exec.MaxThreads)
+			if exec.IsVirtualThread() {
+				pool = "virtual"
+			}
+			users := executorUserPorts(svc, exec.Name)
+			if users == "" {
+				users = "[gray]" + i18n.T("connector.wiring.unused") + "[-]"
+			}
+			list.AddItem(
+				fmt.Sprintf("  [yellow]⚙ %s[-] [gray](%s)[-]", exec.Name, pool),
+				fmt.Sprintf("    %s: %s", i18n.T("connector.wiring.usedby"), users),
+				0,
+				func() { v.showExecutorDetail(svcIdx, execIdx) },
+			)
+			for _, ci := range connector.ExecutorUsers(svc, exec.Name) {
+				connIdx := ci
+				list.AddItem(
+					fmt.Sprintf("      └ %s", v.connectorLabel(&svc.Connectors[ci])),
+					"",
+					0,
+					func() { v.showWiringActions(svcIdx, connIdx) },
+				)
+			}
+		}
+
+		// Connectors with their own pool (or a reference to a missing executor)
+		for ci := range svc.Connectors {
+			conn := &svc.Connectors[ci]
+			if connector.FindExecutor(svc, conn.Executor) != nil {
+				continue
+			}
+			svcIdx, connIdx := si, ci
+			secondary := fmt.Sprintf("    %s: %s-%s", i18n.T("connector.wiring.internal"), conn.MinSpareThreads, conn.MaxThreads)
+			if conn.Executor != "" {
+				secondary = "    [red]" + fmt.Sprintf(i18n.T("connector.wiring.missing"), conn.Executor) + "[-]"
+			}
+			list.AddItem(fmt.Sprintf("  %s", v.connectorLabel(conn)), secondary, 0, func() {
+				v.showWiringActions(svcIdx, connIdx)
+			})
+		}
+	}
+
+	list.AddItem("[-:-:-] [white:red] "+i18n.T("common.back")+" [-:-:-]", i18n.T("connector.returnmenu"), 'b', func() {
+		v.Show()
+	})
+
+	list.SetBorder(true).SetTitle(" " + i18n.T("connector.wiring.title") + " ").SetBorderColor(tcell.ColorDarkCyan)
+
+	flex := tview.NewFlex().
+		AddItem(list, 0, 2, true).
+		AddItem(HelpPanel("help.connector.wiring"), 0, 1, false)
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.Show()
+			return nil
+		}
+		return event
+	})
+
+	v.pages.AddAndSwitchToPage("executor-wiring", flex, true)
+	v.app.SetFocus(list)
+}
+
+// showWiringActions lets the user move a connector between executors and its internal pool
+func (v *ConnectorView) showWiringActions(serviceIndex, connectorIndex int) {
+	svc := v.configService.GetService(serviceIndex)
+	if svc == nil || connectorIndex >= len(svc.Connectors) {
+		return
+	}
+	conn := &svc.Connectors[connectorIndex]
+
+	// save persists a wiring change and returns to the wiring view
+	save := func(err error, message string) {
+		if err != nil {
+			v.showError(err.Error())
+			return
+		}
+		v.configService.UpdateService(serviceIndex, *svc)
+		if err := v.configService.Save(); err != nil {
+			v.showError(fmt.Sprintf("Failed to save: %v", err))
+			return
+		}
+		v.setStatus("[green]" + message + "[-]")
+		v.showExecutorWiring()
+	}
+
+	list := tview.NewList().ShowSecondaryText(true)
+
+	for _, exec := range svc.Executors {
+		if exec.Name == conn.Executor {
+			continue
+		}
+		name := exec.Name
+		list.AddItem(
+			fmt.Sprintf(i18n.T("connector.wiring.useexecutor"), name),
+			fmt.Sprintf("%s-%s", exec.MinSpareThreads, exec.MaxThreads),
+			0,
+			func() {
+				save(connector.AssignExecutor(svc, connectorIndex, name), fmt.Sprintf(i18n.T("connector.wiring.assigned"), conn.Port, name))
+			},
+		)
+	}
+
+	if conn.Executor != "" {
+		list.AddItem(i18n.T("connector.wiring.useinternal"), i18n.T("connector.wiring.useinternal.desc"), 'i', func() {
+			save(connector.UseInternalPool(svc, connectorIndex), fmt.Sprintf(i18n.T("connector.wiring.detached"), conn.Port))
+		})
+	} else {
+		list.AddItem(i18n.T("connector.wiring.convert"), i18n.T("connector.wiring.convert.desc"), 'c', func() {
+			v.showConvertToExecutor(serviceIndex, connectorIndex)
+		})
+	}
+
+	list.AddItem("[black:yellow] "+i18n.T("common.cancel")+" [-:-]", "", 0, func() {
+		v.showExecutorWiring()
+	})
+
+	list.SetBorder(true).SetTitle(fmt.Sprintf(" %s %s ", i18n.T("connector.wiring.connector"), v.connectorLabel(conn))).SetBorderColor(tcell.ColorDarkCyan)
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showExecutorWiring()
+			return nil
+		}
+		return event
+	})
+
+	v.pages.AddAndSwitchToPage("executor-wiring-actions", list, true)
+	v.app.SetFocus(list)
+}
+
+// showConvertToExecutor converts a connector's inline thread pool into a new shared executor
+func (v *ConnectorView) showConvertToExecutor(serviceIndex, connectorIndex int) {
+	svc := v.configService.GetService(serviceIndex)
+	if svc == nil || connectorIndex >= len(svc.Connectors) {
+		return
+	}
+	conn := &svc.Connectors[connectorIndex]
+
+	form := tview.NewForm()
+	preview := NewPreviewPanel()
+
+	// Function to update preview from the connector's current inline pool
+	updatePreview := func(name string) {
+		exec := server.NewStandardExecutor(name)
+		if conn.MaxThreads != "" {
+			exec.MaxThreads = conn.MaxThreads
+		}
+		if conn.MinSpareThreads != "" {
+			exec.MinSpareThreads = conn.MinSpareThreads
+		}
+		preview.SetXMLPreview(GenerateExecutorXML(exec))
+	}
+
+	defaultName := fmt.Sprintf("exec-%s", conn.Port)
+	form.AddInputField(i18n.T("connector.executor.name"), defaultName, 30, nil, func(text string) {
+		updatePreview(text)
+	})
+
+	form.AddButton("[white:green]"+i18n.T("connector.wiring.convert")+"[-:-]", func() {
+		name := form.GetFormItem(0).(*tview.InputField).GetText()
+		if _, err := connector.ConvertToExecutor(svc, connectorIndex, name); err != nil {
+			v.showError(err.Error())
+			return
+		}
+		v.configService.UpdateService(serviceIndex, *svc)
+		if err := v.configService.Save(); err != nil {
+			v.showError(fmt.Sprintf("Failed to save: %v", err))
+			return
+		}
+		v.setStatus("[green]" + fmt.Sprintf(i18n.T("connector.wiring.converted"), conn.Port, name) + "[-]")
+		v.showExecutorWiring()
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		v.showWiringActions(serviceIndex, connectorIndex)
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s - %s ", i18n.T("connector.wiring.convert"), v.connectorLabel(conn))).SetBorderColor(tcell.ColorGreen)
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showWiringActions(serviceIndex, connectorIndex)
+			return nil
+		}
+		return event
+	})
+
+	updatePreview(defaultName)
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(preview, 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("executor-convert", layout, true)
+	v.app.SetFocus(form)
+}
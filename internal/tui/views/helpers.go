@@ -1,10 +1,12 @@
 package views
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
 	"github.com/playok/tomcatkit/internal/i18n"
 	"github.com/rivo/tview"
 )
@@ -109,6 +111,78 @@ func GetFormInt(form *tview.Form, label string) int {
 	return 0
 }
 
+// GetFormIntAttr gets a numeric attribute from an InputField by label. The
+// text may be a ${...} expression, which is kept as written. Invalid text
+// reads as unset, so this is only meant for previews; save handlers use
+// formInts.
+func GetFormIntAttr(form *tview.Form, label string) placeholder.Int {
+	value, _ := placeholder.ParseInt(GetFormText(form, label))
+	return value
+}
+
+// formInts parses numeric attributes from the input fields of a form. An
+// invalid value leaves the attribute as it was and is remembered in err, so
+// the form can refuse to save.
+type formInts struct {
+	err error
+}
+
+// Int parses the text of item, returning current when it is not a number or
+// ${...} expression
+func (f *formInts) Int(item tview.FormItem, current placeholder.Int) placeholder.Int {
+	input, ok := item.(*tview.InputField)
+	if !ok {
+		return current
+	}
+	value, err := placeholder.ParseInt(input.GetText())
+	if err != nil {
+		if f.err == nil {
+			label := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(input.GetLabel()), ":"))
+			f.err = fmt.Errorf(i18n.T("common.notanumber"), label, strings.TrimSpace(input.GetText()))
+		}
+		return current
+	}
+	return value
+}
+
+// acceptIntAttr accepts digits, or any text once it starts a ${...} expression
+func acceptIntAttr(text string, lastChar rune) bool {
+	return strings.HasPrefix(text, "$") || (lastChar >= '0' && lastChar <= '9') || (text == "-" && lastChar == '-')
+}
+
+// addResolvedValues lists the ${...} expressions used by the form's input
+// fields with the values they resolve to. It is appended after the fields
+// so items keep their indexes.
+func addResolvedValues(form *tview.Form, r *placeholder.Resolver) {
+	var lines []string
+	for i := 0; i < form.GetFormItemCount(); i++ {
+		input, ok := form.GetFormItem(i).(*tview.InputField)
+		if !ok || !placeholder.HasPlaceholder(input.GetText()) {
+			continue
+		}
+		label := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(input.GetLabel()), ":"))
+		lines = append(lines, label+": "+ResolvedText(input.GetText(), r))
+	}
+	if len(lines) == 0 {
+		return
+	}
+	form.AddTextView(i18n.T("placeholder.resolved"), strings.Join(lines, "\n"), 0, len(lines), true, false)
+}
+
+// ResolvedText describes what a ${...} expression resolves to, e.g.
+// "[green]8080[-] (http.port: catalina.properties)"
+func ResolvedText(text string, r *placeholder.Resolver) string {
+	value, missing := r.Expand(text)
+	if len(missing) > 0 {
+		return "[red]" + tview.Escape(fmt.Sprintf(i18n.T("placeholder.unresolved"), strings.Join(missing, ", "))) + "[-]"
+	}
+	result := "[green]" + tview.Escape(value) + "[-]"
+	if sources := r.Sources(text); len(sources) > 0 {
+		result += " [gray](" + tview.Escape(strings.Join(sources, ", ")) + ")[-]"
+	}
+	return result
+}
+
 // GetFormDropDownIndex safely gets the selected index from a DropDown by label
 func GetFormDropDownIndex(form *tview.Form, label string) int {
 	if field := form.GetFormItemByLabel(label); field != nil {
@@ -2,9 +2,9 @@ package views
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
 	"github.com/playok/tomcatkit/internal/config/server"
 	"github.com/playok/tomcatkit/internal/i18n"
 	"github.com/rivo/tview"
@@ -185,6 +185,7 @@ func (v *HostView) showEngineForm() {
 	form.AddInputField("Default Host", engine.DefaultHost, 40, nil, func(text string) { updatePreview() })
 	form.AddInputField("JVM Route", engine.JvmRoute, 30, nil, func(text string) { updatePreview() })
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
 		engine.Name = form.GetFormItemByLabel("Name").(*tview.InputField).GetText()
 		engine.DefaultHost = form.GetFormItemByLabel("Default Host").(*tview.InputField).GetText()
@@ -250,9 +251,9 @@ func (v *HostView) showHostList() {
 		newHost := &server.Host{
 			Name:            "newhost.example.com",
 			AppBase:         "webapps",
-			UnpackWARs:      true,
-			AutoDeploy:      true,
-			DeployOnStartup: true,
+			UnpackWARs:      placeholder.BoolOf(true),
+			AutoDeploy:      placeholder.BoolOf(true),
+			DeployOnStartup: placeholder.BoolOf(true),
 		}
 		v.showHostForm(newHost, true)
 	})
@@ -298,14 +299,14 @@ func (v *HostView) showHostForm(host *server.Host, isNew bool) {
 			Name:             GetFormText(form, "Name (hostname)"),
 			AppBase:          GetFormText(form, "App Base"),
 			WorkDir:          GetFormText(form, "Work Dir"),
-			UnpackWARs:       GetFormBool(form, "Unpack WARs"),
-			AutoDeploy:       GetFormBool(form, "Auto Deploy"),
-			DeployOnStartup:  GetFormBool(form, "Deploy On Startup"),
-			CreateDirs:       GetFormBool(form, "Create Dirs"),
-			DeployXML:        GetFormBool(form, "Deploy XML"),
-			CopyXML:          GetFormBool(form, "Copy XML"),
+			UnpackWARs:       placeholder.BoolOf(GetFormBool(form, "Unpack WARs")),
+			AutoDeploy:       placeholder.BoolOf(GetFormBool(form, "Auto Deploy")),
+			DeployOnStartup:  placeholder.BoolOf(GetFormBool(form, "Deploy On Startup")),
+			CreateDirs:       placeholder.BoolOf(GetFormBool(form, "Create Dirs")),
+			DeployXML:        placeholder.BoolOf(GetFormBool(form, "Deploy XML")),
+			CopyXML:          placeholder.BoolOf(GetFormBool(form, "Copy XML")),
 			DeployIgnore:     GetFormText(form, "Deploy Ignore (regex)"),
-			StartStopThreads: GetFormInt(form, "Start/Stop Threads"),
+			StartStopThreads: GetFormIntAttr(form, "Start/Stop Threads"),
 		}
 		previewPanel.SetXMLPreview(GenerateHostXML(&tempHost))
 	}
@@ -316,33 +317,40 @@ func (v *HostView) showHostForm(host *server.Host, isNew bool) {
 	form.AddInputField("Work Dir", host.WorkDir, 40, nil, func(text string) { updatePreview() })
 
 	// Deployment settings
-	form.AddCheckbox("Unpack WARs", host.UnpackWARs, func(checked bool) { updatePreview() })
-	form.AddCheckbox("Auto Deploy", host.AutoDeploy, func(checked bool) { updatePreview() })
-	form.AddCheckbox("Deploy On Startup", host.DeployOnStartup, func(checked bool) { updatePreview() })
-	form.AddCheckbox("Create Dirs", host.CreateDirs, func(checked bool) { updatePreview() })
-	form.AddCheckbox("Deploy XML", host.DeployXML, func(checked bool) { updatePreview() })
-	form.AddCheckbox("Copy XML", host.CopyXML, func(checked bool) { updatePreview() })
+	form.AddCheckbox("Unpack WARs", host.UnpackWARs.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
+	form.AddCheckbox("Auto Deploy", host.AutoDeploy.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
+	form.AddCheckbox("Deploy On Startup", host.DeployOnStartup.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
+	form.AddCheckbox("Create Dirs", host.CreateDirs.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
+	form.AddCheckbox("Deploy XML", host.DeployXML.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
+	form.AddCheckbox("Copy XML", host.CopyXML.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
 
 	// Advanced settings
 	form.AddInputField("Deploy Ignore (regex)", host.DeployIgnore, 40, nil, func(text string) { updatePreview() })
-	form.AddInputField("Start/Stop Threads", strconv.Itoa(host.StartStopThreads), 10, acceptNumber, func(text string) { updatePreview() })
+	form.AddInputField("Start/Stop Threads", host.StartStopThreads.Text(), 10, acceptNumber, func(text string) { updatePreview() })
 
 	form.AddInputField("Aliases (comma-separated)", aliasStr, 50, nil, nil)
 
 	originalName := host.Name
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		var ints formInts
 		host.Name = GetFormText(form, "Name (hostname)")
 		host.AppBase = GetFormText(form, "App Base")
 		host.WorkDir = GetFormText(form, "Work Dir")
-		host.UnpackWARs = GetFormBool(form, "Unpack WARs")
-		host.AutoDeploy = GetFormBool(form, "Auto Deploy")
-		host.DeployOnStartup = GetFormBool(form, "Deploy On Startup")
-		host.CreateDirs = GetFormBool(form, "Create Dirs")
-		host.DeployXML = GetFormBool(form, "Deploy XML")
-		host.CopyXML = GetFormBool(form, "Copy XML")
+		host.UnpackWARs = host.UnpackWARs.With(GetFormBool(form, "Unpack WARs"), v.configService.Resolver())
+		host.AutoDeploy = host.AutoDeploy.With(GetFormBool(form, "Auto Deploy"), v.configService.Resolver())
+		host.DeployOnStartup = host.DeployOnStartup.With(GetFormBool(form, "Deploy On Startup"), v.configService.Resolver())
+		host.CreateDirs = host.CreateDirs.With(GetFormBool(form, "Create Dirs"), v.configService.Resolver())
+		host.DeployXML = host.DeployXML.With(GetFormBool(form, "Deploy XML"), v.configService.Resolver())
+		host.CopyXML = host.CopyXML.With(GetFormBool(form, "Copy XML"), v.configService.Resolver())
 		host.DeployIgnore = GetFormText(form, "Deploy Ignore (regex)")
-		host.StartStopThreads = GetFormInt(form, "Start/Stop Threads")
+		host.StartStopThreads = ints.Int(form.GetFormItemByLabel("Start/Stop Threads"), host.StartStopThreads)
+
+		if ints.err != nil {
+			v.setStatus("[red]" + ints.err.Error() + "[-]")
+			return
+		}
 
 		// Parse aliases
 		host.Aliases = nil
@@ -505,8 +513,8 @@ func (v *HostView) showContextList(host *server.Host) {
 		newCtx := &server.Context{
 			Path:       "/newapp",
 			DocBase:    "newapp",
-			Reloadable: false,
-			Cookies:    true,
+			Reloadable: "",
+			Cookies:    placeholder.BoolOf(true),
 		}
 		v.showContextForm(host, newCtx, true)
 	})
@@ -542,22 +550,22 @@ func (v *HostView) showContextForm(host *server.Host, ctx *server.Context, isNew
 		tempCtx := server.Context{
 			Path:                form.GetFormItemByLabel("Path").(*tview.InputField).GetText(),
 			DocBase:             form.GetFormItemByLabel("DocBase").(*tview.InputField).GetText(),
-			Reloadable:          form.GetFormItemByLabel("Reloadable").(*tview.Checkbox).IsChecked(),
-			CrossContext:        form.GetFormItemByLabel("Cross Context").(*tview.Checkbox).IsChecked(),
-			Privileged:          form.GetFormItemByLabel("Privileged").(*tview.Checkbox).IsChecked(),
-			Cookies:             form.GetFormItemByLabel("Cookies").(*tview.Checkbox).IsChecked(),
+			Reloadable:          placeholder.BoolOf(form.GetFormItemByLabel("Reloadable").(*tview.Checkbox).IsChecked()),
+			CrossContext:        placeholder.BoolOf(form.GetFormItemByLabel("Cross Context").(*tview.Checkbox).IsChecked()),
+			Privileged:          placeholder.BoolOf(form.GetFormItemByLabel("Privileged").(*tview.Checkbox).IsChecked()),
+			Cookies:             placeholder.BoolOf(form.GetFormItemByLabel("Cookies").(*tview.Checkbox).IsChecked()),
 			SessionCookieName:   form.GetFormItemByLabel("Session Cookie Name").(*tview.InputField).GetText(),
 			SessionCookiePath:   form.GetFormItemByLabel("Session Cookie Path").(*tview.InputField).GetText(),
 			SessionCookieDomain: form.GetFormItemByLabel("Session Cookie Domain").(*tview.InputField).GetText(),
-			UseHttpOnly:         form.GetFormItemByLabel("Use HttpOnly").(*tview.Checkbox).IsChecked(),
-			AntiResourceLocking: form.GetFormItemByLabel("Anti Resource Locking").(*tview.Checkbox).IsChecked(),
-			SwallowOutput:       form.GetFormItemByLabel("Swallow Output").(*tview.Checkbox).IsChecked(),
-			CachingAllowed:      form.GetFormItemByLabel("Caching Allowed").(*tview.Checkbox).IsChecked(),
+			UseHttpOnly:         placeholder.BoolOf(form.GetFormItemByLabel("Use HttpOnly").(*tview.Checkbox).IsChecked()),
+			AntiResourceLocking: placeholder.BoolOf(form.GetFormItemByLabel("Anti Resource Locking").(*tview.Checkbox).IsChecked()),
+			SwallowOutput:       placeholder.BoolOf(form.GetFormItemByLabel("Swallow Output").(*tview.Checkbox).IsChecked()),
+			CachingAllowed:      placeholder.BoolOf(form.GetFormItemByLabel("Caching Allowed").(*tview.Checkbox).IsChecked()),
 		}
 		maxSize := form.GetFormItemByLabel("Cache Max Size (KB)").(*tview.InputField).GetText()
-		tempCtx.CacheMaxSize, _ = strconv.Atoi(maxSize)
+		tempCtx.CacheMaxSize, _ = placeholder.ParseInt(maxSize)
 		ttl := form.GetFormItemByLabel("Cache TTL (ms)").(*tview.InputField).GetText()
-		tempCtx.CacheTTL, _ = strconv.Atoi(ttl)
+		tempCtx.CacheTTL, _ = placeholder.ParseInt(ttl)
 		previewPanel.SetXMLPreview(GenerateContextXML(&tempCtx))
 	}
 
@@ -566,44 +574,51 @@ func (v *HostView) showContextForm(host *server.Host, ctx *server.Context, isNew
 	form.AddInputField("DocBase", ctx.DocBase, 40, nil, func(text string) { updatePreview() })
 
 	// Behavior settings
-	form.AddCheckbox("Reloadable", ctx.Reloadable, func(checked bool) { updatePreview() })
-	form.AddCheckbox("Cross Context", ctx.CrossContext, func(checked bool) { updatePreview() })
-	form.AddCheckbox("Privileged", ctx.Privileged, func(checked bool) { updatePreview() })
+	form.AddCheckbox("Reloadable", ctx.Reloadable.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
+	form.AddCheckbox("Cross Context", ctx.CrossContext.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
+	form.AddCheckbox("Privileged", ctx.Privileged.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
 
 	// Session settings
-	form.AddCheckbox("Cookies", ctx.Cookies, func(checked bool) { updatePreview() })
+	form.AddCheckbox("Cookies", ctx.Cookies.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
 	form.AddInputField("Session Cookie Name", ctx.SessionCookieName, 30, nil, func(text string) { updatePreview() })
 	form.AddInputField("Session Cookie Path", ctx.SessionCookiePath, 30, nil, func(text string) { updatePreview() })
 	form.AddInputField("Session Cookie Domain", ctx.SessionCookieDomain, 30, nil, func(text string) { updatePreview() })
-	form.AddCheckbox("Use HttpOnly", ctx.UseHttpOnly, func(checked bool) { updatePreview() })
+	form.AddCheckbox("Use HttpOnly", ctx.UseHttpOnly.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
 
 	// Resource handling
-	form.AddCheckbox("Anti Resource Locking", ctx.AntiResourceLocking, func(checked bool) { updatePreview() })
-	form.AddCheckbox("Swallow Output", ctx.SwallowOutput, func(checked bool) { updatePreview() })
+	form.AddCheckbox("Anti Resource Locking", ctx.AntiResourceLocking.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
+	form.AddCheckbox("Swallow Output", ctx.SwallowOutput.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
 
 	// Cache settings
-	form.AddCheckbox("Caching Allowed", ctx.CachingAllowed, func(checked bool) { updatePreview() })
-	form.AddInputField("Cache Max Size (KB)", strconv.Itoa(ctx.CacheMaxSize), 10, acceptNumber, func(text string) { updatePreview() })
-	form.AddInputField("Cache TTL (ms)", strconv.Itoa(ctx.CacheTTL), 10, acceptNumber, func(text string) { updatePreview() })
+	form.AddCheckbox("Caching Allowed", ctx.CachingAllowed.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
+	form.AddInputField("Cache Max Size (KB)", ctx.CacheMaxSize.Text(), 10, acceptNumber, func(text string) { updatePreview() })
+	form.AddInputField("Cache TTL (ms)", ctx.CacheTTL.Text(), 10, acceptNumber, func(text string) { updatePreview() })
 
 	originalPath := ctx.Path
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		var ints formInts
 		ctx.Path = form.GetFormItemByLabel("Path").(*tview.InputField).GetText()
 		ctx.DocBase = form.GetFormItemByLabel("DocBase").(*tview.InputField).GetText()
-		ctx.Reloadable = form.GetFormItemByLabel("Reloadable").(*tview.Checkbox).IsChecked()
-		ctx.CrossContext = form.GetFormItemByLabel("Cross Context").(*tview.Checkbox).IsChecked()
-		ctx.Privileged = form.GetFormItemByLabel("Privileged").(*tview.Checkbox).IsChecked()
-		ctx.Cookies = form.GetFormItemByLabel("Cookies").(*tview.Checkbox).IsChecked()
+		ctx.Reloadable = ctx.Reloadable.With(form.GetFormItemByLabel("Reloadable").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		ctx.CrossContext = ctx.CrossContext.With(form.GetFormItemByLabel("Cross Context").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		ctx.Privileged = ctx.Privileged.With(form.GetFormItemByLabel("Privileged").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		ctx.Cookies = ctx.Cookies.With(form.GetFormItemByLabel("Cookies").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
 		ctx.SessionCookieName = form.GetFormItemByLabel("Session Cookie Name").(*tview.InputField).GetText()
 		ctx.SessionCookiePath = form.GetFormItemByLabel("Session Cookie Path").(*tview.InputField).GetText()
 		ctx.SessionCookieDomain = form.GetFormItemByLabel("Session Cookie Domain").(*tview.InputField).GetText()
-		ctx.UseHttpOnly = form.GetFormItemByLabel("Use HttpOnly").(*tview.Checkbox).IsChecked()
-		ctx.AntiResourceLocking = form.GetFormItemByLabel("Anti Resource Locking").(*tview.Checkbox).IsChecked()
-		ctx.SwallowOutput = form.GetFormItemByLabel("Swallow Output").(*tview.Checkbox).IsChecked()
-		ctx.CachingAllowed = form.GetFormItemByLabel("Caching Allowed").(*tview.Checkbox).IsChecked()
-		ctx.CacheMaxSize, _ = strconv.Atoi(form.GetFormItemByLabel("Cache Max Size (KB)").(*tview.InputField).GetText())
-		ctx.CacheTTL, _ = strconv.Atoi(form.GetFormItemByLabel("Cache TTL (ms)").(*tview.InputField).GetText())
+		ctx.UseHttpOnly = ctx.UseHttpOnly.With(form.GetFormItemByLabel("Use HttpOnly").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		ctx.AntiResourceLocking = ctx.AntiResourceLocking.With(form.GetFormItemByLabel("Anti Resource Locking").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		ctx.SwallowOutput = ctx.SwallowOutput.With(form.GetFormItemByLabel("Swallow Output").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		ctx.CachingAllowed = ctx.CachingAllowed.With(form.GetFormItemByLabel("Caching Allowed").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
+		ctx.CacheMaxSize = ints.Int(form.GetFormItemByLabel("Cache Max Size (KB)"), ctx.CacheMaxSize)
+		ctx.CacheTTL = ints.Int(form.GetFormItemByLabel("Cache TTL (ms)"), ctx.CacheTTL)
+
+		if ints.err != nil {
+			v.setStatus("[red]" + ints.err.Error() + "[-]")
+			return
+		}
 
 		if isNew {
 			host.Contexts = append(host.Contexts, *ctx)
@@ -722,7 +737,7 @@ func (v *HostView) showParameterList(host *server.Host, ctx *server.Context) {
 		newParam := &server.Parameter{
 			Name:     "newParam",
 			Value:    "",
-			Override: true,
+			Override: placeholder.BoolOf(true),
 		}
 		v.showParameterForm(host, ctx, newParam, true)
 	})
@@ -758,7 +773,7 @@ func (v *HostView) showParameterForm(host *server.Host, ctx *server.Context, par
 		tempParam := server.Parameter{
 			Name:        form.GetFormItemByLabel("Name").(*tview.InputField).GetText(),
 			Value:       form.GetFormItemByLabel("Value").(*tview.InputField).GetText(),
-			Override:    form.GetFormItemByLabel("Override").(*tview.Checkbox).IsChecked(),
+			Override:    placeholder.BoolOf(form.GetFormItemByLabel("Override").(*tview.Checkbox).IsChecked()),
 			Description: form.GetFormItemByLabel("Description").(*tview.InputField).GetText(),
 		}
 		previewPanel.SetXMLPreview(GenerateParameterXML(&tempParam))
@@ -766,15 +781,16 @@ func (v *HostView) showParameterForm(host *server.Host, ctx *server.Context, par
 
 	form.AddInputField("Name", param.Name, 40, nil, func(text string) { updatePreview() })
 	form.AddInputField("Value", param.Value, 50, nil, func(text string) { updatePreview() })
-	form.AddCheckbox("Override", param.Override, func(checked bool) { updatePreview() })
+	form.AddCheckbox("Override", param.Override.Value(v.configService.Resolver()), func(checked bool) { updatePreview() })
 	form.AddInputField("Description", param.Description, 50, nil, func(text string) { updatePreview() })
 
 	originalName := param.Name
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
 		param.Name = form.GetFormItemByLabel("Name").(*tview.InputField).GetText()
 		param.Value = form.GetFormItemByLabel("Value").(*tview.InputField).GetText()
-		param.Override = form.GetFormItemByLabel("Override").(*tview.Checkbox).IsChecked()
+		param.Override = param.Override.With(form.GetFormItemByLabel("Override").(*tview.Checkbox).IsChecked(), v.configService.Resolver())
 		param.Description = form.GetFormItemByLabel("Description").(*tview.InputField).GetText()
 
 		if isNew {
@@ -898,36 +914,43 @@ func (v *HostView) showManagerForm(host *server.Host, ctx *server.Context) {
 		tempMgr := server.Manager{}
 		_, tempMgr.ClassName = form.GetFormItemByLabel("Manager Class").(*tview.DropDown).GetCurrentOption()
 		maxActive := form.GetFormItemByLabel("Max Active Sessions").(*tview.InputField).GetText()
-		tempMgr.MaxActiveSessions, _ = strconv.Atoi(maxActive)
+		tempMgr.MaxActiveSessions, _ = placeholder.ParseInt(maxActive)
 		sidLen := form.GetFormItemByLabel("Session ID Length").(*tview.InputField).GetText()
-		tempMgr.SessionIdLength, _ = strconv.Atoi(sidLen)
+		tempMgr.SessionIdLength, _ = placeholder.ParseInt(sidLen)
 		maxInactive := form.GetFormItemByLabel("Max Inactive Interval (sec)").(*tview.InputField).GetText()
-		tempMgr.MaxInactiveInterval, _ = strconv.Atoi(maxInactive)
+		tempMgr.MaxInactiveInterval, _ = placeholder.ParseInt(maxInactive)
 		tempMgr.Pathname = form.GetFormItemByLabel("Session File Path").(*tview.InputField).GetText()
 		procExp := form.GetFormItemByLabel("Process Expires Frequency").(*tview.InputField).GetText()
-		tempMgr.ProcessExpiresFrequency, _ = strconv.Atoi(procExp)
+		tempMgr.ProcessExpiresFrequency, _ = placeholder.ParseInt(procExp)
 		previewPanel.SetXMLPreview(GenerateManagerXML(&tempMgr))
 	}
 
 	form.AddDropDown("Manager Class", managerClasses, classIdx, func(option string, optionIndex int) { updatePreview() })
 
-	form.AddInputField("Max Active Sessions", strconv.Itoa(ctx.Manager.MaxActiveSessions), 10, acceptNumber, func(text string) { updatePreview() })
-	form.AddInputField("Session ID Length", strconv.Itoa(ctx.Manager.SessionIdLength), 10, acceptNumber, func(text string) { updatePreview() })
-	form.AddInputField("Max Inactive Interval (sec)", strconv.Itoa(ctx.Manager.MaxInactiveInterval), 10, acceptNumber, func(text string) { updatePreview() })
+	form.AddInputField("Max Active Sessions", ctx.Manager.MaxActiveSessions.Text(), 10, acceptNumber, func(text string) { updatePreview() })
+	form.AddInputField("Session ID Length", ctx.Manager.SessionIdLength.Text(), 10, acceptNumber, func(text string) { updatePreview() })
+	form.AddInputField("Max Inactive Interval (sec)", ctx.Manager.MaxInactiveInterval.Text(), 10, acceptNumber, func(text string) { updatePreview() })
 	form.AddInputField("Session File Path", ctx.Manager.Pathname, 40, nil, func(text string) { updatePreview() })
-	form.AddInputField("Process Expires Frequency", strconv.Itoa(ctx.Manager.ProcessExpiresFrequency), 10, acceptNumber, func(text string) { updatePreview() })
+	form.AddInputField("Process Expires Frequency", ctx.Manager.ProcessExpiresFrequency.Text(), 10, acceptNumber, func(text string) { updatePreview() })
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		var ints formInts
 		_, ctx.Manager.ClassName = form.GetFormItemByLabel("Manager Class").(*tview.DropDown).GetCurrentOption()
-		ctx.Manager.MaxActiveSessions, _ = strconv.Atoi(form.GetFormItemByLabel("Max Active Sessions").(*tview.InputField).GetText())
-		ctx.Manager.SessionIdLength, _ = strconv.Atoi(form.GetFormItemByLabel("Session ID Length").(*tview.InputField).GetText())
-		ctx.Manager.MaxInactiveInterval, _ = strconv.Atoi(form.GetFormItemByLabel("Max Inactive Interval (sec)").(*tview.InputField).GetText())
+		ctx.Manager.MaxActiveSessions = ints.Int(form.GetFormItemByLabel("Max Active Sessions"), ctx.Manager.MaxActiveSessions)
+		ctx.Manager.SessionIdLength = ints.Int(form.GetFormItemByLabel("Session ID Length"), ctx.Manager.SessionIdLength)
+		ctx.Manager.MaxInactiveInterval = ints.Int(form.GetFormItemByLabel("Max Inactive Interval (sec)"), ctx.Manager.MaxInactiveInterval)
 		ctx.Manager.Pathname = form.GetFormItemByLabel("Session File Path").(*tview.InputField).GetText()
-		ctx.Manager.ProcessExpiresFrequency, _ = strconv.Atoi(form.GetFormItemByLabel("Process Expires Frequency").(*tview.InputField).GetText())
+		ctx.Manager.ProcessExpiresFrequency = ints.Int(form.GetFormItemByLabel("Process Expires Frequency"), ctx.Manager.ProcessExpiresFrequency)
+
+		if ints.err != nil {
+			v.setStatus("[red]" + ints.err.Error() + "[-]")
+			return
+		}
 
 		// Remove manager if all fields are empty/default
-		if ctx.Manager.ClassName == "" && ctx.Manager.MaxActiveSessions == 0 &&
-			ctx.Manager.SessionIdLength == 0 && ctx.Manager.MaxInactiveInterval == 0 {
+		if ctx.Manager.ClassName == "" && ctx.Manager.MaxActiveSessions == "" &&
+			ctx.Manager.SessionIdLength == "" && ctx.Manager.MaxInactiveInterval == "" {
 			ctx.Manager = nil
 		}
 
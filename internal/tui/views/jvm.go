@@ -0,0 +1,585 @@
+package views
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/jvm"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/rivo/tview"
+)
+
+// JVMView provides TUI for JVM options in bin/setenv.sh and bin/setenv.bat
+type JVMView struct {
+	app           *tview.Application
+	pages         *tview.Pages
+	mainPages     *tview.Pages
+	statusBar     *tview.TextView
+	onReturn      func()
+	catalinaBase  string
+	configService *jvm.ConfigService
+}
+
+// NewJVMView creates a new JVM options view
+func NewJVMView(app *tview.Application, mainPages *tview.Pages, statusBar *tview.TextView, catalinaBase string, onReturn func()) *JVMView {
+	return &JVMView{
+		app:          app,
+		mainPages:    mainPages,
+		statusBar:    statusBar,
+		onReturn:     onReturn,
+		catalinaBase: catalinaBase,
+	}
+}
+
+// Load initializes the view with the script used on this platform
+func (v *JVMView) Load() error {
+	script := jvm.ScriptSh
+	if runtime.GOOS == "windows" {
+		script = jvm.ScriptBat
+	}
+	// Prefer the script the instance already has
+	other := jvm.ScriptBat
+	if script == jvm.ScriptBat {
+		other = jvm.ScriptSh
+	}
+	if _, err := os.Stat(jvm.NewConfigService(v.catalinaBase, script).GetFilePath()); os.IsNotExist(err) {
+		if _, err := os.Stat(jvm.NewConfigService(v.catalinaBase, other).GetFilePath()); err == nil {
+			script = other
+		}
+	}
+
+	if err := v.loadScript(script); err != nil {
+		return err
+	}
+
+	v.pages = tview.NewPages()
+	v.showMainMenu()
+
+	v.mainPages.AddAndSwitchToPage("jvm", v.pages, true)
+	return nil
+}
+
+// loadScript switches the view to another setenv script
+func (v *JVMView) loadScript(script jvm.Script) error {
+	service := jvm.NewConfigService(v.catalinaBase, script)
+	if err := service.Load(); err != nil {
+		return fmt.Errorf("failed to load %s: %w", script, err)
+	}
+	v.configService = service
+	return nil
+}
+
+// showMainMenu displays the JVM options main menu
+func (v *JVMView) showMainMenu() {
+	opts := v.configService.GetOptions()
+
+	helpPanel := NewDynamicHelpPanel()
+
+	heap := i18n.T("jvm.notset")
+	if opts.HeapMin != "" || opts.HeapMax != "" {
+		heap = fmt.Sprintf("-Xms%s -Xmx%s", valueOr(opts.HeapMin, "?"), valueOr(opts.HeapMax, "?"))
+	}
+	gc := string(opts.GC)
+	if gc == "" {
+		gc = i18n.T("jvm.gc.default")
+	}
+	jmx := i18n.T("jvm.jmx.disabled")
+	if opts.JMX.Enabled {
+		jmx = fmt.Sprintf(i18n.T("jvm.jmx.enabled"), valueOr(opts.JMX.Port, "?"))
+	}
+	scriptState := ""
+	if !v.configService.Exists() {
+		scriptState = " [yellow](" + i18n.T("jvm.script.new") + ")[-]"
+	}
+
+	list := tview.NewList().
+		AddItem(i18n.T("jvm.script")+": [yellow]"+string(v.configService.GetScript())+"[-]"+scriptState, i18n.T("jvm.script.desc"), 't', func() {
+			v.toggleScript()
+		}).
+		AddItem(i18n.T("jvm.general"), fmt.Sprintf("%s | GC: %s", heap, gc), 'g', func() {
+			v.showGeneralForm()
+		}).
+		AddItem(i18n.T("jvm.xx"), fmt.Sprintf(i18n.T("jvm.count"), len(opts.XXFlags)), 'x', func() {
+			v.showXXFlags()
+		}).
+		AddItem(i18n.T("jvm.props"), fmt.Sprintf(i18n.T("jvm.count"), len(opts.Properties)), 'd', func() {
+			v.showProperties()
+		}).
+		AddItem(i18n.T("jvm.jmx"), jmx, 'j', func() {
+			v.showJMXForm()
+		}).
+		AddItem(i18n.T("jvm.preserved"), fmt.Sprintf(i18n.T("jvm.preserved.count"), len(opts.Other), len(v.configService.UnmanagedLines())), 'o', func() {
+			v.showPreserved()
+		}).
+		AddItem(i18n.T("jvm.preview"), i18n.T("jvm.preview.desc"), 'p', func() {
+			v.showPreview()
+		}).
+		AddItem(i18n.T("common.save"), i18n.T("jvm.save.desc"), 's', func() {
+			v.saveConfiguration()
+		}).
+		AddItem(i18n.T("common.back"), i18n.T("common.return"), 'b', func() {
+			v.mainPages.RemovePage("jvm")
+			v.onReturn()
+		})
+
+	list.SetChangedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {
+		switch index {
+		case 0:
+			helpPanel.SetHelpKey("help.jvm.script")
+		case 1:
+			helpPanel.SetHelpKey("help.jvm.general")
+		case 2:
+			helpPanel.SetHelpKey("help.jvm.xx")
+		case 3:
+			helpPanel.SetHelpKey("help.jvm.props")
+		case 4:
+			helpPanel.SetHelpKey("help.jvm.jmx")
+		case 5:
+			helpPanel.SetHelpKey("help.jvm.preserved")
+		default:
+			helpPanel.SetText("")
+		}
+	})
+
+	helpPanel.SetHelpKey("help.jvm.script")
+
+	list.SetBorder(true).SetTitle(" " + i18n.T("jvm.title") + " ")
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.mainPages.RemovePage("jvm")
+			v.onReturn()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(list, 0, 2, true).
+		AddItem(helpPanel, 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("menu", flex, true)
+	v.setStatus(i18n.T("jvm.title") + ": " + v.configService.GetFilePath())
+}
+
+// toggleScript switches between setenv.sh and setenv.bat
+func (v *JVMView) toggleScript() {
+	next := jvm.ScriptBat
+	if v.configService.GetScript() == jvm.ScriptBat {
+		next = jvm.ScriptSh
+	}
+	if err := v.loadScript(next); err != nil {
+		v.setStatus("[red]" + err.Error() + "[-]")
+		return
+	}
+	v.showMainMenu()
+}
+
+// showGeneralForm edits JAVA_HOME, CATALINA_PID, heap size and GC
+func (v *JVMView) showGeneralForm() {
+	opts := v.configService.GetOptions()
+
+	form := tview.NewForm()
+	form.AddInputField("JAVA_HOME", opts.JavaHome, 50, nil, nil)
+	form.AddInputField("CATALINA_PID", opts.CatalinaPID, 50, nil, nil)
+	form.AddInputField(i18n.T("jvm.heap.min"), opts.HeapMin, 10, nil, nil)
+	form.AddInputField(i18n.T("jvm.heap.max"), opts.HeapMax, 10, nil, nil)
+
+	gcs := jvm.AvailableGCs()
+	gcOptions := make([]string, len(gcs))
+	initialGC := 0
+	for i, gc := range gcs {
+		gcOptions[i] = string(gc)
+		switch gc {
+		case jvm.GCDefault:
+			gcOptions[i] = i18n.T("jvm.gc.default")
+		case jvm.GCCMS:
+			gcOptions[i] = i18n.T("jvm.gc.cms")
+		}
+		if gc == opts.GC {
+			initialGC = i
+		}
+	}
+	// Keep a collector that is not offered (e.g. Epsilon) selectable
+	if opts.GC != jvm.GCDefault && initialGC == 0 {
+		gcs = append(gcs, opts.GC)
+		gcOptions = append(gcOptions, string(opts.GC))
+		initialGC = len(gcs) - 1
+	}
+	form.AddDropDown(i18n.T("jvm.gc"), gcOptions, initialGC, nil)
+
+	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		heapMin := strings.TrimSpace(form.GetFormItem(2).(*tview.InputField).GetText())
+		heapMax := strings.TrimSpace(form.GetFormItem(3).(*tview.InputField).GetText())
+		for _, size := range []string{heapMin, heapMax} {
+			if size != "" && !jvm.ValidMemorySize(size) {
+				v.setStatus("[red]" + fmt.Sprintf(i18n.T("jvm.heap.invalid"), size) + "[-]")
+				return
+			}
+		}
+
+		opts.JavaHome = strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		opts.CatalinaPID = strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText())
+		opts.HeapMin = heapMin
+		opts.HeapMax = heapMax
+		gcIndex, _ := form.GetFormItem(4).(*tview.DropDown).GetCurrentOption()
+		opts.GC = gcs[gcIndex]
+
+		v.showMainMenu()
+		v.setStatus(i18n.T("jvm.updated"))
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		v.showMainMenu()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("jvm.general") + " ")
+
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showMainMenu()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(form, 0, 2, true).
+		AddItem(HelpPanel("help.jvm.general"), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("general-form", flex, true)
+}
+
+// showXXFlags lists -XX options
+func (v *JVMView) showXXFlags() {
+	opts := v.configService.GetOptions()
+
+	list := tview.NewList()
+	for i, flag := range opts.XXFlags {
+		idx := i
+		list.AddItem("-XX:"+flag, "", 0, func() {
+			v.showXXFlagForm(idx)
+		})
+	}
+
+	list.AddItem("[green]+ "+i18n.T("jvm.xx.add")+"[-]", i18n.T("jvm.xx.add.desc"), 'a', func() {
+		v.showXXFlagForm(-1)
+	})
+	list.AddItem(i18n.T("common.back"), i18n.T("jvm.returnmenu"), 'b', func() {
+		v.showMainMenu()
+	})
+
+	list.SetBorder(true).SetTitle(" " + i18n.T("jvm.xx") + " ")
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showMainMenu()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(list, 0, 2, true).
+		AddItem(HelpPanel("help.jvm.xx"), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("xx-flags", flex, true)
+}
+
+// showXXFlagForm adds (index -1) or edits a -XX option
+func (v *JVMView) showXXFlagForm(index int) {
+	opts := v.configService.GetOptions()
+
+	current := ""
+	if index >= 0 {
+		current = opts.XXFlags[index]
+	}
+
+	form := tview.NewForm()
+	form.AddInputField("-XX:", current, 50, nil, nil)
+
+	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		flag := strings.TrimPrefix(strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText()), "-XX:")
+		if flag == "" {
+			v.setStatus("[red]" + i18n.T("jvm.xx.required") + "[-]")
+			return
+		}
+		if index >= 0 {
+			opts.XXFlags[index] = flag
+		} else {
+			opts.XXFlags = append(opts.XXFlags, flag)
+		}
+		v.showXXFlags()
+	})
+
+	if index >= 0 {
+		form.AddButton("[white:red]"+i18n.T("common.delete")+"[-:-]", func() {
+			opts.XXFlags = append(opts.XXFlags[:index], opts.XXFlags[index+1:]...)
+			v.showXXFlags()
+		})
+	}
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		v.showXXFlags()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("jvm.xx") + " ")
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showXXFlags()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(form, 0, 2, true).
+		AddItem(HelpPanel("help.jvm.xx"), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("xx-form", flex, true)
+}
+
+// showProperties lists -D system properties
+func (v *JVMView) showProperties() {
+	opts := v.configService.GetOptions()
+
+	list := tview.NewList()
+	for i, prop := range opts.Properties {
+		idx := i
+		list.AddItem(prop.Name, prop.Value, 0, func() {
+			v.showPropertyForm(idx)
+		})
+	}
+
+	list.AddItem("[green]+ "+i18n.T("jvm.props.add")+"[-]", i18n.T("jvm.props.add.desc"), 'a', func() {
+		v.showPropertyForm(-1)
+	})
+	list.AddItem(i18n.T("common.back"), i18n.T("jvm.returnmenu"), 'b', func() {
+		v.showMainMenu()
+	})
+
+	list.SetBorder(true).SetTitle(" " + i18n.T("jvm.props") + " ")
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showMainMenu()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(list, 0, 2, true).
+		AddItem(HelpPanel("help.jvm.props"), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("properties", flex, true)
+}
+
+// showPropertyForm adds (index -1) or edits a system property
+func (v *JVMView) showPropertyForm(index int) {
+	opts := v.configService.GetOptions()
+
+	var current jvm.SystemProperty
+	if index >= 0 {
+		current = opts.Properties[index]
+	}
+
+	form := tview.NewForm()
+	form.AddInputField(i18n.T("jvm.props.name"), current.Name, 40, nil, nil)
+	form.AddInputField(i18n.T("jvm.props.value"), current.Value, 50, nil, nil)
+
+	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		name := strings.TrimPrefix(strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText()), "-D")
+		if name == "" {
+			v.setStatus("[red]" + i18n.T("jvm.props.required") + "[-]")
+			return
+		}
+		prop := jvm.SystemProperty{Name: name, Value: form.GetFormItem(1).(*tview.InputField).GetText()}
+		if index >= 0 {
+			opts.Properties[index] = prop
+		} else {
+			opts.Properties = append(opts.Properties, prop)
+		}
+		v.showProperties()
+	})
+
+	if index >= 0 {
+		form.AddButton("[white:red]"+i18n.T("common.delete")+"[-:-]", func() {
+			opts.Properties = append(opts.Properties[:index], opts.Properties[index+1:]...)
+			v.showProperties()
+		})
+	}
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		v.showProperties()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("jvm.props") + " ")
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showProperties()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(form, 0, 2, true).
+		AddItem(HelpPanel("help.jvm.props"), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("property-form", flex, true)
+}
+
+// showJMXForm edits the com.sun.management.jmxremote options
+func (v *JVMView) showJMXForm() {
+	opts := v.configService.GetOptions()
+
+	boolOptions := []string{i18n.T("jvm.notset"), "true", "false"}
+	boolIndex := func(value string) int {
+		switch value {
+		case "true":
+			return 1
+		case "false":
+			return 2
+		}
+		return 0
+	}
+	boolValue := func(index int) string {
+		if index == 0 {
+			return ""
+		}
+		return boolOptions[index]
+	}
+
+	form := tview.NewForm()
+	form.AddCheckbox(i18n.T("jvm.jmx.enable"), opts.JMX.Enabled, nil)
+	form.AddInputField(i18n.T("jvm.jmx.port"), opts.JMX.Port, 10, acceptDigits, nil)
+	form.AddInputField(i18n.T("jvm.jmx.rmiport"), opts.JMX.RMIPort, 10, acceptDigits, nil)
+	form.AddDropDown(i18n.T("jvm.jmx.authenticate"), boolOptions, boolIndex(opts.JMX.Authenticate), nil)
+	form.AddDropDown(i18n.T("jvm.jmx.ssl"), boolOptions, boolIndex(opts.JMX.SSL), nil)
+	form.AddInputField(i18n.T("jvm.jmx.hostname"), opts.JMX.Hostname, 40, nil, nil)
+
+	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		jmx := jvm.JMXOptions{
+			Enabled:  form.GetFormItem(0).(*tview.Checkbox).IsChecked(),
+			Port:     form.GetFormItem(1).(*tview.InputField).GetText(),
+			RMIPort:  form.GetFormItem(2).(*tview.InputField).GetText(),
+			Hostname: strings.TrimSpace(form.GetFormItem(5).(*tview.InputField).GetText()),
+		}
+		authIndex, _ := form.GetFormItem(3).(*tview.DropDown).GetCurrentOption()
+		sslIndex, _ := form.GetFormItem(4).(*tview.DropDown).GetCurrentOption()
+		jmx.Authenticate = boolValue(authIndex)
+		jmx.SSL = boolValue(sslIndex)
+
+		if jmx.Enabled && jmx.Port == "" {
+			v.setStatus("[red]" + i18n.T("jvm.jmx.portrequired") + "[-]")
+			return
+		}
+		// The ports are what opens JMX remote; the other settings are kept
+		if !jmx.Enabled {
+			jmx.Port, jmx.RMIPort = "", ""
+		}
+		opts.JMX = jmx
+		v.showMainMenu()
+		v.setStatus(i18n.T("jvm.updated"))
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		v.showMainMenu()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("jvm.jmx") + " ")
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showMainMenu()
+			return nil
+		}
+		return event
+	})
+
+	flex := tview.NewFlex().
+		AddItem(form, 0, 2, true).
+		AddItem(HelpPanel("help.jvm.jmx"), 0, 1, false)
+
+	v.pages.AddAndSwitchToPage("jmx-form", flex, true)
+}
+
+// showPreserved shows options and lines that are kept exactly as written
+func (v *JVMView) showPreserved() {
+	opts := v.configService.GetOptions()
+
+	var sb strings.Builder
+	sb.WriteString("[yellow::b]" + fmt.Sprintf(i18n.T("jvm.preserved.options"), opts.OptsVar) + "[-::-]\n")
+	if len(opts.Other) == 0 {
+		sb.WriteString("  [gray]" + i18n.T("jvm.preserved.none") + "[-]\n")
+	}
+	for _, opt := range opts.Other {
+		sb.WriteString("  " + tview.Escape(opt) + "\n")
+	}
+
+	sb.WriteString("\n[yellow::b]" + i18n.T("jvm.preserved.lines") + "[-::-]\n")
+	lines := v.configService.UnmanagedLines()
+	if len(lines) == 0 {
+		sb.WriteString("  [gray]" + i18n.T("jvm.preserved.none") + "[-]\n")
+	}
+	for _, line := range lines {
+		sb.WriteString("  " + tview.Escape(line) + "\n")
+	}
+
+	v.showTextPage("preserved", i18n.T("jvm.preserved"), sb.String())
+}
+
+// showPreview shows the script as it will be written
+func (v *JVMView) showPreview() {
+	v.showTextPage("preview", string(v.configService.GetScript()), tview.Escape(v.configService.GenerateContent()))
+}
+
+// showTextPage shows read-only text; Esc or Enter returns to the menu
+func (v *JVMView) showTextPage(name, title, text string) {
+	textView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(text)
+	textView.SetBorder(true).SetTitle(" " + title + " ").SetBorderColor(tcell.ColorBlue)
+
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyEnter {
+			v.showMainMenu()
+			return nil
+		}
+		return event
+	})
+
+	v.pages.AddAndSwitchToPage(name, textView, true)
+	v.app.SetFocus(textView)
+}
+
+// saveConfiguration writes the setenv script
+func (v *JVMView) saveConfiguration() {
+	if err := v.configService.Save(); err != nil {
+		v.setStatus("[red]Error saving: " + err.Error() + "[-]")
+		return
+	}
+	v.showMainMenu()
+	v.setStatus("[green]" + fmt.Sprintf(i18n.T("jvm.saved"), v.configService.GetFilePath()) + "[-]")
+}
+
+// setStatus updates the status bar
+func (v *JVMView) setStatus(message string) {
+	if v.statusBar != nil {
+		v.statusBar.SetText(" " + message)
+	}
+}
+
+// valueOr returns value, or fallback when value is empty
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,297 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/playok/tomcatkit/internal/lifecycle"
+	"github.com/rivo/tview"
+)
+
+// logPaneMaxLines limits how much output the log pane keeps
+const logPaneMaxLines = 2000
+
+// LifecycleView starts, stops and restarts an instance and shows the output
+type LifecycleView struct {
+	app        *tview.Application
+	mainPages  *tview.Pages
+	statusBar  *tview.TextView
+	onReturn   func()
+	onChange   func()
+	instance   *config.TomcatInstance
+	checkpoint *lifecycle.Checkpoint
+	settings   *config.SettingsManager
+	controller *lifecycle.Controller
+	list       *tview.List
+	logPane    *tview.TextView
+	busy       bool
+}
+
+// NewLifecycleView creates a new lifecycle view. checkpoint holds the
+// configuration from before the current changes and enables applying them
+// safely; it may be nil. onChange is called after the running state of the
+// instance may have changed.
+func NewLifecycleView(app *tview.Application, mainPages *tview.Pages, statusBar *tview.TextView, instance *config.TomcatInstance, checkpoint *lifecycle.Checkpoint, settings *config.SettingsManager, onChange func(), onReturn func()) *LifecycleView {
+	return &LifecycleView{
+		app:        app,
+		mainPages:  mainPages,
+		statusBar:  statusBar,
+		onReturn:   onReturn,
+		onChange:   onChange,
+		instance:   instance,
+		checkpoint: checkpoint,
+		settings:   settings,
+	}
+}
+
+// Load shows the lifecycle menu and log pane
+func (v *LifecycleView) Load() error {
+	v.logPane = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetMaxLines(logPaneMaxLines).
+		SetChangedFunc(func() { v.app.Draw() })
+	v.logPane.SetBorder(true).SetTitle(" " + i18n.T("lifecycle.log") + " ").SetBorderColor(tcell.ColorBlue)
+
+	// Output arrives from the controller's goroutine; TextView writes are
+	// safe there and the pane follows the end while it is scrolled down
+	v.logPane.ScrollToEnd()
+	v.controller = lifecycle.NewController(v.instance, func(line string) {
+		fmt.Fprintln(v.logPane, tview.Escape(line))
+	})
+
+	v.list = tview.NewList().ShowSecondaryText(true)
+	v.list.SetBorder(true).SetTitle(" " + i18n.T("lifecycle.title") + " ").SetBorderColor(tcell.ColorDarkCyan)
+	v.list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.close()
+			return nil
+		}
+		return event
+	})
+
+	layout := tview.NewFlex().
+		AddItem(v.list, 0, 1, true).
+		AddItem(v.logPane, 0, 2, false)
+
+	v.mainPages.AddAndSwitchToPage("lifecycle", layout, true)
+	v.app.SetFocus(v.list)
+
+	v.controller.Refresh()
+	v.showMenu()
+	return nil
+}
+
+// showMenu rebuilds the action list for the current state
+func (v *LifecycleView) showMenu() {
+	v.list.Clear()
+
+	status := "[red]" + i18n.T("instance.stopped") + "[-]"
+	if v.instance.IsRunning {
+		status = fmt.Sprintf("[green]%s[-] (PID: %d)", i18n.T("instance.running"), v.instance.PID)
+	}
+	v.list.AddItem(i18n.T("instance.status")+": "+status, v.instance.CatalinaBase, 0, nil)
+	v.list.AddItem("", "", 0, nil)
+
+	if v.instance.IsRunning {
+		v.list.AddItem("[red]"+i18n.T("lifecycle.stop")+"[-]", i18n.T("lifecycle.stop.desc"), 'o', func() {
+			v.runAction(i18n.T("lifecycle.stopping"), i18n.T("lifecycle.stopped"), v.controller.Stop)
+		})
+		v.list.AddItem("[yellow]"+i18n.T("lifecycle.restart")+"[-]", i18n.T("lifecycle.restart.desc"), 'r', func() {
+			v.runAction(i18n.T("lifecycle.restarting"), i18n.T("lifecycle.started"), v.controller.Restart)
+		})
+	} else {
+		v.list.AddItem("[green]"+i18n.T("lifecycle.start")+"[-]", i18n.T("lifecycle.start.desc"), 's', func() {
+			v.runAction(i18n.T("lifecycle.starting"), i18n.T("lifecycle.started"), v.controller.Start)
+		})
+		v.list.AddItem("[green]"+i18n.T("lifecycle.run")+"[-]", i18n.T("lifecycle.run.desc"), 'f', func() {
+			v.runAction(i18n.T("lifecycle.starting"), i18n.T("lifecycle.started"), v.controller.Run)
+		})
+	}
+	if v.checkpoint != nil {
+		count := 0
+		if changes, err := v.checkpoint.Changes(); err == nil {
+			count = len(changes)
+		}
+		v.list.AddItem("[yellow]"+i18n.T("lifecycle.apply")+"[-]", fmt.Sprintf(i18n.T("lifecycle.apply.desc"), count), 'a', func() {
+			if !v.busy {
+				v.showApplyForm()
+			}
+		})
+	}
+	v.list.AddItem(i18n.T("lifecycle.refresh"), i18n.T("lifecycle.refresh.desc"), 'u', func() {
+		v.controller.Refresh()
+		v.changed()
+	})
+	v.list.AddItem(i18n.T("lifecycle.clear"), i18n.T("lifecycle.clear.desc"), 'c', func() {
+		v.logPane.Clear()
+		v.logPane.ScrollToEnd()
+	})
+
+	v.list.AddItem("", "", 0, nil)
+	v.list.AddItem("[red]"+i18n.T("common.back")+"[-]", i18n.T("common.return"), 0, func() {
+		v.close()
+	})
+	v.list.SetCurrentItem(2)
+}
+
+// runAction runs a controller action in the background, as starting and
+// stopping wait for Tomcat
+func (v *LifecycleView) runAction(progress, done string, action func() error) {
+	if v.busy {
+		return
+	}
+	v.busy = true
+	v.setStatus("[yellow]" + progress + "[-]")
+	v.logPane.ScrollToEnd()
+
+	go func() {
+		err := action()
+		v.app.QueueUpdateDraw(func() {
+			v.busy = false
+			if err != nil {
+				fmt.Fprintln(v.logPane, "[red]"+tview.Escape(err.Error())+"[-]")
+				v.logPane.ScrollToEnd()
+				v.setStatus("[red]" + err.Error() + "[-]")
+			} else {
+				v.setStatus("[green]" + done + "[-]")
+			}
+			v.changed()
+		})
+	}()
+}
+
+// lifecycleApplyHelpKeysByIndex maps apply form items to help keys
+var lifecycleApplyHelpKeysByIndex = []string{
+	"help.lifecycle.apply.urls",
+	"help.lifecycle.apply.timeout",
+}
+
+// showApplyForm asks for the health URLs and the timeout, then applies the
+// changes since the checkpoint with a verified restart
+func (v *LifecycleView) showApplyForm() {
+	form := tview.NewForm()
+	helpPanel := NewDynamicHelpPanel()
+	preview := NewPreviewPanel()
+
+	var urls []string
+	if v.settings != nil {
+		urls = v.settings.GetHealthURLs(v.instance.CatalinaBase)
+	}
+	form.AddInputField(i18n.T("lifecycle.apply.urls"), strings.Join(urls, " "), 60, nil, nil)
+	form.AddInputField(i18n.T("lifecycle.apply.timeout"), strconv.Itoa(int(lifecycle.DefaultApplyTimeout/time.Second)), 10, acceptDigits, nil)
+
+	// The preview lists what will be applied and what is checked
+	var b strings.Builder
+	b.WriteString("[yellow]" + i18n.T("lifecycle.apply.changes") + "[-]\n")
+	changes, err := v.checkpoint.Changes()
+	switch {
+	case err != nil:
+		b.WriteString("[red]" + tview.Escape(err.Error()) + "[-]\n")
+	case len(changes) == 0:
+		b.WriteString("[gray]" + i18n.T("lifecycle.apply.nochanges") + "[-]\n")
+	}
+	for _, change := range changes {
+		fmt.Fprintf(&b, "  %-8s %s\n", change.Kind, tview.Escape(change.Path))
+	}
+	b.WriteString("\n[yellow]HealthCheckValve[-]\n")
+	valveURLs := lifecycle.HealthCheckURLs(v.instance.CatalinaHome, v.instance.CatalinaBase)
+	if len(valveURLs) == 0 {
+		b.WriteString("[gray]" + i18n.T("lifecycle.apply.novalve") + "[-]\n")
+	}
+	for _, u := range valveURLs {
+		b.WriteString("  " + tview.Escape(u) + "\n")
+	}
+	preview.SetPreview(b.String())
+
+	form.AddButton("[white:green]"+i18n.T("lifecycle.apply")+"[-:-]", func() {
+		urls := strings.Fields(strings.ReplaceAll(form.GetFormItem(0).(*tview.InputField).GetText(), ",", " "))
+		seconds, _ := strconv.Atoi(form.GetFormItem(1).(*tview.InputField).GetText())
+		if v.settings != nil {
+			v.settings.SetHealthURLs(v.instance.CatalinaBase, urls)
+			v.settings.Save()
+		}
+		v.closeApplyForm()
+
+		opts := lifecycle.ApplyOptions{
+			HealthURLs: urls,
+			Timeout:    time.Duration(seconds) * time.Second,
+		}
+		v.runAction(i18n.T("lifecycle.applying"), i18n.T("lifecycle.applied"), func() error {
+			_, err := v.controller.Apply(v.checkpoint, opts)
+			return err
+		})
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		v.closeApplyForm()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("lifecycle.apply.title") + " ").SetBorderColor(tcell.ColorDarkCyan)
+
+	// Update help when focus changes
+	lastFocusedIndex := -1
+	updateHelp := func(index int) {
+		if index >= 0 && index < len(lifecycleApplyHelpKeysByIndex) {
+			helpPanel.SetHelpKey(lifecycleApplyHelpKeysByIndex[index])
+		} else {
+			helpPanel.SetHelpKey("help.lifecycle.apply")
+		}
+	}
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.closeApplyForm()
+			return nil
+		}
+		go func() {
+			v.app.QueueUpdateDraw(func() {
+				idx, _ := form.GetFocusedItemIndex()
+				if idx != lastFocusedIndex {
+					lastFocusedIndex = idx
+					updateHelp(idx)
+				}
+			})
+		}()
+		return event
+	})
+	updateHelp(0)
+
+	v.mainPages.AddAndSwitchToPage("lifecycle-apply", CreateFormWithHelpAndPreview(form, helpPanel, preview), true)
+	v.app.SetFocus(form)
+}
+
+// closeApplyForm returns to the lifecycle menu
+func (v *LifecycleView) closeApplyForm() {
+	v.mainPages.RemovePage("lifecycle-apply")
+	v.mainPages.SwitchToPage("lifecycle")
+	v.app.SetFocus(v.list)
+}
+
+// changed refreshes the menu and tells the app about the new state
+func (v *LifecycleView) changed() {
+	v.showMenu()
+	if v.onChange != nil {
+		v.onChange()
+	}
+}
+
+// close returns to the main menu
+func (v *LifecycleView) close() {
+	v.mainPages.RemovePage("lifecycle")
+	if v.onReturn != nil {
+		v.onReturn()
+	}
+}
+
+// setStatus updates the status bar
+func (v *LifecycleView) setStatus(message string) {
+	if v.statusBar != nil {
+		v.statusBar.SetText(" " + message)
+	}
+}
@@ -0,0 +1,98 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
+	"github.com/playok/tomcatkit/internal/config/server"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/playok/tomcatkit/internal/ports"
+	"github.com/rivo/tview"
+)
+
+var (
+	portCheckMu      sync.Mutex
+	portCheckers     = make(map[string]*ports.Checker)
+	portCheckPending = make(map[string]bool)
+)
+
+// preparePortChecker rebuilds the port checker for an instance in the
+// background. Scanning sockets and other instances is slow, so it is done
+// when a view is entered rather than on every check; sockets and instances
+// that came or went since the last view are picked up this way.
+func preparePortChecker(catalinaBase string) {
+	portCheckMu.Lock()
+	defer portCheckMu.Unlock()
+	if portCheckPending[catalinaBase] {
+		return
+	}
+	// A stale checker would report ports that are free by now
+	delete(portCheckers, catalinaBase)
+	portCheckPending[catalinaBase] = true
+
+	go func() {
+		ownPID, others := ports.Discover(catalinaBase)
+		checker := ports.NewChecker(ownPID, others)
+
+		portCheckMu.Lock()
+		portCheckers[catalinaBase] = checker
+		delete(portCheckPending, catalinaBase)
+		portCheckMu.Unlock()
+	}()
+}
+
+// portChecker returns the checker for an instance, or nil while it is being built
+func portChecker(catalinaBase string) *ports.Checker {
+	portCheckMu.Lock()
+	defer portCheckMu.Unlock()
+	return portCheckers[catalinaBase]
+}
+
+// portConflictMessage returns a localized description of a conflict
+func portConflictMessage(c ports.Conflict) string {
+	switch c.Kind {
+	case ports.ConflictDuplicate:
+		return fmt.Sprintf(i18n.T("ports.conflict.duplicate"), c.Usage.Port, c.Detail)
+	case ports.ConflictBound:
+		return fmt.Sprintf(i18n.T("ports.conflict.bound"), c.Usage.Port, c.Detail)
+	default:
+		return fmt.Sprintf(i18n.T("ports.conflict.instance"), c.Usage.Port, c.Detail)
+	}
+}
+
+// checkPortField highlights a port input field that conflicts with another
+// port of this instance, a bound socket or another instance. It returns the
+// warning to show, or an empty string when the port is free.
+func checkPortField(field *tview.InputField, configService *server.ConfigService, kind ports.Kind, where string) string {
+	field.SetFieldTextColor(tview.Styles.PrimaryTextColor)
+
+	port, err := placeholder.Int(strings.TrimSpace(field.GetText())).Resolve(configService.Resolver())
+	if err != nil {
+		var unresolved *placeholder.UnresolvedError
+		if errors.As(err, &unresolved) {
+			field.SetFieldTextColor(tcell.ColorRed)
+			return fmt.Sprintf(i18n.T("placeholder.unresolved"), strings.Join(unresolved.Names, ", "))
+		}
+		return ""
+	}
+
+	base := configService.GetCatalinaBase()
+	checker := portChecker(base)
+	if checker == nil {
+		return ""
+	}
+
+	usage := ports.Usage{Port: port, Kind: kind, Where: where, UDP: kind == ports.KindMembership}
+	own := append(ports.Collect(configService.GetServer(), configService.Resolver()), ports.CollectJMX(base)...)
+	conflicts := checker.CheckUsage(usage, own)
+	if len(conflicts) == 0 {
+		return ""
+	}
+
+	field.SetFieldTextColor(tcell.ColorRed)
+	return portConflictMessage(conflicts[0])
+}
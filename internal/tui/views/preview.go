@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/connector"
 	"github.com/playok/tomcatkit/internal/config/jndi"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
 	"github.com/playok/tomcatkit/internal/config/server"
 	"github.com/playok/tomcatkit/internal/i18n"
 	"github.com/rivo/tview"
@@ -52,6 +54,7 @@ func highlightXML(xmlContent string) string {
 	// Highlight tag names
 	result = strings.ReplaceAll(result, "<Connector", "[yellow]<Connector[white]")
 	result = strings.ReplaceAll(result, "<Executor", "[yellow]<Executor[white]")
+	result = strings.ReplaceAll(result, "<UpgradeProtocol", "[yellow]<UpgradeProtocol[white]")
 	result = strings.ReplaceAll(result, "<Host", "[yellow]<Host[white]")
 	result = strings.ReplaceAll(result, "<Context", "[yellow]<Context[white]")
 	result = strings.ReplaceAll(result, "<Parameter", "[yellow]<Parameter[white]")
@@ -123,24 +126,26 @@ func CreateFormWithHelpAndPreview(form *tview.Form, helpPanel *DynamicHelpPanel,
 func GenerateConnectorXML(conn *server.Connector) string {
 	// Create a simplified view for preview
 	type ConnectorPreview struct {
-		XMLName           xml.Name `xml:"Connector"`
-		Port              int      `xml:"port,attr"`
-		Protocol          string   `xml:"protocol,attr,omitempty"`
-		ConnectionTimeout int      `xml:"connectionTimeout,attr,omitempty"`
-		RedirectPort      int      `xml:"redirectPort,attr,omitempty"`
-		MaxThreads        int      `xml:"maxThreads,attr,omitempty"`
-		MinSpareThreads   int      `xml:"minSpareThreads,attr,omitempty"`
-		AcceptCount       int      `xml:"acceptCount,attr,omitempty"`
-		Executor          string   `xml:"executor,attr,omitempty"`
-		SSLEnabled        bool     `xml:"SSLEnabled,attr,omitempty"`
-		Scheme            string   `xml:"scheme,attr,omitempty"`
-		Secure            bool     `xml:"secure,attr,omitempty"`
-		KeystoreFile      string   `xml:"keystoreFile,attr,omitempty"`
-		KeystoreType      string   `xml:"keystoreType,attr,omitempty"`
-		SSLProtocol       string   `xml:"sslProtocol,attr,omitempty"`
-		ClientAuth        string   `xml:"clientAuth,attr,omitempty"`
-		SecretRequired    bool     `xml:"secretRequired,attr,omitempty"`
-		Secret            string   `xml:"secret,attr,omitempty"`
+		XMLName           xml.Name                 `xml:"Connector"`
+		Port              placeholder.Int          `xml:"port,attr"`
+		Protocol          string                   `xml:"protocol,attr,omitempty"`
+		ConnectionTimeout placeholder.Int          `xml:"connectionTimeout,attr,omitempty"`
+		RedirectPort      placeholder.Int          `xml:"redirectPort,attr,omitempty"`
+		MaxThreads        placeholder.Int          `xml:"maxThreads,attr,omitempty"`
+		MinSpareThreads   placeholder.Int          `xml:"minSpareThreads,attr,omitempty"`
+		AcceptCount       placeholder.Int          `xml:"acceptCount,attr,omitempty"`
+		Executor          string                   `xml:"executor,attr,omitempty"`
+		SSLEnabled        placeholder.Bool         `xml:"SSLEnabled,attr,omitempty"`
+		Scheme            string                   `xml:"scheme,attr,omitempty"`
+		Secure            placeholder.Bool         `xml:"secure,attr,omitempty"`
+		KeystoreFile      string                   `xml:"keystoreFile,attr,omitempty"`
+		KeystoreType      string                   `xml:"keystoreType,attr,omitempty"`
+		SSLProtocol       string                   `xml:"sslProtocol,attr,omitempty"`
+		ClientAuth        string                   `xml:"clientAuth,attr,omitempty"`
+		SecretRequired    placeholder.Bool         `xml:"secretRequired,attr,omitempty"`
+		Secret            string                   `xml:"secret,attr,omitempty"`
+		Attributes        []xml.Attr               `xml:",any,attr"`
+		UpgradeProtocols  []server.UpgradeProtocol `xml:"UpgradeProtocol"`
 	}
 
 	preview := ConnectorPreview{
@@ -161,6 +166,22 @@ func GenerateConnectorXML(conn *server.Connector) string {
 		ClientAuth:        conn.ClientAuth,
 		SecretRequired:    conn.SecretRequired,
 		Secret:            conn.Secret,
+		UpgradeProtocols:  conn.UpgradeProtocols,
+	}
+
+	// Remaining catalogue attributes that are set on the connector
+	shown := map[string]bool{
+		"port": true, "protocol": true, "connectionTimeout": true, "redirectPort": true,
+		"maxThreads": true, "minSpareThreads": true, "acceptCount": true, "executor": true,
+		"scheme": true, "secure": true, "secretRequired": true, "secret": true,
+	}
+	for _, spec := range connector.Attributes() {
+		if shown[spec.Name] {
+			continue
+		}
+		if value := conn.GetAttribute(spec.Name); value != "" {
+			preview.Attributes = append(preview.Attributes, xml.Attr{Name: xml.Name{Local: spec.Name}, Value: value})
+		}
 	}
 
 	output, err := xml.MarshalIndent(preview, "", "    ")
@@ -174,14 +195,14 @@ func GenerateConnectorXML(conn *server.Connector) string {
 // GenerateExecutorXML generates XML preview for an executor
 func GenerateExecutorXML(exec *server.Executor) string {
 	type ExecutorPreview struct {
-		XMLName         xml.Name `xml:"Executor"`
-		ClassName       string   `xml:"className,attr,omitempty"`
-		Name            string   `xml:"name,attr"`
-		NamePrefix      string   `xml:"namePrefix,attr,omitempty"`
-		MaxThreads      int      `xml:"maxThreads,attr,omitempty"`
-		MinSpareThreads int      `xml:"minSpareThreads,attr,omitempty"`
-		MaxIdleTime     int      `xml:"maxIdleTime,attr,omitempty"`
-		MaxQueueSize    int      `xml:"maxQueueSize,attr,omitempty"`
+		XMLName         xml.Name        `xml:"Executor"`
+		ClassName       string          `xml:"className,attr,omitempty"`
+		Name            string          `xml:"name,attr"`
+		NamePrefix      string          `xml:"namePrefix,attr,omitempty"`
+		MaxThreads      placeholder.Int `xml:"maxThreads,attr,omitempty"`
+		MinSpareThreads placeholder.Int `xml:"minSpareThreads,attr,omitempty"`
+		MaxIdleTime     placeholder.Int `xml:"maxIdleTime,attr,omitempty"`
+		MaxQueueSize    placeholder.Int `xml:"maxQueueSize,attr,omitempty"`
 	}
 
 	preview := ExecutorPreview{
@@ -205,12 +226,12 @@ func GenerateExecutorXML(exec *server.Executor) string {
 // GenerateHostXML generates XML preview for a host
 func GenerateHostXML(host *server.Host) string {
 	type HostPreview struct {
-		XMLName         xml.Name `xml:"Host"`
-		Name            string   `xml:"name,attr"`
-		AppBase         string   `xml:"appBase,attr,omitempty"`
-		UnpackWARs      bool     `xml:"unpackWARs,attr,omitempty"`
-		AutoDeploy      bool     `xml:"autoDeploy,attr,omitempty"`
-		DeployOnStartup bool     `xml:"deployOnStartup,attr,omitempty"`
+		XMLName         xml.Name         `xml:"Host"`
+		Name            string           `xml:"name,attr"`
+		AppBase         string           `xml:"appBase,attr,omitempty"`
+		UnpackWARs      placeholder.Bool `xml:"unpackWARs,attr,omitempty"`
+		AutoDeploy      placeholder.Bool `xml:"autoDeploy,attr,omitempty"`
+		DeployOnStartup placeholder.Bool `xml:"deployOnStartup,attr,omitempty"`
 	}
 
 	preview := HostPreview{
@@ -232,9 +253,9 @@ func GenerateHostXML(host *server.Host) string {
 // GenerateServerXML generates XML preview for server settings
 func GenerateServerXML(srv *server.Server) string {
 	type ServerPreview struct {
-		XMLName  xml.Name `xml:"Server"`
-		Port     int      `xml:"port,attr"`
-		Shutdown string   `xml:"shutdown,attr"`
+		XMLName  xml.Name        `xml:"Server"`
+		Port     placeholder.Int `xml:"port,attr"`
+		Shutdown string          `xml:"shutdown,attr"`
 	}
 
 	preview := ServerPreview{
@@ -367,22 +388,22 @@ func GenerateResourceXML(res *server.Resource) string {
 // GenerateContextXML generates XML preview for a context
 func GenerateContextXML(ctx *server.Context) string {
 	type ContextPreview struct {
-		XMLName             xml.Name `xml:"Context"`
-		Path                string   `xml:"path,attr"`
-		DocBase             string   `xml:"docBase,attr,omitempty"`
-		Reloadable          bool     `xml:"reloadable,attr,omitempty"`
-		CrossContext        bool     `xml:"crossContext,attr,omitempty"`
-		Privileged          bool     `xml:"privileged,attr,omitempty"`
-		Cookies             bool     `xml:"cookies,attr,omitempty"`
-		SessionCookieName   string   `xml:"sessionCookieName,attr,omitempty"`
-		SessionCookiePath   string   `xml:"sessionCookiePath,attr,omitempty"`
-		SessionCookieDomain string   `xml:"sessionCookieDomain,attr,omitempty"`
-		UseHttpOnly         bool     `xml:"useHttpOnly,attr,omitempty"`
-		AntiResourceLocking bool     `xml:"antiResourceLocking,attr,omitempty"`
-		SwallowOutput       bool     `xml:"swallowOutput,attr,omitempty"`
-		CachingAllowed      bool     `xml:"cachingAllowed,attr,omitempty"`
-		CacheMaxSize        int      `xml:"cacheMaxSize,attr,omitempty"`
-		CacheTTL            int      `xml:"cacheTTL,attr,omitempty"`
+		XMLName             xml.Name         `xml:"Context"`
+		Path                string           `xml:"path,attr"`
+		DocBase             string           `xml:"docBase,attr,omitempty"`
+		Reloadable          placeholder.Bool `xml:"reloadable,attr,omitempty"`
+		CrossContext        placeholder.Bool `xml:"crossContext,attr,omitempty"`
+		Privileged          placeholder.Bool `xml:"privileged,attr,omitempty"`
+		Cookies             placeholder.Bool `xml:"cookies,attr,omitempty"`
+		SessionCookieName   string           `xml:"sessionCookieName,attr,omitempty"`
+		SessionCookiePath   string           `xml:"sessionCookiePath,attr,omitempty"`
+		SessionCookieDomain string           `xml:"sessionCookieDomain,attr,omitempty"`
+		UseHttpOnly         placeholder.Bool `xml:"useHttpOnly,attr,omitempty"`
+		AntiResourceLocking placeholder.Bool `xml:"antiResourceLocking,attr,omitempty"`
+		SwallowOutput       placeholder.Bool `xml:"swallowOutput,attr,omitempty"`
+		CachingAllowed      placeholder.Bool `xml:"cachingAllowed,attr,omitempty"`
+		CacheMaxSize        placeholder.Int  `xml:"cacheMaxSize,attr,omitempty"`
+		CacheTTL            placeholder.Int  `xml:"cacheTTL,attr,omitempty"`
 	}
 
 	preview := ContextPreview{
@@ -414,11 +435,11 @@ func GenerateContextXML(ctx *server.Context) string {
 // GenerateParameterXML generates XML preview for a parameter
 func GenerateParameterXML(param *server.Parameter) string {
 	type ParameterPreview struct {
-		XMLName     xml.Name `xml:"Parameter"`
-		Name        string   `xml:"name,attr"`
-		Value       string   `xml:"value,attr"`
-		Override    bool     `xml:"override,attr,omitempty"`
-		Description string   `xml:"description,attr,omitempty"`
+		XMLName     xml.Name         `xml:"Parameter"`
+		Name        string           `xml:"name,attr"`
+		Value       string           `xml:"value,attr"`
+		Override    placeholder.Bool `xml:"override,attr,omitempty"`
+		Description string           `xml:"description,attr,omitempty"`
 	}
 
 	preview := ParameterPreview{
@@ -439,13 +460,13 @@ func GenerateParameterXML(param *server.Parameter) string {
 // GenerateManagerXML generates XML preview for a manager
 func GenerateManagerXML(mgr *server.Manager) string {
 	type ManagerPreview struct {
-		XMLName                 xml.Name `xml:"Manager"`
-		ClassName               string   `xml:"className,attr,omitempty"`
-		MaxActiveSessions       int      `xml:"maxActiveSessions,attr,omitempty"`
-		SessionIdLength         int      `xml:"sessionIdLength,attr,omitempty"`
-		MaxInactiveInterval     int      `xml:"maxInactiveInterval,attr,omitempty"`
-		Pathname                string   `xml:"pathname,attr,omitempty"`
-		ProcessExpiresFrequency int      `xml:"processExpiresFrequency,attr,omitempty"`
+		XMLName                 xml.Name        `xml:"Manager"`
+		ClassName               string          `xml:"className,attr,omitempty"`
+		MaxActiveSessions       placeholder.Int `xml:"maxActiveSessions,attr,omitempty"`
+		SessionIdLength         placeholder.Int `xml:"sessionIdLength,attr,omitempty"`
+		MaxInactiveInterval     placeholder.Int `xml:"maxInactiveInterval,attr,omitempty"`
+		Pathname                string          `xml:"pathname,attr,omitempty"`
+		ProcessExpiresFrequency placeholder.Int `xml:"processExpiresFrequency,attr,omitempty"`
 	}
 
 	preview := ManagerPreview{
@@ -551,28 +572,28 @@ func GenerateResourceLinkXML(link *jndi.ResourceLink) string {
 // GenerateContextSettingsXML generates XML preview for context settings (from context.xml)
 func GenerateContextSettingsXML(ctx *jndi.Context) string {
 	type ContextSettingsPreview struct {
-		XMLName             xml.Name `xml:"Context"`
-		Reloadable          bool     `xml:"reloadable,attr,omitempty"`
-		CrossContext        bool     `xml:"crossContext,attr,omitempty"`
-		Privileged          bool     `xml:"privileged,attr,omitempty"`
-		Cookies             string   `xml:"cookies,attr,omitempty"`
-		UseHttpOnly         string   `xml:"useHttpOnly,attr,omitempty"`
-		SessionCookieName   string   `xml:"sessionCookieName,attr,omitempty"`
-		CachingAllowed      string   `xml:"cachingAllowed,attr,omitempty"`
-		CacheMaxSize        int      `xml:"cacheMaxSize,attr,omitempty"`
-		AntiResourceLocking string   `xml:"antiResourceLocking,attr,omitempty"`
-		SwallowOutput       string   `xml:"swallowOutput,attr,omitempty"`
+		XMLName             xml.Name         `xml:"Context"`
+		Reloadable          placeholder.Bool `xml:"reloadable,attr,omitempty"`
+		CrossContext        placeholder.Bool `xml:"crossContext,attr,omitempty"`
+		Privileged          placeholder.Bool `xml:"privileged,attr,omitempty"`
+		Cookies             string           `xml:"cookies,attr,omitempty"`
+		UseHttpOnly         string           `xml:"useHttpOnly,attr,omitempty"`
+		SessionCookieName   string           `xml:"sessionCookieName,attr,omitempty"`
+		CachingAllowed      string           `xml:"cachingAllowed,attr,omitempty"`
+		CacheMaxSize        placeholder.Int  `xml:"cacheMaxSize,attr,omitempty"`
+		AntiResourceLocking string           `xml:"antiResourceLocking,attr,omitempty"`
+		SwallowOutput       string           `xml:"swallowOutput,attr,omitempty"`
 	}
 
 	preview := ContextSettingsPreview{
-		Reloadable:          ctx.Reloadable,
-		CrossContext:        ctx.CrossContext,
-		Privileged:          ctx.Privileged,
+		Reloadable:          placeholder.BoolOf(ctx.Reloadable),
+		CrossContext:        placeholder.BoolOf(ctx.CrossContext),
+		Privileged:          placeholder.BoolOf(ctx.Privileged),
 		Cookies:             ctx.Cookies,
 		UseHttpOnly:         ctx.UseHttpOnly,
 		SessionCookieName:   ctx.SessionCookieName,
 		CachingAllowed:      ctx.CachingAllowed,
-		CacheMaxSize:        ctx.CacheMaxSize,
+		CacheMaxSize:        placeholder.IntOf(ctx.CacheMaxSize),
 		AntiResourceLocking: ctx.AntiResourceLocking,
 		SwallowOutput:       ctx.SwallowOutput,
 	}
@@ -588,17 +609,17 @@ func GenerateContextSettingsXML(ctx *jndi.Context) string {
 // GenerateContextParameterXML generates XML preview for a context parameter
 func GenerateContextParameterXML(param *jndi.ContextParameter) string {
 	type ParameterPreview struct {
-		XMLName     xml.Name `xml:"Parameter"`
-		Name        string   `xml:"name,attr"`
-		Value       string   `xml:"value,attr"`
-		Override    bool     `xml:"override,attr,omitempty"`
-		Description string   `xml:"description,attr,omitempty"`
+		XMLName     xml.Name         `xml:"Parameter"`
+		Name        string           `xml:"name,attr"`
+		Value       string           `xml:"value,attr"`
+		Override    placeholder.Bool `xml:"override,attr,omitempty"`
+		Description string           `xml:"description,attr,omitempty"`
 	}
 
 	preview := ParameterPreview{
 		Name:        param.Name,
 		Value:       param.Value,
-		Override:    param.Override,
+		Override:    placeholder.BoolOf(param.Override),
 		Description: param.Description,
 	}
 
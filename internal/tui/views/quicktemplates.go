@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/connector"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
 	"github.com/playok/tomcatkit/internal/config/server"
 	"github.com/playok/tomcatkit/internal/i18n"
 	"github.com/rivo/tview"
@@ -55,9 +57,15 @@ func (v *QuickTemplatesView) showMainMenu() {
 		AddItem("[::b]"+i18n.T("qt.https")+"[::-]", i18n.T("qt.https.desc"), 's', func() {
 			v.showHTTPSTemplate()
 		}).
+		AddItem("[::b]"+i18n.T("qt.http2")+"[::-]", i18n.T("qt.http2.desc"), '2', func() {
+			v.showHTTP2Template()
+		}).
 		AddItem("[::b]"+i18n.T("qt.connpool")+"[::-]", i18n.T("qt.connpool.desc"), 'p', func() {
 			v.showConnectionPoolTemplate()
 		}).
+		AddItem("[::b]"+i18n.T("qt.capacity")+"[::-]", i18n.T("qt.capacity.desc"), 'c', func() {
+			v.showCapacityPlanner()
+		}).
 		AddItem("[::b]"+i18n.T("qt.gzip")+"[::-]", i18n.T("qt.gzip.desc"), 'g', func() {
 			v.showGzipTemplate()
 		}).
@@ -116,11 +124,11 @@ func (v *QuickTemplatesView) showVirtualThreadTemplate() {
 
 	// Get available connectors for selection
 	var connectorOptions []string
-	var connectorPorts []int
+	var connectorPorts []placeholder.Int
 	if len(cfg.Services) > 0 {
 		for _, conn := range cfg.Services[0].Connectors {
 			if conn.Protocol == "" || strings.Contains(conn.Protocol, "HTTP") {
-				connectorOptions = append(connectorOptions, fmt.Sprintf("Port %d (%s)", conn.Port, conn.Protocol))
+				connectorOptions = append(connectorOptions, fmt.Sprintf("Port %s (%s)", conn.Port, conn.Protocol))
 				connectorPorts = append(connectorPorts, conn.Port)
 			}
 		}
@@ -244,21 +252,21 @@ func (v *QuickTemplatesView) showHTTPSTemplate() {
 
 		// Create HTTPS connector
 		connector := &server.Connector{
-			Port:              8443,
+			Port:              placeholder.IntOf(8443),
 			Protocol:          "org.apache.coyote.http11.Http11NioProtocol",
-			SSLEnabled:        true,
+			SSLEnabled:        placeholder.BoolOf(true),
 			Scheme:            "https",
-			Secure:            true,
+			Secure:            placeholder.BoolOf(true),
 			KeystoreFile:      keystoreFile,
 			KeystorePass:      keystorePass,
 			KeystoreType:      keystoreTypes[keystoreTypeIdx],
-			MaxThreads:        150,
-			ConnectionTimeout: 20000,
+			MaxThreads:        placeholder.IntOf(150),
+			ConnectionTimeout: placeholder.IntOf(20000),
 		}
 
 		// Parse port
 		if p := parsePort(port); p > 0 {
-			connector.Port = p
+			connector.Port = placeholder.IntOf(p)
 		}
 
 		// Add to first service
@@ -296,6 +304,109 @@ func (v *QuickTemplatesView) showHTTPSTemplate() {
 	v.pages.AddAndSwitchToPage("https-template", flex, true)
 }
 
+// showHTTP2Template shows the template that enables HTTP/2 on an existing TLS connector
+func (v *QuickTemplatesView) showHTTP2Template() {
+	cfg := v.configService.GetServer()
+
+	// Collect TLS connectors from all services
+	type connectorRef struct {
+		service   int
+		connector int
+	}
+	var connectorOptions []string
+	var connectorRefs []connectorRef
+	for si, svc := range cfg.Services {
+		for ci, conn := range svc.Connectors {
+			if !conn.SSLEnabled.Value(v.configService.Resolver()) {
+				continue
+			}
+			label := fmt.Sprintf("Port %s (%s)", conn.Port, svc.Name)
+			if connector.HasHTTP2(&conn) {
+				label += " - h2"
+			}
+			connectorOptions = append(connectorOptions, label)
+			connectorRefs = append(connectorRefs, connectorRef{service: si, connector: ci})
+		}
+	}
+
+	form := tview.NewForm()
+	defaults := connector.DefaultHTTP2UpgradeProtocol()
+
+	selectedConnector := 0
+	if len(connectorOptions) > 0 {
+		form.AddDropDown("TLS Connector", connectorOptions, 0, func(option string, index int) {
+			selectedConnector = index
+		})
+	} else {
+		form.AddTextView("Warning", "[red]"+i18n.T("qt.http2.notls")+"[white]", 50, 2, false, false)
+	}
+
+	maxStreams := defaults.MaxConcurrentStreams.String()
+	form.AddInputField("Max Concurrent Streams", maxStreams, 10, acceptNumber, func(text string) {
+		maxStreams = text
+	})
+
+	windowSize := defaults.InitialWindowSize.String()
+	form.AddInputField("Initial Window Size", windowSize, 10, acceptNumber, func(text string) {
+		windowSize = text
+	})
+
+	readTimeout := defaults.ReadTimeout.String()
+	form.AddInputField("Read Timeout (ms)", readTimeout, 10, acceptNumber, func(text string) {
+		readTimeout = text
+	})
+
+	keepAliveTimeout := defaults.KeepAliveTimeout.String()
+	form.AddInputField("Keep-Alive Timeout (ms)", keepAliveTimeout, 10, acceptNumber, func(text string) {
+		keepAliveTimeout = text
+	})
+
+	form.AddButton("[white:green]Apply Template[-:-]", func() {
+		if len(connectorRefs) == 0 {
+			v.setStatus(i18n.T("qt.http2.notls"))
+			return
+		}
+
+		ref := connectorRefs[selectedConnector]
+		conn := &cfg.Services[ref.service].Connectors[ref.connector]
+
+		upgrade := defaults
+		upgrade.MaxConcurrentStreams = placeholder.IntOf(parsePort(maxStreams))
+		upgrade.InitialWindowSize = placeholder.IntOf(parsePort(windowSize))
+		upgrade.ReadTimeout = placeholder.IntOf(parsePort(readTimeout))
+		upgrade.KeepAliveTimeout = placeholder.IntOf(parsePort(keepAliveTimeout))
+		connector.EnableHTTP2(conn, upgrade)
+
+		if err := v.configService.Save(); err != nil {
+			v.setStatus("Error saving: " + err.Error())
+			return
+		}
+
+		v.setStatus(fmt.Sprintf(i18n.T("qt.http2.success"), conn.Port))
+		v.showMainMenu()
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		v.showMainMenu()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" HTTP/2 Template ")
+
+	// Create layout with form and help panel
+	flex := CreateFormWithHelp(form, "help.qt.http2", "")
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.showMainMenu()
+			return nil
+		}
+		return event
+	})
+
+	v.pages.AddAndSwitchToPage("http2-template", flex, true)
+}
+
 // showConnectionPoolTemplate shows the connection pool tuning template
 func (v *QuickTemplatesView) showConnectionPoolTemplate() {
 	cfg := v.configService.GetServer()
@@ -336,10 +447,10 @@ func (v *QuickTemplatesView) showConnectionPoolTemplate() {
 			for i := range cfg.Services[0].Connectors {
 				conn := &cfg.Services[0].Connectors[i]
 				if conn.Protocol == "" || strings.Contains(conn.Protocol, "HTTP") {
-					conn.MaxThreads = maxT
-					conn.MinSpareThreads = minS
-					conn.AcceptCount = accC
-					conn.ConnectionTimeout = connT
+					conn.MaxThreads = placeholder.IntOf(maxT)
+					conn.MinSpareThreads = placeholder.IntOf(minS)
+					conn.AcceptCount = placeholder.IntOf(accC)
+					conn.ConnectionTimeout = placeholder.IntOf(connT)
 				}
 			}
 		}
@@ -389,16 +500,7 @@ func (v *QuickTemplatesView) showGzipTemplate() {
 		minS := parsePort(minSize)
 
 		// Update all HTTP connectors with compression settings
-		if len(cfg.Services) > 0 {
-			for i := range cfg.Services[0].Connectors {
-				conn := &cfg.Services[0].Connectors[i]
-				if conn.Protocol == "" || strings.Contains(conn.Protocol, "HTTP") {
-					conn.Compression = "on"
-					conn.CompressionMinSize = minS
-					conn.CompressibleMimeType = "text/html,text/xml,text/plain,text/css,text/javascript,application/javascript,application/json,application/xml"
-				}
-			}
-		}
+		server.EnableCompression(cfg, minS)
 
 		if err := v.configService.Save(); err != nil {
 			v.setStatus("Error saving: " + err.Error())
@@ -466,7 +568,7 @@ func (v *QuickTemplatesView) showAccessLogTemplate() {
 			Prefix:    prefix,
 			Suffix:    suffix,
 			Pattern:   pattern,
-			Rotatable: true,
+			Rotatable: placeholder.BoolOf(true),
 		}
 		previewPanel.SetXMLPreview(GenerateValveXML(&valve))
 	}
@@ -523,7 +625,7 @@ func (v *QuickTemplatesView) showAccessLogTemplate() {
 			Prefix:    prefix,
 			Suffix:    suffix,
 			Pattern:   pattern,
-			Rotatable: true,
+			Rotatable: placeholder.BoolOf(true),
 		}
 
 		// Add to default host
@@ -588,46 +690,12 @@ func (v *QuickTemplatesView) showSecurityHardeningTemplate() {
 	form.AddButton("[white:green]Apply Template[-:-]", func() {
 		cfg := v.configService.GetServer()
 
-		// Disable shutdown port
-		if disableShutdown {
-			cfg.Port = -1
-			cfg.Shutdown = "DISABLED_" + generateRandomString(8)
-		}
-
-		// Add security listener
-		if addSecurityListener {
-			hasSecurityListener := false
-			for _, l := range cfg.Listeners {
-				if l.ClassName == "org.apache.catalina.security.SecurityListener" {
-					hasSecurityListener = true
-					break
-				}
-			}
-			if !hasSecurityListener {
-				cfg.Listeners = append(cfg.Listeners, server.Listener{
-					ClassName: "org.apache.catalina.security.SecurityListener",
-				})
-			}
-		}
-
-		// Add error report valve with showServerInfo=false
-		if removeServerInfo && len(cfg.Services) > 0 {
-			for i := range cfg.Services[0].Engine.Hosts {
-				hasErrorValve := false
-				for j := range cfg.Services[0].Engine.Hosts[i].Valves {
-					if cfg.Services[0].Engine.Hosts[i].Valves[j].ClassName == "org.apache.catalina.valves.ErrorReportValve" {
-						cfg.Services[0].Engine.Hosts[i].Valves[j].ShowServerInfo = false
-						hasErrorValve = true
-					}
-				}
-				if !hasErrorValve {
-					cfg.Services[0].Engine.Hosts[i].Valves = append(cfg.Services[0].Engine.Hosts[i].Valves, server.Valve{
-						ClassName:      "org.apache.catalina.valves.ErrorReportValve",
-						ShowServerInfo: false,
-					})
-				}
-			}
-		}
+		server.HardenSecurity(cfg, server.Hardening{
+			DisableShutdown:  disableShutdown,
+			ShutdownCommand:  "DISABLED_" + generateRandomString(8),
+			HideServerInfo:   removeServerInfo,
+			SecurityListener: addSecurityListener,
+		})
 
 		if err := v.configService.Save(); err != nil {
 			v.setStatus("Error saving: " + err.Error())
@@ -712,13 +780,13 @@ func (v *QuickTemplatesView) showApacheHttpdTemplate() {
 
 		// Create AJP connector
 		connector := &server.Connector{
-			Port:              parsePort(ajpPort),
+			Port:              placeholder.IntOf(parsePort(ajpPort)),
 			Protocol:          "AJP/1.3",
 			Address:           address,
-			SecretRequired:    true,
+			SecretRequired:    placeholder.BoolOf(true),
 			Secret:            secret,
-			RedirectPort:      8443,
-			ConnectionTimeout: 20000,
+			RedirectPort:      placeholder.IntOf(8443),
+			ConnectionTimeout: placeholder.IntOf(20000),
 		}
 
 		// Add to first service
@@ -852,8 +920,8 @@ func (v *QuickTemplatesView) showNginxProxyTemplate() {
 	if len(cfg.Services) > 0 {
 		for _, conn := range cfg.Services[0].Connectors {
 			if conn.Protocol == "" || strings.Contains(conn.Protocol, "HTTP") {
-				httpPorts = append(httpPorts, fmt.Sprintf("Port %d", conn.Port))
-				httpPortValues = append(httpPortValues, conn.Port)
+				httpPorts = append(httpPorts, fmt.Sprintf("Port %s", conn.Port))
+				httpPortValues = append(httpPortValues, conn.Port.Value(v.configService.Resolver()))
 			}
 		}
 	}
@@ -1041,8 +1109,8 @@ func (v *QuickTemplatesView) showHAProxyTemplate() {
 	if len(cfg.Services) > 0 {
 		for _, conn := range cfg.Services[0].Connectors {
 			if conn.Protocol == "" || strings.Contains(conn.Protocol, "HTTP") {
-				httpPorts = append(httpPorts, fmt.Sprintf("Port %d", conn.Port))
-				httpPortValues = append(httpPortValues, conn.Port)
+				httpPorts = append(httpPorts, fmt.Sprintf("Port %s", conn.Port))
+				httpPortValues = append(httpPortValues, conn.Port.Value(v.configService.Resolver()))
 			}
 		}
 	}
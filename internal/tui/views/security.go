@@ -2,7 +2,6 @@ package views
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/playok/tomcatkit/internal/config/realm"
@@ -928,14 +927,20 @@ func (v *SecurityView) showCredentialHandler() {
 		indexOf(r.CredentialHandler.ClassName, realm.CredentialHandlerClasses()), nil)
 	form.AddDropDown("Algorithm", realm.CredentialHandlerAlgorithms(),
 		indexOf(r.CredentialHandler.Algorithm, realm.CredentialHandlerAlgorithms()), nil)
-	form.AddInputField("Iterations", strconv.Itoa(r.CredentialHandler.Iterations), 10, acceptDigits, nil)
-	form.AddInputField("Salt Length", strconv.Itoa(r.CredentialHandler.SaltLength), 10, acceptDigits, nil)
+	form.AddInputField("Iterations", r.CredentialHandler.Iterations.Text(), 10, acceptIntAttr, nil)
+	form.AddInputField("Salt Length", r.CredentialHandler.SaltLength.Text(), 10, acceptIntAttr, nil)
 
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
+		var ints formInts
 		_, r.CredentialHandler.ClassName = form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
 		_, r.CredentialHandler.Algorithm = form.GetFormItem(1).(*tview.DropDown).GetCurrentOption()
-		r.CredentialHandler.Iterations, _ = strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText())
-		r.CredentialHandler.SaltLength, _ = strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText())
+		r.CredentialHandler.Iterations = ints.Int(form.GetFormItem(2), r.CredentialHandler.Iterations)
+		r.CredentialHandler.SaltLength = ints.Int(form.GetFormItem(3), r.CredentialHandler.SaltLength)
+
+		if ints.err != nil {
+			v.showError(ints.err.Error())
+			return
+		}
 
 		if err := v.configService.Save(); err != nil {
 			v.showError(fmt.Sprintf("Failed to save: %v", err))
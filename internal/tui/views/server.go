@@ -5,8 +5,11 @@ import (
 	"strconv"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/connector"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
 	"github.com/playok/tomcatkit/internal/config/server"
 	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/playok/tomcatkit/internal/ports"
 	"github.com/rivo/tview"
 )
 
@@ -43,6 +46,7 @@ type ServerView struct {
 
 // NewServerView creates a new server configuration view
 func NewServerView(app *tview.Application, pages *tview.Pages, configService *server.ConfigService, statusBar *tview.TextView, onBack func()) *ServerView {
+	preparePortChecker(configService.GetCatalinaBase())
 	return &ServerView{
 		app:           app,
 		pages:         pages,
@@ -155,23 +159,26 @@ func (v *ServerView) showServerSettings() {
 		}
 
 		if port, err := strconv.Atoi(form.GetFormItem(0).(*tview.InputField).GetText()); err == nil {
-			tempSrv.Port = port
+			tempSrv.Port = placeholder.IntOf(port)
 		}
 		tempSrv.Shutdown = form.GetFormItem(1).(*tview.InputField).GetText()
 
 		previewPanel.SetXMLPreview(GenerateServerXML(&tempSrv))
 	}
 
-	form.AddInputField(i18n.T("server.port"), strconv.Itoa(srv.Port), 10, func(text string, lastChar rune) bool {
+	form.AddInputField(i18n.T("server.port"), srv.Port.Text(), 10, func(text string, lastChar rune) bool {
 		// Only allow digits
 		return lastChar >= '0' && lastChar <= '9'
 	}, func(text string) {
 		updatePreview()
+		v.checkPort(form.GetFormItem(0).(*tview.InputField))
 	})
 	form.AddInputField(i18n.T("server.shutdown"), srv.Shutdown, 20, nil, func(text string) {
 		updatePreview()
 	})
+	v.checkPort(form.GetFormItem(0).(*tview.InputField))
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save")+"[-:-]", func() {
 		portStr := form.GetFormItem(0).(*tview.InputField).GetText()
 		shutdown := form.GetFormItem(1).(*tview.InputField).GetText()
@@ -328,6 +335,7 @@ func (v *ServerView) showListenerDetail(index int) {
 		})
 	}
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save")+"[-:-]", func() {
 		className := form.GetFormItem(0).(*tview.InputField).GetText()
 		listeners[index].ClassName = className
@@ -596,6 +604,7 @@ func (v *ServerView) showEditServiceName(index int) {
 		updatePreview()
 	})
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save")+"[-:-]", func() {
 		name := form.GetFormItem(0).(*tview.InputField).GetText()
 		svc.Name = name
@@ -696,6 +705,7 @@ func (v *ServerView) showEngineSettings(serviceIndex int) {
 		updatePreview()
 	})
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save")+"[-:-]", func() {
 		svc.Engine.Name = form.GetFormItem(0).(*tview.InputField).GetText()
 		svc.Engine.DefaultHost = form.GetFormItem(1).(*tview.InputField).GetText()
@@ -817,13 +827,13 @@ func (v *ServerView) showExecutorDetail(serviceIndex, executorIndex int) {
 		tempExec.Name = form.GetFormItem(0).(*tview.InputField).GetText()
 		tempExec.NamePrefix = form.GetFormItem(1).(*tview.InputField).GetText()
 		if maxThreads, err := strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText()); err == nil {
-			tempExec.MaxThreads = maxThreads
+			tempExec.MaxThreads = placeholder.IntOf(maxThreads)
 		}
 		if minThreads, err := strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText()); err == nil {
-			tempExec.MinSpareThreads = minThreads
+			tempExec.MinSpareThreads = placeholder.IntOf(minThreads)
 		}
 		if maxIdle, err := strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText()); err == nil {
-			tempExec.MaxIdleTime = maxIdle
+			tempExec.MaxIdleTime = placeholder.IntOf(maxIdle)
 		}
 
 		preview.SetXMLPreview(GenerateExecutorXML(&tempExec))
@@ -835,22 +845,41 @@ func (v *ServerView) showExecutorDetail(serviceIndex, executorIndex int) {
 	form.AddInputField(i18n.T("server.executor.prefix"), exec.NamePrefix, 30, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("server.executor.maxthreads"), strconv.Itoa(exec.MaxThreads), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("server.executor.maxthreads"), exec.MaxThreads.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("server.executor.minthreads"), strconv.Itoa(exec.MinSpareThreads), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("server.executor.minthreads"), exec.MinSpareThreads.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField(i18n.T("server.executor.maxidle"), strconv.Itoa(exec.MaxIdleTime), 10, acceptDigits, func(text string) {
+	form.AddInputField(i18n.T("server.executor.maxidle"), exec.MaxIdleTime.Text(), 10, acceptIntAttr, func(text string) {
 		updatePreview()
 	})
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save")+"[-:-]", func() {
-		exec.Name = form.GetFormItem(0).(*tview.InputField).GetText()
+		var ints formInts
+		maxThreads := ints.Int(form.GetFormItem(2), exec.MaxThreads)
+		minSpareThreads := ints.Int(form.GetFormItem(3), exec.MinSpareThreads)
+		maxIdleTime := ints.Int(form.GetFormItem(4), exec.MaxIdleTime)
+		if ints.err != nil {
+			v.showError(ints.err.Error())
+			return
+		}
+
+		newName := form.GetFormItem(0).(*tview.InputField).GetText()
+		if newName != exec.Name && connector.FindExecutor(svc, newName) != nil {
+			v.showError(fmt.Sprintf(i18n.T("connector.executor.exists"), newName))
+			return
+		}
+		// Keeps connectors pointing at the renamed executor
+		if err := connector.RenameExecutor(svc, executorIndex, newName); err != nil {
+			v.showError(err.Error())
+			return
+		}
 		exec.NamePrefix = form.GetFormItem(1).(*tview.InputField).GetText()
-		exec.MaxThreads, _ = strconv.Atoi(form.GetFormItem(2).(*tview.InputField).GetText())
-		exec.MinSpareThreads, _ = strconv.Atoi(form.GetFormItem(3).(*tview.InputField).GetText())
-		exec.MaxIdleTime, _ = strconv.Atoi(form.GetFormItem(4).(*tview.InputField).GetText())
+		exec.MaxThreads = maxThreads
+		exec.MinSpareThreads = minSpareThreads
+		exec.MaxIdleTime = maxIdleTime
 
 		v.configService.UpdateService(serviceIndex, *svc)
 
@@ -864,9 +893,16 @@ func (v *ServerView) showExecutorDetail(serviceIndex, executorIndex int) {
 	})
 
 	form.AddButton("[white:red]"+i18n.T("common.delete")+"[-:-]", func() {
+		if users := executorUserPorts(svc, exec.Name); users != "" {
+			v.showError(fmt.Sprintf(i18n.T("connector.executor.inuse"), exec.Name, users))
+			return
+		}
 		v.showConfirm(i18n.T("common.delete"), i18n.T("server.confirm.delete"), func(confirmed bool) {
 			if confirmed {
-				svc.Executors = append(svc.Executors[:executorIndex], svc.Executors[executorIndex+1:]...)
+				if err := connector.RemoveExecutor(svc, executorIndex); err != nil {
+					v.showError(err.Error())
+					return
+				}
 				v.configService.UpdateService(serviceIndex, *svc)
 				if err := v.configService.Save(); err != nil {
 					v.showError(fmt.Sprintf("Failed to save: %v", err))
@@ -920,9 +956,9 @@ func (v *ServerView) showAddExecutor(serviceIndex int) {
 		exec := server.Executor{
 			Name:            form.GetFormItem(0).(*tview.InputField).GetText(),
 			NamePrefix:      form.GetFormItem(1).(*tview.InputField).GetText(),
-			MaxThreads:      maxThreads,
-			MinSpareThreads: minSpare,
-			MaxIdleTime:     maxIdle,
+			MaxThreads:      placeholder.IntOf(maxThreads),
+			MinSpareThreads: placeholder.IntOf(minSpare),
+			MaxIdleTime:     placeholder.IntOf(maxIdle),
 		}
 
 		svc.Executors = append(svc.Executors, exec)
@@ -1030,6 +1066,7 @@ func (v *ServerView) showGlobalResourceDetail(index int) {
 		updatePreview()
 	})
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save")+"[-:-]", func() {
 		res.Name = form.GetFormItem(0).(*tview.InputField).GetText()
 		res.Auth = form.GetFormItem(1).(*tview.InputField).GetText()
@@ -1122,6 +1159,13 @@ func (v *ServerView) showAddGlobalResource() {
 	v.app.SetFocus(form)
 }
 
+// checkPort flags a conflicting shutdown port field and reports it in the status bar
+func (v *ServerView) checkPort(field *tview.InputField) {
+	if msg := checkPortField(field, v.configService, ports.KindShutdown, "Server"); msg != "" {
+		v.setStatus("[yellow]" + msg + "[-]")
+	}
+}
+
 // Helper functions
 
 func (v *ServerView) showError(message string) {
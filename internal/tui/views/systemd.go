@@ -0,0 +1,208 @@
+package views
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config"
+	"github.com/playok/tomcatkit/internal/i18n"
+	"github.com/playok/tomcatkit/internal/systemd"
+	"github.com/rivo/tview"
+)
+
+// SystemdView generates a systemd unit file for an instance
+type SystemdView struct {
+	app       *tview.Application
+	mainPages *tview.Pages
+	statusBar *tview.TextView
+	onReturn  func()
+	instance  *config.TomcatInstance
+}
+
+// NewSystemdView creates a new systemd unit view
+func NewSystemdView(app *tview.Application, mainPages *tview.Pages, statusBar *tview.TextView, instance *config.TomcatInstance, onReturn func()) *SystemdView {
+	return &SystemdView{
+		app:       app,
+		mainPages: mainPages,
+		statusBar: statusBar,
+		onReturn:  onReturn,
+		instance:  instance,
+	}
+}
+
+// systemdHelpKeysByIndex maps form items to help keys
+var systemdHelpKeysByIndex = []string{
+	"help.systemd.user",
+	"help.systemd.user",
+	"help.systemd.javahome",
+	"help.systemd.pidfile",
+	"help.systemd.nofile",
+	"help.systemd.hardening",
+	"help.systemd.template",
+	"help.systemd.template",
+	"help.systemd.output",
+}
+
+// Load shows the unit form
+func (v *SystemdView) Load() error {
+	opts := systemd.DefaultOptions(v.instance)
+
+	form := tview.NewForm()
+	helpPanel := NewDynamicHelpPanel()
+	preview := NewPreviewPanel()
+	formReady := false
+
+	// Fields are read by index, so the order matches systemdHelpKeysByIndex
+	readOptions := func() (systemd.Options, error) {
+		o := opts
+		o.User = strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		o.Group = strings.TrimSpace(form.GetFormItem(1).(*tview.InputField).GetText())
+		o.JavaHome = strings.TrimSpace(form.GetFormItem(2).(*tview.InputField).GetText())
+		o.PIDFile = strings.TrimSpace(form.GetFormItem(3).(*tview.InputField).GetText())
+		if text := strings.TrimSpace(form.GetFormItem(4).(*tview.InputField).GetText()); text != "" {
+			n, err := strconv.Atoi(text)
+			if err != nil {
+				return o, fmt.Errorf("LimitNOFILE: %q is not a number", text)
+			}
+			o.LimitNOFILE = n
+		} else {
+			o.LimitNOFILE = 0
+		}
+		o.Hardening = form.GetFormItem(5).(*tview.Checkbox).IsChecked()
+		o.Template = form.GetFormItem(6).(*tview.Checkbox).IsChecked()
+		o.BasesDir = strings.TrimSpace(form.GetFormItem(7).(*tview.InputField).GetText())
+		return o, nil
+	}
+
+	updatePreview := func() {
+		if !formReady {
+			return
+		}
+		o, err := readOptions()
+		if err == nil {
+			var unit string
+			if unit, err = systemd.Generate(o); err == nil {
+				preview.SetPreview(tview.Escape(unit))
+				return
+			}
+		}
+		preview.SetPreview("[red]" + tview.Escape(err.Error()) + "[-]")
+	}
+	changed := func(string) { updatePreview() }
+
+	// The output path follows the unit name until it is edited
+	var output *tview.InputField
+	outputEdited := false
+	updateOutput := func() {
+		if !formReady || outputEdited {
+			return
+		}
+		if o, err := readOptions(); err == nil {
+			output.SetText(filepath.Join(systemd.UnitDir, o.UnitName()))
+		}
+	}
+
+	form.AddInputField("User", opts.User, 20, nil, changed)
+	form.AddInputField("Group", opts.Group, 20, nil, changed)
+	form.AddInputField("JAVA_HOME", opts.JavaHome, 50, nil, changed)
+	form.AddInputField(i18n.T("systemd.pidfile"), opts.PIDFile, 50, nil, changed)
+	form.AddInputField("LimitNOFILE", strconv.Itoa(opts.LimitNOFILE), 10, acceptDigits, changed)
+	form.AddCheckbox(i18n.T("systemd.hardening"), opts.Hardening, func(bool) { updatePreview() })
+	form.AddCheckbox(i18n.T("systemd.template"), opts.Template, func(bool) {
+		updateOutput()
+		updatePreview()
+	})
+	form.AddInputField(i18n.T("systemd.basesdir"), opts.BasesDir, 50, nil, changed)
+	form.AddInputField(i18n.T("systemd.output"), filepath.Join(systemd.UnitDir, opts.UnitName()), 50, nil, func(string) {
+		if formReady {
+			outputEdited = true
+		}
+	})
+	output = form.GetFormItem(8).(*tview.InputField)
+
+	form.AddButton("[white:green]"+i18n.T("common.save")+"[-:-]", func() {
+		o, err := readOptions()
+		if err != nil {
+			v.setStatus("[red]" + err.Error() + "[-]")
+			return
+		}
+		unit, err := systemd.Generate(o)
+		if err != nil {
+			v.setStatus("[red]" + err.Error() + "[-]")
+			return
+		}
+		path := strings.TrimSpace(output.GetText())
+		if path == "" {
+			v.setStatus("[red]" + i18n.T("systemd.output.required") + "[-]")
+			return
+		}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			path = filepath.Join(path, o.UnitName())
+		}
+		if err := systemd.Write(path, unit); err != nil {
+			v.setStatus("[red]" + err.Error() + "[-]")
+			return
+		}
+		v.setStatus("[green]" + fmt.Sprintf(i18n.T("systemd.saved"), path) + "[-]")
+	})
+
+	form.AddButton("[black:yellow]"+i18n.T("common.cancel")+"[-:-]", func() {
+		v.close()
+	})
+
+	form.SetButtonBackgroundColor(tcell.ColorDefault)
+	form.SetBorder(true).SetTitle(" " + i18n.T("systemd.title") + " ").SetBorderColor(tcell.ColorDarkCyan)
+
+	// Update help when focus changes
+	lastFocusedIndex := -1
+	updateHelp := func(index int) {
+		if index >= 0 && index < len(systemdHelpKeysByIndex) {
+			helpPanel.SetHelpKey(systemdHelpKeysByIndex[index])
+		} else {
+			helpPanel.SetHelpKey("help.systemd")
+		}
+	}
+	form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			v.close()
+			return nil
+		}
+		go func() {
+			v.app.QueueUpdateDraw(func() {
+				idx, _ := form.GetFocusedItemIndex()
+				if idx != lastFocusedIndex {
+					lastFocusedIndex = idx
+					updateHelp(idx)
+				}
+			})
+		}()
+		return event
+	})
+
+	formReady = true
+	updatePreview()
+	updateHelp(0)
+
+	v.mainPages.AddAndSwitchToPage("systemd", CreateFormWithHelpAndPreview(form, helpPanel, preview), true)
+	v.app.SetFocus(form)
+	return nil
+}
+
+// close returns to the main menu
+func (v *SystemdView) close() {
+	v.mainPages.RemovePage("systemd")
+	if v.onReturn != nil {
+		v.onReturn()
+	}
+}
+
+// setStatus updates the status bar
+func (v *SystemdView) setStatus(message string) {
+	if v.statusBar != nil {
+		v.statusBar.SetText(" " + message)
+	}
+}
@@ -2,9 +2,9 @@ package views
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/playok/tomcatkit/internal/config/placeholder"
 	"github.com/playok/tomcatkit/internal/config/server"
 	"github.com/playok/tomcatkit/internal/i18n"
 	"github.com/rivo/tview"
@@ -554,6 +554,7 @@ func (v *ValveView) showValveForm(valve *server.Valve, isNew bool, scope string,
 		helpPanel.SetHelpKey(helpKeys[0])
 	}
 
+	addResolvedValues(form, v.configService.Resolver())
 	form.AddButton("[white:green]"+i18n.T("common.save.short")+"[-:-]", func() {
 		v.saveValveFromForm(form, valve, isNew, scope, host, ctx)
 	})
@@ -631,16 +632,16 @@ func (v *ValveView) addAccessLogFields(form *tview.Form, valve *server.Valve, up
 	form.AddInputField("File Date Format", valve.FileDateFormat, 20, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddCheckbox("Rotatable", valve.Rotatable, func(checked bool) {
+	form.AddCheckbox("Rotatable", valve.Rotatable.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
-	form.AddCheckbox("Rename On Rotate", valve.RenameOnRotate, func(checked bool) {
+	form.AddCheckbox("Rename On Rotate", valve.RenameOnRotate.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
 	form.AddInputField("Encoding", valve.Encoding, 20, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddCheckbox("Buffered", valve.Buffered, func(checked bool) {
+	form.AddCheckbox("Buffered", valve.Buffered.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
 	form.AddInputField("Condition If", valve.ConditionIf, 30, nil, func(text string) {
@@ -659,13 +660,13 @@ func (v *ValveView) addRemoteAddrFields(form *tview.Form, valve *server.Valve, u
 	form.AddInputField("Deny (regex)", valve.Deny, 60, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField("Deny Status", strconv.Itoa(valve.DenyStatus), 10, nil, func(text string) {
+	form.AddInputField("Deny Status", valve.DenyStatus.Text(), 10, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddCheckbox("Add Connector Port", valve.AddConnectorPort, func(checked bool) {
+	form.AddCheckbox("Add Connector Port", valve.AddConnectorPort.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
-	form.AddCheckbox("Invalid Auth When Deny", valve.InvalidAuthenticationWhenDeny, func(checked bool) {
+	form.AddCheckbox("Invalid Auth When Deny", valve.InvalidAuthenticationWhenDeny.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
 }
@@ -690,20 +691,20 @@ func (v *ValveView) addRemoteIpFields(form *tview.Form, valve *server.Valve, upd
 	form.AddInputField("Trusted Proxies (regex)", valve.TrustedProxies, 60, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddCheckbox("Change Local Port", valve.ChangeLocalPort, func(checked bool) {
+	form.AddCheckbox("Change Local Port", valve.ChangeLocalPort.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
-	form.AddCheckbox("Change Local Name", valve.ChangeLocalName, func(checked bool) {
+	form.AddCheckbox("Change Local Name", valve.ChangeLocalName.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
 }
 
 // addErrorReportFields adds form fields for ErrorReportValve
 func (v *ValveView) addErrorReportFields(form *tview.Form, valve *server.Valve, updatePreview func()) {
-	form.AddCheckbox("Show Server Info", valve.ShowServerInfo, func(checked bool) {
+	form.AddCheckbox("Show Server Info", valve.ShowServerInfo.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
-	form.AddCheckbox("Show Report", valve.ShowReport, func(checked bool) {
+	form.AddCheckbox("Show Report", valve.ShowReport.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
 }
@@ -716,17 +717,17 @@ func (v *ValveView) addSingleSignOnFields(form *tview.Form, valve *server.Valve,
 	form.AddInputField("Cookie Name", valve.CookieName, 30, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddCheckbox("Require Reauthentication", valve.RequireReauthentication, func(checked bool) {
+	form.AddCheckbox("Require Reauthentication", valve.RequireReauthentication.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
 }
 
 // addStuckThreadFields adds form fields for StuckThreadDetectionValve
 func (v *ValveView) addStuckThreadFields(form *tview.Form, valve *server.Valve, updatePreview func()) {
-	form.AddInputField("Threshold (seconds)", strconv.Itoa(valve.Threshold), 10, nil, func(text string) {
+	form.AddInputField("Threshold (seconds)", valve.Threshold.Text(), 10, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField("Interrupt Thread Threshold", strconv.Itoa(valve.InterruptThreadThreshold), 10, nil, func(text string) {
+	form.AddInputField("Interrupt Thread Threshold", valve.InterruptThreadThreshold.Text(), 10, nil, func(text string) {
 		updatePreview()
 	})
 }
@@ -736,20 +737,20 @@ func (v *ValveView) addCrawlerFields(form *tview.Form, valve *server.Valve, upda
 	form.AddInputField("Crawler User Agents", valve.CrawlerUserAgents, 60, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddInputField("Session Inactive Interval", strconv.Itoa(valve.SessionInactiveInterval), 10, nil, func(text string) {
+	form.AddInputField("Session Inactive Interval", valve.SessionInactiveInterval.Text(), 10, nil, func(text string) {
 		updatePreview()
 	})
 }
 
 // addSemaphoreFields adds form fields for SemaphoreValve
 func (v *ValveView) addSemaphoreFields(form *tview.Form, valve *server.Valve, updatePreview func()) {
-	form.AddInputField("Concurrency", strconv.Itoa(valve.Concurrency), 10, nil, func(text string) {
+	form.AddInputField("Concurrency", valve.Concurrency.Text(), 10, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddCheckbox("Fairness", valve.Fairness, func(checked bool) {
+	form.AddCheckbox("Fairness", valve.Fairness.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
-	form.AddCheckbox("Block", valve.Block, func(checked bool) {
+	form.AddCheckbox("Block", valve.Block.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
 }
@@ -759,7 +760,7 @@ func (v *ValveView) addReplicationFields(form *tview.Form, valve *server.Valve,
 	form.AddInputField("Filter", valve.Filter, 60, nil, func(text string) {
 		updatePreview()
 	})
-	form.AddCheckbox("Primary Indicator", valve.PrimaryIndicator, func(checked bool) {
+	form.AddCheckbox("Primary Indicator", valve.PrimaryIndicator.Value(v.configService.Resolver()), func(checked bool) {
 		updatePreview()
 	})
 	form.AddInputField("Primary Indicator Name", valve.PrimaryIndicatorName, 30, nil, func(text string) {
@@ -768,110 +769,119 @@ func (v *ValveView) addReplicationFields(form *tview.Form, valve *server.Valve,
 }
 
 // readAccessLogValve reads AccessLogValve fields from form
-func readAccessLogValve(form *tview.Form, valve *server.Valve) {
+func readAccessLogValve(form *tview.Form, valve *server.Valve, r *placeholder.Resolver) {
 	valve.Directory = GetFormText(form, "Directory")
 	valve.Prefix = GetFormText(form, "Prefix")
 	valve.Suffix = GetFormText(form, "Suffix")
 	valve.Pattern = GetFormText(form, "Pattern")
 	valve.FileDateFormat = GetFormText(form, "File Date Format")
-	valve.Rotatable = GetFormBool(form, "Rotatable")
-	valve.RenameOnRotate = GetFormBool(form, "Rename On Rotate")
+	valve.Rotatable = valve.Rotatable.With(GetFormBool(form, "Rotatable"), r)
+	valve.RenameOnRotate = valve.RenameOnRotate.With(GetFormBool(form, "Rename On Rotate"), r)
 	valve.Encoding = GetFormText(form, "Encoding")
-	valve.Buffered = GetFormBool(form, "Buffered")
+	valve.Buffered = valve.Buffered.With(GetFormBool(form, "Buffered"), r)
 	valve.ConditionIf = GetFormText(form, "Condition If")
 	valve.ConditionUnless = GetFormText(form, "Condition Unless")
 }
 
 // readRemoteAddrValve reads RemoteAddrValve fields from form
-func readRemoteAddrValve(form *tview.Form, valve *server.Valve) {
+func readRemoteAddrValve(form *tview.Form, valve *server.Valve, r *placeholder.Resolver, ints *formInts) {
 	valve.Allow = GetFormText(form, "Allow (regex)")
 	valve.Deny = GetFormText(form, "Deny (regex)")
-	valve.DenyStatus = GetFormInt(form, "Deny Status")
-	valve.AddConnectorPort = GetFormBool(form, "Add Connector Port")
-	valve.InvalidAuthenticationWhenDeny = GetFormBool(form, "Invalid Auth When Deny")
+	valve.DenyStatus = ints.Int(form.GetFormItemByLabel("Deny Status"), valve.DenyStatus)
+	valve.AddConnectorPort = valve.AddConnectorPort.With(GetFormBool(form, "Add Connector Port"), r)
+	valve.InvalidAuthenticationWhenDeny = valve.InvalidAuthenticationWhenDeny.With(GetFormBool(form, "Invalid Auth When Deny"), r)
 }
 
 // readRemoteIpValve reads RemoteIpValve fields from form
-func readRemoteIpValve(form *tview.Form, valve *server.Valve) {
+func readRemoteIpValve(form *tview.Form, valve *server.Valve, r *placeholder.Resolver) {
 	valve.RemoteIpHeader = GetFormText(form, "Remote IP Header")
 	valve.ProtocolHeader = GetFormText(form, "Protocol Header")
 	valve.ProtocolHeaderHttpsValue = GetFormText(form, "Protocol Header HTTPS Value")
 	valve.PortHeader = GetFormText(form, "Port Header")
 	valve.InternalProxies = GetFormText(form, "Internal Proxies (regex)")
 	valve.TrustedProxies = GetFormText(form, "Trusted Proxies (regex)")
-	valve.ChangeLocalPort = GetFormBool(form, "Change Local Port")
-	valve.ChangeLocalName = GetFormBool(form, "Change Local Name")
+	valve.ChangeLocalPort = valve.ChangeLocalPort.With(GetFormBool(form, "Change Local Port"), r)
+	valve.ChangeLocalName = valve.ChangeLocalName.With(GetFormBool(form, "Change Local Name"), r)
 }
 
 // readErrorReportValve reads ErrorReportValve fields from form
-func readErrorReportValve(form *tview.Form, valve *server.Valve) {
-	valve.ShowServerInfo = GetFormBool(form, "Show Server Info")
-	valve.ShowReport = GetFormBool(form, "Show Report")
+func readErrorReportValve(form *tview.Form, valve *server.Valve, r *placeholder.Resolver) {
+	valve.ShowServerInfo = valve.ShowServerInfo.With(GetFormBool(form, "Show Server Info"), r)
+	valve.ShowReport = valve.ShowReport.With(GetFormBool(form, "Show Report"), r)
 }
 
 // readSingleSignOnValve reads SingleSignOnValve fields from form
-func readSingleSignOnValve(form *tview.Form, valve *server.Valve) {
+func readSingleSignOnValve(form *tview.Form, valve *server.Valve, r *placeholder.Resolver) {
 	valve.CookieDomain = GetFormText(form, "Cookie Domain")
 	valve.CookieName = GetFormText(form, "Cookie Name")
-	valve.RequireReauthentication = GetFormBool(form, "Require Reauthentication")
+	valve.RequireReauthentication = valve.RequireReauthentication.With(GetFormBool(form, "Require Reauthentication"), r)
 }
 
 // readStuckThreadValve reads StuckThreadDetectionValve fields from form
-func readStuckThreadValve(form *tview.Form, valve *server.Valve) {
-	valve.Threshold = GetFormInt(form, "Threshold (seconds)")
-	valve.InterruptThreadThreshold = GetFormInt(form, "Interrupt Thread Threshold")
+func readStuckThreadValve(form *tview.Form, valve *server.Valve, ints *formInts) {
+	valve.Threshold = ints.Int(form.GetFormItemByLabel("Threshold (seconds)"), valve.Threshold)
+	valve.InterruptThreadThreshold = ints.Int(form.GetFormItemByLabel("Interrupt Thread Threshold"), valve.InterruptThreadThreshold)
 }
 
 // readCrawlerSessionValve reads CrawlerSessionManagerValve fields from form
-func readCrawlerSessionValve(form *tview.Form, valve *server.Valve) {
+func readCrawlerSessionValve(form *tview.Form, valve *server.Valve, ints *formInts) {
 	valve.CrawlerUserAgents = GetFormText(form, "Crawler User Agents")
-	valve.SessionInactiveInterval = GetFormInt(form, "Session Inactive Interval")
+	valve.SessionInactiveInterval = ints.Int(form.GetFormItemByLabel("Session Inactive Interval"), valve.SessionInactiveInterval)
 }
 
 // readSemaphoreValve reads SemaphoreValve fields from form
-func readSemaphoreValve(form *tview.Form, valve *server.Valve) {
-	valve.Concurrency = GetFormInt(form, "Concurrency")
-	valve.Fairness = GetFormBool(form, "Fairness")
-	valve.Block = GetFormBool(form, "Block")
+func readSemaphoreValve(form *tview.Form, valve *server.Valve, r *placeholder.Resolver, ints *formInts) {
+	valve.Concurrency = ints.Int(form.GetFormItemByLabel("Concurrency"), valve.Concurrency)
+	valve.Fairness = valve.Fairness.With(GetFormBool(form, "Fairness"), r)
+	valve.Block = valve.Block.With(GetFormBool(form, "Block"), r)
 }
 
 // readReplicationValve reads ReplicationValve fields from form
-func readReplicationValve(form *tview.Form, valve *server.Valve) {
+func readReplicationValve(form *tview.Form, valve *server.Valve, r *placeholder.Resolver) {
 	valve.Filter = GetFormText(form, "Filter")
-	valve.PrimaryIndicator = GetFormBool(form, "Primary Indicator")
+	valve.PrimaryIndicator = valve.PrimaryIndicator.With(GetFormBool(form, "Primary Indicator"), r)
 	valve.PrimaryIndicatorName = GetFormText(form, "Primary Indicator Name")
 }
 
-// readValveFromForm reads form values into a valve struct
-func (v *ValveView) readValveFromForm(form *tview.Form, valve *server.Valve) {
+// readValveFromForm reads form values into a valve struct. A numeric field
+// that does not parse keeps its old value and is reported in the error.
+func (v *ValveView) readValveFromForm(form *tview.Form, valve *server.Valve) error {
+	var ints formInts
 	switch valve.ClassName {
 	case server.ValveAccessLog, server.ValveExtendedAccessLog:
-		readAccessLogValve(form, valve)
+		readAccessLogValve(form, valve, v.configService.Resolver())
 	case server.ValveRemoteAddr, server.ValveRemoteCIDR, server.ValveRemoteHost:
-		readRemoteAddrValve(form, valve)
+		readRemoteAddrValve(form, valve, v.configService.Resolver(), &ints)
 	case server.ValveRemoteIp:
-		readRemoteIpValve(form, valve)
+		readRemoteIpValve(form, valve, v.configService.Resolver())
 	case server.ValveErrorReport:
-		readErrorReportValve(form, valve)
+		readErrorReportValve(form, valve, v.configService.Resolver())
 	case server.ValveSingleSignOn:
-		readSingleSignOnValve(form, valve)
+		readSingleSignOnValve(form, valve, v.configService.Resolver())
 	case server.ValveStuckThreadDetection:
-		readStuckThreadValve(form, valve)
+		readStuckThreadValve(form, valve, &ints)
 	case server.ValveCrawlerSessionManager:
-		readCrawlerSessionValve(form, valve)
+		readCrawlerSessionValve(form, valve, &ints)
 	case server.ValveSemaphore:
-		readSemaphoreValve(form, valve)
+		readSemaphoreValve(form, valve, v.configService.Resolver(), &ints)
 	case server.ValveReplication:
-		readReplicationValve(form, valve)
+		readReplicationValve(form, valve, v.configService.Resolver())
 	default:
 		valve.ClassName = GetFormText(form, "Class Name")
 	}
+	return ints.err
 }
 
 // saveValveFromForm saves valve data from form
 func (v *ValveView) saveValveFromForm(form *tview.Form, valve *server.Valve, isNew bool, scope string, host *server.Host, ctx *server.Context) {
-	// Extract values using the readValveFromForm helper
-	v.readValveFromForm(form, valve)
+	// Extract values using the readValveFromForm helper; an invalid number
+	// leaves the valve untouched
+	updated := *valve
+	if err := v.readValveFromForm(form, &updated); err != nil {
+		v.setStatus("[red]" + err.Error() + "[-]")
+		return
+	}
+	*valve = updated
 
 	// Add valve if new
 	if isNew {